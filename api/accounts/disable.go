@@ -0,0 +1,51 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+)
+
+// Disable disables a account. A disabled account cannot authenticate, and its
+// auth tokens and sessions are revoked.
+func (c *Client) Disable(ctx context.Context, accountId string, opt ...Option) (*AccountUpdateResult, error) {
+	return c.setActive(ctx, "Disable", "disable", accountId, opt...)
+}
+
+// Enable enables a account which was disabled, allowing it to authenticate
+// again.
+func (c *Client) Enable(ctx context.Context, accountId string, opt ...Option) (*AccountUpdateResult, error) {
+	return c.setActive(ctx, "Enable", "enable", accountId, opt...)
+}
+
+func (c *Client) setActive(ctx context.Context, name, action, accountId string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into %s request", name)
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in %s request", name)
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:%s", accountId, action), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", name, err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", name, err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", name, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
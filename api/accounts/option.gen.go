@@ -155,3 +155,27 @@ func DefaultPasswordAccountPassword() Option {
 		o.postMap["attributes"] = val
 	}
 }
+
+func WithPasswordAccountPasswordHash(inPasswordHash string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_hash"] = inPasswordHash
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAccountPasswordHash() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_hash"] = nil
+		o.postMap["attributes"] = val
+	}
+}
@@ -1,7 +1,17 @@
 // Code generated by "make api"; DO NOT EDIT.
 package accounts
 
+import (
+	"time"
+)
+
 type PasswordAccountAttributes struct {
-	LoginName string `json:"login_name,omitempty"`
-	Password  string `json:"password,omitempty"`
+	LoginName             string    `json:"login_name,omitempty"`
+	Password              string    `json:"password,omitempty"`
+	FailedAttemptCount    uint32    `json:"failed_attempt_count,omitempty"`
+	LockoutCount          uint32    `json:"lockout_count,omitempty"`
+	LastFailedAttemptTime time.Time `json:"last_failed_attempt_time,omitempty"`
+	LockedUntilTime       time.Time `json:"locked_until_time,omitempty"`
+	TotpEnabled           bool      `json:"totp_enabled,omitempty"`
+	PasswordHash          string    `json:"password_hash,omitempty"`
 }
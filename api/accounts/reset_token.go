@@ -0,0 +1,67 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// ResetTokenCreateResult is the result of creating a password reset token
+// for an account. The token is only ever returned by the create request.
+type ResetTokenCreateResult struct {
+	Item           *Account  `json:"item,omitempty"`
+	ResetToken     string    `json:"reset_token,omitempty"`
+	ExpirationTime time.Time `json:"expiration_time,omitempty"`
+	response       *api.Response
+}
+
+func (n ResetTokenCreateResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n ResetTokenCreateResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// CreateResetToken creates a one-time token which can be used to reset the
+// password of an account without authenticating, replacing any existing reset
+// token of the account. If ttlSeconds is 0 the token expires after the
+// controller's default.
+func (c *Client) CreateResetToken(ctx context.Context, accountId string, ttlSeconds uint32, opt ...Option) (*ResetTokenCreateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into CreateResetToken request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in CreateResetToken request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{}
+	if ttlSeconds > 0 {
+		reqBody["ttl_seconds"] = ttlSeconds
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:create-reset-token", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating CreateResetToken request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during CreateResetToken call: %w", err)
+	}
+
+	target := new(ResetTokenCreateResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CreateResetToken response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
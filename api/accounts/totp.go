@@ -0,0 +1,138 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// TotpEnrollResult is the result of enrolling an account in TOTP. The secret,
+// URL, and recovery codes are only ever returned by the enrollment request.
+type TotpEnrollResult struct {
+	Item          *Account `json:"item,omitempty"`
+	TotpSecret    string   `json:"totp_secret,omitempty"`
+	TotpUrl       string   `json:"totp_url,omitempty"`
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	response      *api.Response
+}
+
+func (n TotpEnrollResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n TotpEnrollResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// EnrollTotp creates a pending TOTP enrollment for an account. The pending
+// enrollment must be confirmed with ConfirmTotp before it replaces any
+// existing enrollment and a TOTP code of it is required to authenticate.
+func (c *Client) EnrollTotp(ctx context.Context, accountId string, opt ...Option) (*TotpEnrollResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into EnrollTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in EnrollTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:enroll-totp", accountId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating EnrollTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during EnrollTotp call: %w", err)
+	}
+
+	target := new(TotpEnrollResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding EnrollTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ConfirmTotp confirms the TOTP enrollment of an account with a code
+// generated from the secret returned by EnrollTotp.
+func (c *Client) ConfirmTotp(ctx context.Context, accountId, code string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into ConfirmTotp request")
+	}
+	if code == "" {
+		return nil, fmt.Errorf("empty code value passed into ConfirmTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ConfirmTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"code": code,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:confirm-totp", accountId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ConfirmTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ConfirmTotp call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ConfirmTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// RemoveTotp removes the TOTP enrollment and the recovery codes of an
+// account.
+func (c *Client) RemoveTotp(ctx context.Context, accountId string, opt ...Option) (*AccountUpdateResult, error) {
+	if accountId == "" {
+		return nil, fmt.Errorf("empty accountId value passed into RemoveTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RemoveTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("accounts/%s:remove-totp", accountId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RemoveTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RemoveTotp call: %w", err)
+	}
+
+	target := new(AccountUpdateResult)
+	target.Item = new(Account)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RemoveTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
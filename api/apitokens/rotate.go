@@ -0,0 +1,52 @@
+package apitokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Rotate replaces the value of the API Token with the given ID. The new value
+// is returned in the Token field of the result; the previous value can no
+// longer be used. WithExpirationTime can be used to set a new expiration time.
+func (c *Client) Rotate(ctx context.Context, apiTokenId string, opt ...Option) (*ApiTokenUpdateResult, error) {
+	if apiTokenId == "" {
+		return nil, fmt.Errorf("empty apiTokenId value passed into Rotate request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("api-tokens/%s:rotate", apiTokenId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Rotate request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Rotate call: %w", err)
+	}
+
+	target := new(ApiTokenUpdateResult)
+	target.Item = new(ApiToken)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Rotate response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
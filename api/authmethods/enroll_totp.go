@@ -0,0 +1,70 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// EnrollTotpResult is the result of enrolling an account in TOTP with its
+// password. The secret, URL, and recovery codes are only ever returned by the
+// enrollment request.
+type EnrollTotpResult struct {
+	TotpSecret    string   `json:"totp_secret,omitempty"`
+	TotpUrl       string   `json:"totp_url,omitempty"`
+	RecoveryCodes []string `json:"recovery_codes,omitempty"`
+	response      *api.Response
+}
+
+func (n EnrollTotpResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// EnrollTotp creates a pending TOTP enrollment for the account with loginName
+// and password in the auth method, which must require TOTP. The account must
+// not have enrolled in TOTP already. The enrollment is confirmed by
+// authenticating with a TOTP code generated from the returned secret. It
+// does not require the client to be authenticated.
+func (c *Client) EnrollTotp(ctx context.Context, authMethodId, loginName, password string, opt ...Option) (*EnrollTotpResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into EnrollTotp request")
+	}
+	if loginName == "" {
+		return nil, fmt.Errorf("empty loginName value passed into EnrollTotp request")
+	}
+	if password == "" {
+		return nil, fmt.Errorf("empty password value passed into EnrollTotp request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in EnrollTotp request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"login_name": loginName,
+		"password":   password,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:enroll-totp", authMethodId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating EnrollTotp request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during EnrollTotp call: %w", err)
+	}
+
+	target := new(EnrollTotpResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding EnrollTotp response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
@@ -85,6 +85,30 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithPasswordAuthMethodAllowedClientCidrs(inAllowedClientCidrs []string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["allowed_client_cidrs"] = inAllowedClientCidrs
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodAllowedClientCidrs() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["allowed_client_cidrs"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -97,6 +121,102 @@ func DefaultAttributes() Option {
 	}
 }
 
+func WithPasswordAuthMethodAuthTokenTimeToLiveSeconds(inAuthTokenTimeToLiveSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_token_time_to_live_seconds"] = inAuthTokenTimeToLiveSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodAuthTokenTimeToLiveSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_token_time_to_live_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodAuthTokenTimeToStaleSeconds(inAuthTokenTimeToStaleSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_token_time_to_stale_seconds"] = inAuthTokenTimeToStaleSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodAuthTokenTimeToStaleSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["auth_token_time_to_stale_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodBannedPasswords(inBannedPasswords []string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banned_passwords"] = inBannedPasswords
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodBannedPasswords() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["banned_passwords"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodDeniedClientCidrs(inDeniedClientCidrs []string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["denied_client_cidrs"] = inDeniedClientCidrs
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodDeniedClientCidrs() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["denied_client_cidrs"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -109,6 +229,102 @@ func DefaultDescription() Option {
 	}
 }
 
+func WithPasswordAuthMethodLockoutDurationSeconds(inLockoutDurationSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = inLockoutDurationSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutDurationSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_duration_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodLockoutThreshold(inLockoutThreshold uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = inLockoutThreshold
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodLockoutThreshold() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["lockout_threshold"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodMaxPasswordAgeSeconds(inMaxPasswordAgeSeconds uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_seconds"] = inMaxPasswordAgeSeconds
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMaxPasswordAgeSeconds() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["max_password_age_seconds"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodMfaPolicy(inMfaPolicy string) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["mfa_policy"] = inMfaPolicy
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodMfaPolicy() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["mfa_policy"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
 func WithPasswordAuthMethodMinLoginNameLength(inMinLoginNameLength uint32) Option {
 	return func(o *options) {
 		raw, ok := o.postMap["attributes"]
@@ -168,3 +384,123 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithPasswordAuthMethodPasswordHistoryCount(inPasswordHistoryCount uint32) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_count"] = inPasswordHistoryCount
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordHistoryCount() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_history_count"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodPasswordRequireDigit(inPasswordRequireDigit bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_digit"] = inPasswordRequireDigit
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordRequireDigit() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_digit"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodPasswordRequireLowercase(inPasswordRequireLowercase bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_lowercase"] = inPasswordRequireLowercase
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordRequireLowercase() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_lowercase"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodPasswordRequireSymbol(inPasswordRequireSymbol bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_symbol"] = inPasswordRequireSymbol
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordRequireSymbol() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_symbol"] = nil
+		o.postMap["attributes"] = val
+	}
+}
+
+func WithPasswordAuthMethodPasswordRequireUppercase(inPasswordRequireUppercase bool) Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_uppercase"] = inPasswordRequireUppercase
+		o.postMap["attributes"] = val
+	}
+}
+
+func DefaultPasswordAuthMethodPasswordRequireUppercase() Option {
+	return func(o *options) {
+		raw, ok := o.postMap["attributes"]
+		if !ok {
+			raw = interface{}(map[string]interface{}{})
+		}
+		val := raw.(map[string]interface{})
+		val["password_require_uppercase"] = nil
+		o.postMap["attributes"] = val
+	}
+}
@@ -2,6 +2,20 @@
 package authmethods
 
 type PasswordAuthMethodAttributes struct {
-	MinLoginNameLength uint32 `json:"min_login_name_length,omitempty"`
-	MinPasswordLength  uint32 `json:"min_password_length,omitempty"`
+	MinLoginNameLength          uint32   `json:"min_login_name_length,omitempty"`
+	MinPasswordLength           uint32   `json:"min_password_length,omitempty"`
+	AuthTokenTimeToLiveSeconds  uint32   `json:"auth_token_time_to_live_seconds,omitempty"`
+	AuthTokenTimeToStaleSeconds uint32   `json:"auth_token_time_to_stale_seconds,omitempty"`
+	LockoutThreshold            uint32   `json:"lockout_threshold,omitempty"`
+	LockoutDurationSeconds      uint32   `json:"lockout_duration_seconds,omitempty"`
+	MfaPolicy                   string   `json:"mfa_policy,omitempty"`
+	PasswordRequireUppercase    bool     `json:"password_require_uppercase,omitempty"`
+	PasswordRequireLowercase    bool     `json:"password_require_lowercase,omitempty"`
+	PasswordRequireDigit        bool     `json:"password_require_digit,omitempty"`
+	PasswordRequireSymbol       bool     `json:"password_require_symbol,omitempty"`
+	PasswordHistoryCount        uint32   `json:"password_history_count,omitempty"`
+	MaxPasswordAgeSeconds       uint32   `json:"max_password_age_seconds,omitempty"`
+	BannedPasswords             []string `json:"banned_passwords,omitempty"`
+	AllowedClientCidrs          []string `json:"allowed_client_cidrs,omitempty"`
+	DeniedClientCidrs           []string `json:"denied_client_cidrs,omitempty"`
 }
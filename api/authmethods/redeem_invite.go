@@ -0,0 +1,73 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type RedeemInviteResult struct {
+	AccountId string `json:"account_id,omitempty"`
+	UserId    string `json:"user_id,omitempty"`
+	response  *api.Response
+}
+
+func (n RedeemInviteResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// RedeemInvite redeems the invite which token was created for, creating an
+// account with loginName and password in the auth method and a user
+// associated with the account. An invite can only be redeemed once. It does
+// not require the client to be authenticated. WithName sets the name of the
+// created user.
+func (c *Client) RedeemInvite(ctx context.Context, authMethodId, token, loginName, password string, opt ...Option) (*RedeemInviteResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into RedeemInvite request")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("empty token value passed into RedeemInvite request")
+	}
+	if loginName == "" {
+		return nil, fmt.Errorf("empty loginName value passed into RedeemInvite request")
+	}
+	if password == "" {
+		return nil, fmt.Errorf("empty password value passed into RedeemInvite request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RedeemInvite request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"token":      token,
+		"login_name": loginName,
+		"password":   password,
+	}
+	if name, ok := opts.postMap["name"]; ok {
+		reqBody["name"] = name
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:redeem-invite", authMethodId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RedeemInvite request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RedeemInvite call: %w", err)
+	}
+
+	target := new(RedeemInviteResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RedeemInvite response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
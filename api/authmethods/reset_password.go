@@ -0,0 +1,61 @@
+package authmethods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// ResetPasswordResult is the result of resetting the password of an account.
+type ResetPasswordResult struct {
+	response *api.Response
+}
+
+func (n ResetPasswordResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ResetPassword sets the password of the account which token was created for
+// to newPassword. The token can only be used once. It does not require the
+// client to be authenticated.
+func (c *Client) ResetPassword(ctx context.Context, authMethodId, token, newPassword string, opt ...Option) (*ResetPasswordResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into ResetPassword request")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("empty token value passed into ResetPassword request")
+	}
+	if newPassword == "" {
+		return nil, fmt.Errorf("empty newPassword value passed into ResetPassword request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ResetPassword request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"token":        token,
+		"new_password": newPassword,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-methods/%s:reset-password", authMethodId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ResetPassword request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ResetPassword call: %w", err)
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ResetPassword response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return &ResetPasswordResult{response: resp}, nil
+}
@@ -23,6 +23,9 @@ type AuthToken struct {
 	UpdatedTime             time.Time         `json:"updated_time,omitempty"`
 	ApproximateLastUsedTime time.Time         `json:"approximate_last_used_time,omitempty"`
 	ExpirationTime          time.Time         `json:"expiration_time,omitempty"`
+	ParentId                string            `json:"parent_id,omitempty"`
+	GrantScopeId            string            `json:"grant_scope_id,omitempty"`
+	GrantStrings            []string          `json:"grant_strings,omitempty"`
 	AuthorizedActions       []string          `json:"authorized_actions,omitempty"`
 
 	response *api.Response
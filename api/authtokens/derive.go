@@ -0,0 +1,59 @@
+package authtokens
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Derive exchanges the Auth Token with the given ID, which must be the Auth
+// Token the client is using, for a new, short-lived Auth Token which is only
+// allowed to do what is allowed by both the given grants and the grants of the
+// original Auth Token's User. The new value is returned in the Token field of
+// the result. WithGrantScopeId and WithExpirationTime can be used to set the
+// scope the grants apply to and when the new Auth Token expires.
+func (c *Client) Derive(ctx context.Context, authTokenId string, grants []string, opt ...Option) (*AuthTokenCreateResult, error) {
+	if authTokenId == "" {
+		return nil, fmt.Errorf("empty authTokenId value passed into Derive request")
+	}
+	if len(grants) == 0 {
+		return nil, fmt.Errorf("empty grants value passed into Derive request")
+	}
+	if c.client == nil {
+		return nil, errors.New("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.postMap["grant_strings"] = grants
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("auth-tokens/%s:derive", authTokenId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Derive request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Derive call: %w", err)
+	}
+
+	target := new(AuthTokenCreateResult)
+	target.Item = new(AuthToken)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Derive response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
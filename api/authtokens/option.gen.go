@@ -3,6 +3,7 @@ package authtokens
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/api"
 )
@@ -84,3 +85,39 @@ func WithRecursive(recurse bool) Option {
 		o.withRecursive = true
 	}
 }
+
+func WithExpirationTime(inExpirationTime time.Time) Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = inExpirationTime
+	}
+}
+
+func DefaultExpirationTime() Option {
+	return func(o *options) {
+		o.postMap["expiration_time"] = nil
+	}
+}
+
+func WithGrantScopeId(inGrantScopeId string) Option {
+	return func(o *options) {
+		o.postMap["grant_scope_id"] = inGrantScopeId
+	}
+}
+
+func DefaultGrantScopeId() Option {
+	return func(o *options) {
+		o.postMap["grant_scope_id"] = nil
+	}
+}
+
+func WithGrantStrings(inGrantStrings []string) Option {
+	return func(o *options) {
+		o.postMap["grant_strings"] = inGrantStrings
+	}
+}
+
+func DefaultGrantStrings() Option {
+	return func(o *options) {
+		o.postMap["grant_strings"] = nil
+	}
+}
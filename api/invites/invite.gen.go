@@ -0,0 +1,257 @@
+// Code generated by "make api"; DO NOT EDIT.
+package invites
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+)
+
+type Invite struct {
+	Id                string            `json:"id,omitempty"`
+	Scope             *scopes.ScopeInfo `json:"scope,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	CreatedTime       time.Time         `json:"created_time,omitempty"`
+	UpdatedTime       time.Time         `json:"updated_time,omitempty"`
+	Version           uint32            `json:"version,omitempty"`
+	AuthMethodId      string            `json:"auth_method_id,omitempty"`
+	RoleIds           []string          `json:"role_ids,omitempty"`
+	ExpirationTime    time.Time         `json:"expiration_time,omitempty"`
+	Token             string            `json:"token,omitempty"`
+	RedeemedTime      time.Time         `json:"redeemed_time,omitempty"`
+	AccountId         string            `json:"account_id,omitempty"`
+	UserId            string            `json:"user_id,omitempty"`
+	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
+
+	response *api.Response
+}
+
+type InviteReadResult struct {
+	Item     *Invite
+	response *api.Response
+}
+
+func (n InviteReadResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n InviteReadResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type (
+	InviteCreateResult = InviteReadResult
+	InviteUpdateResult = InviteReadResult
+)
+
+type InviteDeleteResult struct {
+	response *api.Response
+}
+
+func (n InviteDeleteResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type InviteListResult struct {
+	Items    []*Invite
+	response *api.Response
+}
+
+func (n InviteListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n InviteListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
+
+func (c *Client) Create(ctx context.Context, authMethodId string, opt ...Option) (*InviteCreateResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into Create request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts.postMap["auth_method_id"] = authMethodId
+
+	req, err := c.client.NewRequest(ctx, "POST", "invites", opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Create request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Create call: %w", err)
+	}
+
+	target := new(InviteCreateResult)
+	target.Item = new(Invite)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Create response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+func (c *Client) Read(ctx context.Context, inviteId string, opt ...Option) (*InviteReadResult, error) {
+	if inviteId == "" {
+		return nil, fmt.Errorf("empty inviteId value passed into Read request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("invites/%s", inviteId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Read request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Read call: %w", err)
+	}
+
+	target := new(InviteReadResult)
+	target.Item = new(Invite)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Read response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+func (c *Client) Delete(ctx context.Context, inviteId string, opt ...Option) (*InviteDeleteResult, error) {
+	if inviteId == "" {
+		return nil, fmt.Errorf("empty inviteId value passed into Delete request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("invites/%s", inviteId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Delete request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Delete call: %w", err)
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Delete response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	target := &InviteDeleteResult{
+		response: resp,
+	}
+	return target, nil
+}
+
+func (c *Client) List(ctx context.Context, authMethodId string, opt ...Option) (*InviteListResult, error) {
+	if authMethodId == "" {
+		return nil, fmt.Errorf("empty authMethodId value passed into List request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["auth_method_id"] = authMethodId
+
+	req, err := c.client.NewRequest(ctx, "GET", "invites", nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating List request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during List call: %w", err)
+	}
+
+	target := new(InviteListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding List response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
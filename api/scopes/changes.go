@@ -0,0 +1,183 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// DefaultChangeFeedPollInterval is the interval a ChangeFeed waits for new
+// changes when it has received all the changes recorded.
+const DefaultChangeFeedPollInterval = 5 * time.Second
+
+// ChangeMessage describes the change to a table recorded by a Change.  The
+// value is the row that was written or deleted, without its encrypted and
+// hashed values.
+type ChangeMessage struct {
+	Type           string                 `json:"type,omitempty"`
+	Operation      string                 `json:"operation,omitempty"`
+	FieldMaskPaths []string               `json:"field_mask_paths,omitempty"`
+	SetToNullPaths []string               `json:"set_to_null_paths,omitempty"`
+	Value          map[string]interface{} `json:"value,omitempty"`
+}
+
+// Change describes an entry of the oplog, which records a write to the
+// database of Boundary.
+type Change struct {
+	Id            uint32           `json:"id,omitempty"`
+	CreatedTime   time.Time        `json:"created_time,omitempty"`
+	AggregateName string           `json:"aggregate_name,omitempty"`
+	ScopeId       string           `json:"scope_id,omitempty"`
+	ResourceType  string           `json:"resource_type,omitempty"`
+	ResourceId    string           `json:"resource_id,omitempty"`
+	Operation     string           `json:"operation,omitempty"`
+	Messages      []*ChangeMessage `json:"messages,omitempty"`
+}
+
+// ChangeFilter restricts the changes listed to those made in one of the
+// scopes and to one of the resource types.  Empty fields do not restrict the
+// changes.  Limit is the maximum number of changes listed, with zero using
+// the controller's default.
+type ChangeFilter struct {
+	ScopeIds      []string
+	ResourceTypes []string
+	Limit         uint32
+}
+
+type ChangeListResult struct {
+	Items    []*Change
+	Cursor   uint32
+	response *api.Response
+}
+
+func (n ChangeListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n ChangeListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListChanges returns the changes recorded in the oplog after the cursor,
+// which is the id of the last change received, in the order they were
+// recorded.  The cursor of the result is the id of its last change, or the
+// cursor passed in if there are no new changes.  Changes can only be listed
+// in the global scope.
+func (c *Client) ListChanges(ctx context.Context, scopeId string, cursor uint32, filter ChangeFilter, opt ...Option) (*ChangeListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListChanges request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ListChanges request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-changes", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListChanges request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	if cursor > 0 {
+		q.Set("cursor", strconv.FormatUint(uint64(cursor), 10))
+	}
+	if filter.Limit > 0 {
+		q.Set("limit", strconv.FormatUint(uint64(filter.Limit), 10))
+	}
+	for _, id := range filter.ScopeIds {
+		q.Add("scope_ids", id)
+	}
+	for _, t := range filter.ResourceTypes {
+		q.Add("resource_types", t)
+	}
+	if len(q) > 0 {
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListChanges call: %w", err)
+	}
+
+	target := new(ChangeListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListChanges response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if target.Cursor == 0 {
+		target.Cursor = cursor
+	}
+	target.response = resp
+	return target, nil
+}
+
+// ChangeFeed streams the changes recorded in the oplog by listing them
+// page by page and polling for new changes once all of them have been
+// received.  Its cursor can be saved to resume the feed later with
+// NewChangeFeed.  A ChangeFeed is not safe for concurrent use.
+type ChangeFeed struct {
+	client       *Client
+	scopeId      string
+	cursor       uint32
+	filter       ChangeFilter
+	opts         []Option
+	pollInterval time.Duration
+	pending      []*Change
+}
+
+// NewChangeFeed returns a ChangeFeed of the changes recorded after the
+// cursor, with zero starting at the first change recorded.  If pollInterval
+// is zero, DefaultChangeFeedPollInterval is used.
+func NewChangeFeed(c *Client, scopeId string, cursor uint32, filter ChangeFilter, pollInterval time.Duration, opt ...Option) *ChangeFeed {
+	if pollInterval <= 0 {
+		pollInterval = DefaultChangeFeedPollInterval
+	}
+	return &ChangeFeed{
+		client:       c,
+		scopeId:      scopeId,
+		cursor:       cursor,
+		filter:       filter,
+		opts:         opt,
+		pollInterval: pollInterval,
+	}
+}
+
+// Cursor returns the id of the last change returned by Next.
+func (f *ChangeFeed) Cursor() uint32 {
+	return f.cursor
+}
+
+// Next returns the next change, waiting for one to be recorded if all the
+// changes have been received.  It returns when the context is done.
+func (f *ChangeFeed) Next(ctx context.Context) (*Change, error) {
+	for len(f.pending) == 0 {
+		result, err := f.client.ListChanges(ctx, f.scopeId, f.cursor, f.filter, f.opts...)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Items) > 0 {
+			f.pending = result.Items
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(f.pollInterval):
+		}
+	}
+	next := f.pending[0]
+	f.pending = f.pending[1:]
+	f.cursor = next.Id
+	return next, nil
+}
@@ -0,0 +1,148 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// JobRun describes a run of a job.  Duration is how long the run took, or has
+// taken so far if it is running, formatted as a number of seconds with an
+// "s" suffix, e.g. "1.5s", which time.ParseDuration accepts.
+type JobRun struct {
+	Id         uint32    `json:"id,omitempty"`
+	Controller string    `json:"controller,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	StartTime  time.Time `json:"start_time,omitempty"`
+	EndTime    time.Time `json:"end_time,omitempty"`
+	Duration   string    `json:"duration,omitempty"`
+}
+
+// Job describes a job run by the scheduler of the controllers and its most
+// recent runs.
+type Job struct {
+	Name             string    `json:"name,omitempty"`
+	Description      string    `json:"description,omitempty"`
+	NextScheduledRun time.Time `json:"next_scheduled_run,omitempty"`
+	Runs             []*JobRun `json:"runs,omitempty"`
+}
+
+type JobListResult struct {
+	Items    []*Job
+	response *api.Response
+}
+
+func (n JobListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n JobListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+type JobRunResult struct {
+	Item     *Job
+	response *api.Response
+}
+
+func (n JobRunResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n JobRunResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListJobs returns the jobs run by the scheduler of the controllers with
+// their most recent runs.  runsLimit is the maximum number of runs returned
+// for each job, with zero using the controller's default.  Jobs can only be
+// listed in the global scope.
+func (c *Client) ListJobs(ctx context.Context, scopeId string, runsLimit uint32, opt ...Option) (*JobListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListJobs request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ListJobs request")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-jobs", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListJobs request: %w", err)
+	}
+
+	q := url.Values{}
+	for k, v := range opts.queryMap {
+		q.Add(k, v)
+	}
+	if runsLimit > 0 {
+		q.Set("runs_limit", strconv.FormatUint(uint64(runsLimit), 10))
+	}
+	if len(q) > 0 {
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListJobs call: %w", err)
+	}
+
+	target := new(JobListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListJobs response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// RunJob makes the job with the name due, so it is run by the next controller
+// looking for due jobs, and returns the job.  A running job is run again once
+// its current run ends.  Jobs can only be run in the global scope.
+func (c *Client) RunJob(ctx context.Context, scopeId, name string, opt ...Option) (*JobRunResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RunJob request")
+	}
+	if name == "" {
+		return nil, fmt.Errorf("empty name value passed into RunJob request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RunJob request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"name": name,
+	}
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:run-job", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RunJob request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RunJob call: %w", err)
+	}
+
+	target := new(JobRunResult)
+	target.Item = new(Job)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RunJob response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
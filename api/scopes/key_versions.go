@@ -0,0 +1,127 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// KeyVersionReencryptionTable is the progress of a key version re-encryption
+// for a single table.
+type KeyVersionReencryptionTable struct {
+	Name           string `json:"name,omitempty"`
+	TotalCount     int64  `json:"total_count,omitempty"`
+	RemainingCount int64  `json:"remaining_count,omitempty"`
+}
+
+// KeyVersionReencryption is the progress of the re-encryption of the data
+// encrypted with a key version of a scope.
+type KeyVersionReencryption struct {
+	KeyVersionId string                         `json:"key_version_id,omitempty"`
+	ScopeId      string                         `json:"scope_id,omitempty"`
+	Purpose      string                         `json:"purpose,omitempty"`
+	CreatedTime  time.Time                      `json:"created_time,omitempty"`
+	UpdatedTime  time.Time                      `json:"updated_time,omitempty"`
+	Completed    bool                           `json:"completed,omitempty"`
+	Tables       []*KeyVersionReencryptionTable `json:"tables,omitempty"`
+}
+
+type KeyVersionReencryptionResult struct {
+	Item     *KeyVersionReencryption
+	response *api.Response
+}
+
+func (n KeyVersionReencryptionResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n KeyVersionReencryptionResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ReencryptKeyVersion starts re-encrypting the data encrypted with a previous
+// version of a data encryption key of a scope with the current version and
+// returns the progress of the re-encryption. Calling it again returns the
+// progress of the re-encryption already started.
+func (c *Client) ReencryptKeyVersion(ctx context.Context, scopeId, keyVersionId string, opt ...Option) (*KeyVersionReencryptionResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReencryptKeyVersion request")
+	}
+	if keyVersionId == "" {
+		return nil, fmt.Errorf("empty keyVersionId value passed into ReencryptKeyVersion request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ReencryptKeyVersion request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"key_version_id": keyVersionId,
+	}
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:reencrypt-key-version", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReencryptKeyVersion request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReencryptKeyVersion call: %w", err)
+	}
+
+	target := new(KeyVersionReencryptionResult)
+	target.Item = new(KeyVersionReencryption)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReencryptKeyVersion response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
+
+// DestroyKeyVersion destroys a previous version of the root key or of a data
+// encryption key of a scope. The destruction is refused while any data is
+// still encrypted with the version.
+func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string, opt ...Option) (*ScopeUpdateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into DestroyKeyVersion request")
+	}
+	if keyVersionId == "" {
+		return nil, fmt.Errorf("empty keyVersionId value passed into DestroyKeyVersion request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in DestroyKeyVersion request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"key_version_id": keyVersionId,
+	}
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:destroy-key-version", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DestroyKeyVersion request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DestroyKeyVersion call: %w", err)
+	}
+
+	target := new(ScopeUpdateResult)
+	target.Item = new(Scope)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DestroyKeyVersion response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
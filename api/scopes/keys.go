@@ -0,0 +1,76 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+// KeyVersion describes a version of a key, without its key material.
+type KeyVersion struct {
+	Id              string           `json:"id,omitempty"`
+	Version         uint32           `json:"version,omitempty"`
+	CreatedTime     time.Time        `json:"created_time,omitempty"`
+	Current         bool             `json:"current,omitempty"`
+	ReferenceCounts map[string]int64 `json:"reference_counts,omitempty"`
+}
+
+// Key describes the root key or a data encryption key of a scope and its
+// versions.
+type Key struct {
+	Id          string        `json:"id,omitempty"`
+	ScopeId     string        `json:"scope_id,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Purpose     string        `json:"purpose,omitempty"`
+	CreatedTime time.Time     `json:"created_time,omitempty"`
+	Versions    []*KeyVersion `json:"versions,omitempty"`
+}
+
+type KeyListResult struct {
+	Items    []*Key
+	response *api.Response
+}
+
+func (n KeyListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyListResult) GetResponse() *api.Response {
+	return n.response
+}
+
+// ListKeys returns the root key and data encryption keys of a scope with
+// their versions and the number of rows encrypted with each version.
+func (c *Client) ListKeys(ctx context.Context, scopeId string, opt ...Option) (*KeyListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeys request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in ListKeys request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-keys", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeys request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeys call: %w", err)
+	}
+
+	target := new(KeyListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
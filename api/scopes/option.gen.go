@@ -86,6 +86,30 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithAuthTokenTimeToLiveSeconds(inAuthTokenTimeToLiveSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_live_seconds"] = inAuthTokenTimeToLiveSeconds
+	}
+}
+
+func DefaultAuthTokenTimeToLiveSeconds() Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_live_seconds"] = nil
+	}
+}
+
+func WithAuthTokenTimeToStaleSeconds(inAuthTokenTimeToStaleSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_stale_seconds"] = inAuthTokenTimeToStaleSeconds
+	}
+}
+
+func DefaultAuthTokenTimeToStaleSeconds() Option {
+	return func(o *options) {
+		o.postMap["auth_token_time_to_stale_seconds"] = nil
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -110,6 +134,18 @@ func DefaultName() Option {
 	}
 }
 
+func WithSessionRetentionSeconds(inSessionRetentionSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["session_retention_seconds"] = inSessionRetentionSeconds
+	}
+}
+
+func DefaultSessionRetentionSeconds() Option {
+	return func(o *options) {
+		o.postMap["session_retention_seconds"] = nil
+	}
+}
+
 func WithSkipAdminRoleCreation(inSkipAdminRoleCreation bool) Option {
 	return func(o *options) {
 		o.queryMap["skip_admin_role_creation"] = fmt.Sprintf("%v", inSkipAdminRoleCreation)
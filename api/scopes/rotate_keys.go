@@ -0,0 +1,42 @@
+package scopes
+
+import (
+	"context"
+	"fmt"
+)
+
+// RotateKeys creates new versions of the root key and data encryption keys of
+// a scope. The new versions are used to encrypt data from then on, while the
+// previous versions remain available to decrypt existing data.
+func (c *Client) RotateKeys(ctx context.Context, scopeId string, opt ...Option) (*ScopeUpdateResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RotateKeys request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in RotateKeys request")
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:rotate-keys", scopeId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateKeys request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateKeys call: %w", err)
+	}
+
+	target := new(ScopeUpdateResult)
+	target.Item = new(Scope)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
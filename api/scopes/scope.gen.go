@@ -21,6 +21,9 @@ type Scope struct {
 	UpdatedTime                 time.Time           `json:"updated_time,omitempty"`
 	Version                     uint32              `json:"version,omitempty"`
 	Type                        string              `json:"type,omitempty"`
+	AuthTokenTimeToLiveSeconds  uint32              `json:"auth_token_time_to_live_seconds,omitempty"`
+	AuthTokenTimeToStaleSeconds uint32              `json:"auth_token_time_to_stale_seconds,omitempty"`
+	SessionRetentionSeconds     uint32              `json:"session_retention_seconds,omitempty"`
 	AuthorizedActions           []string            `json:"authorized_actions,omitempty"`
 	AuthorizedCollectionActions map[string][]string `json:"authorized_collection_actions,omitempty"`
 
@@ -85,6 +85,54 @@ func WithRecursive(recurse bool) Option {
 	}
 }
 
+func WithAccessWindowDurationSeconds(inAccessWindowDurationSeconds uint32) Option {
+	return func(o *options) {
+		o.postMap["access_window_duration_seconds"] = inAccessWindowDurationSeconds
+	}
+}
+
+func DefaultAccessWindowDurationSeconds() Option {
+	return func(o *options) {
+		o.postMap["access_window_duration_seconds"] = nil
+	}
+}
+
+func WithAccessWindowSchedule(inAccessWindowSchedule string) Option {
+	return func(o *options) {
+		o.postMap["access_window_schedule"] = inAccessWindowSchedule
+	}
+}
+
+func DefaultAccessWindowSchedule() Option {
+	return func(o *options) {
+		o.postMap["access_window_schedule"] = nil
+	}
+}
+
+func WithAccessWindowTimeZone(inAccessWindowTimeZone string) Option {
+	return func(o *options) {
+		o.postMap["access_window_time_zone"] = inAccessWindowTimeZone
+	}
+}
+
+func DefaultAccessWindowTimeZone() Option {
+	return func(o *options) {
+		o.postMap["access_window_time_zone"] = nil
+	}
+}
+
+func WithAllowedClientCidrs(inAllowedClientCidrs []string) Option {
+	return func(o *options) {
+		o.postMap["allowed_client_cidrs"] = inAllowedClientCidrs
+	}
+}
+
+func DefaultAllowedClientCidrs() Option {
+	return func(o *options) {
+		o.postMap["allowed_client_cidrs"] = nil
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -121,6 +169,18 @@ func DefaultTcpTargetDefaultPort() Option {
 	}
 }
 
+func WithDeniedClientCidrs(inDeniedClientCidrs []string) Option {
+	return func(o *options) {
+		o.postMap["denied_client_cidrs"] = inDeniedClientCidrs
+	}
+}
+
+func DefaultDeniedClientCidrs() Option {
+	return func(o *options) {
+		o.postMap["denied_client_cidrs"] = nil
+	}
+}
+
 func WithDescription(inDescription string) Option {
 	return func(o *options) {
 		o.postMap["description"] = inDescription
@@ -13,22 +13,27 @@ import (
 )
 
 type Target struct {
-	Id                     string                 `json:"id,omitempty"`
-	ScopeId                string                 `json:"scope_id,omitempty"`
-	Scope                  *scopes.ScopeInfo      `json:"scope,omitempty"`
-	Name                   string                 `json:"name,omitempty"`
-	Description            string                 `json:"description,omitempty"`
-	CreatedTime            time.Time              `json:"created_time,omitempty"`
-	UpdatedTime            time.Time              `json:"updated_time,omitempty"`
-	Version                uint32                 `json:"version,omitempty"`
-	Type                   string                 `json:"type,omitempty"`
-	HostSetIds             []string               `json:"host_set_ids,omitempty"`
-	HostSets               []*HostSet             `json:"host_sets,omitempty"`
-	SessionMaxSeconds      uint32                 `json:"session_max_seconds,omitempty"`
-	SessionConnectionLimit int32                  `json:"session_connection_limit,omitempty"`
-	WorkerFilter           string                 `json:"worker_filter,omitempty"`
-	Attributes             map[string]interface{} `json:"attributes,omitempty"`
-	AuthorizedActions      []string               `json:"authorized_actions,omitempty"`
+	Id                          string                 `json:"id,omitempty"`
+	ScopeId                     string                 `json:"scope_id,omitempty"`
+	Scope                       *scopes.ScopeInfo      `json:"scope,omitempty"`
+	Name                        string                 `json:"name,omitempty"`
+	Description                 string                 `json:"description,omitempty"`
+	CreatedTime                 time.Time              `json:"created_time,omitempty"`
+	UpdatedTime                 time.Time              `json:"updated_time,omitempty"`
+	Version                     uint32                 `json:"version,omitempty"`
+	Type                        string                 `json:"type,omitempty"`
+	HostSetIds                  []string               `json:"host_set_ids,omitempty"`
+	HostSets                    []*HostSet             `json:"host_sets,omitempty"`
+	SessionMaxSeconds           uint32                 `json:"session_max_seconds,omitempty"`
+	SessionConnectionLimit      int32                  `json:"session_connection_limit,omitempty"`
+	WorkerFilter                string                 `json:"worker_filter,omitempty"`
+	AllowedClientCidrs          []string               `json:"allowed_client_cidrs,omitempty"`
+	DeniedClientCidrs           []string               `json:"denied_client_cidrs,omitempty"`
+	AccessWindowSchedule        string                 `json:"access_window_schedule,omitempty"`
+	AccessWindowDurationSeconds uint32                 `json:"access_window_duration_seconds,omitempty"`
+	AccessWindowTimeZone        string                 `json:"access_window_time_zone,omitempty"`
+	Attributes                  map[string]interface{} `json:"attributes,omitempty"`
+	AuthorizedActions           []string               `json:"authorized_actions,omitempty"`
 
 	response *api.Response
 }
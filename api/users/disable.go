@@ -0,0 +1,51 @@
+package users
+
+import (
+	"context"
+	"fmt"
+)
+
+// Disable disables a user. A disabled user cannot authenticate, and its
+// auth tokens and sessions are revoked.
+func (c *Client) Disable(ctx context.Context, userId string, opt ...Option) (*UserUpdateResult, error) {
+	return c.setActive(ctx, "Disable", "disable", userId, opt...)
+}
+
+// Enable enables a user which was disabled, allowing it to authenticate
+// again.
+func (c *Client) Enable(ctx context.Context, userId string, opt ...Option) (*UserUpdateResult, error) {
+	return c.setActive(ctx, "Enable", "enable", userId, opt...)
+}
+
+func (c *Client) setActive(ctx context.Context, name, action, userId string, opt ...Option) (*UserUpdateResult, error) {
+	if userId == "" {
+		return nil, fmt.Errorf("empty userId value passed into %s request", name)
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client in %s request", name)
+	}
+
+	_, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("users/%s:%s", userId, action), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s request: %w", name, err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during %s call: %w", name, err)
+	}
+
+	target := new(UserUpdateResult)
+	target.Item = new(User)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s response: %w", name, err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.response = resp
+	return target, nil
+}
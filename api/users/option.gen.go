@@ -108,3 +108,15 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithServiceAccount(inServiceAccount bool) Option {
+	return func(o *options) {
+		o.postMap["service_account"] = inServiceAccount
+	}
+}
+
+func DefaultServiceAccount() Option {
+	return func(o *options) {
+		o.postMap["service_account"] = nil
+	}
+}
@@ -23,6 +23,8 @@ type User struct {
 	Version           uint32            `json:"version,omitempty"`
 	AccountIds        []string          `json:"account_ids,omitempty"`
 	Accounts          []*Account        `json:"accounts,omitempty"`
+	ServiceAccount    bool              `json:"service_account,omitempty"`
+	Active            bool              `json:"active,omitempty"`
 	AuthorizedActions []string          `json:"authorized_actions,omitempty"`
 
 	response *api.Response
@@ -6,11 +6,13 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/apitokens"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/invites"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
@@ -283,6 +285,20 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto: &invites.Invite{},
+		outFile: "invites/invite.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"invite"},
+		parentTypeName:      "auth-method",
+		createResponseTypes: true,
+	},
 	// Auth Tokens
 	{
 		inProto: &authtokens.AuthToken{},
@@ -297,6 +313,20 @@ var inputStructs = []*structInfo{
 		createResponseTypes: true,
 		recursiveListing:    true,
 	},
+	{
+		inProto: &apitokens.ApiToken{},
+		outFile: "apitokens/api_token.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"api-token"},
+		createResponseTypes: true,
+		recursiveListing:    true,
+	},
 	// Host related resources
 	{
 		inProto: &hostcatalogs.HostCatalog{},
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
@@ -55,6 +56,9 @@ type RequestInfo struct {
 	Token          string
 	TokenFormat    TokenFormat
 
+	// ClientIp is the IP address of the client which made the request
+	ClientIp string
+
 	// The following are useful for tests
 	scopeIdOverride      string
 	userIdOverride       string
@@ -69,6 +73,10 @@ type VerifyResults struct {
 	Scope         *scopes.ScopeInfo
 	Authenticated bool
 
+	// ApiToken is true if the request was made with an api token, in which
+	// case AuthTokenId is the id of the api token.
+	ApiToken bool
+
 	// RoundTripValue can be set to allow the function performing authentication
 	// (often accompanied by lookup(s)) to return a result of that lookup to the
 	// calling function. It is opaque to this package.
@@ -112,6 +120,16 @@ func NewVerifierContext(ctx context.Context,
 	})
 }
 
+// ClientIpFromContext returns the IP address of the client which made the
+// request, or an empty string if the context does not contain it.
+func ClientIpFromContext(ctx context.Context) string {
+	v, ok := ctx.Value(verifierKey).(*verifier)
+	if !ok {
+		return ""
+	}
+	return v.requestInfo.ClientIp
+}
+
 // Verify takes in a context that has expected parameters as values and runs an
 // authn/authz check. It returns a user ID, the scope ID for the request (which
 // may come from the URL and may come from the token) and whether or not to
@@ -208,6 +226,7 @@ func Verify(ctx context.Context, opt ...Option) (ret VerifyResults) {
 	}
 
 	ret.AuthTokenId = v.requestInfo.PublicId
+	ret.ApiToken = isApiTokenId(v.requestInfo.PublicId)
 	ret.Authenticated = authResults.Authenticated
 	if !authResults.Authorized {
 		if v.requestInfo.DisableAuthzFailures {
@@ -249,19 +268,39 @@ func (v *verifier) decryptToken() {
 			return
 		}
 
-		at, err := tokenRepo.LookupAuthToken(v.ctx, v.requestInfo.PublicId)
-		if err != nil {
-			v.logger.Trace("decrypt bearer token: failed to look up auth token by public ID", "error", err)
-			v.requestInfo.TokenFormat = AuthTokenTypeUnknown
-			return
-		}
-		if at == nil {
-			v.logger.Trace("decrypt bearer token: nil result from looking up auth token by public ID")
-			v.requestInfo.TokenFormat = AuthTokenTypeUnknown
-			return
+		// Both auth tokens and api tokens are encrypted with the tokens
+		// wrapper of the scope they belong to
+		var tokenScopeId string
+		switch {
+		case isApiTokenId(v.requestInfo.PublicId):
+			at, _, err := tokenRepo.LookupApiToken(v.ctx, v.requestInfo.PublicId)
+			if err != nil {
+				v.logger.Trace("decrypt bearer token: failed to look up api token by public ID", "error", err)
+				v.requestInfo.TokenFormat = AuthTokenTypeUnknown
+				return
+			}
+			if at == nil {
+				v.logger.Trace("decrypt bearer token: nil result from looking up api token by public ID")
+				v.requestInfo.TokenFormat = AuthTokenTypeUnknown
+				return
+			}
+			tokenScopeId = at.GetScopeId()
+		default:
+			at, err := tokenRepo.LookupAuthToken(v.ctx, v.requestInfo.PublicId)
+			if err != nil {
+				v.logger.Trace("decrypt bearer token: failed to look up auth token by public ID", "error", err)
+				v.requestInfo.TokenFormat = AuthTokenTypeUnknown
+				return
+			}
+			if at == nil {
+				v.logger.Trace("decrypt bearer token: nil result from looking up auth token by public ID")
+				v.requestInfo.TokenFormat = AuthTokenTypeUnknown
+				return
+			}
+			tokenScopeId = at.GetScopeId()
 		}
 
-		tokenWrapper, err := v.kms.GetWrapper(v.ctx, at.GetScopeId(), kms.KeyPurposeTokens)
+		tokenWrapper, err := v.kms.GetWrapper(v.ctx, tokenScopeId, kms.KeyPurposeTokens)
 		if err != nil {
 			v.logger.Warn("decrypt bearer token: unable to get wrapper for tokens; continuing as anonymous user", "error", err)
 			v.requestInfo.TokenFormat = AuthTokenTypeUnknown
@@ -363,6 +402,8 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 	scopeInfo = new(scopes.ScopeInfo)
 	userId = "u_anon"
 	var accountId string
+	var restricted bool
+	var restrictions []perms.Grant
 
 	// Validate the token and fetch the corresponding user ID
 	switch v.requestInfo.TokenFormat {
@@ -384,6 +425,35 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 			retErr = errors.Wrap(err, op)
 			return
 		}
+		if isApiTokenId(v.requestInfo.PublicId) {
+			at, grants, err := tokenRepo.ValidateApiToken(v.ctx, v.requestInfo.PublicId, v.requestInfo.Token)
+			if err != nil {
+				v.logger.Error("perform auth check: error validating api token; continuing as anonymous user", "error", err)
+				break
+			}
+			if at == nil {
+				break
+			}
+			// The grants of an api token can only restrict what its service
+			// account is allowed to do, so they are parsed here and
+			// intersected with the grants of the service account below
+			restricted = len(grants) > 0
+			restrictions = make([]perms.Grant, 0, len(grants))
+			for _, g := range grants {
+				parsed, err := perms.Parse(
+					at.GetGrantScopeId(),
+					g.GetCanonicalGrant(),
+					perms.WithUserId(at.GetIamUserId()),
+					perms.WithSkipFinalValidation(true))
+				if err != nil {
+					retErr = errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse api token grant %#v", g.GetCanonicalGrant())))
+					return
+				}
+				restrictions = append(restrictions, parsed)
+			}
+			userId = at.GetIamUserId()
+			break
+		}
 		at, err := tokenRepo.ValidateToken(v.ctx, v.requestInfo.PublicId, v.requestInfo.Token)
 		if err != nil {
 			// Continue as the anonymous user as maybe this token is expired but
@@ -398,6 +468,32 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 				v.logger.Warn("perform auth check: valid token did not map to a user, likely because no account is associated with the user any longer; continuing as u_anon", "token_id", at.GetPublicId())
 				userId = "u_anon"
 				accountId = ""
+				break
+			}
+			if at.IsDerived() {
+				// A derived token is only allowed to do what is allowed by
+				// both its grants and the grants of its user, so its grants
+				// are parsed here and intersected with the user's grants below
+				grants, err := tokenRepo.ListAuthTokenGrants(v.ctx, at.GetPublicId())
+				if err != nil {
+					retErr = errors.Wrap(err, op, errors.WithMsg("failed to list derived auth token grants"))
+					return
+				}
+				restricted = true
+				restrictions = make([]perms.Grant, 0, len(grants))
+				for _, g := range grants {
+					parsed, err := perms.Parse(
+						at.GetGrantScopeId(),
+						g.GetCanonicalGrant(),
+						perms.WithUserId(userId),
+						perms.WithAccountId(accountId),
+						perms.WithSkipFinalValidation(true))
+					if err != nil {
+						retErr = errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse derived auth token grant %#v", g.GetCanonicalGrant())))
+						return
+					}
+					restrictions = append(restrictions, parsed)
+				}
 			}
 		}
 	}
@@ -476,6 +572,9 @@ func (v verifier) performAuthCheck() (aclResults perms.ACLResults, userId string
 	}
 
 	retAcl = perms.NewACL(parsedGrants...)
+	if restricted {
+		retAcl = retAcl.Restrict(restrictions...)
+	}
 	aclResults = retAcl.Allowed(*v.res, v.act)
 	// We don't set authenticated above because setting this but not authorized
 	// is used for further permissions checks, such as during recursive listing.
@@ -536,6 +635,12 @@ func (r *VerifyResults) fetchActions(ctx context.Context, id string, typ resourc
 	return ret
 }
 
+// isApiTokenId returns true if the public id is the id of an api token rather
+// than of an auth token.
+func isApiTokenId(publicId string) bool {
+	return strings.HasPrefix(publicId, authtoken.ApiTokenPrefix+"_")
+}
+
 // GetTokenFromRequest pulls the token from either the Authorization header or
 // split cookies and parses it. If it cannot be parsed successfully, the issue
 // is logged and we return blank, so logic will continue as the anonymous user.
@@ -11,8 +11,11 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -133,3 +136,206 @@ func TestAuthTokenAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestApiTokenAuthenticator(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	logger := hclog.New(nil)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return tokenRepo, nil
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iamRepo)
+	sa := iam.TestUser(t, iamRepo, o.GetPublicId(), iam.WithServiceAccount(true))
+	role := iam.TestRole(t, conn, o.GetPublicId())
+	iam.TestUserRole(t, conn, role.GetPublicId(), sa.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=read,update")
+
+	newApiToken := func(grants ...string) string {
+		at, err := authtoken.NewApiToken(sa.GetPublicId())
+		require.NoError(t, err)
+		at, _, err = tokenRepo.CreateApiToken(context.Background(), at, grants)
+		require.NoError(t, err)
+		encToken, err := authtoken.EncryptToken(context.Background(), kms, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+		require.NoError(t, err)
+		return at.GetPublicId() + "_" + encToken
+	}
+
+	cases := []struct {
+		name           string
+		token          string
+		act            action.Type
+		wantUserId     string
+		wantAuthorized bool
+	}{
+		{
+			name:           "unrestricted",
+			token:          newApiToken(),
+			act:            action.Update,
+			wantUserId:     sa.GetPublicId(),
+			wantAuthorized: true,
+		},
+		{
+			name:           "restricted-allowed",
+			token:          newApiToken("id=*;type=*;actions=read"),
+			act:            action.Read,
+			wantUserId:     sa.GetPublicId(),
+			wantAuthorized: true,
+		},
+		{
+			name:       "restricted-denied",
+			token:      newApiToken("id=*;type=*;actions=read"),
+			act:        action.Update,
+			wantUserId: sa.GetPublicId(),
+		},
+		{
+			name:       "restriction-does-not-grant",
+			token:      newApiToken("id=*;type=*;actions=delete"),
+			act:        action.Delete,
+			wantUserId: sa.GetPublicId(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := httptest.NewRequest("GET", "http://127.0.0.1/v1/scopes/"+o.GetPublicId(), nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.token))
+
+			requestInfo := RequestInfo{
+				Path:   req.URL.Path,
+				Method: req.Method,
+			}
+			requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(logger, kms, req)
+			require.Equal(AuthTokenTypeBearer, requestInfo.TokenFormat)
+
+			ctx := NewVerifierContext(context.Background(), logger, iamRepoFn, tokenRepoFn, serversRepoFn, kms, requestInfo)
+			v, ok := ctx.Value(verifierKey).(*verifier)
+			require.True(ok)
+			v.ctx = ctx
+			v.act = tc.act
+			v.res = &perms.Resource{ScopeId: o.GetPublicId(), Id: "ttcp_1234567890", Type: resource.Target}
+
+			v.decryptToken()
+			require.NotEmpty(v.requestInfo.Token)
+
+			results, userId, _, _, err := v.performAuthCheck()
+			require.NoError(err)
+			assert.Equal(tc.wantUserId, userId)
+			assert.True(results.Authenticated)
+			assert.Equal(tc.wantAuthorized, results.Authorized)
+		})
+	}
+}
+
+func TestDerivedAuthTokenAuthenticator(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	logger := hclog.New(nil)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	tokenRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	tokenRepoFn := func() (*authtoken.Repository, error) {
+		return tokenRepo, nil
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	o, p := iam.TestScopes(t, iamRepo)
+	parent := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	role := iam.TestRole(t, conn, o.GetPublicId(), iam.WithGrantScopeId(p.GetPublicId()))
+	iam.TestUserRole(t, conn, role.GetPublicId(), parent.GetIamUserId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=*;actions=read,update")
+
+	encrypt := func(at *authtoken.AuthToken) string {
+		encToken, err := authtoken.EncryptToken(context.Background(), kms, o.GetPublicId(), at.GetPublicId(), at.GetToken())
+		require.NoError(t, err)
+		return at.GetPublicId() + "_" + encToken
+	}
+	newDerivedToken := func(grants ...string) string {
+		at, _, err := tokenRepo.DeriveAuthToken(context.Background(), parent.GetPublicId(), grants, authtoken.WithGrantScopeId(p.GetPublicId()))
+		require.NoError(t, err)
+		return encrypt(at)
+	}
+
+	cases := []struct {
+		name           string
+		token          string
+		act            action.Type
+		wantAuthorized bool
+	}{
+		{
+			name:           "parent",
+			token:          encrypt(parent),
+			act:            action.Update,
+			wantAuthorized: true,
+		},
+		{
+			name:           "restricted-allowed",
+			token:          newDerivedToken("id=ttcp_1234567890;actions=read"),
+			act:            action.Read,
+			wantAuthorized: true,
+		},
+		{
+			name:  "restricted-denied",
+			token: newDerivedToken("id=ttcp_1234567890;actions=read"),
+			act:   action.Update,
+		},
+		{
+			name:  "restricted-other-id",
+			token: newDerivedToken("id=ttcp_0987654321;actions=read,update"),
+			act:   action.Read,
+		},
+		{
+			name:  "restriction-does-not-grant",
+			token: newDerivedToken("id=*;type=*;actions=delete"),
+			act:   action.Delete,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := httptest.NewRequest("GET", "http://127.0.0.1/v1/targets/ttcp_1234567890", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tc.token))
+
+			requestInfo := RequestInfo{
+				Path:   req.URL.Path,
+				Method: req.Method,
+			}
+			requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = GetTokenFromRequest(logger, kms, req)
+			require.Equal(AuthTokenTypeBearer, requestInfo.TokenFormat)
+
+			ctx := NewVerifierContext(context.Background(), logger, iamRepoFn, tokenRepoFn, serversRepoFn, kms, requestInfo)
+			v, ok := ctx.Value(verifierKey).(*verifier)
+			require.True(ok)
+			v.ctx = ctx
+			v.act = tc.act
+			v.res = &perms.Resource{ScopeId: p.GetPublicId(), Id: "ttcp_1234567890", Type: resource.Target}
+
+			v.decryptToken()
+			require.NotEmpty(v.requestInfo.Token)
+
+			results, userId, _, _, err := v.performAuthCheck()
+			require.NoError(err)
+			assert.Equal(parent.GetIamUserId(), userId)
+			assert.True(results.Authenticated)
+			assert.Equal(tc.wantAuthorized, results.Authorized)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package password
+
+import (
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+)
+
+// An AccountLockout contains the failed authentication attempts and lockouts
+// of an account. It is maintained by the repository while authenticating.
+type AccountLockout struct {
+	*store.AccountLockout
+	tableName string
+}
+
+func allocAccountLockout() *AccountLockout {
+	return &AccountLockout{
+		AccountLockout: &store.AccountLockout{},
+	}
+}
+
+// TableName returns the table name.
+func (l *AccountLockout) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "auth_password_account_lockout"
+}
+
+// SetTableName sets the table name.
+func (l *AccountLockout) SetTableName(n string) {
+	l.tableName = n
+}
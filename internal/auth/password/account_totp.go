@@ -0,0 +1,155 @@
+package password
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+)
+
+// An AccountTotp contains the TOTP secret of an account. The secret is
+// encrypted with the database key of the account's scope before it is stored.
+type AccountTotp struct {
+	*store.AccountTotp
+	tableName string
+}
+
+func allocAccountTotp() *AccountTotp {
+	return &AccountTotp{
+		AccountTotp: &store.AccountTotp{},
+	}
+}
+
+// TableName returns the table name.
+func (t *AccountTotp) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_account_totp"
+}
+
+// SetTableName sets the table name.
+func (t *AccountTotp) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *AccountTotp) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(AccountTotp).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.AccountTotp, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	t.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (t *AccountTotp) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(AccountTotp).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.AccountTotp, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (t *AccountTotp) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{t.AccountId},
+		"resource-type":      []string{"password account totp"},
+		"op-type":            []string{op.String()},
+	}
+}
+
+// An AccountTotpRecoveryCode contains the hash of a single-use recovery code
+// which can be used instead of a TOTP code.
+type AccountTotpRecoveryCode struct {
+	*store.AccountTotpRecoveryCode
+	tableName string
+}
+
+// TableName returns the table name.
+func (c *AccountTotpRecoveryCode) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_account_totp_recovery_code"
+}
+
+// SetTableName sets the table name.
+func (c *AccountTotpRecoveryCode) SetTableName(n string) {
+	c.tableName = n
+}
+
+// An AccountTotpPending contains the TOTP secret of a new enrollment of an
+// account which has not been confirmed. It replaces the AccountTotp of the
+// account when it is confirmed. The secret is encrypted with the database key
+// of the account's scope before it is stored.
+type AccountTotpPending struct {
+	*store.AccountTotpPending
+	tableName string
+}
+
+func allocAccountTotpPending() *AccountTotpPending {
+	return &AccountTotpPending{
+		AccountTotpPending: &store.AccountTotpPending{},
+	}
+}
+
+// TableName returns the table name.
+func (t *AccountTotpPending) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_account_totp_pending"
+}
+
+// SetTableName sets the table name.
+func (t *AccountTotpPending) SetTableName(n string) {
+	t.tableName = n
+}
+
+func (t *AccountTotpPending) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(AccountTotpPending).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, t.AccountTotpPending, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	t.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (t *AccountTotpPending) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(AccountTotpPending).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, t.AccountTotpPending, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (t *AccountTotpPending) oplog(op oplog.OpType) oplog.Metadata {
+	return oplog.Metadata{
+		"resource-public-id": []string{t.AccountId},
+		"resource-type":      []string{"password account totp pending"},
+		"op-type":            []string{op.String()},
+	}
+}
+
+// An AccountTotpPendingRecoveryCode contains the hash of a recovery code of a
+// pending enrollment.
+type AccountTotpPendingRecoveryCode struct {
+	*store.AccountTotpPendingRecoveryCode
+	tableName string
+}
+
+// TableName returns the table name.
+func (c *AccountTotpPendingRecoveryCode) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_account_totp_pending_recovery_code"
+}
+
+// SetTableName sets the table name.
+func (c *AccountTotpPendingRecoveryCode) SetTableName(n string) {
+	c.tableName = n
+}
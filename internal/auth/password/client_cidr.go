@@ -0,0 +1,116 @@
+package password
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+const (
+	allowClientCidrRule = "allow"
+	denyClientCidrRule  = "deny"
+)
+
+// A ClientCidr is a CIDR block which clients of an auth method must, or must
+// not, authenticate from.
+type ClientCidr struct {
+	*store.ClientCidr
+	tableName string
+}
+
+func allocClientCidr() *ClientCidr {
+	return &ClientCidr{
+		ClientCidr: &store.ClientCidr{},
+	}
+}
+
+// TableName returns the table name.
+func (c *ClientCidr) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_method_client_cidr"
+}
+
+// SetTableName sets the table name.
+func (c *ClientCidr) SetTableName(n string) {
+	c.tableName = n
+}
+
+// clientCidrs returns the allowed and denied client cidrs of the auth methods
+// with the provided ids keyed by auth method id.
+func clientCidrs(ctx context.Context, reader db.Reader, authMethodIds ...string) (allowed, denied map[string][]string, err error) {
+	const op = "password.clientCidrs"
+	if len(authMethodIds) == 0 {
+		return nil, nil, nil
+	}
+	var cidrs []*ClientCidr
+	if err := reader.SearchWhere(ctx, &cidrs, "password_method_id in (?)", []interface{}{authMethodIds}, db.WithLimit(-1), db.WithOrder("cidr asc")); err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	allowed = make(map[string][]string, len(authMethodIds))
+	denied = make(map[string][]string, len(authMethodIds))
+	for _, c := range cidrs {
+		switch c.Rule {
+		case allowClientCidrRule:
+			allowed[c.PasswordMethodId] = append(allowed[c.PasswordMethodId], c.Cidr)
+		case denyClientCidrRule:
+			denied[c.PasswordMethodId] = append(denied[c.PasswordMethodId], c.Cidr)
+		}
+	}
+	return allowed, denied, nil
+}
+
+// setClientCidrs replaces the allowed and denied client cidrs of m.
+func setClientCidrs(ctx context.Context, r db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, m *AuthMethod, allowed, denied []string) error {
+	const op = "password.setClientCidrs"
+	allowed, err := cidr.Normalize(allowed)
+	if err != nil {
+		return errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	denied, err = cidr.Normalize(denied)
+	if err != nil {
+		return errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	var existing []*ClientCidr
+	if err := r.SearchWhere(ctx, &existing, "password_method_id = ?", []interface{}{m.PublicId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if len(existing) > 0 {
+		items := make([]interface{}, 0, len(existing))
+		for _, c := range existing {
+			items = append(items, c)
+		}
+		if _, err := w.DeleteItems(ctx, items, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_DELETE))); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to delete client cidrs"))
+		}
+	}
+	items := make([]interface{}, 0, len(allowed)+len(denied))
+	for rule, cidrs := range map[string][]string{allowClientCidrRule: allowed, denyClientCidrRule: denied} {
+		for _, c := range cidrs {
+			cc := allocClientCidr()
+			cc.PasswordMethodId = m.PublicId
+			cc.Cidr = c
+			cc.Rule = rule
+			items = append(items, cc)
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := w.CreateItems(ctx, items, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to create client cidrs"))
+	}
+	return nil
+}
+
+// ClientAllowed reports whether a client with the provided IP address is
+// allowed to authenticate with m.
+func (m *AuthMethod) ClientAllowed(clientIp string) bool {
+	return cidr.Allowed(clientIp, m.GetAllowedClientCidrs(), m.GetDeniedClientCidrs())
+}
@@ -0,0 +1,64 @@
+package password
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ClientCidrs(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	in, err := NewAuthMethod(o.GetPublicId())
+	require.NoError(t, err)
+	in.AllowedClientCidrs = []string{"192.168.1.1", "10.0.0.0/8"}
+	in.DeniedClientCidrs = []string{"10.1.0.0/16"}
+	am, err := repo.CreateAuthMethod(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32"}, am.AllowedClientCidrs)
+	assert.Equal(t, []string{"10.1.0.0/16"}, am.DeniedClientCidrs)
+	assert.True(t, am.ClientAllowed("10.0.0.1"))
+	assert.False(t, am.ClientAllowed("10.1.0.1"))
+	assert.False(t, am.ClientAllowed("172.16.0.1"))
+
+	got, err := repo.LookupAuthMethod(ctx, am.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, am.AllowedClientCidrs, got.AllowedClientCidrs)
+	assert.Equal(t, am.DeniedClientCidrs, got.DeniedClientCidrs)
+
+	// only the denied cidrs are replaced
+	got.DeniedClientCidrs = []string{"10.2.0.0/16"}
+	updated, rows, err := repo.UpdateAuthMethod(ctx, got, got.Version, []string{"DeniedClientCidrs"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rows)
+	assert.Equal(t, got.Version+1, updated.Version)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.1.1/32"}, updated.AllowedClientCidrs)
+	assert.Equal(t, []string{"10.2.0.0/16"}, updated.DeniedClientCidrs)
+
+	// removing all of them allows every client
+	updated.AllowedClientCidrs, updated.DeniedClientCidrs = nil, nil
+	updated, _, err = repo.UpdateAuthMethod(ctx, updated, updated.Version, []string{"AllowedClientCidrs", "DeniedClientCidrs"})
+	require.NoError(t, err)
+	assert.Empty(t, updated.AllowedClientCidrs)
+	assert.Empty(t, updated.DeniedClientCidrs)
+	assert.True(t, updated.ClientAllowed("172.16.0.1"))
+
+	updated.AllowedClientCidrs = []string{"not-a-cidr"}
+	_, _, err = repo.UpdateAuthMethod(ctx, updated, updated.Version, []string{"AllowedClientCidrs"})
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
@@ -0,0 +1,295 @@
+package password
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/proto"
+)
+
+// The types of password hashes which can be imported.
+const (
+	hashTypeBcrypt       = "bcrypt"
+	hashTypePbkdf2Sha1   = "pbkdf2-sha1"
+	hashTypePbkdf2Sha256 = "pbkdf2-sha256"
+	hashTypePbkdf2Sha512 = "pbkdf2-sha512"
+	hashTypeScrypt       = "scrypt"
+)
+
+// Upper bounds on the cost parameters of imported hashes. They keep a
+// malicious hash from making every authentication attempt for an account
+// arbitrarily expensive.
+const (
+	maxPbkdf2Iterations = 10_000_000
+	maxScryptLogN       = 20
+	maxScryptR          = 32
+	maxScryptP          = 16
+)
+
+// An ImportedCredential contains a password hash imported from another
+// system. It is owned by an Account and is replaced by an Argon2Credential
+// using the current configuration of the auth method the next time the
+// Account successfully authenticates.
+type ImportedCredential struct {
+	*store.ImportedCredential
+	tableName string
+}
+
+func allocImportedCredential() *ImportedCredential {
+	return &ImportedCredential{
+		ImportedCredential: &store.ImportedCredential{},
+	}
+}
+
+// newImportedCredential returns a credential for accountId containing
+// passwordHash. An error with code PasswordUnsupportedHash is returned if
+// passwordHash can not be parsed.
+func newImportedCredential(accountId, passwordHash string) (*ImportedCredential, error) {
+	const op = "password.newImportedCredential"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing accountId")
+	}
+	if passwordHash == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing password hash")
+	}
+	h, err := parsePasswordHash(passwordHash)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	id, err := newImportedCredentialId()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return &ImportedCredential{
+		ImportedCredential: &store.ImportedCredential{
+			PrivateId:         id,
+			PasswordAccountId: accountId,
+			HashType:          h.hashType,
+			PasswordHash:      []byte(passwordHash),
+		},
+	}, nil
+}
+
+func (c *ImportedCredential) clone() *ImportedCredential {
+	cp := proto.Clone(c.ImportedCredential)
+	return &ImportedCredential{
+		ImportedCredential: cp.(*store.ImportedCredential),
+	}
+}
+
+// TableName returns the table name.
+func (c *ImportedCredential) TableName() string {
+	if c != nil && c.tableName != "" {
+		return c.tableName
+	}
+	return "auth_password_imported_cred"
+}
+
+// SetTableName sets the table name.
+func (c *ImportedCredential) SetTableName(n string) {
+	c.tableName = n
+}
+
+func (c *ImportedCredential) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(ImportedCredential).encrypt"
+	if err := structwrapping.WrapStruct(ctx, cipher, c.ImportedCredential, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	c.KeyId = cipher.KeyID()
+	return nil
+}
+
+func (c *ImportedCredential) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	const op = "password.(ImportedCredential).decrypt"
+	if err := structwrapping.UnwrapStruct(ctx, cipher, c.ImportedCredential, nil); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Decrypt))
+	}
+	return nil
+}
+
+func (c *ImportedCredential) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id":  []string{c.PrivateId},
+		"resource-type":       []string{"imported credential"},
+		"op-type":             []string{op.String()},
+		"password-account-id": []string{c.PasswordAccountId},
+	}
+	if c.PasswordMethodId != "" {
+		metadata["password-method-id"] = []string{c.PasswordMethodId}
+	}
+	return metadata
+}
+
+// verify reports whether password matches the decrypted password hash of c.
+func (c *ImportedCredential) verify(password string) (bool, error) {
+	const op = "password.(ImportedCredential).verify"
+	h, err := parsePasswordHash(string(c.PasswordHash))
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	return h.verify(password)
+}
+
+// passwordHash is a parsed password hash.
+type passwordHash struct {
+	hashType string
+	encoded  string
+
+	// pbkdf2 and scrypt parameters
+	salt       []byte
+	key        []byte
+	iterations int
+	prf        func() hash.Hash
+	logN       int
+	r          int
+	p          int
+}
+
+// parsePasswordHash parses an encoded password hash. bcrypt hashes use the
+// modular crypt format ($2a$, $2b$, or $2y$). PBKDF2 and scrypt hashes use
+// the PHC string format with base64 encoded salts and hashes:
+//
+//	$pbkdf2-sha256$i=<iterations>$<salt>$<hash>
+//	$scrypt$ln=<log2 N>,r=<r>,p=<p>$<salt>$<hash>
+//
+// PBKDF2 hashes may use sha1, sha256, or sha512.
+func parsePasswordHash(encoded string) (*passwordHash, error) {
+	const op = "password.parsePasswordHash"
+	unsupported := func(msg string) error {
+		return errors.New(errors.PasswordUnsupportedHash, op, msg)
+	}
+	h := &passwordHash{encoded: encoded}
+	if strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$") {
+		if _, err := bcrypt.Cost([]byte(encoded)); err != nil {
+			return nil, unsupported(fmt.Sprintf("invalid bcrypt hash: %s", err))
+		}
+		h.hashType = hashTypeBcrypt
+		return h, nil
+	}
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[0] != "" {
+		return nil, unsupported("unknown hash format")
+	}
+	var err error
+	if h.salt, err = decodeHashBase64(parts[3]); err != nil || len(h.salt) == 0 {
+		return nil, unsupported("invalid salt")
+	}
+	if h.key, err = decodeHashBase64(parts[4]); err != nil || len(h.key) == 0 {
+		return nil, unsupported("invalid hash")
+	}
+
+	switch parts[1] {
+	case hashTypePbkdf2Sha1, hashTypePbkdf2Sha256, hashTypePbkdf2Sha512:
+		h.hashType = parts[1]
+		switch h.hashType {
+		case hashTypePbkdf2Sha1:
+			h.prf = sha1.New
+		case hashTypePbkdf2Sha256:
+			h.prf = sha256.New
+		default:
+			h.prf = sha512.New
+		}
+		params, err := parseHashParams(parts[2], "i")
+		if err != nil {
+			return nil, unsupported(err.Error())
+		}
+		h.iterations = params["i"]
+		if h.iterations < 1 || h.iterations > maxPbkdf2Iterations {
+			return nil, unsupported(fmt.Sprintf("pbkdf2 iterations must be between 1 and %d", maxPbkdf2Iterations))
+		}
+	case hashTypeScrypt:
+		h.hashType = hashTypeScrypt
+		params, err := parseHashParams(parts[2], "ln", "r", "p")
+		if err != nil {
+			return nil, unsupported(err.Error())
+		}
+		h.logN, h.r, h.p = params["ln"], params["r"], params["p"]
+		switch {
+		case h.logN < 1 || h.logN > maxScryptLogN:
+			return nil, unsupported(fmt.Sprintf("scrypt ln must be between 1 and %d", maxScryptLogN))
+		case h.r < 1 || h.r > maxScryptR:
+			return nil, unsupported(fmt.Sprintf("scrypt r must be between 1 and %d", maxScryptR))
+		case h.p < 1 || h.p > maxScryptP:
+			return nil, unsupported(fmt.Sprintf("scrypt p must be between 1 and %d", maxScryptP))
+		}
+	default:
+		return nil, unsupported(fmt.Sprintf("unsupported hash algorithm %q", parts[1]))
+	}
+	return h, nil
+}
+
+// verify reports whether password matches h.
+func (h *passwordHash) verify(password string) (bool, error) {
+	const op = "password.(passwordHash).verify"
+	switch h.hashType {
+	case hashTypeBcrypt:
+		switch err := bcrypt.CompareHashAndPassword([]byte(h.encoded), []byte(password)); {
+		case err == nil:
+			return true, nil
+		case err == bcrypt.ErrMismatchedHashAndPassword:
+			return false, nil
+		default:
+			return false, errors.New(errors.PasswordUnsupportedHash, op, err.Error())
+		}
+	case hashTypePbkdf2Sha1, hashTypePbkdf2Sha256, hashTypePbkdf2Sha512:
+		key := pbkdf2.Key([]byte(password), h.salt, h.iterations, len(h.key), h.prf)
+		return subtle.ConstantTimeCompare(key, h.key) == 1, nil
+	case hashTypeScrypt:
+		key, err := scrypt.Key([]byte(password), h.salt, 1<<h.logN, h.r, h.p, len(h.key))
+		if err != nil {
+			return false, errors.New(errors.PasswordUnsupportedHash, op, err.Error())
+		}
+		return subtle.ConstantTimeCompare(key, h.key) == 1, nil
+	default:
+		return false, errors.New(errors.PasswordUnsupportedHash, op, fmt.Sprintf("unsupported hash type %q", h.hashType))
+	}
+}
+
+// parseHashParams parses the comma separated name=value parameters of a PHC
+// string. All of names, and no other parameters, must be present.
+func parseHashParams(in string, names ...string) (map[string]int, error) {
+	params := make(map[string]int, len(names))
+	for _, kv := range strings.Split(in, ",") {
+		k, v := kv, ""
+		if i := strings.IndexByte(kv, '='); i >= 0 {
+			k, v = kv[:i], kv[i+1:]
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter %q", kv)
+		}
+		params[k] = n
+	}
+	if len(params) != len(names) {
+		return nil, fmt.Errorf("parameters must be %s", strings.Join(names, ","))
+	}
+	for _, n := range names {
+		if _, ok := params[n]; !ok {
+			return nil, fmt.Errorf("missing parameter %q", n)
+		}
+	}
+	return params, nil
+}
+
+// decodeHashBase64 decodes the base64 encoded salts and hashes of PHC
+// strings. Padding is optional.
+func decodeHashBase64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+}
@@ -0,0 +1,197 @@
+package password
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+func testPbkdf2Hash(t *testing.T, hashType, password string) string {
+	t.Helper()
+	salt := []byte("0123456789abcdef")
+	var key []byte
+	switch hashType {
+	case hashTypePbkdf2Sha1:
+		key = pbkdf2.Key([]byte(password), salt, 1000, sha1.Size, sha1.New)
+	case hashTypePbkdf2Sha256:
+		key = pbkdf2.Key([]byte(password), salt, 1000, sha256.Size, sha256.New)
+	case hashTypePbkdf2Sha512:
+		key = pbkdf2.Key([]byte(password), salt, 1000, sha512.Size, sha512.New)
+	}
+	return fmt.Sprintf("$%s$i=1000$%s$%s", hashType, base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func testScryptHash(t *testing.T, password string) string {
+	t.Helper()
+	salt := []byte("0123456789abcdef")
+	key, err := scrypt.Key([]byte(password), salt, 1<<10, 8, 1, 32)
+	require.NoError(t, err)
+	return fmt.Sprintf("$scrypt$ln=10,r=8,p=1$%s$%s", base64.StdEncoding.EncodeToString(salt), base64.StdEncoding.EncodeToString(key))
+}
+
+func testBcryptHash(t *testing.T, password string) string {
+	t.Helper()
+	h, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(h)
+}
+
+func TestPasswordHash_Verify(t *testing.T) {
+	t.Parallel()
+	const passwd = "imported password"
+	tests := []struct {
+		name     string
+		encoded  string
+		hashType string
+	}{
+		{"bcrypt", testBcryptHash(t, passwd), hashTypeBcrypt},
+		{"pbkdf2-sha1", testPbkdf2Hash(t, hashTypePbkdf2Sha1, passwd), hashTypePbkdf2Sha1},
+		{"pbkdf2-sha256", testPbkdf2Hash(t, hashTypePbkdf2Sha256, passwd), hashTypePbkdf2Sha256},
+		{"pbkdf2-sha512", testPbkdf2Hash(t, hashTypePbkdf2Sha512, passwd), hashTypePbkdf2Sha512},
+		{"scrypt-padded", testScryptHash(t, passwd), hashTypeScrypt},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			h, err := parsePasswordHash(tt.encoded)
+			require.NoError(err)
+			assert.Equal(tt.hashType, h.hashType)
+
+			ok, err := h.verify(passwd)
+			require.NoError(err)
+			assert.True(ok)
+
+			ok, err = h.verify("wrong password")
+			require.NoError(err)
+			assert.False(ok)
+		})
+	}
+}
+
+func TestParsePasswordHash_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{"plaintext", "password"},
+		{"unknown-algorithm", "$md5$i=1$c2FsdA$aGFzaA"},
+		{"bad-bcrypt", "$2a$99$short"},
+		{"missing-iterations", "$pbkdf2-sha256$c2FsdA$aGFzaA"},
+		{"bad-iterations", "$pbkdf2-sha256$i=abc$c2FsdA$aGFzaA"},
+		{"too-many-iterations", "$pbkdf2-sha256$i=100000000$c2FsdA$aGFzaA"},
+		{"extra-param", "$pbkdf2-sha256$i=1,x=2$c2FsdA$aGFzaA"},
+		{"bad-salt", "$pbkdf2-sha256$i=1$!!!$aGFzaA"},
+		{"empty-hash", "$pbkdf2-sha256$i=1$c2FsdA$"},
+		{"missing-scrypt-param", "$scrypt$ln=10,r=8$c2FsdA$aGFzaA"},
+		{"scrypt-ln-too-large", "$scrypt$ln=30,r=8,p=1$c2FsdA$aGFzaA"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h, err := parsePasswordHash(tt.encoded)
+			assert.Truef(t, errors.Match(errors.T(errors.PasswordUnsupportedHash), err), "unexpected error %v", err)
+			assert.Nil(t, h)
+		})
+	}
+}
+
+func TestRepository_ImportedCredential(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	am := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	const passwd = "imported password"
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "invalid"},
+		}, WithPasswordHash("not a hash"))
+		assert.Truef(t, errors.Match(errors.T(errors.PasswordUnsupportedHash), err), "unexpected error %v", err)
+
+		_, err = repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "invalid"},
+		}, WithPassword(passwd), WithPasswordHash(testBcryptHash(t, passwd)))
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+
+	for _, tt := range []struct {
+		loginName string
+		hash      string
+	}{
+		{"bcrypt", testBcryptHash(t, passwd)},
+		{"pbkdf2", testPbkdf2Hash(t, hashTypePbkdf2Sha256, passwd)},
+		{"scrypt", testScryptHash(t, passwd)},
+	} {
+		tt := tt
+		t.Run(tt.loginName, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+				Account: &store.Account{AuthMethodId: am.PublicId, LoginName: tt.loginName},
+			}, WithPasswordHash(tt.hash))
+			require.NoError(err)
+
+			a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, tt.loginName, "wrong password")
+			require.NoError(err)
+			assert.Nil(a)
+
+			a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, tt.loginName, passwd)
+			require.NoError(err)
+			require.NotNil(a)
+
+			// the imported hash has been replaced with an argon2 credential
+			var imported []*ImportedCredential
+			require.NoError(rw.SearchWhere(ctx, &imported, "password_account_id = ?", []interface{}{acct.PublicId}))
+			assert.Empty(imported)
+			var creds []*Argon2Credential
+			require.NoError(rw.SearchWhere(ctx, &creds, "password_account_id = ?", []interface{}{acct.PublicId}))
+			assert.Len(creds, 1)
+
+			a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, tt.loginName, passwd)
+			require.NoError(err)
+			assert.NotNil(a)
+		})
+	}
+
+	t.Run("change-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "change"},
+		}, WithPasswordHash(testBcryptHash(t, passwd)))
+		require.NoError(err)
+
+		acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, passwd, "new password", acct.Version)
+		require.NoError(err)
+		require.NotNil(acct)
+
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, "change", "new password")
+		require.NoError(err)
+		assert.NotNil(a)
+	})
+}
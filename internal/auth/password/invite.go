@@ -0,0 +1,112 @@
+package password
+
+import (
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"google.golang.org/protobuf/proto"
+)
+
+// An Invite is an invitation to enroll in an AuthMethod. Redeeming an Invite
+// creates an Account in the AuthMethod and a user in the scope of the
+// AuthMethod. The Account is associated with the user and the user is added
+// to the roles of the Invite. An Invite can only be redeemed once. Only the
+// SHA-256 hash of the invite token is stored.
+type Invite struct {
+	*store.Invite
+	// RoleIds are the ids of the roles the user created by redeeming the
+	// Invite is added to.
+	RoleIds []string `gorm:"-"`
+	// Token is the invite token. It is only set on the Invite returned by
+	// CreateInvite.
+	Token     string `gorm:"-"`
+	tableName string
+}
+
+// NewInvite creates a new in memory Invite for the AuthMethod with
+// authMethodId. WithName and WithDescription are the only valid options. All
+// other options are ignored.
+func NewInvite(authMethodId string, opt ...Option) (*Invite, error) {
+	const op = "password.NewInvite"
+	if authMethodId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	}
+	opts := getOpts(opt...)
+	return &Invite{
+		Invite: &store.Invite{
+			AuthMethodId: authMethodId,
+			Name:         opts.withName,
+			Description:  opts.withDescription,
+		},
+	}, nil
+}
+
+func allocInvite() *Invite {
+	return &Invite{
+		Invite: &store.Invite{},
+	}
+}
+
+func (i *Invite) clone() *Invite {
+	cp := proto.Clone(i.Invite)
+	return &Invite{
+		Invite:  cp.(*store.Invite),
+		RoleIds: append([]string(nil), i.RoleIds...),
+	}
+}
+
+// Redeemed reports whether the Invite has been redeemed.
+func (i *Invite) Redeemed() bool {
+	return i.GetRedeemTime() != nil
+}
+
+// TableName returns the table name.
+func (i *Invite) TableName() string {
+	if i.tableName != "" {
+		return i.tableName
+	}
+	return "auth_password_invite"
+}
+
+// SetTableName sets the table name.
+func (i *Invite) SetTableName(n string) {
+	i.tableName = n
+}
+
+func (i *Invite) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{i.GetPublicId()},
+		"resource-type":      []string{"password invite"},
+		"op-type":            []string{op.String()},
+	}
+	if i.AuthMethodId != "" {
+		metadata["auth-method-id"] = []string{i.AuthMethodId}
+	}
+	return metadata
+}
+
+// An InviteRole is a role the user created by redeeming an Invite is added
+// to.
+type InviteRole struct {
+	*store.InviteRole
+	tableName string
+}
+
+func allocInviteRole() *InviteRole {
+	return &InviteRole{
+		InviteRole: &store.InviteRole{},
+	}
+}
+
+// TableName returns the table name.
+func (r *InviteRole) TableName() string {
+	if r.tableName != "" {
+		return r.tableName
+	}
+	return "auth_password_invite_role"
+}
+
+// SetTableName sets the table name.
+func (r *InviteRole) SetTableName(n string) {
+	r.tableName = n
+}
@@ -1,5 +1,11 @@
 package password
 
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
 // getOpts - iterate the inbound Options and return a struct.
 func getOpts(opt ...Option) options {
 	opts := getDefaultOptions()
@@ -14,14 +20,21 @@ type Option func(*options)
 
 // options = how options are represented
 type options struct {
-	withName        string
-	withDescription string
-	withLoginName   string
-	withLimit       int
-	withConfig      Configuration
-	withPublicId    string
-	password        string
-	withPassword    bool
+	withName         string
+	withDescription  string
+	withLoginName    string
+	withLimit        int
+	withConfig       Configuration
+	withPublicId     string
+	password         string
+	withPassword     bool
+	withTotpCode     string
+	withRecoveryCode string
+	withNewPassword  string
+	withPasswordHash string
+	withResetTtl     time.Duration
+	withInviteTtl    time.Duration
+	withAccountTxFn  func(db.Reader, db.Writer, *Account) error
 }
 
 func getDefaultOptions() options {
@@ -81,3 +94,58 @@ func WithConfiguration(config Configuration) Option {
 		o.withConfig = config
 	}
 }
+
+// WithTotpCode provides an optional TOTP code.
+func WithTotpCode(code string) Option {
+	return func(o *options) {
+		o.withTotpCode = code
+	}
+}
+
+// WithRecoveryCode provides an optional TOTP recovery code.
+func WithRecoveryCode(code string) Option {
+	return func(o *options) {
+		o.withRecoveryCode = code
+	}
+}
+
+// WithNewPassword provides an optional new password which replaces an
+// expired password.
+func WithNewPassword(password string) Option {
+	return func(o *options) {
+		o.withNewPassword = password
+	}
+}
+
+// WithPasswordHash provides an optional password hash imported from another
+// system.
+func WithPasswordHash(hash string) Option {
+	return func(o *options) {
+		o.withPasswordHash = hash
+	}
+}
+
+// WithResetTokenTtl provides an optional duration for which a reset token
+// can be used.
+func WithResetTokenTtl(ttl time.Duration) Option {
+	return func(o *options) {
+		o.withResetTtl = ttl
+	}
+}
+
+// withAccountTxFn provides a function which CreateAccount calls with the new
+// account within the transaction which creates the account. The account is
+// not created if the function returns an error.
+func withAccountTxFn(fn func(db.Reader, db.Writer, *Account) error) Option {
+	return func(o *options) {
+		o.withAccountTxFn = fn
+	}
+}
+
+// WithInviteTtl provides an optional duration for which an invite can be
+// redeemed.
+func WithInviteTtl(ttl time.Duration) Option {
+	return func(o *options) {
+		o.withInviteTtl = ttl
+	}
+}
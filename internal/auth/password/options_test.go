@@ -2,6 +2,7 @@ package password
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -57,4 +58,40 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withConfig = c
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithTotpCode", func(t *testing.T) {
+		opts := getOpts(WithTotpCode("123456"))
+		testOpts := getDefaultOptions()
+		testOpts.withTotpCode = "123456"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithRecoveryCode", func(t *testing.T) {
+		opts := getOpts(WithRecoveryCode("abcde-fghjk"))
+		testOpts := getDefaultOptions()
+		testOpts.withRecoveryCode = "abcde-fghjk"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithNewPassword", func(t *testing.T) {
+		opts := getOpts(WithNewPassword("new password"))
+		testOpts := getDefaultOptions()
+		testOpts.withNewPassword = "new password"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithPasswordHash", func(t *testing.T) {
+		opts := getOpts(WithPasswordHash("$2a$10$hash"))
+		testOpts := getDefaultOptions()
+		testOpts.withPasswordHash = "$2a$10$hash"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithResetTokenTtl", func(t *testing.T) {
+		opts := getOpts(WithResetTokenTtl(time.Minute))
+		testOpts := getDefaultOptions()
+		testOpts.withResetTtl = time.Minute
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithInviteTtl", func(t *testing.T) {
+		opts := getOpts(WithInviteTtl(time.Hour))
+		testOpts := getDefaultOptions()
+		testOpts.withInviteTtl = time.Hour
+		assert.Equal(t, opts, testOpts)
+	})
 }
@@ -0,0 +1,256 @@
+package password
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"golang.org/x/crypto/argon2"
+)
+
+// A PolicyViolationError lists the rules of the password policy of an auth
+// method which a password violates. It is wrapped by the errors with code
+// PasswordPolicyViolation returned when setting a password.
+type PolicyViolationError struct {
+	Violations []string
+}
+
+// Error satisfies the error interface.
+func (e *PolicyViolationError) Error() string {
+	return strings.Join(e.Violations, ", ")
+}
+
+// A BannedPassword is a password which can not be used by the accounts of an
+// auth method.
+type BannedPassword struct {
+	*store.BannedPassword
+	tableName string
+}
+
+func allocBannedPassword() *BannedPassword {
+	return &BannedPassword{
+		BannedPassword: &store.BannedPassword{},
+	}
+}
+
+// TableName returns the table name.
+func (b *BannedPassword) TableName() string {
+	if b.tableName != "" {
+		return b.tableName
+	}
+	return "auth_password_method_banned_password"
+}
+
+// SetTableName sets the table name.
+func (b *BannedPassword) SetTableName(n string) {
+	b.tableName = n
+}
+
+// normalizeBannedPasswords returns the distinct, lower cased, non-empty
+// passwords in passwords, preserving their order.
+func normalizeBannedPasswords(passwords []string) []string {
+	var out []string
+	seen := make(map[string]bool, len(passwords))
+	for _, p := range passwords {
+		p = strings.ToLower(p)
+		if p == "" || seen[p] {
+			continue
+		}
+		seen[p] = true
+		out = append(out, p)
+	}
+	return out
+}
+
+// bannedPasswords returns the banned passwords of the auth methods with the
+// provided ids keyed by auth method id.
+func bannedPasswords(ctx context.Context, reader db.Reader, authMethodIds ...string) (map[string][]string, error) {
+	const op = "password.bannedPasswords"
+	if len(authMethodIds) == 0 {
+		return nil, nil
+	}
+	var banned []*BannedPassword
+	if err := reader.SearchWhere(ctx, &banned, "password_method_id in (?)", []interface{}{authMethodIds}, db.WithLimit(-1)); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	out := make(map[string][]string, len(authMethodIds))
+	for _, b := range banned {
+		out[b.PasswordMethodId] = append(out[b.PasswordMethodId], b.Password)
+	}
+	return out, nil
+}
+
+// setBannedPasswords replaces the banned passwords of m with passwords.
+func setBannedPasswords(ctx context.Context, r db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, m *AuthMethod, passwords []string) error {
+	const op = "password.setBannedPasswords"
+	var existing []*BannedPassword
+	if err := r.SearchWhere(ctx, &existing, "password_method_id = ?", []interface{}{m.PublicId}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if len(existing) > 0 {
+		items := make([]interface{}, 0, len(existing))
+		for _, b := range existing {
+			items = append(items, b)
+		}
+		if _, err := w.DeleteItems(ctx, items, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_DELETE))); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to delete banned passwords"))
+		}
+	}
+	passwords = normalizeBannedPasswords(passwords)
+	if len(passwords) == 0 {
+		return nil
+	}
+	items := make([]interface{}, 0, len(passwords))
+	for _, p := range passwords {
+		b := allocBannedPassword()
+		b.PasswordMethodId = m.PublicId
+		b.Password = p
+		items = append(items, b)
+	}
+	if err := w.CreateItems(ctx, items, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to create banned passwords"))
+	}
+	return nil
+}
+
+// policyViolations returns the character class and banned password rules of
+// the password policy in c which password violates.
+func (c *currentConfig) policyViolations(password string, banned []string) []string {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsLetter(r):
+			hasSymbol = true
+		}
+	}
+	var violations []string
+	if c.PasswordRequireUppercase && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if c.PasswordRequireLowercase && !hasLower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if c.PasswordRequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if c.PasswordRequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a character which is not a letter or a digit")
+	}
+	lower := strings.ToLower(password)
+	for _, b := range banned {
+		if lower == b {
+			violations = append(violations, "must not be a banned password")
+			break
+		}
+	}
+	return violations
+}
+
+// checkPasswordPolicy returns an error with code PasswordPolicyViolation if
+// password violates the password policy in cc. If accountId is not empty,
+// password must also not be one of the cc.PasswordHistoryCount most recent
+// passwords of the account. The minimum password length is not checked.
+func (r *Repository) checkPasswordPolicy(ctx context.Context, cc *currentConfig, scopeId, accountId, password string) error {
+	const op = "password.(Repository).checkPasswordPolicy"
+	banned, err := bannedPasswords(ctx, r.reader, cc.PasswordMethodId)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	violations := cc.policyViolations(password, banned[cc.PasswordMethodId])
+	if accountId != "" && cc.PasswordHistoryCount > 0 {
+		reused, err := r.passwordReused(ctx, scopeId, accountId, password, cc.PasswordHistoryCount)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if reused {
+			violations = append(violations, fmt.Sprintf("must not be one of the last %d passwords", cc.PasswordHistoryCount))
+		}
+	}
+	if len(violations) > 0 {
+		return errors.New(errors.PasswordPolicyViolation, op, "password violates password policy", errors.WithWrap(&PolicyViolationError{Violations: violations}))
+	}
+	return nil
+}
+
+// previousPassword is the salt and derived key of the current or a previous
+// password of an account along with the argon2 parameters used to derive the
+// key.
+type previousPassword struct {
+	CtSalt     []byte `gorm:"column:salt" wrapping:"ct,entry_salt"`
+	Salt       []byte `gorm:"-" wrapping:"pt,entry_salt"`
+	DerivedKey []byte
+	KeyId      string
+	Iterations uint32
+	Memory     uint32
+	Threads    uint32
+	KeyLength  uint32
+}
+
+// passwordReused reports whether password matches the current password of
+// accountId or one of its historyCount - 1 previous passwords.
+func (r *Repository) passwordReused(ctx context.Context, scopeId, accountId, password string, historyCount int) (bool, error) {
+	const op = "password.(Repository).passwordReused"
+	rows, err := r.reader.Query(ctx, previousPasswordsQuery, []interface{}{accountId, historyCount - 1})
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var prev []*previousPassword
+	for rows.Next() {
+		var p previousPassword
+		if err := r.reader.ScanRows(rows, &p); err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		prev = append(prev, &p)
+	}
+	for _, p := range prev {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(p.KeyId))
+		if err != nil {
+			return false, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := structwrapping.UnwrapStruct(ctx, databaseWrapper, p, nil); err != nil {
+			return false, errors.Wrap(err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt previous password"))
+		}
+		key := argon2.IDKey([]byte(password), p.Salt, p.Iterations, p.Memory, uint8(p.Threads), p.KeyLength)
+		if subtle.ConstantTimeCompare(key, p.DerivedKey) == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// updatePasswordHistory adds the current credential of accountId to its
+// password history, if historyCount is greater than 1, and removes all but
+// the historyCount - 1 most recent passwords from the history. It must be
+// called before the current credential is replaced.
+func updatePasswordHistory(ctx context.Context, w db.Writer, accountId string, historyCount int) error {
+	const op = "password.updatePasswordHistory"
+	if historyCount > 1 {
+		if _, err := w.Exec(ctx, savePasswordHistoryQuery, []interface{}{accountId}); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	keep := historyCount - 1
+	if keep < 0 {
+		keep = 0
+	}
+	if _, err := w.Exec(ctx, prunePasswordHistoryQuery, []interface{}{accountId, keep}); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
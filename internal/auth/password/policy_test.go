@@ -0,0 +1,180 @@
+package password
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrentConfig_policyViolations(t *testing.T) {
+	t.Parallel()
+	all := &currentConfig{
+		PasswordRequireUppercase: true,
+		PasswordRequireLowercase: true,
+		PasswordRequireDigit:     true,
+		PasswordRequireSymbol:    true,
+	}
+	tests := []struct {
+		name     string
+		cc       *currentConfig
+		password string
+		banned   []string
+		want     []string
+	}{
+		{
+			name:     "no-policy",
+			cc:       &currentConfig{},
+			password: "password",
+		},
+		{
+			name:     "all-classes",
+			cc:       all,
+			password: "Passw0rd!",
+		},
+		{
+			name:     "missing-all-classes",
+			cc:       all,
+			password: "      ",
+			want: []string{
+				"must contain an uppercase letter",
+				"must contain a lowercase letter",
+				"must contain a digit",
+			},
+		},
+		{
+			name:     "missing-upper-and-symbol",
+			cc:       all,
+			password: "passw0rd",
+			want: []string{
+				"must contain an uppercase letter",
+				"must contain a character which is not a letter or a digit",
+			},
+		},
+		{
+			name:     "banned-case-insensitive",
+			cc:       &currentConfig{},
+			password: "PassWord",
+			banned:   []string{"hunter2", "password"},
+			want:     []string{"must not be a banned password"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.cc.policyViolations(tt.password, tt.banned))
+		})
+	}
+}
+
+func TestNormalizeBannedPasswords(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, normalizeBannedPasswords(nil))
+	assert.Equal(t, []string{"password", "hunter2"}, normalizeBannedPasswords([]string{"Password", "", "hunter2", "PASSWORD"}))
+}
+
+func TestRepository_PasswordPolicy(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	am := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	am.PasswordRequireDigit = true
+	am.PasswordHistoryCount = 2
+	am.BannedPasswords = []string{"Password1", "letmein1"}
+	am, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"PasswordRequireDigit", "PasswordHistoryCount", "BannedPasswords"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"password1", "letmein1"}, am.BannedPasswords)
+
+	got, err := repo.LookupAuthMethod(ctx, am.PublicId)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, am.BannedPasswords, got.BannedPasswords)
+
+	assertViolation := func(t *testing.T, err error, want ...string) {
+		t.Helper()
+		require.Truef(t, errors.Match(errors.T(errors.PasswordPolicyViolation), err), "unexpected error %v", err)
+		var pve *PolicyViolationError
+		require.True(t, stderrors.As(err, &pve))
+		assert.Equal(t, want, pve.Violations)
+	}
+
+	t.Run("create", func(t *testing.T) {
+		acct := &Account{Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "create"}}
+		_, err := repo.CreateAccount(ctx, o.GetPublicId(), acct, WithPassword("nodigits"))
+		assertViolation(t, err, "must contain a digit")
+		_, err = repo.CreateAccount(ctx, o.GetPublicId(), acct, WithPassword("PASSWORD1"))
+		assertViolation(t, err, "must not be a banned password")
+		_, err = repo.CreateAccount(ctx, o.GetPublicId(), acct, WithPassword("correct1"))
+		require.NoError(t, err)
+	})
+
+	t.Run("history", func(t *testing.T) {
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "history"},
+		}, WithPassword("first111"))
+		require.NoError(t, err)
+
+		acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "first111", "second22", acct.Version)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+
+		// the current and the previous password can not be reused
+		_, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "second22", acct.Version)
+		assertViolation(t, err, "must not be one of the last 2 passwords")
+		_, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "second22", "first111", acct.Version)
+		assertViolation(t, err, "must not be one of the last 2 passwords")
+
+		acct, err = repo.ChangePassword(ctx, o.GetPublicId(), acct.PublicId, "second22", "third333", acct.Version)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+
+		// first111 has fallen out of the history
+		acct, err = repo.SetPassword(ctx, o.GetPublicId(), acct.PublicId, "first111", acct.Version)
+		require.NoError(t, err)
+		require.NotNil(t, acct)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+			Account: &store.Account{AuthMethodId: am.PublicId, LoginName: "expiry"},
+		}, WithPassword("expire11"))
+		require.NoError(t, err)
+
+		am.MaxPasswordAgeSeconds = 1
+		am, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"MaxPasswordAgeSeconds"})
+		require.NoError(t, err)
+		time.Sleep(1500 * time.Millisecond)
+
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "expire11")
+		assert.Truef(t, errors.Match(errors.T(errors.PasswordExpired), err), "unexpected error %v", err)
+		assert.Nil(t, a)
+
+		_, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "expire11", WithNewPassword("nodigits"))
+		assertViolation(t, err, "must contain a digit")
+
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "expire11", WithNewPassword("renewed1"))
+		require.NoError(t, err)
+		require.NotNil(t, a)
+		assert.Equal(t, acct.Version+1, a.Version)
+
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "renewed1")
+		require.NoError(t, err)
+		assert.NotNil(t, a)
+	})
+}
@@ -9,6 +9,7 @@ import (
 const (
 	argon2ConfigurationPrefix = "arg2conf"
 	argon2CredentialPrefix    = "arg2cred"
+	importedCredentialPrefix  = "impcred"
 )
 
 func newArgon2ConfigurationId() (string, error) {
@@ -28,3 +29,12 @@ func newArgon2CredentialId() (string, error) {
 	}
 	return id, nil
 }
+
+func newImportedCredentialId() (string, error) {
+	const op = "password.newImportedCredentialId"
+	id, err := db.NewPrivateId(importedCredentialPrefix)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return id, nil
+}
@@ -9,6 +9,7 @@ import (
 const (
 	AuthMethodPrefix = "ampw"
 	AccountPrefix    = "apw"
+	InvitePrefix     = "ipw"
 )
 
 func newAuthMethodId() (string, error) {
@@ -28,3 +29,12 @@ func newAccountId() (string, error) {
 	}
 	return id, nil
 }
+
+func newInviteId() (string, error) {
+	const op = "password.newInviteId"
+	id, err := db.NewPublicId(InvitePrefix)
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return id, nil
+}
@@ -19,4 +19,9 @@ func Test_PublicIds(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, strings.HasPrefix(id, AccountPrefix+"_"))
 	})
+	t.Run("invite", func(t *testing.T) {
+		id, err := newInviteId()
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id, InvitePrefix+"_"))
+	})
 }
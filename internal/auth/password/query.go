@@ -11,7 +11,8 @@ select acct.name,                        -- Account.Name
        acct.create_time,                 -- Account.CreateTime
        acct.update_time,                 -- Account.UpdateTime
        acct.version,                     -- Account.Version
-       cred.private_id as credential_id, -- Account.CredentialId
+       acct.active,                      -- Account.Active
+       coalesce(cred.private_id, imp.private_id) as credential_id, -- Account.CredentialId
        cred.private_id,                  -- Argon2Credential.PrivateId
        cred.password_conf_id,            -- Argon2Credential.PasswordConfId
        cred.salt,                        -- Argon2Credential.CtSalt/Salt
@@ -20,16 +21,127 @@ select acct.name,                        -- Account.Name
        conf.iterations,                  -- Argon2Configuration.Iterations
        conf.memory,                      -- Argon2Configuration.Memory
        conf.threads,                     -- Argon2Configuration.Threads
-       meth.password_conf_id = cred.password_conf_id as is_current_conf
-  from auth_password_argon2_cred cred,
-       auth_password_argon2_conf conf,
-       auth_password_account acct,
-       auth_password_method meth
+       imp.private_id as imported_credential_id,
+       imp.password_hash as imported_password_hash,
+       imp.key_id as imported_key_id,
+       coalesce(meth.password_conf_id = cred.password_conf_id, false) as is_current_conf,
+       coalesce(meth.lockout_threshold, 0) as lockout_threshold,
+       coalesce(meth.lockout_duration_seconds, 0) as lockout_duration_seconds,
+       coalesce(lo.locked_until_time > current_timestamp, false) as is_locked,
+       lo.account_id is not null as has_lockout,
+       totp.confirm_time is not null as has_totp,
+       coalesce(meth.mfa_policy, 'optional') = 'required' as mfa_required,
+       coalesce(coalesce(cred.create_time, imp.create_time) + make_interval(secs => meth.max_password_age_seconds) < current_timestamp, false) as is_password_expired
+  from auth_password_account acct
+  join auth_password_method meth
+         on acct.auth_method_id = meth.public_id
+  left join auth_password_argon2_cred cred
+         on cred.password_account_id = acct.public_id
+  left join auth_password_argon2_conf conf
+         on cred.password_conf_id = conf.private_id
+  left join auth_password_imported_cred imp
+         on imp.password_account_id = acct.public_id
+  left join auth_password_account_lockout lo
+         on lo.account_id = acct.public_id
+  left join auth_password_account_totp totp
+         on totp.account_id = acct.public_id
  where acct.auth_method_id = $1
    and acct.login_name = $2
-   and cred.password_conf_id = conf.private_id
-   and cred.password_account_id = acct.public_id
-   and acct.auth_method_id = meth.public_id ;
+   and (cred.private_id is not null or imp.private_id is not null);
+`
+	recordFailedAttemptQuery = `
+insert into auth_password_account_lockout
+       (account_id, failed_attempt_count, last_failed_attempt_time)
+values ($1, 1, current_timestamp)
+    on conflict (account_id) do update
+   set failed_attempt_count     = auth_password_account_lockout.failed_attempt_count + 1,
+       last_failed_attempt_time = current_timestamp;
+`
+	// lockAccountQuery locks an account once its failed authentication
+	// attempts reach the lockout threshold ($2). The first lockout lasts $3
+	// seconds and each consecutive lockout lasts twice as long as the previous
+	// one, up to a maximum of 24 hours.
+	lockAccountQuery = `
+update auth_password_account_lockout
+   set failed_attempt_count = 0,
+       lockout_count        = lockout_count + 1,
+       locked_until_time    = current_timestamp + least(
+                                make_interval(secs => $3 * power(2, least(lockout_count, 16))),
+                                interval '24 hours')
+ where account_id = $1
+   and failed_attempt_count >= $2;
+`
+	resetLockoutQuery = `
+update auth_password_account_lockout
+   set failed_attempt_count = 0,
+       lockout_count        = 0,
+       locked_until_time    = null
+ where account_id = $1;
+`
+	useTotpCodeQuery = `
+update auth_password_account_totp
+   set last_counter = $2
+ where account_id = $1
+   and confirm_time is not null
+   and (last_counter is null or last_counter < $2);
+`
+	useRecoveryCodeQuery = `
+delete from auth_password_account_totp_recovery_code
+ where account_id = $1
+   and code_hash = $2;
+`
+	deleteResetTokensQuery = `
+delete from auth_password_reset_token
+ where account_id = $1;
+`
+	useResetTokenQuery = `
+delete from auth_password_reset_token
+ where token_hash = $1
+   and account_id = $2
+   and expiration_time > current_timestamp;
+`
+	// previousPasswordsQuery returns the current password of an account ($1)
+	// and its $2 most recent previous passwords.
+	previousPasswordsQuery = `
+select cred.salt,
+       cred.derived_key,
+       cred.key_id,
+       conf.iterations,
+       conf.memory,
+       conf.threads,
+       conf.key_length
+  from (
+         select password_conf_id, salt, derived_key, key_id
+           from auth_password_argon2_cred
+          where password_account_id = $1
+      union all
+        (select password_conf_id, salt, derived_key, key_id
+           from auth_password_argon2_cred_history
+          where password_account_id = $1
+       order by create_time desc
+          limit $2)
+       ) cred
+  join auth_password_argon2_conf conf
+    on conf.private_id = cred.password_conf_id;
+`
+	savePasswordHistoryQuery = `
+insert into auth_password_argon2_cred_history
+       (password_account_id, password_conf_id, salt, derived_key, key_id)
+select password_account_id, password_conf_id, salt, derived_key, key_id
+  from auth_password_argon2_cred
+ where password_account_id = $1
+    on conflict do nothing;
+`
+	prunePasswordHistoryQuery = `
+delete from auth_password_argon2_cred_history
+ where password_account_id = $1
+   and derived_key not in (
+         select derived_key
+           from auth_password_argon2_cred_history
+          where password_account_id = $1
+       order by create_time desc
+          limit $2
+       );
 `
 	currentConfigForAccountQuery = `
 select *
@@ -40,5 +152,9 @@ select *
          from auth_password_account
         where public_id = $1
     );
+`
+	deleteAccountAuthTokensQuery = `
+delete from auth_token
+ where auth_account_id = ?;
 `
 )
@@ -21,7 +21,11 @@ import (
 // a must contain a valid LoginName. a.LoginName must be unique within
 // a.AuthMethodId.
 //
-// WithPassword is the only valid option. All other options are ignored.
+// WithPassword and WithPasswordHash are the only valid options. All other
+// options are ignored. WithPasswordHash imports a password hash from another
+// system; the password policy of the auth method can not be checked for an
+// imported hash. See ImportedCredential for the supported hash formats. Only
+// one of WithPassword and WithPasswordHash can be provided.
 //
 // Both a.Name and a.Description are optional. If a.Name is set, it must be
 // unique within a.AuthMethodId.
@@ -64,11 +68,25 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 
 	opts := getOpts(opt...)
 
+	if opts.withPassword && opts.withPasswordHash != "" {
+		return nil, errors.New(errors.InvalidParameter, op, "password and password hash are mutually exclusive")
+	}
+
+	var importedCred *ImportedCredential
+	if opts.withPasswordHash != "" {
+		if importedCred, err = newImportedCredential(id, opts.withPasswordHash); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
 	var cred *Argon2Credential
 	if opts.withPassword {
 		if cc.MinPasswordLength > len(opts.password) {
 			return nil, errors.New(errors.PasswordTooShort, op, fmt.Sprintf("must be longer than %v", cc.MinPasswordLength))
 		}
+		if err := r.checkPasswordPolicy(ctx, cc, scopeId, "", opts.password); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
 		if cred, err = newArgon2Credential(id, opts.password, cc.argon2()); err != nil {
 			return nil, errors.Wrap(err, op)
 		}
@@ -86,7 +104,7 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 	var newCred *Argon2Credential
 	var newAccount *Account
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			newAccount = a.clone()
 			if err := w.Create(ctx, newAccount, db.WithOplog(oplogWrapper, a.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
 				return errors.Wrap(err, op)
@@ -101,6 +119,18 @@ func (r *Repository) CreateAccount(ctx context.Context, scopeId string, a *Accou
 					return errors.Wrap(err, op)
 				}
 			}
+			if importedCred != nil {
+				newImported := importedCred.clone()
+				if err := newImported.encrypt(ctx, databaseWrapper); err != nil {
+					return errors.Wrap(err, op)
+				}
+				if err := w.Create(ctx, newImported, db.WithOplog(oplogWrapper, importedCred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			if opts.withAccountTxFn != nil {
+				return opts.withAccountTxFn(reader, w, newAccount)
+			}
 			return nil
 		},
 	)
@@ -314,3 +344,62 @@ func (r *Repository) UpdateAccount(ctx context.Context, scopeId string, a *Accou
 
 	return returnedAccount, rowsUpdated, nil
 }
+
+// SetAccountActive sets whether the account is active and returns the
+// account. An account which is not active is disabled and cannot
+// authenticate. Disabling an account deletes the auth tokens issued to it in
+// the same transaction, and the database cancels the sessions created with
+// them. Setting the state an account is already in is not an error. All
+// options are ignored.
+func (r *Repository) SetAccountActive(ctx context.Context, scopeId, accountId string, active bool, _ ...Option) (*Account, error) {
+	const op = "password.(Repository).SetAccountActive"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	a := allocAccount()
+	a.PublicId = accountId
+	if err := r.reader.LookupByPublicId(ctx, a); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", accountId)))
+	}
+	if a.Active == active {
+		return a, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt),
+			errors.WithMsg(("unable to get oplog wrapper")))
+	}
+	metadata := a.oplog(oplog.OpType_OP_TYPE_UPDATE)
+
+	var returnedAccount *Account
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedAccount = a.clone()
+			returnedAccount.Active = active
+			// the version is not checked as the active state does not depend
+			// on the other fields of the account.
+			rowsUpdated, err := w.Update(ctx, returnedAccount, []string{"Active"}, nil, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if !active {
+				// tokens are not replicated, so they don't need oplog entries.
+				if _, err := w.Exec(ctx, deleteAccountAuthTokensQuery, []interface{}{accountId}); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete auth tokens"))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(accountId))
+	}
+	return returnedAccount, nil
+}
@@ -0,0 +1,82 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// defaultLockoutDuration is the duration of the first lockout of an account
+// if the auth method of the account does not set a lockout duration.
+const defaultLockoutDuration = 5 * time.Minute
+
+// LookupAccountLockout returns the failed authentication attempts and
+// lockouts of the account with the provided id. If the account has never
+// failed to authenticate, it will return nil, nil. All options are ignored.
+func (r *Repository) LookupAccountLockout(ctx context.Context, accountId string, _ ...Option) (*AccountLockout, error) {
+	const op = "password.(Repository).LookupAccountLockout"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	l := allocAccountLockout()
+	if err := r.reader.LookupWhere(ctx, l, "account_id = ?", accountId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", accountId)))
+	}
+	return l, nil
+}
+
+// UnlockAccount unlocks the account with the provided id and resets its
+// failed authentication attempts and lockouts. Unlocking an account which is
+// not locked is not an error. All options are ignored.
+func (r *Repository) UnlockAccount(ctx context.Context, accountId string, _ ...Option) error {
+	const op = "password.(Repository).UnlockAccount"
+	if accountId == "" {
+		return errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	if err := r.resetLockout(ctx, accountId); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// recordFailedAttempt records a failed authentication attempt for accountId
+// and locks the account if the failed attempts reach threshold. A zero
+// threshold means the account is never locked.
+func (r *Repository) recordFailedAttempt(ctx context.Context, accountId string, threshold uint32, duration time.Duration) error {
+	const op = "password.(Repository).recordFailedAttempt"
+	if duration <= 0 {
+		duration = defaultLockoutDuration
+	}
+	_, err := r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, recordFailedAttemptQuery, []interface{}{accountId}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to record failed attempt"))
+			}
+			if threshold == 0 {
+				return nil
+			}
+			if _, err := w.Exec(ctx, lockAccountQuery, []interface{}{accountId, threshold, duration.Seconds()}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to lock account"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+func (r *Repository) resetLockout(ctx context.Context, accountId string) error {
+	const op = "password.(Repository).resetLockout"
+	if _, err := r.writer.Exec(ctx, resetLockoutQuery, []interface{}{accountId}); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
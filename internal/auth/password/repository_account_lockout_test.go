@@ -0,0 +1,119 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AccountLockout(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	am := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	am.LockoutThreshold = 3
+	am.LockoutDurationSeconds = 60
+	am, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"LockoutThreshold", "LockoutDurationSeconds"})
+	require.NoError(t, err)
+
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+		Account: &store.Account{
+			AuthMethodId: am.PublicId,
+			LoginName:    "kazmierczak",
+		},
+	}, WithPassword(passwd))
+	require.NoError(t, err)
+
+	authenticate := func(t *testing.T, pw string) *Account {
+		t.Helper()
+		got, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, pw)
+		require.NoError(t, err)
+		return got
+	}
+	lockout := func(t *testing.T) *AccountLockout {
+		t.Helper()
+		l, err := repo.LookupAccountLockout(ctx, acct.PublicId)
+		require.NoError(t, err)
+		return l
+	}
+
+	t.Run("failed-attempts-reset-on-success", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		assert.Nil(lockout(t))
+		assert.Nil(authenticate(t, "wrong password"))
+		assert.Nil(authenticate(t, "wrong password"))
+		l := lockout(t)
+		require.NotNil(l)
+		assert.Equal(uint32(2), l.FailedAttemptCount)
+		assert.NotNil(l.LastFailedAttemptTime)
+		assert.Nil(l.LockedUntilTime)
+
+		assert.NotNil(authenticate(t, passwd))
+		l = lockout(t)
+		require.NotNil(l)
+		assert.Zero(l.FailedAttemptCount)
+		assert.Zero(l.LockoutCount)
+	})
+	t.Run("locked-after-threshold", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		for i := 0; i < 3; i++ {
+			assert.Nil(authenticate(t, "wrong password"))
+		}
+		l := lockout(t)
+		require.NotNil(l)
+		assert.Zero(l.FailedAttemptCount)
+		assert.Equal(uint32(1), l.LockoutCount)
+		require.NotNil(l.LockedUntilTime)
+		lockedFor := time.Until(l.LockedUntilTime.GetTimestamp().AsTime())
+		assert.True(lockedFor > 50*time.Second && lockedFor <= 60*time.Second, "locked for %s", lockedFor)
+
+		// the correct password does not authenticate a locked account
+		assert.Nil(authenticate(t, passwd))
+	})
+	t.Run("lockout-duration-doubles", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := rw.Exec(ctx, "update auth_password_account_lockout set locked_until_time = now() - interval '1 second' where account_id = $1", []interface{}{acct.PublicId})
+		require.NoError(err)
+		for i := 0; i < 3; i++ {
+			assert.Nil(authenticate(t, "wrong password"))
+		}
+		l := lockout(t)
+		require.NotNil(l)
+		assert.Equal(uint32(2), l.LockoutCount)
+		require.NotNil(l.LockedUntilTime)
+		lockedFor := time.Until(l.LockedUntilTime.GetTimestamp().AsTime())
+		assert.True(lockedFor > 110*time.Second && lockedFor <= 120*time.Second, "locked for %s", lockedFor)
+	})
+	t.Run("unlock", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NoError(repo.UnlockAccount(ctx, acct.PublicId))
+		l := lockout(t)
+		require.NotNil(l)
+		assert.Zero(l.FailedAttemptCount)
+		assert.Zero(l.LockoutCount)
+		assert.Nil(l.LockedUntilTime)
+		assert.NotNil(authenticate(t, passwd))
+	})
+	t.Run("missing-account-id", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.Error(repo.UnlockAccount(ctx, ""))
+		_, err := repo.LookupAccountLockout(ctx, "")
+		assert.Error(err)
+	})
+}
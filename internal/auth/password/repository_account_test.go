@@ -452,6 +452,53 @@ func TestRepository_DeleteAccount(t *testing.T) {
 	}
 }
 
+func TestRepository_SetAccountActive(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	authMethod := TestAuthMethods(t, conn, org.GetPublicId(), 1)[0]
+	account := TestAccounts(t, conn, authMethod.GetPublicId(), 1)[0]
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	t.Run("disable-and-enable", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.SetAccountActive(context.Background(), org.GetPublicId(), account.GetPublicId(), false)
+		require.NoError(err)
+		assert.False(got.GetActive())
+		assert.NoError(db.TestVerifyOplog(t, rw, account.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second)))
+
+		got, err = repo.SetAccountActive(context.Background(), org.GetPublicId(), account.GetPublicId(), false)
+		require.NoError(err)
+		assert.False(got.GetActive())
+
+		got, err = repo.SetAccountActive(context.Background(), org.GetPublicId(), account.GetPublicId(), true)
+		require.NoError(err)
+		assert.True(got.GetActive())
+		found, err := repo.LookupAccount(context.Background(), account.GetPublicId())
+		require.NoError(err)
+		assert.True(found.GetActive())
+	})
+	t.Run("errors", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.SetAccountActive(context.Background(), org.GetPublicId(), "", false)
+		assert.True(errors.Match(errors.T(errors.InvalidPublicId), err))
+		_, err = repo.SetAccountActive(context.Background(), "", account.GetPublicId(), false)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		newAcctId, err := newAccountId()
+		require.NoError(t, err)
+		_, err = repo.SetAccountActive(context.Background(), org.GetPublicId(), newAcctId, false)
+		assert.True(errors.IsNotFoundError(err))
+	})
+}
+
 func TestRepository_ListAccounts(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
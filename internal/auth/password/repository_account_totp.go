@@ -0,0 +1,385 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// A TotpEnrollment contains the TOTP secret and the recovery codes of a new
+// TOTP enrollment. They are not returned by the repository again.
+type TotpEnrollment struct {
+	// Secret is the base32 encoded TOTP secret.
+	Secret string
+	// Url is an otpauth URL containing the TOTP secret.
+	Url string
+	// RecoveryCodes are single-use codes which can be used instead of a TOTP
+	// code.
+	RecoveryCodes []string
+}
+
+// EnrollTotp creates a pending TOTP enrollment with new recovery codes for
+// the account with the provided id, replacing any pending enrollment of the
+// account. A confirmed enrollment of the account and its recovery codes are
+// still used to authenticate until the pending enrollment is confirmed with
+// ConfirmTotp. All options are ignored.
+func (r *Repository) EnrollTotp(ctx context.Context, scopeId, accountId string, _ ...Option) (*TotpEnrollment, error) {
+	const op = "password.(Repository).EnrollTotp"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+
+	secret, err := newTotpSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to generate totp secret"))
+	}
+	recoveryCodes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to generate recovery codes"))
+	}
+	pending := allocAccountTotpPending()
+	pending.AccountId = accountId
+	pending.Secret = secret
+	if err := pending.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	codes := make([]interface{}, 0, len(recoveryCodes))
+	for _, c := range recoveryCodes {
+		codes = append(codes, &AccountTotpPendingRecoveryCode{
+			AccountTotpPendingRecoveryCode: &store.AccountTotpPendingRecoveryCode{
+				AccountId: accountId,
+				CodeHash:  hashRecoveryCode(c),
+			},
+		})
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(rr db.Reader, w db.Writer) error {
+			oldPending := allocAccountTotpPending()
+			if err := rr.LookupWhere(ctx, oldPending, "account_id = ?", accountId); err != nil {
+				if !errors.IsNotFoundError(err) {
+					return errors.Wrap(err, op)
+				}
+			}
+			if oldPending.AccountId != "" {
+				// deleting the existing pending enrollment deletes its
+				// recovery codes
+				if _, err := w.Delete(ctx, oldPending, db.WithOplog(oplogWrapper, oldPending.oplog(oplog.OpType_OP_TYPE_DELETE))); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete existing pending enrollment"))
+				}
+			}
+			if err := w.Create(ctx, pending, db.WithOplog(oplogWrapper, pending.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create pending enrollment"))
+			}
+			if err := w.CreateItems(ctx, codes, db.WithOplog(oplogWrapper, pending.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create recovery codes"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return &TotpEnrollment{
+		Secret:        totpEncoding.EncodeToString(secret),
+		Url:           totpUrl(secret, acct.GetLoginName()),
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+// ConfirmTotp confirms the pending TOTP enrollment of the account with the
+// provided id if code is a valid TOTP code for the pending enrollment. The
+// confirmed enrollment and its recovery codes replace any existing
+// enrollment and recovery codes of the account. Returns an error with code
+// TotpInvalidCode if code is not valid and an error with code RecordNotFound
+// if the account does not have a pending enrollment. All options are
+// ignored.
+func (r *Repository) ConfirmTotp(ctx context.Context, scopeId, accountId, code string, _ ...Option) error {
+	const op = "password.(Repository).ConfirmTotp"
+	if accountId == "" {
+		return errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	if scopeId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	if code == "" {
+		return errors.New(errors.InvalidParameter, op, "missing code")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	pending := allocAccountTotpPending()
+	if err := r.reader.LookupWhere(ctx, pending, "account_id = ?", accountId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return errors.New(errors.RecordNotFound, op, fmt.Sprintf("account %s does not have a pending totp enrollment", accountId))
+		}
+		return errors.Wrap(err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(pending.GetKeyId()))
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := pending.decrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(err, op)
+	}
+	counter, ok := validateTotpCode(pending.Secret, code, time.Now(), 0)
+	if !ok {
+		return errors.New(errors.TotpInvalidCode, op, "invalid totp code")
+	}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(rr db.Reader, w db.Writer) error {
+			var pendingCodes []*AccountTotpPendingRecoveryCode
+			if err := rr.SearchWhere(ctx, &pendingCodes, "account_id = ?", []interface{}{accountId}, db.WithLimit(-1)); err != nil {
+				return errors.Wrap(err, op)
+			}
+			// deleting the pending enrollment deletes its recovery codes, it
+			// fails if the pending enrollment was confirmed or replaced
+			// concurrently
+			deleted := allocAccountTotpPending()
+			deleted.AccountId = accountId
+			rowsDeleted, err := w.Delete(ctx, deleted, db.WithOplog(oplogWrapper, deleted.oplog(oplog.OpType_OP_TYPE_DELETE)),
+				db.WithWhere("key_id = ? and secret = ?", pending.GetKeyId(), pending.GetCtSecret()))
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to delete pending enrollment"))
+			}
+			if rowsDeleted != 1 {
+				return errors.New(errors.TotpInvalidCode, op, "pending enrollment changed")
+			}
+
+			oldTotp := allocAccountTotp()
+			if err := rr.LookupWhere(ctx, oldTotp, "account_id = ?", accountId); err != nil {
+				if !errors.IsNotFoundError(err) {
+					return errors.Wrap(err, op)
+				}
+			}
+			if oldTotp.AccountId != "" {
+				// deleting the existing enrollment deletes its recovery codes
+				if _, err := w.Delete(ctx, oldTotp, db.WithOplog(oplogWrapper, oldTotp.oplog(oplog.OpType_OP_TYPE_DELETE))); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete existing enrollment"))
+				}
+			}
+
+			newTotp := allocAccountTotp()
+			newTotp.AccountId = accountId
+			newTotp.CtSecret = pending.GetCtSecret()
+			newTotp.KeyId = pending.GetKeyId()
+			newTotp.ConfirmTime = &timestamp.Timestamp{Timestamp: ptypes.TimestampNow()}
+			newTotp.LastCounter = counter
+			if err := w.Create(ctx, newTotp, db.WithOplog(oplogWrapper, newTotp.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create enrollment"))
+			}
+			if len(pendingCodes) == 0 {
+				return nil
+			}
+			codes := make([]interface{}, 0, len(pendingCodes))
+			for _, c := range pendingCodes {
+				codes = append(codes, &AccountTotpRecoveryCode{
+					AccountTotpRecoveryCode: &store.AccountTotpRecoveryCode{
+						AccountId: accountId,
+						CodeHash:  c.GetCodeHash(),
+					},
+				})
+			}
+			if err := w.CreateItems(ctx, codes, db.WithOplog(oplogWrapper, newTotp.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create recovery codes"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// EnrollTotpWithPassword creates a pending TOTP enrollment for the account
+// with loginName in authMethodId if password matches the password of the
+// account, so an account which has not enrolled in TOTP can enroll when the
+// auth method requires TOTP. The pending enrollment is confirmed by
+// authenticating with a TOTP code for it. Returns nil if password does not
+// match, which is a failed authentication attempt like for Authenticate.
+//
+// Returns an error with code TotpCodeRequired if the account has a confirmed
+// enrollment, which can only be replaced with EnrollTotp, and an error with
+// code InvalidParameter if the auth method does not require TOTP. All
+// options are ignored.
+func (r *Repository) EnrollTotpWithPassword(ctx context.Context, scopeId, authMethodId, loginName, password string, _ ...Option) (*TotpEnrollment, error) {
+	const op = "password.(Repository).EnrollTotpWithPassword"
+	if authMethodId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing authMethodId")
+	}
+	if loginName == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing loginName")
+	}
+	if password == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing password")
+	}
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scopeId")
+	}
+	acct, err := r.authenticate(ctx, scopeId, authMethodId, loginName, password)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if acct == nil {
+		return nil, nil
+	}
+	switch {
+	case acct.HasTotp:
+		return nil, errors.New(errors.TotpCodeRequired, op, "account has already enrolled in totp")
+	case !acct.MfaRequired:
+		return nil, errors.New(errors.InvalidParameter, op, "auth method does not require totp")
+	}
+	enrollment, err := r.EnrollTotp(ctx, scopeId, acct.Account.PublicId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return enrollment, nil
+}
+
+// DeleteTotp deletes the confirmed and the pending TOTP enrollments and the
+// recovery codes of the account with the provided id, returning a count of
+// the number of enrollments deleted. All options are ignored.
+func (r *Repository) DeleteTotp(ctx context.Context, scopeId, accountId string, _ ...Option) (int, error) {
+	const op = "password.(Repository).DeleteTotp"
+	if accountId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	t := allocAccountTotp()
+	t.AccountId = accountId
+	pending := allocAccountTotpPending()
+	pending.AccountId = accountId
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			confirmedDeleted, err := w.Delete(ctx, t, db.WithOplog(oplogWrapper, t.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			pendingDeleted, err := w.Delete(ctx, pending, db.WithOplog(oplogWrapper, pending.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if confirmedDeleted > 1 || pendingDeleted > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			rowsDeleted = confirmedDeleted + pendingDeleted
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(accountId))
+	}
+	return rowsDeleted, nil
+}
+
+// LookupAccountTotp returns the TOTP enrollment of the account with the
+// provided id without its secret. If the account has not enrolled in TOTP,
+// it will return nil, nil. All options are ignored.
+func (r *Repository) LookupAccountTotp(ctx context.Context, accountId string, _ ...Option) (*AccountTotp, error) {
+	const op = "password.(Repository).LookupAccountTotp"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	t := allocAccountTotp()
+	if err := r.reader.LookupWhere(ctx, t, "account_id = ?", accountId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", accountId)))
+	}
+	t.CtSecret = nil
+	return t, nil
+}
+
+// lookupTotpSecret returns the TOTP enrollment of accountId with its
+// decrypted secret.
+func (r *Repository) lookupTotpSecret(ctx context.Context, scopeId, accountId string) (*AccountTotp, error) {
+	const op = "password.(Repository).lookupTotpSecret"
+	t := allocAccountTotp()
+	if err := r.reader.LookupWhere(ctx, t, "account_id = ?", accountId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("account %s has not enrolled in totp", accountId))
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(t.GetKeyId()))
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := t.decrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return t, nil
+}
+
+// verifySecondFactor reports whether totpCode is a valid TOTP code or
+// recoveryCode is a valid recovery code for the confirmed TOTP enrollment of
+// accountId. A valid TOTP code can not be used again and a valid recovery
+// code is deleted. Returns an error with code TotpCodeRequired if neither
+// code is provided.
+func (r *Repository) verifySecondFactor(ctx context.Context, scopeId, accountId, totpCode, recoveryCode string) (bool, error) {
+	const op = "password.(Repository).verifySecondFactor"
+	switch {
+	case totpCode != "":
+		t, err := r.lookupTotpSecret(ctx, scopeId, accountId)
+		if err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		counter, ok := validateTotpCode(t.Secret, totpCode, time.Now(), t.LastCounter)
+		if !ok {
+			return false, nil
+		}
+		// the update only succeeds once per code, even for concurrent
+		// requests
+		rowsUpdated, err := r.writer.Exec(ctx, useTotpCodeQuery, []interface{}{accountId, counter})
+		if err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		return rowsUpdated == 1, nil
+	case recoveryCode != "":
+		rowsDeleted, err := r.writer.Exec(ctx, useRecoveryCodeQuery, []interface{}{accountId, hashRecoveryCode(recoveryCode)})
+		if err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		return rowsDeleted == 1, nil
+	default:
+		return false, errors.New(errors.TotpCodeRequired, op, "missing totp code")
+	}
+}
@@ -0,0 +1,184 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_AccountTotp(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	am := TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	passwd := "12345678"
+	acct, err := repo.CreateAccount(ctx, o.GetPublicId(), &Account{
+		Account: &store.Account{
+			AuthMethodId: am.PublicId,
+			LoginName:    "kazmierczak",
+		},
+	}, WithPassword(passwd))
+	require.NoError(t, err)
+
+	var enrollment *TotpEnrollment
+	t.Run("enroll", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		enrollment, err = repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		require.NotNil(enrollment)
+		assert.NotEmpty(enrollment.Secret)
+		assert.Contains(enrollment.Url, enrollment.Secret)
+		assert.Len(enrollment.RecoveryCodes, recoveryCodeCount)
+
+		// a pending enrollment is not returned
+		got, err := repo.LookupAccountTotp(ctx, acct.PublicId)
+		require.NoError(err)
+		assert.Nil(got)
+
+		// a pending enrollment does not require a second factor
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		require.NoError(err)
+		assert.NotNil(a)
+	})
+	t.Run("confirm", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, "000000")
+		assert.Truef(errors.Match(errors.T(errors.TotpInvalidCode), err), "unexpected error %v", err)
+
+		code := TestTotpCode(t, enrollment.Secret, time.Now())
+		require.NoError(repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, code))
+		got, err := repo.LookupAccountTotp(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.NotNil(got.ConfirmTime)
+	})
+	t.Run("authenticate", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.TotpCodeRequired), err), "unexpected error %v", err)
+		assert.Nil(a)
+
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithTotpCode("000000"))
+		require.NoError(err)
+		assert.Nil(a)
+
+		// the code of the current time step was used to confirm the
+		// enrollment, so use the code of the next time step
+		code := TestTotpCode(t, enrollment.Secret, time.Now().Add(totpPeriod))
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithTotpCode(code))
+		require.NoError(err)
+		assert.NotNil(a)
+
+		// codes can only be used once
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithTotpCode(code))
+		require.NoError(err)
+		assert.Nil(a)
+	})
+	t.Run("recovery-code", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rc := enrollment.RecoveryCodes[0]
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(rc))
+		require.NoError(err)
+		assert.NotNil(a)
+
+		// recovery codes can only be used once
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(rc))
+		require.NoError(err)
+		assert.Nil(a)
+	})
+	t.Run("re-enroll", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		pending, err := repo.EnrollTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		require.NotNil(pending)
+		assert.NotEqual(enrollment.Secret, pending.Secret)
+
+		// the confirmed enrollment is used until the pending enrollment is
+		// confirmed
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.TotpCodeRequired), err), "unexpected error %v", err)
+		assert.Nil(a)
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(pending.RecoveryCodes[0]))
+		require.NoError(err)
+		assert.Nil(a)
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(enrollment.RecoveryCodes[1]))
+		require.NoError(err)
+		assert.NotNil(a)
+
+		code := TestTotpCode(t, pending.Secret, time.Now())
+		require.NoError(repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, code))
+		got, err := repo.LookupAccountTotp(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotNil(got)
+		assert.NotNil(got.ConfirmTime)
+
+		// the recovery codes of the replaced enrollment can not be used
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(enrollment.RecoveryCodes[2]))
+		require.NoError(err)
+		assert.Nil(a)
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithRecoveryCode(pending.RecoveryCodes[0]))
+		require.NoError(err)
+		assert.NotNil(a)
+
+		// the pending enrollment was confirmed
+		err = repo.ConfirmTotp(ctx, o.GetPublicId(), acct.PublicId, code)
+		assert.Truef(errors.IsNotFoundError(err), "unexpected error %v", err)
+		enrollment = pending
+	})
+	t.Run("required-policy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rows, err := repo.DeleteTotp(ctx, o.GetPublicId(), acct.PublicId)
+		require.NoError(err)
+		assert.Equal(1, rows)
+
+		am.MfaPolicy = "required"
+		am, _, err = repo.UpdateAuthMethod(ctx, am, am.Version, []string{"MfaPolicy"})
+		require.NoError(err)
+
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.TotpEnrollmentRequired), err), "unexpected error %v", err)
+		assert.Nil(a)
+	})
+	t.Run("enroll-with-password", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "wrong-password")
+		require.NoError(err)
+		assert.Nil(got)
+
+		got, err = repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		require.NoError(err)
+		require.NotNil(got)
+
+		// a code for the pending enrollment confirms it
+		a, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithTotpCode("000000"))
+		require.NoError(err)
+		assert.Nil(a)
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd, WithTotpCode(TestTotpCode(t, got.Secret, time.Now())))
+		require.NoError(err)
+		assert.NotNil(a)
+
+		a, err = repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.TotpCodeRequired), err), "unexpected error %v", err)
+		assert.Nil(a)
+
+		// a confirmed enrollment can not be replaced with the password
+		_, err = repo.EnrollTotpWithPassword(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, passwd)
+		assert.Truef(errors.Match(errors.T(errors.TotpCodeRequired), err), "unexpected error %v", err)
+	})
+}
@@ -21,7 +21,9 @@ import (
 // options are ignored.
 //
 // Both m.Name and m.Description are optional. If m.Name is set, it must be
-// unique within m.ScopeId.
+// unique within m.ScopeId. m.BannedPasswords are stored in lower case.
+// m.AllowedClientCidrs and m.DeniedClientCidrs must be valid CIDR blocks or IP
+// addresses and are stored in their canonical form.
 func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ...Option) (*AuthMethod, error) {
 	const op = "password.(Repository).CreateAuthMethod"
 	if m == nil {
@@ -76,7 +78,7 @@ func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ..
 	var newAuthMethod *AuthMethod
 	var newArgon2Conf *Argon2Configuration
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			newArgon2Conf = c.clone()
 			if err := w.Create(ctx, newArgon2Conf, db.WithOplog(oplogWrapper, c.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
 				return errors.Wrap(err, op, errors.WithMsg("unable to create argon conf"))
@@ -85,6 +87,22 @@ func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ..
 			if err := w.Create(ctx, newAuthMethod, db.WithOplog(oplogWrapper, m.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
 				return errors.Wrap(err, op, errors.WithMsg("unable to create auth method"))
 			}
+			if len(m.BannedPasswords) > 0 {
+				if err := setBannedPasswords(ctx, reader, w, oplogWrapper, newAuthMethod, m.BannedPasswords); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			if len(m.AllowedClientCidrs) > 0 || len(m.DeniedClientCidrs) > 0 {
+				if err := setClientCidrs(ctx, reader, w, oplogWrapper, newAuthMethod, m.AllowedClientCidrs, m.DeniedClientCidrs); err != nil {
+					return errors.Wrap(err, op)
+				}
+				allowed, denied, err := clientCidrs(ctx, reader, newAuthMethod.PublicId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				newAuthMethod.AllowedClientCidrs = allowed[newAuthMethod.PublicId]
+				newAuthMethod.DeniedClientCidrs = denied[newAuthMethod.PublicId]
+			}
 			return nil
 		},
 	)
@@ -95,6 +113,7 @@ func (r *Repository) CreateAuthMethod(ctx context.Context, m *AuthMethod, opt ..
 		}
 		return nil, errors.Wrap(err, op, errors.WithMsg(m.ScopeId))
 	}
+	newAuthMethod.BannedPasswords = normalizeBannedPasswords(m.BannedPasswords)
 	return newAuthMethod, nil
 }
 
@@ -113,6 +132,16 @@ func (r *Repository) LookupAuthMethod(ctx context.Context, publicId string, _ ..
 		}
 		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", publicId)))
 	}
+	banned, err := bannedPasswords(ctx, r.reader, publicId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	a.BannedPasswords = banned[publicId]
+	allowed, denied, err := clientCidrs(ctx, r.reader, publicId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	a.AllowedClientCidrs, a.DeniedClientCidrs = allowed[publicId], denied[publicId]
 	return &a, nil
 }
 
@@ -133,6 +162,22 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeIds []string, opt
 	if err != nil {
 		return nil, errors.Wrap(err, op)
 	}
+	ids := make([]string, 0, len(authMethods))
+	for _, am := range authMethods {
+		ids = append(ids, am.PublicId)
+	}
+	banned, err := bannedPasswords(ctx, r.reader, ids...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	allowed, denied, err := clientCidrs(ctx, r.reader, ids...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	for _, am := range authMethods {
+		am.BannedPasswords = banned[am.PublicId]
+		am.AllowedClientCidrs, am.DeniedClientCidrs = allowed[am.PublicId], denied[am.PublicId]
+	}
 	return authMethods, nil
 }
 
@@ -188,8 +233,20 @@ func (r *Repository) DeleteAuthMethod(ctx context.Context, scopeId, publicId str
 // NewAuthMethod.  fieldMaskPaths provides field_mask.proto paths for fields
 // that should be updated.  Fields will be set to NULL if the field is a zero
 // value and included in fieldMask. Name, Description, MinPasswordLength,
-// and MinLoginNameLength are the only updatable fields, If no updatable fields
-// are included in the fieldMaskPaths, then an error is returned.
+// MinLoginNameLength, AuthTokenTimeToLiveSeconds, AuthTokenTimeToStaleSeconds,
+// LockoutThreshold, LockoutDurationSeconds, MfaPolicy, the PasswordRequire
+// fields, PasswordHistoryCount, MaxPasswordAgeSeconds, BannedPasswords,
+// AllowedClientCidrs, and DeniedClientCidrs are the only updatable fields, If
+// no updatable fields are included in the
+// fieldMaskPaths, then an error is returned. Setting
+// AuthTokenTimeToLiveSeconds or AuthTokenTimeToStaleSeconds to NULL means the
+// setting of the auth method's scope is used. Setting LockoutThreshold to NULL
+// disables account lockouts and setting LockoutDurationSeconds to NULL means
+// the default lockout duration is used. Setting MfaPolicy to NULL is the same
+// as setting it to "optional". Setting any of the password policy fields to
+// NULL disables the respective rule. BannedPasswords replaces all of the
+// banned passwords of the auth method. AllowedClientCidrs and
+// DeniedClientCidrs replace the respective client cidrs of the auth method.
 func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMethod, version uint32, fieldMaskPaths []string, opt ...Option) (*AuthMethod, int, error) {
 	const op = "password.(Repository).UpdateAuthMethod"
 	if authMethod == nil {
@@ -201,12 +258,30 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	if authMethod.ScopeId == "" {
 		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing scope id")
 	}
+	var updateBanned, updateCidrs bool
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
 		case strings.EqualFold("MinLoginNameLength", f):
 		case strings.EqualFold("MinPasswordLength", f):
+		case strings.EqualFold("AuthTokenTimeToLiveSeconds", f):
+		case strings.EqualFold("AuthTokenTimeToStaleSeconds", f):
+		case strings.EqualFold("LockoutThreshold", f):
+		case strings.EqualFold("LockoutDurationSeconds", f):
+		case strings.EqualFold("MfaPolicy", f):
+		case strings.EqualFold("PasswordRequireUppercase", f):
+		case strings.EqualFold("PasswordRequireLowercase", f):
+		case strings.EqualFold("PasswordRequireDigit", f):
+		case strings.EqualFold("PasswordRequireSymbol", f):
+		case strings.EqualFold("PasswordHistoryCount", f):
+		case strings.EqualFold("MaxPasswordAgeSeconds", f):
+		case strings.EqualFold("BannedPasswords", f):
+			updateBanned = true
+		case strings.EqualFold("AllowedClientCidrs", f):
+			updateCidrs = true
+		case strings.EqualFold("DeniedClientCidrs", f):
+			updateCidrs = true
 		default:
 			return nil, db.NoRowsAffected, errors.New(errors.InvalidFieldMask, op, f)
 		}
@@ -214,16 +289,32 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"Name":               authMethod.Name,
-			"Description":        authMethod.Description,
-			"MinPasswordLength":  authMethod.MinPasswordLength,
-			"MinLoginNameLength": authMethod.MinLoginNameLength,
+			"Name":                        authMethod.Name,
+			"Description":                 authMethod.Description,
+			"MinPasswordLength":           authMethod.MinPasswordLength,
+			"MinLoginNameLength":          authMethod.MinLoginNameLength,
+			"AuthTokenTimeToLiveSeconds":  authMethod.AuthTokenTimeToLiveSeconds,
+			"AuthTokenTimeToStaleSeconds": authMethod.AuthTokenTimeToStaleSeconds,
+			"LockoutThreshold":            authMethod.LockoutThreshold,
+			"LockoutDurationSeconds":      authMethod.LockoutDurationSeconds,
+			"MfaPolicy":                   authMethod.MfaPolicy,
+			"PasswordRequireUppercase":    authMethod.PasswordRequireUppercase,
+			"PasswordRequireLowercase":    authMethod.PasswordRequireLowercase,
+			"PasswordRequireDigit":        authMethod.PasswordRequireDigit,
+			"PasswordRequireSymbol":       authMethod.PasswordRequireSymbol,
+			"PasswordHistoryCount":        authMethod.PasswordHistoryCount,
+			"MaxPasswordAgeSeconds":       authMethod.MaxPasswordAgeSeconds,
 		},
 		fieldMaskPaths,
 		nil,
 	)
 	if len(dbMask) == 0 && len(nullFields) == 0 {
-		return nil, db.NoRowsAffected, errors.New(errors.EmptyFieldMask, op, "field mask must not be empty")
+		if !updateBanned && !updateCidrs {
+			return nil, db.NoRowsAffected, errors.New(errors.EmptyFieldMask, op, "field mask must not be empty")
+		}
+		// only the banned passwords or client cidrs are changing, bump the
+		// version of the auth method so the change is still versioned
+		dbMask = []string{"Version"}
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, authMethod.ScopeId, kms.KeyPurposeOplog)
@@ -233,12 +324,15 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 	}
 
 	upAuthMethod := authMethod.clone()
+	if (updateBanned || updateCidrs) && len(dbMask) == 1 && dbMask[0] == "Version" {
+		upAuthMethod.Version = version + 1
+	}
 	var rowsUpdated int
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			dbOpts := []db.Option{
 				db.WithOplog(oplogWrapper, upAuthMethod.oplog(oplog.OpType_OP_TYPE_UPDATE)),
 				db.WithVersion(&version),
@@ -257,6 +351,28 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 			if rowsUpdated > 1 {
 				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
 			}
+			if updateBanned && rowsUpdated == 1 {
+				if err := setBannedPasswords(ctx, reader, w, oplogWrapper, upAuthMethod, authMethod.BannedPasswords); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			if updateCidrs && rowsUpdated == 1 {
+				// only the cidrs in the field mask are replaced
+				allowed, denied := authMethod.AllowedClientCidrs, authMethod.DeniedClientCidrs
+				currentAllowed, currentDenied, err := clientCidrs(ctx, reader, upAuthMethod.PublicId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				if !contains(fieldMaskPaths, "AllowedClientCidrs") {
+					allowed = currentAllowed[upAuthMethod.PublicId]
+				}
+				if !contains(fieldMaskPaths, "DeniedClientCidrs") {
+					denied = currentDenied[upAuthMethod.PublicId]
+				}
+				if err := setClientCidrs(ctx, reader, w, oplogWrapper, upAuthMethod, allowed, denied); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 			return nil
 		},
 	)
@@ -266,5 +382,15 @@ func (r *Repository) UpdateAuthMethod(ctx context.Context, authMethod *AuthMetho
 		}
 		return nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(authMethod.PublicId))
 	}
+	banned, err := bannedPasswords(ctx, r.reader, upAuthMethod.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	upAuthMethod.BannedPasswords = banned[upAuthMethod.PublicId]
+	allowed, denied, err := clientCidrs(ctx, r.reader, upAuthMethod.PublicId)
+	if err != nil {
+		return nil, db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	upAuthMethod.AllowedClientCidrs, upAuthMethod.DeniedClientCidrs = allowed[upAuthMethod.PublicId], denied[upAuthMethod.PublicId]
 	return upAuthMethod, rowsUpdated, nil
 }
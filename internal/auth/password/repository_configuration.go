@@ -119,9 +119,14 @@ func (r *Repository) setArgon2Conf(ctx context.Context, scopeId string, c *Argon
 }
 
 type currentConfig struct {
-	ConfType           string
-	MinLoginNameLength int
-	MinPasswordLength  int
+	ConfType                 string
+	MinLoginNameLength       int
+	MinPasswordLength        int
+	PasswordRequireUppercase bool
+	PasswordRequireLowercase bool
+	PasswordRequireDigit     bool
+	PasswordRequireSymbol    bool
+	PasswordHistoryCount     int
 
 	*Argon2Configuration
 }
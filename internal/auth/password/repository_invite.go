@@ -0,0 +1,326 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+)
+
+const (
+	inviteTokenLength = 32
+
+	// DefaultInviteTtl is the duration for which an invite can be redeemed
+	// if WithInviteTtl is not provided.
+	DefaultInviteTtl = 7 * 24 * time.Hour
+
+	// MaxInviteTtl is the maximum duration for which an invite can be
+	// redeemed.
+	MaxInviteTtl = 30 * 24 * time.Hour
+)
+
+// CreateInvite inserts in into the repository and returns a new Invite
+// containing the invite's PublicId and Token. in is not changed. in must
+// contain a valid AuthMethodId and must not contain a PublicId. The
+// PublicId and the Token are generated and assigned by this method. The
+// Token is only returned by this method.
+//
+// in.RoleIds are the roles the user created by redeeming the invite is added
+// to. The roles must exist. Both in.Name and in.Description are optional.
+// If in.Name is set, it must be unique within in.AuthMethodId.
+//
+// WithInviteTtl sets the duration for which the invite can be redeemed. It
+// defaults to DefaultInviteTtl and can not be greater than MaxInviteTtl.
+// All other options are ignored.
+func (r *Repository) CreateInvite(ctx context.Context, scopeId string, in *Invite, opt ...Option) (*Invite, error) {
+	const op = "password.(Repository).CreateInvite"
+	switch {
+	case in == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "missing Invite")
+	case in.Invite == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "missing embedded Invite")
+	case in.AuthMethodId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	case in.PublicId != "":
+		return nil, errors.New(errors.InvalidParameter, op, "public id must be empty")
+	case scopeId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	ttl := opts.withInviteTtl
+	switch {
+	case ttl == 0:
+		ttl = DefaultInviteTtl
+	case ttl < 0 || ttl > MaxInviteTtl:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("invite ttl must be between 1s and %s", MaxInviteTtl))
+	}
+
+	in = in.clone()
+	id, err := newInviteId()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	in.PublicId = id
+	token, err := base62.Random(inviteTokenLength)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to generate invite token"))
+	}
+	in.TokenHash = hashToken(token)
+	// We truncate the expiration time to the nearest second to make testing
+	// in different platforms with different time resolutions easier.
+	exp, err := ptypes.TimestampProto(time.Now().Add(ttl).Truncate(time.Second))
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	in.ExpirationTime = &timestamp.Timestamp{Timestamp: exp}
+
+	seen := make(map[string]bool, len(in.RoleIds))
+	roles := make([]interface{}, 0, len(in.RoleIds))
+	for _, roleId := range in.RoleIds {
+		if roleId == "" {
+			return nil, errors.New(errors.InvalidParameter, op, "empty role id")
+		}
+		if seen[roleId] {
+			continue
+		}
+		seen[roleId] = true
+		ir := allocInviteRole()
+		ir.InviteId = id
+		ir.RoleId = roleId
+		roles = append(roles, ir)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
+	}
+
+	var newInvite *Invite
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			newInvite = in.clone()
+			if err := w.Create(ctx, newInvite, db.WithOplog(oplogWrapper, in.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op)
+			}
+			if len(roles) > 0 {
+				if err := w.CreateItems(ctx, roles, db.WithOplog(oplogWrapper, in.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add roles"))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(errors.NotUnique, op, fmt.Sprintf("in auth method %s: name %q already exists", in.AuthMethodId, in.Name))
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(in.AuthMethodId))
+	}
+	newInvite.RoleIds = make([]string, 0, len(roles))
+	for _, ir := range roles {
+		newInvite.RoleIds = append(newInvite.RoleIds, ir.(*InviteRole).RoleId)
+	}
+	newInvite.Token = token
+	return newInvite, nil
+}
+
+// LookupInvite will look up an invite in the repository. If the invite is
+// not found, it will return nil, nil. All options are ignored.
+func (r *Repository) LookupInvite(ctx context.Context, withPublicId string, _ ...Option) (*Invite, error) {
+	const op = "password.(Repository).LookupInvite"
+	if withPublicId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	in := allocInvite()
+	in.PublicId = withPublicId
+	if err := r.reader.LookupByPublicId(ctx, in); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", withPublicId)))
+	}
+	if err := inviteRoleIds(ctx, r.reader, in); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return in, nil
+}
+
+// ListInvites lists up to WithLimit invites for the given authMethodId,
+// including invites which have been redeemed or have expired.
+func (r *Repository) ListInvites(ctx context.Context, withAuthMethodId string, opt ...Option) ([]*Invite, error) {
+	const op = "password.(Repository).ListInvites"
+	if withAuthMethodId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var invites []*Invite
+	err := r.reader.SearchWhere(ctx, &invites, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := inviteRoleIds(ctx, r.reader, invites...); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return invites, nil
+}
+
+// DeleteInvite deletes the invite for the provided id from the repository
+// returning a count of the number of records deleted. Deleting an invite
+// which has not been redeemed revokes it. All options are ignored.
+func (r *Repository) DeleteInvite(ctx context.Context, scopeId, withPublicId string, _ ...Option) (int, error) {
+	const op = "password.(Repository).DeleteInvite"
+	if withPublicId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	if scopeId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	in := allocInvite()
+	in.PublicId = withPublicId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) (err error) {
+			dIn := in.clone()
+			rowsDeleted, err = w.Delete(ctx, dIn, db.WithOplog(oplogWrapper, in.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(withPublicId))
+	}
+	return rowsDeleted, nil
+}
+
+// RedeemInvite redeems the invite in authMethodId which token was created
+// for. In a single transaction, it creates an account in authMethodId with
+// loginName and password, creates a user in scopeId associated with the
+// account, adds the user to the roles of the invite, and marks the invite as
+// redeemed. The password must satisfy the password policy of the auth
+// method. The new account and user are returned.
+//
+// Returns an error with code PasswordInviteInvalid if token does not exist,
+// has expired, has already been redeemed, or was not created for
+// authMethodId. WithName and WithDescription set the name and description of
+// the user. All other options are ignored.
+func (r *Repository) RedeemInvite(ctx context.Context, scopeId, authMethodId, token, loginName, password string, opt ...Option) (*Account, *iam.User, error) {
+	const op = "password.(Repository).RedeemInvite"
+	switch {
+	case scopeId == "":
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	case authMethodId == "":
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	case token == "":
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing token")
+	case password == "":
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing password")
+	}
+	opts := getOpts(opt...)
+
+	in := allocInvite()
+	if err := r.reader.LookupWhere(ctx, in, "token_hash = ? and auth_method_id = ? and redeem_time is null and expiration_time > current_timestamp", hashToken(token), authMethodId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, errors.New(errors.PasswordInviteInvalid, op, "invite not found, expired or already redeemed")
+		}
+		return nil, nil, errors.Wrap(err, op)
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"), errors.WithCode(errors.Encrypt))
+	}
+
+	var user *iam.User
+	redeem := func(reader db.Reader, w db.Writer, acct *Account) error {
+		// the roles are read within the transaction so roles deleted since
+		// the invite was created are not added
+		if err := inviteRoleIds(ctx, reader, in); err != nil {
+			return errors.Wrap(err, op)
+		}
+		var err error
+		user, err = iam.CreateUserWithAccount(ctx, r.kms, reader, w, scopeId, acct.PublicId, in.RoleIds,
+			iam.WithName(opts.withName), iam.WithDescription(opts.withDescription))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		now, err := ptypes.TimestampProto(time.Now())
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+		}
+		updated := allocInvite()
+		updated.PublicId = in.PublicId
+		updated.RedeemTime = &timestamp.Timestamp{Timestamp: now}
+		updated.AccountId = acct.PublicId
+		updated.IamUserId = user.PublicId
+		// the update only succeeds once per invite, even for concurrent
+		// requests
+		rowsUpdated, err := w.Update(ctx, updated, []string{"RedeemTime", "AccountId", "IamUserId"}, nil,
+			db.WithOplog(oplogWrapper, in.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+			db.WithVersion(&in.Version),
+			db.WithWhere("redeem_time is null and expiration_time > current_timestamp"))
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if rowsUpdated != 1 {
+			return errors.New(errors.PasswordInviteInvalid, op, "invite already redeemed")
+		}
+		return nil
+	}
+
+	acct := allocAccount()
+	acct.AuthMethodId = authMethodId
+	acct.LoginName = loginName
+	acct, err = r.CreateAccount(ctx, scopeId, acct, WithPassword(password), withAccountTxFn(redeem))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return acct, user, nil
+}
+
+// inviteRoleIds sets the RoleIds of invites.
+func inviteRoleIds(ctx context.Context, reader db.Reader, invites ...*Invite) error {
+	const op = "password.inviteRoleIds"
+	if len(invites) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(invites))
+	byId := make(map[string]*Invite, len(invites))
+	for _, in := range invites {
+		in.RoleIds = nil
+		ids = append(ids, in.PublicId)
+		byId[in.PublicId] = in
+	}
+	var roles []*InviteRole
+	if err := reader.SearchWhere(ctx, &roles, "invite_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, ir := range roles {
+		in := byId[ir.InviteId]
+		in.RoleIds = append(in.RoleIds, ir.RoleId)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Invite(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	ams := TestAuthMethods(t, conn, o.GetPublicId(), 2)
+	am, otherAm := ams[0], ams[1]
+	role := iam.TestRole(t, conn, o.GetPublicId())
+
+	isInvalid := func(t *testing.T, err error) {
+		t.Helper()
+		assert.Truef(t, errors.Match(errors.T(errors.PasswordInviteInvalid), err), "unexpected error %v", err)
+	}
+
+	t.Run("invalid-ttl", func(t *testing.T) {
+		in, err := NewInvite(am.PublicId)
+		require.NoError(t, err)
+		_, err = repo.CreateInvite(ctx, o.GetPublicId(), in, WithInviteTtl(MaxInviteTtl+time.Second))
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+
+	t.Run("redeem", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewInvite(am.PublicId, WithName("redeem"))
+		require.NoError(err)
+		in.RoleIds = []string{role.PublicId, role.PublicId}
+		in, err = repo.CreateInvite(ctx, o.GetPublicId(), in)
+		require.NoError(err)
+		require.NotEmpty(in.Token)
+		assert.Equal(hashToken(in.Token), in.TokenHash)
+		assert.Equal([]string{role.PublicId}, in.RoleIds)
+
+		got, err := repo.LookupInvite(ctx, in.PublicId)
+		require.NoError(err)
+		assert.Equal([]string{role.PublicId}, got.RoleIds)
+		assert.Empty(got.Token)
+		assert.False(got.Redeemed())
+
+		_, _, err = repo.RedeemInvite(ctx, o.GetPublicId(), otherAm.PublicId, in.Token, "invited", "invitedpassword")
+		isInvalid(t, err)
+
+		acct, user, err := repo.RedeemInvite(ctx, o.GetPublicId(), am.PublicId, in.Token, "invited", "invitedpassword", WithName("invited user"))
+		require.NoError(err)
+		assert.Equal("invited", acct.LoginName)
+		assert.Equal("invited user", user.Name)
+
+		_, accountIds, err := iamRepo.LookupUser(ctx, user.PublicId)
+		require.NoError(err)
+		assert.Equal([]string{acct.PublicId}, accountIds)
+		principals, err := iamRepo.ListPrincipalRoles(ctx, role.PublicId)
+		require.NoError(err)
+		require.Len(principals, 1)
+		assert.Equal(user.PublicId, principals[0].PrincipalId)
+
+		authed, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, "invited", "invitedpassword")
+		require.NoError(err)
+		assert.NotNil(authed)
+
+		got, err = repo.LookupInvite(ctx, in.PublicId)
+		require.NoError(err)
+		assert.True(got.Redeemed())
+		assert.Equal(acct.PublicId, got.AccountId)
+		assert.Equal(user.PublicId, got.IamUserId)
+
+		_, _, err = repo.RedeemInvite(ctx, o.GetPublicId(), am.PublicId, in.Token, "invited2", "invitedpassword")
+		isInvalid(t, err)
+	})
+
+	t.Run("failed-redeem-rolls-back", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		in, err := NewInvite(am.PublicId)
+		require.NoError(err)
+		in, err = repo.CreateInvite(ctx, o.GetPublicId(), in)
+		require.NoError(err)
+
+		// the login name is already in use
+		_, _, err = repo.RedeemInvite(ctx, o.GetPublicId(), am.PublicId, in.Token, "invited", "invitedpassword")
+		require.Error(err)
+		got, err := repo.LookupInvite(ctx, in.PublicId)
+		require.NoError(err)
+		assert.False(got.Redeemed())
+
+		_, _, err = repo.RedeemInvite(ctx, o.GetPublicId(), am.PublicId, in.Token, "invited3", "invitedpassword")
+		require.NoError(err)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		in, err := NewInvite(am.PublicId)
+		require.NoError(t, err)
+		in, err = repo.CreateInvite(ctx, o.GetPublicId(), in)
+		require.NoError(t, err)
+
+		n, err := repo.DeleteInvite(ctx, o.GetPublicId(), in.PublicId)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		_, _, err = repo.RedeemInvite(ctx, o.GetPublicId(), am.PublicId, in.Token, "revoked", "invitedpassword")
+		isInvalid(t, err)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		invites, err := repo.ListInvites(ctx, am.PublicId)
+		require.NoError(t, err)
+		assert.Len(t, invites, 3)
+		invites, err = repo.ListInvites(ctx, otherAm.PublicId)
+		require.NoError(t, err)
+		assert.Empty(t, invites)
+	})
+}
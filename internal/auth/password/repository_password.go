@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/subtle"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
@@ -17,21 +18,56 @@ type authAccount struct {
 	*Account
 	*Argon2Credential
 	*Argon2Configuration
-	IsCurrentConf bool
+	IsCurrentConf          bool
+	LockoutThreshold       uint32
+	LockoutDurationSeconds uint32
+	IsLocked               bool
+	HasLockout             bool
+	HasTotp                bool
+	MfaRequired            bool
+	IsPasswordExpired      bool
+	ImportedCredentialId   string
+	ImportedPasswordHash   []byte
+	ImportedKeyId          string
 }
 
 // Authenticate authenticates loginName and password match for loginName in
 // authMethodId. The account for the loginName is returned if authentication
 // is successful. Returns nil if authentication fails.
 //
+// Failed authentication attempts are recorded for the account. If the auth
+// method has a LockoutThreshold, the account is locked once its consecutive
+// failed attempts reach the threshold, and Authenticate fails for a locked
+// account even if password is correct. A successful authentication resets
+// the failed attempts and lockouts of the account. Authenticate fails for an
+// account which is not active.
+//
+// If the account has a confirmed TOTP enrollment, a valid TOTP code or
+// recovery code must be provided with WithTotpCode or WithRecoveryCode. An
+// error with code TotpCodeRequired is returned if neither is provided and
+// password is correct. An invalid code is a failed authentication attempt.
+// If the auth method requires TOTP and the account has not enrolled, an
+// error with code TotpEnrollmentRequired is returned unless a TOTP code for
+// the pending enrollment created by EnrollTotpWithPassword is provided with
+// WithTotpCode, which confirms the enrollment.
+//
+// If the auth method has a MaxPasswordAgeSeconds and the password of the
+// account is older, an error with code PasswordExpired is returned unless a
+// new password is provided with WithNewPassword. The password of the account
+// is changed to the new password once authentication is successful, and the
+// new password must satisfy the password policy of the auth method.
+//
 // The CredentialId in the returned account represents a user's current
 // password. A new CredentialId is generated when a user's password is
 // changed and the old one is deleted.
 //
 // Authenticate will update the stored values for password to the current
 // password settings for authMethodId if authentication is successful and
-// the stored values are not using the current password settings.
-func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string) (*Account, error) {
+// the stored values are not using the current password settings, such as
+// after SetConfiguration changes the settings. An imported password hash is
+// replaced with an Argon2Credential using the current password settings
+// once authentication is successful.
+func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, loginName, password string, opt ...Option) (*Account, error) {
 	const op = "password.(Repository).Authenticate"
 	if authMethodId == "" {
 		return nil, errors.New(errors.InvalidParameter, op, "missing authMethodId")
@@ -59,6 +95,70 @@ func (r *Repository) Authenticate(ctx context.Context, scopeId, authMethodId, lo
 		return nil, nil
 	}
 
+	opts := getOpts(opt...)
+	if acct.IsPasswordExpired && opts.withNewPassword == "" {
+		return nil, errors.New(errors.PasswordExpired, op, "password has expired and must be changed")
+	}
+
+	switch {
+	case acct.HasTotp:
+		ok, err := r.verifySecondFactor(ctx, scopeId, acct.Account.PublicId, opts.withTotpCode, opts.withRecoveryCode)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if !ok {
+			lockoutDuration := time.Duration(acct.LockoutDurationSeconds) * time.Second
+			if err := r.recordFailedAttempt(ctx, acct.Account.PublicId, acct.LockoutThreshold, lockoutDuration); err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			return nil, nil
+		}
+	case acct.MfaRequired && opts.withTotpCode != "":
+		// the code confirms the pending enrollment created by
+		// EnrollTotpWithPassword
+		err := r.ConfirmTotp(ctx, scopeId, acct.Account.PublicId, opts.withTotpCode)
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, errors.New(errors.TotpEnrollmentRequired, op, "account must enroll in totp")
+		case errors.Match(errors.T(errors.TotpInvalidCode), err):
+			lockoutDuration := time.Duration(acct.LockoutDurationSeconds) * time.Second
+			if err := r.recordFailedAttempt(ctx, acct.Account.PublicId, acct.LockoutThreshold, lockoutDuration); err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			return nil, nil
+		case err != nil:
+			return nil, errors.Wrap(err, op)
+		}
+	case acct.MfaRequired:
+		return nil, errors.New(errors.TotpEnrollmentRequired, op, "account must enroll in totp")
+	}
+	if acct.HasLockout {
+		if err := r.resetLockout(ctx, acct.Account.PublicId); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if acct.IsPasswordExpired {
+		updated, err := r.ChangePassword(ctx, scopeId, acct.Account.PublicId, password, opts.withNewPassword, acct.Account.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if updated == nil {
+			// the password was changed concurrently
+			return nil, nil
+		}
+		acct.Account.Version = updated.Version
+		acct.Account.CredentialId = updated.CredentialId
+		return acct.Account, nil
+	}
+
+	if acct.ImportedCredentialId != "" {
+		if err := r.replaceImported(ctx, scopeId, acct, password); err != nil {
+			return acct.Account, errors.Wrap(err, op, errors.WithMsg("replace imported credential"))
+		}
+		return acct.Account, nil
+	}
+
 	if !acct.IsCurrentConf {
 		cc, err := r.currentConfig(ctx, authMethodId)
 		if err != nil {
@@ -154,6 +254,11 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	if acct == nil {
 		return nil, nil
 	}
+	if acct.HasLockout {
+		if err := r.resetLockout(ctx, accountId); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
 
 	cc, err := r.currentConfig(ctx, authAccount.GetAuthMethodId())
 	if err != nil {
@@ -162,6 +267,9 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 	if cc.MinPasswordLength > len(new) {
 		return nil, errors.New(errors.PasswordTooShort, op, fmt.Sprintf("must be at least %d", cc.MinPasswordLength))
 	}
+	if err := r.checkPasswordPolicy(ctx, cc, scopeId, accountId, new); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
 	newCred, err := newArgon2Credential(accountId, new, cc.argon2())
 	if err != nil {
 		return nil, errors.Wrap(err, op)
@@ -171,7 +279,17 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
 	}
 
-	oldCred := acct.Argon2Credential
+	var oldCred interface{}
+	var oldCredMetadata oplog.Metadata
+	switch {
+	case acct.ImportedCredentialId != "":
+		imported := allocImportedCredential()
+		imported.PrivateId = acct.ImportedCredentialId
+		imported.PasswordAccountId = accountId
+		oldCred, oldCredMetadata = imported, imported.oplog(oplog.OpType_OP_TYPE_DELETE)
+	default:
+		oldCred, oldCredMetadata = acct.Argon2Credential, acct.Argon2Credential.oplog(oplog.OpType_OP_TYPE_DELETE)
+	}
 
 	var updatedAccount *Account
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
@@ -187,7 +305,10 @@ func (r *Repository) ChangePassword(ctx context.Context, scopeId, accountId, old
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated account and %d rows updated", rowsUpdated))
 			}
 
-			rowsDeleted, err := w.Delete(ctx, oldCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err := updatePasswordHistory(ctx, w, accountId, cc.PasswordHistoryCount); err != nil {
+				return errors.Wrap(err, op)
+			}
+			rowsDeleted, err := w.Delete(ctx, oldCred, db.WithOplog(oplogWrapper, oldCredMetadata))
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
@@ -237,28 +358,137 @@ func (r *Repository) authenticate(ctx context.Context, scopeId, authMethodId, lo
 		acct = accts[0]
 	}
 
-	// We don't pass a wrapper in here because for ecryption we want to indicate the expected key ID
-	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(acct.GetKeyId()))
-	if err != nil {
-		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	if acct.IsLocked {
+		// the account is locked, the password is not checked so a locked
+		// account can not be used to guess passwords
+		return nil, nil
 	}
 
-	if err := acct.decrypt(ctx, databaseWrapper); err != nil {
-		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt credential"))
-	}
+	var match bool
+	if acct.ImportedCredentialId != "" {
+		if match, err = r.verifyImported(ctx, scopeId, &acct, password); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	} else {
+		// We don't pass a wrapper in here because for ecryption we want to indicate the expected key ID
+		databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(acct.GetKeyId()))
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+
+		if err := acct.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithCode(errors.Decrypt), errors.WithMsg("unable to decrypt credential"))
+		}
 
-	inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
-	if subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 0 {
+		inputKey := argon2.IDKey([]byte(password), acct.Salt, acct.Iterations, acct.Memory, uint8(acct.Threads), acct.KeyLength)
+		match = subtle.ConstantTimeCompare(inputKey, acct.DerivedKey) == 1
+	}
+	if !match {
 		// authentication failed, password does not match
+		lockoutDuration := time.Duration(acct.LockoutDurationSeconds) * time.Second
+		if err := r.recordFailedAttempt(ctx, acct.Account.PublicId, acct.LockoutThreshold, lockoutDuration); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		return nil, nil
+	}
+	if !acct.Active {
+		// the account is disabled, it is checked after the password so the
+		// response does not reveal the account is disabled to someone who
+		// does not know the password
 		return nil, nil
 	}
 	return &acct, nil
 }
 
+// verifyImported reports whether password matches the imported password hash
+// of acct.
+func (r *Repository) verifyImported(ctx context.Context, scopeId string, acct *authAccount, password string) (bool, error) {
+	const op = "password.(Repository).verifyImported"
+	cred := allocImportedCredential()
+	cred.PrivateId = acct.ImportedCredentialId
+	cred.PasswordAccountId = acct.Account.PublicId
+	cred.CtPasswordHash = acct.ImportedPasswordHash
+	cred.KeyId = acct.ImportedKeyId
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase, kms.WithKeyId(cred.KeyId))
+	if err != nil {
+		return false, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := cred.decrypt(ctx, databaseWrapper); err != nil {
+		return false, errors.Wrap(err, op, errors.WithMsg("unable to decrypt imported credential"))
+	}
+	ok, err := cred.verify(password)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	return ok, nil
+}
+
+// replaceImported replaces the imported credential of acct with an argon2
+// credential for password using the current configuration of the auth
+// method. The credential id is not changed.
+func (r *Repository) replaceImported(ctx context.Context, scopeId string, acct *authAccount, password string) error {
+	const op = "password.(Repository).replaceImported"
+	cc, err := r.currentConfig(ctx, acct.Account.AuthMethodId)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("retrieve current password configuration"))
+	}
+	cred, err := newArgon2Credential(acct.Account.PublicId, password, cc.argon2())
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.PasswordInvalidConfiguration))
+	}
+	cred.PrivateId = acct.ImportedCredentialId
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get oplog wrapper"))
+	}
+	databaseWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := cred.encrypt(ctx, databaseWrapper); err != nil {
+		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+
+	oldCred := allocImportedCredential()
+	oldCred.PrivateId = acct.ImportedCredentialId
+	oldCred.PasswordAccountId = acct.Account.PublicId
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			rowsDeleted, err := w.Delete(ctx, oldCred.clone(), db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsDeleted != 1 {
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("deleted imported credential and %d rows deleted", rowsDeleted))
+			}
+			if err := w.Create(ctx, cred, db.WithOplog(oplogWrapper, cred.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create argon2 credential"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
 // SetPassword sets the password for accountId to password. If password
 // contains an empty string, the password for accountId will be deleted.
 func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, password string, version uint32) (*Account, error) {
 	const op = "password.(Repository).SetPassword"
+	acct, err := r.setPassword(ctx, scopeId, accountId, password, version, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return acct, nil
+}
+
+// setPassword sets the password for accountId to password. If txFn is not
+// nil, it is called first in the transaction which sets the password.
+func (r *Repository) setPassword(ctx context.Context, scopeId, accountId, password string, version uint32, txFn func(db.Writer) error) (*Account, error) {
+	const op = "password.(Repository).setPassword"
 	if accountId == "" {
 		return nil, errors.New(errors.InvalidParameter, op, "missing accountId")
 	}
@@ -279,6 +509,7 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 	}
 
 	var newCred *Argon2Credential
+	var historyCount int
 	if password != "" {
 		cc, err := r.currentConfigForAccount(ctx, accountId)
 		if err != nil {
@@ -290,6 +521,10 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 		if cc.MinPasswordLength > len(password) {
 			return nil, errors.New(errors.PasswordTooShort, op, fmt.Sprintf("password must be at least %v", cc.MinPasswordLength))
 		}
+		if err := r.checkPasswordPolicy(ctx, cc, scopeId, accountId, password); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		historyCount = cc.PasswordHistoryCount
 		newCred, err = newArgon2Credential(accountId, password, cc.argon2())
 		if err != nil {
 			return nil, errors.Wrap(err, op)
@@ -302,6 +537,11 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 	var acct *Account
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(rr db.Reader, w db.Writer) error {
+			if txFn != nil {
+				if err := txFn(w); err != nil {
+					return err
+				}
+			}
 			updatedAccount := allocAccount()
 			updatedAccount.PublicId = accountId
 			updatedAccount.Version = version + 1
@@ -321,6 +561,9 @@ func (r *Repository) SetPassword(ctx context.Context, scopeId, accountId, passwo
 				}
 			}
 			if oldCred.PrivateId != "" {
+				if err := updatePasswordHistory(ctx, w, accountId, historyCount); err != nil {
+					return errors.Wrap(err, op)
+				}
 				dCred := oldCred.clone()
 				rowsDeleted, err := w.Delete(ctx, dCred, db.WithOplog(oplogWrapper, oldCred.oplog(oplog.OpType_OP_TYPE_DELETE)))
 				if err != nil {
@@ -0,0 +1,143 @@
+package password
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/vault/sdk/helper/base62"
+)
+
+const (
+	resetTokenLength = 32
+
+	// DefaultResetTokenTtl is the duration for which a reset token can be
+	// used if WithResetTokenTtl is not provided.
+	DefaultResetTokenTtl = time.Hour
+
+	// MaxResetTokenTtl is the maximum duration for which a reset token can
+	// be used.
+	MaxResetTokenTtl = 7 * 24 * time.Hour
+)
+
+// CreateResetToken creates a one-time token which can be used with
+// ResetPassword to reset the password of the account with the provided id,
+// replacing any existing reset token of the account. The token is only
+// returned by this method.
+//
+// WithResetTokenTtl sets the duration for which the token can be used. It
+// defaults to DefaultResetTokenTtl and can not be greater than
+// MaxResetTokenTtl. All other options are ignored.
+func (r *Repository) CreateResetToken(ctx context.Context, accountId string, opt ...Option) (*ResetToken, error) {
+	const op = "password.(Repository).CreateResetToken"
+	if accountId == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing account id")
+	}
+	opts := getOpts(opt...)
+	ttl := opts.withResetTtl
+	switch {
+	case ttl == 0:
+		ttl = DefaultResetTokenTtl
+	case ttl < 0 || ttl > MaxResetTokenTtl:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("reset token ttl must be between 1s and %s", MaxResetTokenTtl))
+	}
+	acct, err := r.LookupAccount(ctx, accountId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if acct == nil {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("account %s not found", accountId))
+	}
+
+	token, err := base62.Random(resetTokenLength)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to generate reset token"))
+	}
+	// We truncate the expiration time to the nearest second to make testing
+	// in different platforms with different time resolutions easier.
+	exp, err := ptypes.TimestampProto(time.Now().Add(ttl).Truncate(time.Second))
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	rt := allocResetToken()
+	rt.AccountId = accountId
+	rt.TokenHash = hashToken(token)
+	rt.ExpirationTime = &timestamp.Timestamp{Timestamp: exp}
+
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, deleteResetTokensQuery, []interface{}{accountId}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to delete existing reset tokens"))
+			}
+			// reset tokens are not replicated, so they don't need oplog
+			// entries.
+			if err := w.Create(ctx, rt); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to create reset token"))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	rt.Token = token
+	return rt, nil
+}
+
+// ResetPassword sets the password of the account in authMethodId which token
+// was created for to password and deletes token. The account is unlocked if
+// it was locked by failed authentication attempts. Returns an error with code
+// PasswordResetTokenInvalid if token does not exist, has expired, or was not
+// created for an account in authMethodId. All options are ignored.
+func (r *Repository) ResetPassword(ctx context.Context, scopeId, authMethodId, token, password string, _ ...Option) (*Account, error) {
+	const op = "password.(Repository).ResetPassword"
+	switch {
+	case scopeId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	case authMethodId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	case token == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing token")
+	case password == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing password")
+	}
+	tokenHash := hashToken(token)
+	rt := allocResetToken()
+	if err := r.reader.LookupWhere(ctx, rt, "token_hash = ? and expiration_time > current_timestamp", tokenHash); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, errors.New(errors.PasswordResetTokenInvalid, op, "reset token not found or expired")
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	acct, err := r.LookupAccount(ctx, rt.AccountId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if acct == nil || acct.AuthMethodId != authMethodId {
+		return nil, errors.New(errors.PasswordResetTokenInvalid, op, "reset token not found or expired")
+	}
+
+	acct, err = r.setPassword(ctx, scopeId, acct.PublicId, password, acct.Version, func(w db.Writer) error {
+		// the delete only succeeds once per token, even for concurrent
+		// requests
+		rowsDeleted, err := w.Exec(ctx, useResetTokenQuery, []interface{}{tokenHash, rt.AccountId})
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		if rowsDeleted != 1 {
+			return errors.New(errors.PasswordResetTokenInvalid, op, "reset token already used")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := r.resetLockout(ctx, acct.PublicId); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return acct, nil
+}
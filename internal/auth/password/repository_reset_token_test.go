@@ -0,0 +1,94 @@
+package password
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ResetToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	ams := TestAuthMethods(t, conn, o.GetPublicId(), 2)
+	am, otherAm := ams[0], ams[1]
+	acct := TestAccounts(t, conn, am.PublicId, 1)[0]
+
+	isInvalid := func(t *testing.T, err error) {
+		t.Helper()
+		assert.Truef(t, errors.Match(errors.T(errors.PasswordResetTokenInvalid), err), "unexpected error %v", err)
+	}
+
+	t.Run("invalid-ttl", func(t *testing.T) {
+		_, err := repo.CreateResetToken(ctx, acct.PublicId, WithResetTokenTtl(MaxResetTokenTtl+time.Second))
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+
+	t.Run("unknown-account", func(t *testing.T) {
+		_, err := repo.CreateResetToken(ctx, "apw_1234567890")
+		assert.Truef(t, errors.IsNotFoundError(err), "unexpected error %v", err)
+	})
+
+	t.Run("single-use", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rt, err := repo.CreateResetToken(ctx, acct.PublicId)
+		require.NoError(err)
+		require.NotEmpty(rt.Token)
+		assert.Equal(hashToken(rt.Token), rt.TokenHash)
+
+		// the token hash is stored, not the token
+		var stored []*ResetToken
+		require.NoError(rw.SearchWhere(ctx, &stored, "account_id = ?", []interface{}{acct.PublicId}))
+		require.Len(stored, 1)
+		assert.NotEqual([]byte(rt.Token), stored[0].TokenHash)
+
+		_, err = repo.ResetPassword(ctx, o.GetPublicId(), otherAm.PublicId, rt.Token, "resetpassword")
+		isInvalid(t, err)
+
+		a, err := repo.ResetPassword(ctx, o.GetPublicId(), am.PublicId, rt.Token, "resetpassword")
+		require.NoError(err)
+		assert.Equal(acct.Version+1, a.Version)
+
+		_, err = repo.ResetPassword(ctx, o.GetPublicId(), am.PublicId, rt.Token, "resetpassword2")
+		isInvalid(t, err)
+
+		authed, err := repo.Authenticate(ctx, o.GetPublicId(), am.PublicId, acct.LoginName, "resetpassword")
+		require.NoError(err)
+		assert.NotNil(authed)
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		first, err := repo.CreateResetToken(ctx, acct.PublicId)
+		require.NoError(t, err)
+		second, err := repo.CreateResetToken(ctx, acct.PublicId)
+		require.NoError(t, err)
+
+		_, err = repo.ResetPassword(ctx, o.GetPublicId(), am.PublicId, first.Token, "resetpassword3")
+		isInvalid(t, err)
+		_, err = repo.ResetPassword(ctx, o.GetPublicId(), am.PublicId, second.Token, "resetpassword3")
+		require.NoError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		rt, err := repo.CreateResetToken(ctx, acct.PublicId, WithResetTokenTtl(time.Second))
+		require.NoError(t, err)
+		time.Sleep(2 * time.Second)
+		_, err = repo.ResetPassword(ctx, o.GetPublicId(), am.PublicId, rt.Token, "resetpassword4")
+		isInvalid(t, err)
+	})
+}
@@ -0,0 +1,43 @@
+package password
+
+import (
+	"crypto/sha256"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+)
+
+// A ResetToken is a one-time token which can be used to reset the password
+// of an account without authenticating. Only the SHA-256 hash of the token is
+// stored.
+type ResetToken struct {
+	*store.ResetToken
+	// Token is the reset token. It is only set on the ResetToken returned
+	// by CreateResetToken.
+	Token     string `gorm:"-"`
+	tableName string
+}
+
+func allocResetToken() *ResetToken {
+	return &ResetToken{
+		ResetToken: &store.ResetToken{},
+	}
+}
+
+// TableName returns the table name.
+func (t *ResetToken) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return "auth_password_reset_token"
+}
+
+// SetTableName sets the table name.
+func (t *ResetToken) SetTableName(n string) {
+	t.tableName = n
+}
+
+// hashToken returns the SHA-256 hash of a reset or invite token.
+func hashToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
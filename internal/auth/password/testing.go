@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/jinzhu/gorm"
@@ -78,3 +79,12 @@ func TestAccounts(t *testing.T, conn *gorm.DB, authMethodId string, count int) [
 	}
 	return auts
 }
+
+// TestTotpCode returns the TOTP code for the base32 encoded secret returned
+// when an account enrolls in TOTP at time now.
+func TestTotpCode(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	return totpCode(key, totpCounter(now))
+}
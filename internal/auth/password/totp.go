@@ -0,0 +1,131 @@
+package password
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// The TOTP parameters follow RFC 6238 and the defaults of common
+// authenticator apps: HMAC-SHA1, 6 digit codes, and 30 second time steps.
+const (
+	totpIssuer     = "Boundary"
+	totpSecretSize = 20
+	totpDigits     = 6
+	totpPeriod     = 30 * time.Second
+
+	// totpSkew is the number of time steps before and after the current time
+	// step for which codes are accepted, to allow for clock drift.
+	totpSkew = 1
+
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func newTotpSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// totpUrl returns an otpauth URL for secret which can be rendered as a QR
+// code for authenticator apps.
+func totpUrl(secret []byte, loginName string) string {
+	v := url.Values{}
+	v.Set("secret", totpEncoding.EncodeToString(secret))
+	v.Set("issuer", totpIssuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(totpDigits))
+	v.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + totpIssuer + ":" + loginName,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// totpCode returns the TOTP code for secret at the time step counter.
+func totpCode(secret []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// validateTotpCode reports whether code is a valid TOTP code for secret at
+// now. Codes for time steps at or before lastCounter are rejected so a code
+// can only be used once. The time step of the code is returned if it is
+// valid.
+func validateTotpCode(secret []byte, code string, now time.Time, lastCounter int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := totpCounter(now)
+	for c := current - totpSkew; c <= current+totpSkew; c++ {
+		if c <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, c)), []byte(code)) == 1 {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCodes returns recoveryCodeCount random recovery codes.
+func newRecoveryCodes() ([]string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	codes := make([]string, 0, recoveryCodeCount)
+	buf := make([]byte, recoveryCodeSize)
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == recoveryCodeSize/2 {
+				sb.WriteByte('-')
+			}
+			// the modulo bias is negligible for a 31 character alphabet
+			sb.WriteByte(alphabet[int(b)%len(alphabet)])
+		}
+		codes = append(codes, sb.String())
+	}
+	return codes, nil
+}
+
+// hashRecoveryCode returns the hash of a recovery code which is stored in
+// the database. Recovery codes are random, so a fast hash is sufficient.
+// Dashes, surrounding whitespace, and case are ignored.
+func hashRecoveryCode(code string) []byte {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(code))
+	return sum[:]
+}
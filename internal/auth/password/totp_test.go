@@ -0,0 +1,86 @@
+package password
+
+import (
+	"encoding/hex"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTotpCodeVectors(t *testing.T) {
+	// test vectors for HMAC-SHA1 from RFC 6238, truncated to 6 digits
+	secret, err := hex.DecodeString("3132333435363738393031323334353637383930")
+	require.NoError(t, err)
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, totpCode(secret, totpCounter(time.Unix(tt.unix, 0))), "time %d", tt.unix)
+	}
+}
+
+func TestValidateTotpCode(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	secret, err := newTotpSecret()
+	require.NoError(err)
+	now := time.Now()
+	current := totpCounter(now)
+
+	counter, ok := validateTotpCode(secret, totpCode(secret, current), now, 0)
+	assert.True(ok)
+	assert.Equal(current, counter)
+
+	// codes from adjacent time steps are accepted
+	_, ok = validateTotpCode(secret, totpCode(secret, current-1), now, 0)
+	assert.True(ok)
+	_, ok = validateTotpCode(secret, totpCode(secret, current+1), now, 0)
+	assert.True(ok)
+	_, ok = validateTotpCode(secret, totpCode(secret, current-2), now, 0)
+	assert.False(ok)
+
+	// a code can not be used twice
+	_, ok = validateTotpCode(secret, totpCode(secret, current), now, current)
+	assert.False(ok)
+
+	_, ok = validateTotpCode(secret, "", now, 0)
+	assert.False(ok)
+	_, ok = validateTotpCode(secret, "12345", now, 0)
+	assert.False(ok)
+}
+
+func TestTotpUrl(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	u, err := url.Parse(totpUrl([]byte("12345678901234567890"), "alice"))
+	require.NoError(err)
+	assert.Equal("otpauth", u.Scheme)
+	assert.Equal("totp", u.Host)
+	assert.Equal("/Boundary:alice", u.Path)
+	assert.Equal("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	assert.Equal("Boundary", u.Query().Get("issuer"))
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	codes, err := newRecoveryCodes()
+	require.NoError(err)
+	assert.Len(codes, recoveryCodeCount)
+	seen := map[string]bool{}
+	for _, c := range codes {
+		assert.Len(c, recoveryCodeSize+1)
+		assert.False(seen[c])
+		seen[c] = true
+	}
+	assert.Equal(hashRecoveryCode("abcde-fghjk"), hashRecoveryCode(" ABCDEFGHJK "))
+	assert.NotEqual(hashRecoveryCode("abcde-fghjk"), hashRecoveryCode("abcde-fghjm"))
+}
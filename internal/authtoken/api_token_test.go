@@ -0,0 +1,108 @@
+package authtoken
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApiToken(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		userId           string
+		opts             []Option
+		wantName         string
+		wantDescription  string
+		wantGrantScopeId string
+		wantIsErr        errors.Code
+	}{
+		{
+			name:   "valid",
+			userId: "u_1234567890",
+		},
+		{
+			name:             "valid-with-options",
+			userId:           "u_1234567890",
+			opts:             []Option{WithName("name"), WithDescription("description"), WithGrantScopeId("p_1234567890")},
+			wantName:         "name",
+			wantDescription:  "description",
+			wantGrantScopeId: "p_1234567890",
+		},
+		{
+			name:      "missing-user-id",
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewApiToken(tt.userId, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.userId, got.GetIamUserId())
+			assert.Equal(tt.wantName, got.GetName())
+			assert.Equal(tt.wantDescription, got.GetDescription())
+			assert.Equal(tt.wantGrantScopeId, got.GetGrantScopeId())
+			assert.Empty(got.GetPublicId())
+			assert.Empty(got.GetToken())
+		})
+	}
+}
+
+func TestNewApiTokenGrant(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		apiTokenId    string
+		grant         string
+		wantCanonical string
+		wantIsErr     errors.Code
+	}{
+		{
+			name:          "valid",
+			apiTokenId:    "apt_1234567890",
+			grant:         "type=*;id=*;actions=read,list",
+			wantCanonical: "id=*;type=*;actions=list,read",
+		},
+		{
+			name:      "missing-api-token-id",
+			grant:     "id=*;type=*;actions=read",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:       "missing-grant",
+			apiTokenId: "apt_1234567890",
+			wantIsErr:  errors.InvalidParameter,
+		},
+		{
+			name:       "bad-grant",
+			apiTokenId: "apt_1234567890",
+			grant:      "id=*;actions=read",
+			wantIsErr:  errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewApiTokenGrant(tt.apiTokenId, tt.grant)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.apiTokenId, got.GetApiTokenId())
+			assert.Equal(tt.grant, got.GetRawGrant())
+			assert.Equal(tt.wantCanonical, got.GetCanonicalGrant())
+		})
+	}
+}
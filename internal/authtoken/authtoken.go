@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/gen/controller/tokens"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/hashicorp/go-kms-wrapping/structwrapping"
 	"github.com/hashicorp/vault/sdk/helper/base62"
@@ -90,6 +91,77 @@ func (at *AuthToken) decrypt(ctx context.Context, cipher wrapping.Wrapper) error
 	return nil
 }
 
+// IsDerived returns true if the auth token was derived from another auth
+// token.
+func (at *AuthToken) IsDerived() bool {
+	return at.GetParentId() != ""
+}
+
+// AuthTokenGrant is a grant which restricts what a derived auth token is
+// allowed to do.
+type AuthTokenGrant struct {
+	*store.AuthTokenGrant
+	tableName string `gorm:"-"`
+}
+
+// ensure that AuthTokenGrant implements the interfaces of: db.VetForWriter
+var _ db.VetForWriter = (*AuthTokenGrant)(nil)
+
+// NewAuthTokenGrant creates a new in memory grant for the derived auth token
+// with the provided id. No options are currently supported.
+func NewAuthTokenGrant(authTokenId, grant string, _ ...Option) (*AuthTokenGrant, error) {
+	const op = "authtoken.NewAuthTokenGrant"
+	if authTokenId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing auth token id")
+	}
+	if grant == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing grant")
+	}
+
+	// Validate that the grant parses successfully. Note that we fake the scope
+	// here to avoid a lookup as the scope is only relevant at actual ACL
+	// checking time and we just care that it parses correctly.
+	perm, err := perms.Parse("o_abcd1234", grant)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("parsing grant string"))
+	}
+	return &AuthTokenGrant{
+		AuthTokenGrant: &store.AuthTokenGrant{
+			AuthTokenId:    authTokenId,
+			RawGrant:       grant,
+			CanonicalGrant: perm.CanonicalString(),
+		},
+	}, nil
+}
+
+func (g *AuthTokenGrant) clone() *AuthTokenGrant {
+	cp := proto.Clone(g.AuthTokenGrant)
+	return &AuthTokenGrant{
+		AuthTokenGrant: cp.(*store.AuthTokenGrant),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for auth token grants.
+func (g *AuthTokenGrant) VetForWrite(_ context.Context, _ db.Reader, _ db.OpType, _ ...db.Option) error {
+	const op = "authtoken.(AuthTokenGrant).VetForWrite"
+	if g.AuthTokenId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing auth token id")
+	}
+	if g.RawGrant == "" {
+		return errors.New(errors.InvalidParameter, op, "missing grant")
+	}
+	perm, err := perms.Parse("o_abcd1234", g.RawGrant)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("parsing grant string"))
+	}
+	canonical := perm.CanonicalString()
+	if g.CanonicalGrant != "" && g.CanonicalGrant != canonical {
+		return errors.New(errors.InvalidParameter, op, "existing canonical grant and derived one do not match")
+	}
+	g.CanonicalGrant = canonical
+	return nil
+}
+
 const (
 	AuthTokenPrefix = "at"
 	// The version prefix is used to differentiate token versions just for future proofing.
@@ -110,18 +182,28 @@ func newAuthTokenId() (string, error) {
 // supported.
 func newAuthToken(_ ...Option) (*AuthToken, error) {
 	const op = "authtoken.newAuthToken"
-	token, err := base62.Random(tokenLength)
+	token, err := newTokenValue()
 	if err != nil {
-		return nil, errors.Wrap(err, op, errors.WithCode(errors.Io))
+		return nil, errors.Wrap(err, op)
 	}
 
 	return &AuthToken{
 		AuthToken: &store.AuthToken{
-			Token: fmt.Sprintf("%s%s", TokenValueVersionPrefix, token),
+			Token: token,
 		},
 	}, nil
 }
 
+// newTokenValue generates a new random token value.
+func newTokenValue() (string, error) {
+	const op = "authtoken.newTokenValue"
+	token, err := base62.Random(tokenLength)
+	if err != nil {
+		return "", errors.Wrap(err, op, errors.WithCode(errors.Io))
+	}
+	return fmt.Sprintf("%s%s", TokenValueVersionPrefix, token), nil
+}
+
 // EncryptToken is a shared function for encrypting a token value for return to
 // the user.
 func EncryptToken(ctx context.Context, kmsCache *kms.Kms, scopeId, publicId, token string) (string, error) {
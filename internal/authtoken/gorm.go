@@ -9,6 +9,10 @@ const (
 	// auth_method_id.  These additional columns are returned via the API for
 	// auth tokens, so the view's handy
 	defaultAuthTokenViewName = "auth_token_account"
+
+	// defaultAuthTokenGrantTableName is the table where the grants which
+	// restrict derived auth tokens are stored.
+	defaultAuthTokenGrantTableName = "auth_token_grant"
 )
 
 // TableName returns the table name for the auth token.
@@ -38,3 +42,17 @@ func (s *authTokenView) TableName() string {
 func (s *authTokenView) SetTableName(n string) {
 	s.tableName = n
 }
+
+// TableName returns the table name for the auth token grant.
+func (g *AuthTokenGrant) TableName() string {
+	if g.tableName != "" {
+		return g.tableName
+	}
+	return defaultAuthTokenGrantTableName
+}
+
+// SetTableName sets the table name.  If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (g *AuthTokenGrant) SetTableName(n string) {
+	g.tableName = n
+}
@@ -7,8 +7,11 @@ import (
 )
 
 var (
-	defaultTokenTimeToLiveDuration  = 7 * 24 * time.Hour
-	defaultTokenTimeToStaleDuration = 24 * time.Hour
+	defaultTokenTimeToLiveDuration    = 7 * 24 * time.Hour
+	defaultTokenTimeToStaleDuration   = 24 * time.Hour
+	defaultApiTokenTimeToLiveDuration = 90 * 24 * time.Hour
+
+	defaultDerivedTokenTimeToLiveDuration = 10 * time.Minute
 )
 
 // getOpts - iterate the inbound Options and return a struct
@@ -29,6 +32,10 @@ type options struct {
 	withTokenTimeToLiveDuration  time.Duration
 	withTokenTimeToStaleDuration time.Duration
 	withLimit                    int
+	withName                     string
+	withDescription              string
+	withGrantScopeId             string
+	withExpirationTime           time.Time
 }
 
 func getDefaultOptions() options {
@@ -75,3 +82,33 @@ func WithLimit(limit int) Option {
 		}
 	}
 }
+
+// WithName provides an optional name.
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithDescription provides an optional description.
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithGrantScopeId provides an optional scope id for the grants which
+// restrict an api token or a derived auth token.
+func WithGrantScopeId(id string) Option {
+	return func(o *options) {
+		o.withGrantScopeId = id
+	}
+}
+
+// WithExpirationTime allows setting the time an api token or a derived auth
+// token expires.
+func WithExpirationTime(t time.Time) Option {
+	return func(o *options) {
+		o.withExpirationTime = t
+	}
+}
@@ -34,4 +34,37 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTokenTimeToStaleDuration = 1 * time.Hour
 		assert.Equal(opts, testOpts)
 	})
+
+	t.Run("WithName", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithName("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withName = "test"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithDescription", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDescription("test"))
+		testOpts := getDefaultOptions()
+		testOpts.withDescription = "test"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithGrantScopeId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithGrantScopeId("o_1234"))
+		testOpts := getDefaultOptions()
+		testOpts.withGrantScopeId = "o_1234"
+		assert.Equal(opts, testOpts)
+	})
+
+	t.Run("WithExpirationTime", func(t *testing.T) {
+		assert := assert.New(t)
+		exp := time.Now().Add(time.Hour)
+		opts := getOpts(WithExpirationTime(exp))
+		testOpts := getDefaultOptions()
+		testOpts.withExpirationTime = exp
+		assert.Equal(opts, testOpts)
+	})
 }
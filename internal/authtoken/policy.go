@@ -0,0 +1,66 @@
+package authtoken
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	// defaultTokenPolicyViewName is a view that resolves the auth token
+	// durations configured on each auth method and its scopes.
+	defaultTokenPolicyViewName = "auth_token_policy"
+)
+
+// tokenPolicy contains the durations which control the lifetime of the auth
+// tokens issued by an auth method.  A zero value means the duration is not
+// set on the auth method or any of its scopes.
+type tokenPolicy struct {
+	AuthMethodId       string `gorm:"primary_key"`
+	ScopeId            string
+	TimeToLiveSeconds  uint32
+	TimeToStaleSeconds uint32
+	tableName          string `gorm:"-"`
+}
+
+// TableName returns the table name for the token policy.
+func (p *tokenPolicy) TableName() string {
+	if p.tableName != "" {
+		return p.tableName
+	}
+	return defaultTokenPolicyViewName
+}
+
+// SetTableName sets the table name.  If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (p *tokenPolicy) SetTableName(n string) {
+	p.tableName = n
+}
+
+// tokenDurations returns the time to live and time to stale durations for the
+// auth tokens issued by the auth method with the provided id.  Durations which
+// are not set on the auth method or its scopes fall back to the durations the
+// repository was created with.
+func (r *Repository) tokenDurations(ctx context.Context, reader db.Reader, authMethodId string) (time.Duration, time.Duration, error) {
+	const op = "authtoken.(Repository).tokenDurations"
+	ttl, tts := r.timeToLiveDuration, r.timeToStaleDuration
+	if authMethodId == "" {
+		return 0, 0, errors.New(errors.InvalidParameter, op, "missing auth method id")
+	}
+	var p tokenPolicy
+	if err := reader.LookupWhere(ctx, &p, "auth_method_id = ?", authMethodId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return ttl, tts, nil
+		}
+		return 0, 0, errors.Wrap(err, op)
+	}
+	if p.TimeToLiveSeconds > 0 {
+		ttl = time.Duration(p.TimeToLiveSeconds) * time.Second
+	}
+	if p.TimeToStaleSeconds > 0 {
+		tts = time.Duration(p.TimeToStaleSeconds) * time.Second
+	}
+	return ttl, tts, nil
+}
@@ -10,9 +10,15 @@ import (
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
 	"github.com/hashicorp/boundary/internal/kms"
 )
 
+const (
+	lockActiveUserQuery            = `select active from iam_user where public_id = ? for share`
+	lockActivePasswordAccountQuery = `select active from auth_password_account where public_id = ? for share`
+)
+
 var (
 	lastAccessedUpdateDuration = 10 * time.Minute
 	timeSkew                   = time.Duration(0)
@@ -85,14 +91,6 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
 	}
 
-	// We truncate the expiration time to the nearest second to make testing in different platforms with
-	// different time resolutions easier.
-	expiration, err := ptypes.TimestampProto(time.Now().Add(r.timeToLiveDuration).Truncate(time.Second))
-	if err != nil {
-		return nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
-	}
-	at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
-
 	var newAuthToken *AuthToken
 	_, err = r.writer.DoTx(
 		ctx,
@@ -108,10 +106,25 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 				return errors.New(errors.InvalidParameter, op,
 					fmt.Sprintf("auth account %q mismatch with iam user %q", withAuthAccountId, withIamUser.GetPublicId()))
 			}
+			if err := lockActive(ctx, read, acct.GetIamUserId(), withAuthAccountId); err != nil {
+				return errors.Wrap(err, op)
+			}
 			at.ScopeId = acct.GetScopeId()
 			at.AuthMethodId = acct.GetAuthMethodId()
 			at.IamUserId = acct.GetIamUserId()
 
+			ttl, _, err := r.tokenDurations(ctx, read, at.GetAuthMethodId())
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			// We truncate the expiration time to the nearest second to make testing in different platforms with
+			// different time resolutions easier.
+			expiration, err := ptypes.TimestampProto(time.Now().Add(ttl).Truncate(time.Second))
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+			}
+			at.ExpirationTime = &timestamp.Timestamp{Timestamp: expiration}
+
 			newAuthToken = at.clone()
 			if err := newAuthToken.encrypt(ctx, databaseWrapper); err != nil {
 				return errors.Wrap(err, op)
@@ -132,6 +145,48 @@ func (r *Repository) CreateAuthToken(ctx context.Context, withIamUser *iam.User,
 	return newAuthToken, nil
 }
 
+// lockActive locks the user and, for a password account, the auth account a
+// new auth token is issued to until the transaction ends, and returns an error
+// if either is not active.  Disabling a user or an account updates its row
+// and deletes its auth tokens in one transaction, so it either waits for the
+// new token and deletes it or the disabled state is seen here.
+func lockActive(ctx context.Context, r db.Reader, userId, accountId string) error {
+	const op = "authtoken.lockActive"
+	found, active, err := lockActiveRow(ctx, r, lockActiveUserQuery, userId)
+	switch {
+	case err != nil:
+		return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lock user %s", userId)))
+	case !found:
+		return errors.New(errors.RecordNotFound, op, fmt.Sprintf("user %s not found", userId))
+	case !active:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("user %s is not active", userId))
+	}
+	// Only password accounts can be disabled.
+	found, active, err = lockActiveRow(ctx, r, lockActivePasswordAccountQuery, accountId)
+	switch {
+	case err != nil:
+		return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lock account %s", accountId)))
+	case found && !active:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("account %s is not active", accountId))
+	}
+	return nil
+}
+
+func lockActiveRow(ctx context.Context, r db.Reader, query, id string) (found, active bool, err error) {
+	rows, err := r.Query(ctx, query, []interface{}{id})
+	if err != nil {
+		return false, false, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return false, false, rows.Err()
+	}
+	if err := rows.Scan(&active); err != nil {
+		return false, false, err
+	}
+	return true, active, nil
+}
+
 // LookupAuthToken returns the AuthToken for the provided id. Returns nil, nil if no AuthToken is found for id.
 // For security reasons, the actual token is not included in the returned AuthToken.
 // All exported options are ignored.
@@ -172,8 +227,8 @@ func (r *Repository) LookupAuthToken(ctx context.Context, id string, opt ...Opti
 // ValidateToken returns a token from storage if the auth token with the provided id and token exists.  The
 // approximate last accessed time may be updated depending on how long it has been since the last time the token
 // was validated.  If a token is returned it is guaranteed to be valid. For security reasons, the actual token
-// value is not included in the returned AuthToken. If no valid auth token is found nil, nil is returned.
-// All options are ignored.
+// value is not included in the returned AuthToken. If no valid auth token is found, or the token's user
+// is not active, nil, nil is returned. All options are ignored.
 //
 // NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
 func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ...Option) (*AuthToken, error) {
@@ -197,21 +252,43 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 		return nil, nil
 	}
 
+	// The durations of the token's auth method are used, so a change to them
+	// applies to the tokens which have already been issued.
+	ttl, tts, err := r.tokenDurations(ctx, r.reader, retAT.GetAuthMethodId())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
 	// If the token is too old or stale invalidate it and return nothing.
-	exp, err := ptypes.Timestamp(retAT.GetExpirationTime().GetTimestamp())
+	now := time.Now()
+	invalid, err := expiredOrStale(retAT, now, ttl, tts)
 	if err != nil {
-		return nil, errors.Wrap(err, op, errors.WithMsg("expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+		return nil, errors.Wrap(err, op)
 	}
 	lastAccessed, err := ptypes.Timestamp(retAT.GetApproximateLastAccessTime().GetTimestamp())
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
 	}
-
-	now := time.Now()
 	sinceLastAccessed := now.Sub(lastAccessed) + timeSkew
-	// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
-	// if it can be eliminated.
-	if now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= r.timeToStaleDuration {
+
+	// A derived token is only valid while the token it was derived from is
+	// valid.  Both belong to the same auth method.
+	if !invalid && retAT.IsDerived() {
+		parent, err := r.LookupAuthToken(ctx, retAT.GetParentId())
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("parent auth token"))
+		}
+		if parent == nil {
+			invalid = true
+		} else {
+			invalid, err = expiredOrStale(parent, now, ttl, tts)
+			if err != nil {
+				return nil, errors.Wrap(err, op, errors.WithMsg("parent auth token"))
+			}
+		}
+	}
+
+	if invalid {
 		// If the token has expired or has become too stale, delete it from the DB.
 		_, err = r.writer.DoTx(
 			ctx,
@@ -238,6 +315,19 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	// retAT.Token set to empty string so the value is not returned as described in the methods' doc.
 	retAT.Token = ""
 
+	// Disabling a user deletes its auth tokens, but a token of a disabled
+	// user must never be accepted even if it was issued concurrently.
+	user := &iam.User{User: &iamStore.User{PublicId: retAT.GetIamUserId()}}
+	if err := r.reader.LookupByPublicId(ctx, user); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", retAT.GetIamUserId())))
+	}
+	if !user.GetActive() {
+		return nil, nil
+	}
+
 	if sinceLastAccessed >= lastAccessedUpdateDuration {
 		// To save the db from being updated too frequently, we only update the
 		// LastAccessTime if it hasn't been updated within lastAccessedUpdateDuration.
@@ -274,6 +364,172 @@ func (r *Repository) ValidateToken(ctx context.Context, id, token string, opt ..
 	return retAT, nil
 }
 
+// expiredOrStale returns true if the auth token has expired, is older than
+// the time to live duration, or has not been used within the time to stale
+// duration.
+func expiredOrStale(at *AuthToken, now time.Time, ttl, tts time.Duration) (bool, error) {
+	const op = "authtoken.expiredOrStale"
+	exp, err := ptypes.Timestamp(at.GetExpirationTime().GetTimestamp())
+	if err != nil {
+		return false, errors.Wrap(err, op, errors.WithMsg("expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	created, err := ptypes.Timestamp(at.GetCreateTime().GetTimestamp())
+	if err != nil {
+		return false, errors.Wrap(err, op, errors.WithMsg("create time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	if maxExp := created.Add(ttl); maxExp.Before(exp) {
+		exp = maxExp
+	}
+	lastAccessed, err := ptypes.Timestamp(at.GetApproximateLastAccessTime().GetTimestamp())
+	if err != nil {
+		return false, errors.Wrap(err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	sinceLastAccessed := now.Sub(lastAccessed) + timeSkew
+	// TODO (jimlambrt 9/2020) - investigate the need for the timeSkew and see
+	// if it can be eliminated.
+	return now.After(exp.Add(-timeSkew)) || sinceLastAccessed >= tts, nil
+}
+
+// DeriveAuthToken exchanges the auth token with the provided id for a new,
+// short-lived auth token which is restricted by the provided grants, and
+// returns the derived auth token and its grants.  The returned auth token
+// contains the auth token value.  A derived auth token is only allowed to do
+// what is allowed by both its grants and the grants of its user. It belongs to
+// the same auth account as its parent, never expires after its parent, and is
+// deleted when its parent is deleted.  Auth tokens cannot be derived from
+// derived auth tokens, attempting it returns an errors.DerivedAuthToken error.
+//
+// WithGrantScopeId and WithExpirationTime are the only valid options. If
+// WithGrantScopeId is not used the grants apply to the scope of the parent auth
+// token, and if WithExpirationTime is not used the derived auth token expires
+// after a default duration.
+func (r *Repository) DeriveAuthToken(ctx context.Context, parentId string, grants []string, opt ...Option) (*AuthToken, []*AuthTokenGrant, error) {
+	const op = "authtoken.(Repository).DeriveAuthToken"
+	if parentId == "" {
+		return nil, nil, errors.New(errors.InvalidPublicId, op, "missing parent public id")
+	}
+	if len(grants) == 0 {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing grants")
+	}
+	opts := getOpts(opt...)
+
+	parent, err := r.LookupAuthToken(ctx, parentId)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	if parent == nil {
+		return nil, nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("auth token %s not found", parentId))
+	}
+	if parent.IsDerived() {
+		return nil, nil, errors.New(errors.DerivedAuthToken, op, fmt.Sprintf("auth token %s is a derived auth token", parentId))
+	}
+
+	expiration := opts.withExpirationTime
+	if expiration.IsZero() {
+		expiration = time.Now().Add(defaultDerivedTokenTimeToLiveDuration)
+	}
+	if !expiration.After(time.Now()) {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "expiration time must be in the future")
+	}
+	parentExpiration, err := ptypes.Timestamp(parent.GetExpirationTime().GetTimestamp())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("parent expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	if expiration.After(parentExpiration) {
+		expiration = parentExpiration
+	}
+
+	at, err := newAuthToken()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	id, err := newAuthTokenId()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	at.PublicId = id
+	at.ParentId = parent.GetPublicId()
+	at.AuthAccountId = parent.GetAuthAccountId()
+	at.ScopeId = parent.GetScopeId()
+	at.AuthMethodId = parent.GetAuthMethodId()
+	at.IamUserId = parent.GetIamUserId()
+	at.GrantScopeId = opts.withGrantScopeId
+	if at.GrantScopeId == "" {
+		at.GrantScopeId = parent.GetScopeId()
+	}
+	// We truncate the expiration time to the nearest second to make testing in different platforms with
+	// different time resolutions easier.
+	exp, err := ptypes.TimestampProto(expiration.Truncate(time.Second))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	at.ExpirationTime = &timestamp.Timestamp{Timestamp: exp}
+
+	newGrants := make([]interface{}, 0, len(grants))
+	for _, grant := range grants {
+		g, err := NewAuthTokenGrant(id, grant)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op)
+		}
+		newGrants = append(newGrants, g)
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, at.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var newAuthToken *AuthToken
+	var createdGrants []*AuthTokenGrant
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			if err := lockActive(ctx, read, at.GetIamUserId(), at.GetAuthAccountId()); err != nil {
+				return errors.Wrap(err, op)
+			}
+			newAuthToken = at.clone()
+			if err := newAuthToken.encrypt(ctx, databaseWrapper); err != nil {
+				return errors.Wrap(err, op)
+			}
+			// tokens are not replicated, so they don't need oplog entries.
+			if err := w.Create(ctx, newAuthToken); err != nil {
+				return errors.Wrap(err, op)
+			}
+			newAuthToken.CtToken = nil
+
+			if err := w.CreateItems(ctx, newGrants); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to add grants"))
+			}
+			createdGrants = make([]*AuthTokenGrant, 0, len(newGrants))
+			for _, g := range newGrants {
+				createdGrants = append(createdGrants, g.(*AuthTokenGrant).clone())
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return newAuthToken, createdGrants, nil
+}
+
+// ListAuthTokenGrants returns the grants which restrict the derived auth token
+// with the provided id.  Auth tokens which are not derived have no grants.  All
+// options are ignored.
+func (r *Repository) ListAuthTokenGrants(ctx context.Context, id string, _ ...Option) ([]*AuthTokenGrant, error) {
+	const op = "authtoken.(Repository).ListAuthTokenGrants"
+	if id == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	var grants []*AuthTokenGrant
+	if err := r.reader.SearchWhere(ctx, &grants, "auth_token_id = ?", []interface{}{id}); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return grants, nil
+}
+
 // ListAuthTokens lists auth tokens in the given scopes and supports the
 // WithLimit option.
 func (r *Repository) ListAuthTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*AuthToken, error) {
@@ -343,3 +599,58 @@ func (r *Repository) DeleteAuthToken(ctx context.Context, id string, opt ...Opti
 
 	return rowsDeleted, nil
 }
+
+// DeleteUserAuthTokens deletes all the auth tokens issued to the user with
+// the provided id, returning a count of the number of records deleted.
+// Sessions created with the deleted auth tokens are canceled by the
+// database. All options are ignored.
+func (r *Repository) DeleteUserAuthTokens(ctx context.Context, userId string, _ ...Option) (int, error) {
+	const op = "authtoken.(Repository).DeleteUserAuthTokens"
+	if userId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing user id")
+	}
+	rowsDeleted, err := r.deleteAuthTokensWhere(ctx, "auth_account_id in (select public_id from auth_account where iam_user_id = ?)", userId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(userId))
+	}
+	return rowsDeleted, nil
+}
+
+// DeleteAccountAuthTokens deletes all the auth tokens issued for the auth
+// account with the provided id, returning a count of the number of records
+// deleted. Sessions created with the deleted auth tokens are canceled by the
+// database. All options are ignored.
+func (r *Repository) DeleteAccountAuthTokens(ctx context.Context, accountId string, _ ...Option) (int, error) {
+	const op = "authtoken.(Repository).DeleteAccountAuthTokens"
+	if accountId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing account id")
+	}
+	rowsDeleted, err := r.deleteAuthTokensWhere(ctx, "auth_account_id = ?", accountId)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(accountId))
+	}
+	return rowsDeleted, nil
+}
+
+func (r *Repository) deleteAuthTokensWhere(ctx context.Context, where string, args ...interface{}) (int, error) {
+	const op = "authtoken.(Repository).deleteAuthTokensWhere"
+	var rowsDeleted int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			var err error
+			// tokens are not replicated, so they don't need oplog entries.
+			rowsDeleted, err = w.Exec(ctx, "delete from auth_token where "+where, args)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return rowsDeleted, nil
+}
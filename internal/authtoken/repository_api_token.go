@@ -0,0 +1,414 @@
+package authtoken
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+)
+
+// CreateApiToken inserts an api token for a service account into the
+// repository and returns the new api token and its grants.  The returned api
+// token contains the api token value. The api token's IamUserId must be the id
+// of a service account. If grants are provided the api token is only allowed to
+// do what is allowed by both its grants and the grants of its service account.
+// WithExpirationTime is the only valid option; if it is not used the api token
+// expires after a default duration.
+func (r *Repository) CreateApiToken(ctx context.Context, apiToken *ApiToken, grants []string, opt ...Option) (*ApiToken, []*ApiTokenGrant, error) {
+	const op = "authtoken.(Repository).CreateApiToken"
+	if apiToken == nil {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing api token")
+	}
+	if apiToken.ApiToken == nil {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing api token store")
+	}
+	if apiToken.PublicId != "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "public id not empty")
+	}
+	if apiToken.IamUserId == "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing user id")
+	}
+	opts := getOpts(opt...)
+
+	expiration := opts.withExpirationTime
+	if expiration.IsZero() {
+		expiration = time.Now().Add(defaultApiTokenTimeToLiveDuration)
+	}
+	if !expiration.After(time.Now()) {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "expiration time must be in the future")
+	}
+
+	at := apiToken.clone()
+	id, err := newApiTokenId()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	at.PublicId = id
+	at.Token, err = newTokenValue()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	// We truncate the expiration time to the nearest second to make testing in different platforms with
+	// different time resolutions easier.
+	exp, err := ptypes.TimestampProto(expiration.Truncate(time.Second))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+	}
+	at.ExpirationTime = &timestamp.Timestamp{Timestamp: exp}
+
+	newGrants := make([]interface{}, 0, len(grants))
+	for _, grant := range grants {
+		g, err := NewApiTokenGrant(id, grant)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op)
+		}
+		newGrants = append(newGrants, g)
+	}
+
+	user := &iam.User{User: &iamStore.User{PublicId: at.IamUserId}}
+	if err := r.reader.LookupByPublicId(ctx, user); err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", at.IamUserId)))
+	}
+	if !user.ServiceAccount {
+		return nil, nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("user %s is not a service account", at.IamUserId))
+	}
+	at.ScopeId = user.ScopeId
+	if at.GrantScopeId == "" {
+		at.GrantScopeId = user.ScopeId
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, at.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+
+	var newApiToken *ApiToken
+	var createdGrants []*ApiTokenGrant
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			newApiToken = at.clone()
+			if err := newApiToken.encrypt(ctx, databaseWrapper); err != nil {
+				return errors.Wrap(err, op)
+			}
+			// api tokens are not replicated, so they don't need oplog entries.
+			if err := w.Create(ctx, newApiToken); err != nil {
+				return errors.Wrap(err, op)
+			}
+			newApiToken.CtToken = nil
+
+			createdGrants = make([]*ApiTokenGrant, 0, len(newGrants))
+			if len(newGrants) > 0 {
+				if err := w.CreateItems(ctx, newGrants); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add grants"))
+				}
+				for _, g := range newGrants {
+					createdGrants = append(createdGrants, g.(*ApiTokenGrant).clone())
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, nil, errors.New(errors.NotUnique, op, fmt.Sprintf("api token %s already exists for user %s", at.Name, at.IamUserId))
+		}
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return newApiToken, createdGrants, nil
+}
+
+// LookupApiToken returns the ApiToken and its grants for the provided id.
+// Returns nil, nil, nil if no ApiToken is found for id. For security reasons,
+// the actual token is not included in the returned ApiToken. All exported
+// options are ignored.
+func (r *Repository) LookupApiToken(ctx context.Context, id string, opt ...Option) (*ApiToken, []*ApiTokenGrant, error) {
+	const op = "authtoken.(Repository).LookupApiToken"
+	if id == "" {
+		return nil, nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	opts := getOpts(opt...)
+
+	at := allocApiToken()
+	at.PublicId = id
+	if err := r.reader.LookupByPublicId(ctx, at); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(err, op)
+	}
+	grants, err := r.listApiTokenGrants(ctx, id)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+
+	if opts.withTokenValue {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, at.GetScopeId(), kms.KeyPurposeDatabase, kms.WithKeyId(at.GetKeyId()))
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt), errors.WithMsg("unable to get database wrapper"))
+		}
+		if err := at.decrypt(ctx, databaseWrapper); err != nil {
+			return nil, nil, errors.Wrap(err, op)
+		}
+	}
+
+	at.CtToken = nil
+	at.KeyId = ""
+	return at, grants, nil
+}
+
+// ValidateApiToken returns an api token and its grants from storage if the api
+// token with the provided id and token exists and has not expired.  The
+// approximate last accessed time may be updated depending on how long it has
+// been since the last time the api token was validated.  For security reasons,
+// the actual token value is not included in the returned ApiToken. If no valid
+// api token is found or the api token's user is not active nil, nil, nil is
+// returned. All options are ignored.
+//
+// NOTE: Do not log or add the token string to any errors to avoid leaking it as it is a secret.
+func (r *Repository) ValidateApiToken(ctx context.Context, id, token string, _ ...Option) (*ApiToken, []*ApiTokenGrant, error) {
+	const op = "authtoken.(Repository).ValidateApiToken"
+	if token == "" {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing token")
+	}
+	if id == "" {
+		return nil, nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+
+	retAT, grants, err := r.LookupApiToken(ctx, id, withTokenValue())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	if retAT == nil {
+		return nil, nil, nil
+	}
+
+	// Unlike auth tokens, api tokens which have expired are not deleted so
+	// their last use can still be audited.
+	exp, err := ptypes.Timestamp(retAT.GetExpirationTime().GetTimestamp())
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg("expiration time"), errors.WithCode(errors.InvalidTimeStamp))
+	}
+	now := time.Now()
+	if now.After(exp.Add(-timeSkew)) {
+		return nil, nil, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(retAT.GetToken()), []byte(token)) != 1 {
+		return nil, nil, nil
+	}
+	// retAT.Token set to empty string so the value is not returned as described in the methods' doc.
+	retAT.Token = ""
+
+	// Api tokens of a disabled user are kept, so enabling the user again
+	// restores them, but they can't be used while the user is disabled.
+	user := &iam.User{User: &iamStore.User{PublicId: retAT.GetIamUserId()}}
+	if err := r.reader.LookupByPublicId(ctx, user); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", retAT.GetIamUserId())))
+	}
+	if !user.GetActive() {
+		return nil, nil, nil
+	}
+
+	updateLastAccessed := retAT.GetApproximateLastAccessTime().GetTimestamp() == nil
+	if !updateLastAccessed {
+		lastAccessed, err := ptypes.Timestamp(retAT.GetApproximateLastAccessTime().GetTimestamp())
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg("last accessed time"), errors.WithCode(errors.InvalidTimeStamp))
+		}
+		updateLastAccessed = now.Sub(lastAccessed)+timeSkew >= lastAccessedUpdateDuration
+	}
+	if updateLastAccessed {
+		// To save the db from being updated too frequently, we only update the
+		// ApproximateLastAccessTime if it hasn't been updated within
+		// lastAccessedUpdateDuration.
+		_, err = r.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				at := allocApiToken()
+				at.PublicId = retAT.GetPublicId()
+				// Setting ApproximateLastAccessTime through the null mask
+				// allows a defined db's trigger to set it to the commit
+				// timestamp. Api tokens are not replicated, so they don't need
+				// oplog entries.
+				rowsUpdated, err := w.Update(
+					ctx,
+					at,
+					nil,
+					[]string{"ApproximateLastAccessTime"},
+				)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				if rowsUpdated > 1 {
+					return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, op, errors.WithMsg(id))
+		}
+	}
+	return retAT, grants, nil
+}
+
+// ListApiTokens lists api tokens in the given scopes and supports the
+// WithLimit option.
+func (r *Repository) ListApiTokens(ctx context.Context, withScopeIds []string, opt ...Option) ([]*ApiToken, error) {
+	const op = "authtoken.(Repository).ListApiTokens"
+	if len(withScopeIds) == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+
+	var apiTokens []*ApiToken
+	if err := r.reader.SearchWhere(ctx, &apiTokens, "scope_id in (?)", []interface{}{withScopeIds}, db.WithLimit(opts.withLimit)); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	for _, at := range apiTokens {
+		at.Token = ""
+		at.CtToken = nil
+		at.KeyId = ""
+	}
+	return apiTokens, nil
+}
+
+// RotateApiToken replaces the value of the api token with the provided id with
+// a new value and returns the api token, which contains the new value.  The
+// previous value is no longer valid once the api token has been rotated.
+// WithExpirationTime is the only valid option; if it is used the expiration
+// time of the api token is changed as well.
+func (r *Repository) RotateApiToken(ctx context.Context, id string, opt ...Option) (*ApiToken, error) {
+	const op = "authtoken.(Repository).RotateApiToken"
+	if id == "" {
+		return nil, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+	opts := getOpts(opt...)
+
+	at, _, err := r.LookupApiToken(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if at == nil {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("api token %s not found", id))
+	}
+
+	updated := allocApiToken()
+	updated.PublicId = id
+	updated.Token, err = newTokenValue()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	fieldMask := []string{"CtToken", "KeyId"}
+	if !opts.withExpirationTime.IsZero() {
+		if !opts.withExpirationTime.After(time.Now()) {
+			return nil, errors.New(errors.InvalidParameter, op, "expiration time must be in the future")
+		}
+		exp, err := ptypes.TimestampProto(opts.withExpirationTime.Truncate(time.Second))
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithCode(errors.InvalidTimeStamp))
+		}
+		updated.ExpirationTime = &timestamp.Timestamp{Timestamp: exp}
+		fieldMask = append(fieldMask, "ExpirationTime")
+	}
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, at.GetScopeId(), kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
+	}
+	if err := updated.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	var rotated *ApiToken
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(read db.Reader, w db.Writer) error {
+			// api tokens are not replicated, so they don't need oplog entries.
+			rowsUpdated, err := w.Update(ctx, updated.clone(), fieldMask, nil)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated != 1 {
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("rotate api token and %d rows updated", rowsUpdated))
+			}
+			rotated = allocApiToken()
+			rotated.PublicId = id
+			if err := read.LookupByPublicId(ctx, rotated); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(id))
+	}
+	rotated.Token = updated.Token
+	rotated.CtToken = nil
+	rotated.KeyId = ""
+	return rotated, nil
+}
+
+// DeleteApiToken deletes the api token with the provided id from the
+// repository returning a count of the number of records deleted.  All options
+// are ignored.
+func (r *Repository) DeleteApiToken(ctx context.Context, id string, _ ...Option) (int, error) {
+	const op = "authtoken.(Repository).DeleteApiToken"
+	if id == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidPublicId, op, "missing public id")
+	}
+
+	var rowsDeleted int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			deleteAT := allocApiToken()
+			deleteAT.PublicId = id
+			// api tokens are not replicated, so they don't need oplog entries.
+			var err error
+			rowsDeleted, err = w.Delete(ctx, deleteAT)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsDeleted > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been deleted")
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(id))
+	}
+	return rowsDeleted, nil
+}
+
+// listApiTokenGrants returns the grants which restrict the api token with the
+// provided id.
+func (r *Repository) listApiTokenGrants(ctx context.Context, apiTokenId string) ([]*ApiTokenGrant, error) {
+	const op = "authtoken.(Repository).listApiTokenGrants"
+	var grants []*ApiTokenGrant
+	if err := r.reader.SearchWhere(ctx, &grants, "api_token_id = ?", []interface{}{apiTokenId}); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return grants, nil
+}
@@ -0,0 +1,323 @@
+package authtoken
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_CreateApiToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	sa := iam.TestUser(t, iamRepo, org.GetPublicId(), iam.WithServiceAccount(true))
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	tests := []struct {
+		name             string
+		apiToken         func() *ApiToken
+		grants           []string
+		opts             []Option
+		wantGrantScopeId string
+		wantExpiration   time.Time
+		wantIsErr        errors.Code
+	}{
+		{
+			name: "valid",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId())
+				require.NoError(t, err)
+				return at
+			},
+			wantGrantScopeId: org.GetPublicId(),
+			wantExpiration:   time.Now().Add(defaultApiTokenTimeToLiveDuration),
+		},
+		{
+			name: "valid-with-grants",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId(), WithName("ci"), WithGrantScopeId(proj.GetPublicId()))
+				require.NoError(t, err)
+				return at
+			},
+			grants:           []string{"id=*;type=target;actions=authorize-session", "id=*;type=host;actions=read"},
+			opts:             []Option{WithExpirationTime(time.Now().Add(time.Hour))},
+			wantGrantScopeId: proj.GetPublicId(),
+			wantExpiration:   time.Now().Add(time.Hour),
+		},
+		{
+			name: "duplicate-name",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId(), WithName("ci"))
+				require.NoError(t, err)
+				return at
+			},
+			wantIsErr: errors.NotUnique,
+		},
+		{
+			name: "not-service-account",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(u.GetPublicId())
+				require.NoError(t, err)
+				return at
+			},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "expiration-in-past",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId())
+				require.NoError(t, err)
+				return at
+			},
+			opts:      []Option{WithExpirationTime(time.Now().Add(-time.Hour))},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "bad-grant",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId())
+				require.NoError(t, err)
+				return at
+			},
+			grants:    []string{"id=*;actions=read"},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "nil-api-token",
+			apiToken:  func() *ApiToken { return nil },
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name: "public-id-set",
+			apiToken: func() *ApiToken {
+				at, err := NewApiToken(sa.GetPublicId())
+				require.NoError(t, err)
+				at.PublicId = "apt_1234567890"
+				return at
+			},
+			wantIsErr: errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			got, gotGrants, err := repo.CreateApiToken(context.Background(), tt.apiToken(), tt.grants, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			db.AssertPublicId(t, ApiTokenPrefix, got.GetPublicId())
+			assert.NotEmpty(got.GetToken())
+			assert.Empty(got.GetCtToken())
+			assert.Equal(sa.GetPublicId(), got.GetIamUserId())
+			assert.Equal(org.GetPublicId(), got.GetScopeId())
+			assert.Equal(tt.wantGrantScopeId, got.GetGrantScopeId())
+			assert.Nil(got.GetApproximateLastAccessTime())
+			exp, err := ptypes.Timestamp(got.GetExpirationTime().GetTimestamp())
+			require.NoError(err)
+			assert.WithinDuration(tt.wantExpiration, exp, 5*time.Second)
+			assert.Len(gotGrants, len(tt.grants))
+
+			// We should find no oplog since api tokens are not replicated, so they don't need oplog entries.
+			assert.Error(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE)))
+
+			found, foundGrants, err := repo.LookupApiToken(context.Background(), got.GetPublicId())
+			require.NoError(err)
+			require.NotNil(found)
+			assert.Empty(found.GetToken())
+			assert.Empty(found.GetCtToken())
+			assert.Len(foundGrants, len(tt.grants))
+		})
+	}
+}
+
+func TestRepository_ValidateApiToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	at := TestApiToken(t, conn, kms, org.GetPublicId(), "id=*;type=target;actions=authorize-session")
+
+	badToken, err := newTokenValue()
+	require.NoError(t, err)
+
+	t.Run("mismatched-token", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, grants, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), badToken)
+		require.NoError(err)
+		assert.Nil(got)
+		assert.Nil(grants)
+	})
+	t.Run("missing-token", func(t *testing.T) {
+		assert := assert.New(t)
+		_, _, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+	})
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, grants, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Empty(got.GetToken())
+		assert.Equal(at.GetIamUserId(), got.GetIamUserId())
+		require.Len(grants, 1)
+		assert.Equal("id=*;type=target;actions=authorize-session", grants[0].GetCanonicalGrant())
+
+		// the first use of the api token sets its last access time
+		found, _, err := repo.LookupApiToken(context.Background(), at.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found.GetApproximateLastAccessTime())
+	})
+	t.Run("expired", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		expired, err := NewApiToken(at.GetIamUserId())
+		require.NoError(err)
+		expired, _, err = repo.CreateApiToken(context.Background(), expired, nil, WithExpirationTime(time.Now().Add(2*time.Second)))
+		require.NoError(err)
+		time.Sleep(2 * time.Second)
+		got, _, err := repo.ValidateApiToken(context.Background(), expired.GetPublicId(), expired.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+
+		// expired api tokens are kept so their last use can be audited
+		found, _, err := repo.LookupApiToken(context.Background(), expired.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+}
+
+func TestRepository_RotateApiToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestApiToken(t, conn, kms, org.GetPublicId())
+		exp := time.Now().Add(48 * time.Hour)
+		got, err := repo.RotateApiToken(context.Background(), at.GetPublicId(), WithExpirationTime(exp))
+		require.NoError(err)
+		require.NotNil(got)
+		assert.NotEmpty(got.GetToken())
+		assert.NotEqual(at.GetToken(), got.GetToken())
+		gotExp, err := ptypes.Timestamp(got.GetExpirationTime().GetTimestamp())
+		require.NoError(err)
+		assert.WithinDuration(exp, gotExp, time.Second)
+
+		old, _, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		assert.Nil(old)
+		rotated, _, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), got.GetToken())
+		require.NoError(err)
+		assert.NotNil(rotated)
+	})
+	t.Run("expiration-in-past", func(t *testing.T) {
+		assert := assert.New(t)
+		at := TestApiToken(t, conn, kms, org.GetPublicId())
+		got, err := repo.RotateApiToken(context.Background(), at.GetPublicId(), WithExpirationTime(time.Now().Add(-time.Hour)))
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := newApiTokenId()
+		require.NoError(err)
+		got, err := repo.RotateApiToken(context.Background(), id)
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "Unexpected error %s", err)
+		assert.Nil(got)
+	})
+}
+
+func TestRepository_DeleteApiToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	at := TestApiToken(t, conn, kms, org.GetPublicId(), "id=*;type=*;actions=read")
+
+	assert, require := assert.New(t), require.New(t)
+	deleted, err := repo.DeleteApiToken(context.Background(), at.GetPublicId())
+	require.NoError(err)
+	assert.Equal(1, deleted)
+
+	found, grants, err := repo.LookupApiToken(context.Background(), at.GetPublicId())
+	require.NoError(err)
+	assert.Nil(found)
+	assert.Empty(grants)
+
+	deleted, err = repo.DeleteApiToken(context.Background(), at.GetPublicId())
+	require.NoError(err)
+	assert.Equal(0, deleted)
+
+	_, err = repo.DeleteApiToken(context.Background(), "")
+	assert.Truef(errors.Match(errors.T(errors.InvalidPublicId), err), "Unexpected error %s", err)
+}
+
+func TestRepository_ListApiTokens(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org1, _ := iam.TestScopes(t, iamRepo)
+	org2, _ := iam.TestScopes(t, iamRepo)
+	var want []string
+	for i := 0; i < 3; i++ {
+		want = append(want, TestApiToken(t, conn, kms, org1.GetPublicId()).GetPublicId())
+	}
+	TestApiToken(t, conn, kms, org2.GetPublicId())
+
+	assert, require := assert.New(t), require.New(t)
+	got, err := repo.ListApiTokens(context.Background(), []string{org1.GetPublicId()})
+	require.NoError(err)
+	var gotIds []string
+	for _, at := range got {
+		assert.Empty(at.GetToken())
+		assert.Empty(at.GetCtToken())
+		gotIds = append(gotIds, at.GetPublicId())
+	}
+	assert.ElementsMatch(want, gotIds)
+
+	got, err = repo.ListApiTokens(context.Background(), []string{org1.GetPublicId(), org2.GetPublicId()})
+	require.NoError(err)
+	assert.Len(got, 4)
+
+	_, err = repo.ListApiTokens(context.Background(), nil)
+	assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "Unexpected error %s", err)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
 )
 
 func TestRepository_New(t *testing.T) {
@@ -604,6 +605,125 @@ func TestRepository_DeleteAuthToken(t *testing.T) {
 	}
 }
 
+func TestRepository_DeleteUserAndAccountAuthTokens(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	require.NotNil(t, repo)
+
+	t.Run("user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.DeleteUserAuthTokens(context.Background(), at.GetIamUserId())
+		require.NoError(err)
+		assert.Equal(1, got, "row count")
+
+		found, err := repo.LookupAuthToken(context.Background(), at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		found, err = repo.LookupAuthToken(context.Background(), other.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+	t.Run("account", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.DeleteAccountAuthTokens(context.Background(), at.GetAuthAccountId())
+		require.NoError(err)
+		assert.Equal(1, got, "row count")
+
+		found, err := repo.LookupAuthToken(context.Background(), at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		found, err = repo.LookupAuthToken(context.Background(), other.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+	})
+	t.Run("missing-ids", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.DeleteUserAuthTokens(context.Background(), "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.DeleteAccountAuthTokens(context.Background(), "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestRepository_InactiveUserAndAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	pwRepo, err := password.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("disable-user-deletes-tokens", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+		other := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		_, err := iamRepo.SetUserActive(ctx, at.GetIamUserId(), false)
+		require.NoError(err)
+
+		found, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+		found, err = repo.LookupAuthToken(ctx, other.GetPublicId())
+		require.NoError(err)
+		assert.NotNil(found)
+
+		u := &iam.User{User: &iamStore.User{PublicId: at.GetIamUserId(), ScopeId: at.GetScopeId()}}
+		_, err = repo.CreateAuthToken(ctx, u, at.GetAuthAccountId())
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err), "got error %v", err)
+	})
+	t.Run("disable-account-deletes-tokens", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		_, err := pwRepo.SetAccountActive(ctx, org.GetPublicId(), at.GetAuthAccountId(), false)
+		require.NoError(err)
+
+		found, err := repo.LookupAuthToken(ctx, at.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+
+		u := &iam.User{User: &iamStore.User{PublicId: at.GetIamUserId(), ScopeId: at.GetScopeId()}}
+		_, err = repo.CreateAuthToken(ctx, u, at.GetAuthAccountId())
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err), "got error %v", err)
+	})
+	t.Run("validate-inactive-user", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		at := TestAuthToken(t, conn, kms, org.GetPublicId())
+
+		got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		assert.NotNil(got)
+
+		// A token which outlived the deactivation of its user must not be
+		// accepted.
+		_, err = rw.Exec(ctx, "update iam_user set active = false where public_id = ?", []interface{}{at.GetIamUserId()})
+		require.NoError(err)
+		got, err = repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
+
 func TestRepository_ListAuthTokens(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
@@ -685,3 +805,282 @@ func TestRepository_ListAuthTokens_Multiple_Scopes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, total, len(got))
 }
+
+func TestRepository_DeriveAuthToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+	parentExp, err := ptypes.Timestamp(parent.GetExpirationTime().GetTimestamp())
+	require.NoError(t, err)
+	derived := TestDerivedAuthToken(t, conn, kms, parent.GetPublicId(), "id=*;type=*;actions=read")
+	badId, err := newAuthTokenId()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		parentId         string
+		grants           []string
+		opts             []Option
+		wantGrantScopeId string
+		wantExpiration   time.Time
+		wantIsErr        errors.Code
+	}{
+		{
+			name:             "valid",
+			parentId:         parent.GetPublicId(),
+			grants:           []string{"id=*;type=target;actions=authorize-session"},
+			wantGrantScopeId: org.GetPublicId(),
+			wantExpiration:   time.Now().Add(defaultDerivedTokenTimeToLiveDuration),
+		},
+		{
+			name:             "valid-with-options",
+			parentId:         parent.GetPublicId(),
+			grants:           []string{"id=ttcp_1234567890;actions=authorize-session", "id=*;type=host;actions=read"},
+			opts:             []Option{WithGrantScopeId(proj.GetPublicId()), WithExpirationTime(time.Now().Add(time.Hour))},
+			wantGrantScopeId: proj.GetPublicId(),
+			wantExpiration:   time.Now().Add(time.Hour),
+		},
+		{
+			name:             "expiration-after-parent",
+			parentId:         parent.GetPublicId(),
+			grants:           []string{"id=*;type=*;actions=read"},
+			opts:             []Option{WithExpirationTime(parentExp.Add(time.Hour))},
+			wantGrantScopeId: org.GetPublicId(),
+			wantExpiration:   parentExp,
+		},
+		{
+			name:      "expiration-in-past",
+			parentId:  parent.GetPublicId(),
+			grants:    []string{"id=*;type=*;actions=read"},
+			opts:      []Option{WithExpirationTime(time.Now().Add(-time.Hour))},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "missing-grants",
+			parentId:  parent.GetPublicId(),
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "bad-grant",
+			parentId:  parent.GetPublicId(),
+			grants:    []string{"id=*;actions=read"},
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:      "derived-parent",
+			parentId:  derived.GetPublicId(),
+			grants:    []string{"id=*;type=*;actions=read"},
+			wantIsErr: errors.DerivedAuthToken,
+		},
+		{
+			name:      "parent-not-found",
+			parentId:  badId,
+			grants:    []string{"id=*;type=*;actions=read"},
+			wantIsErr: errors.RecordNotFound,
+		},
+		{
+			name:      "missing-parent-id",
+			grants:    []string{"id=*;type=*;actions=read"},
+			wantIsErr: errors.InvalidPublicId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			got, gotGrants, err := repo.DeriveAuthToken(context.Background(), tt.parentId, tt.grants, tt.opts...)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "Unexpected error %s", err)
+				assert.Nil(got)
+				return
+			}
+			require.NoError(err)
+			db.AssertPublicId(t, AuthTokenPrefix, got.GetPublicId())
+			assert.NotEmpty(got.GetToken())
+			assert.Empty(got.GetCtToken())
+			assert.True(got.IsDerived())
+			assert.Equal(parent.GetPublicId(), got.GetParentId())
+			assert.Equal(tt.wantGrantScopeId, got.GetGrantScopeId())
+			exp, err := ptypes.Timestamp(got.GetExpirationTime().GetTimestamp())
+			require.NoError(err)
+			assert.WithinDuration(tt.wantExpiration, exp, 5*time.Second)
+			assert.False(exp.After(parentExp))
+			assert.Len(gotGrants, len(tt.grants))
+
+			// We should find no oplog since tokens are not replicated, so they don't need oplog entries.
+			assert.Error(db.TestVerifyOplog(t, rw, got.GetPublicId(), db.WithOperation(oplog.OpType_OP_TYPE_CREATE)))
+
+			found, err := repo.LookupAuthToken(context.Background(), got.GetPublicId())
+			require.NoError(err)
+			require.NotNil(found)
+			assert.Equal(parent.GetAuthAccountId(), found.GetAuthAccountId())
+			assert.Equal(parent.GetIamUserId(), found.GetIamUserId())
+			assert.Equal(parent.GetPublicId(), found.GetParentId())
+			foundGrants, err := repo.ListAuthTokenGrants(context.Background(), got.GetPublicId())
+			require.NoError(err)
+			assert.Len(foundGrants, len(tt.grants))
+		})
+	}
+}
+
+func TestRepository_ValidateToken_derived(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		derived := TestDerivedAuthToken(t, conn, kms, parent.GetPublicId(), "id=*;type=*;actions=read")
+		got, err := repo.ValidateToken(context.Background(), derived.GetPublicId(), derived.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(parent.GetPublicId(), got.GetParentId())
+	})
+	t.Run("parent-deleted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		derived := TestDerivedAuthToken(t, conn, kms, parent.GetPublicId(), "id=*;type=*;actions=read")
+		rows, err := repo.DeleteAuthToken(context.Background(), parent.GetPublicId())
+		require.NoError(err)
+		require.Equal(1, rows)
+		got, err := repo.ValidateToken(context.Background(), derived.GetPublicId(), derived.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+		found, err := repo.LookupAuthToken(context.Background(), derived.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+	})
+	t.Run("parent-stale", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		staleRepo, err := NewRepository(rw, rw, kms, WithTokenTimeToStaleDuration(500*time.Millisecond))
+		require.NoError(err)
+		parent := TestAuthToken(t, conn, kms, org.GetPublicId())
+		time.Sleep(400 * time.Millisecond)
+		derived := TestDerivedAuthToken(t, conn, kms, parent.GetPublicId(), "id=*;type=*;actions=read")
+		time.Sleep(200 * time.Millisecond)
+
+		// the derived token is not stale itself, but the token it was derived
+		// from is
+		got, err := staleRepo.ValidateToken(context.Background(), derived.GetPublicId(), derived.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+		found, err := repo.LookupAuthToken(context.Background(), derived.GetPublicId())
+		require.NoError(err)
+		assert.Nil(found)
+	})
+}
+
+func TestRepository_AuthTokenPolicy(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	pwRepo, err := password.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	const repoTtl = 4 * time.Hour
+	repo, err := NewRepository(rw, rw, kms, WithTokenTimeToLiveDuration(repoTtl))
+	require.NoError(t, err)
+
+	updateScope := func(t *testing.T, s *iam.Scope, ttl uint32) {
+		t.Helper()
+		s = s.Clone().(*iam.Scope)
+		s.AuthTokenTimeToLiveSeconds = ttl
+		_, rows, err := iamRepo.UpdateScope(context.Background(), s, s.GetVersion(), []string{"AuthTokenTimeToLiveSeconds"})
+		require.NoError(t, err)
+		require.Equal(t, 1, rows)
+	}
+	newAuthMethod := func(t *testing.T, scopeId string, ttl, tts uint32) *password.AuthMethod {
+		t.Helper()
+		am := password.TestAuthMethods(t, conn, scopeId, 1)[0]
+		if ttl == 0 && tts == 0 {
+			return am
+		}
+		am.AuthTokenTimeToLiveSeconds = ttl
+		am.AuthTokenTimeToStaleSeconds = tts
+		am, rows, err := pwRepo.UpdateAuthMethod(context.Background(), am, am.GetVersion(),
+			[]string{"AuthTokenTimeToLiveSeconds", "AuthTokenTimeToStaleSeconds"})
+		require.NoError(t, err)
+		require.Equal(t, 1, rows)
+		return am
+	}
+	createToken := func(t *testing.T, am *password.AuthMethod) *AuthToken {
+		t.Helper()
+		acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+		u, err := iamRepo.LookupUserWithLogin(context.Background(), acct.GetPublicId(), iam.WithAutoVivify(true))
+		require.NoError(t, err)
+		at, err := repo.CreateAuthToken(context.Background(), u, acct.GetPublicId())
+		require.NoError(t, err)
+		return at
+	}
+	assertLifetime := func(t *testing.T, at *AuthToken, want time.Duration) {
+		t.Helper()
+		exp, err := ptypes.Timestamp(at.GetExpirationTime().GetTimestamp())
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(want), exp, 10*time.Second)
+	}
+
+	t.Run("repository-default", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		am := newAuthMethod(t, org.GetPublicId(), 0, 0)
+		assertLifetime(t, createToken(t, am), repoTtl)
+	})
+	t.Run("auth-method", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		am := newAuthMethod(t, org.GetPublicId(), uint32(time.Hour.Seconds()), 0)
+		assertLifetime(t, createToken(t, am), time.Hour)
+	})
+	t.Run("scope-default", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		updateScope(t, org, uint32((2 * time.Hour).Seconds()))
+		am := newAuthMethod(t, org.GetPublicId(), 0, 0)
+		assertLifetime(t, createToken(t, am), 2*time.Hour)
+	})
+	t.Run("auth-method-overrides-scope", func(t *testing.T) {
+		org, _ := iam.TestScopes(t, iamRepo)
+		updateScope(t, org, uint32((2 * time.Hour).Seconds()))
+		am := newAuthMethod(t, org.GetPublicId(), uint32(time.Hour.Seconds()), 0)
+		assertLifetime(t, createToken(t, am), time.Hour)
+	})
+	t.Run("ttl-lowered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		am := newAuthMethod(t, org.GetPublicId(), 0, 0)
+		at := createToken(t, am)
+		time.Sleep(1100 * time.Millisecond)
+		am.AuthTokenTimeToLiveSeconds = 1
+		_, _, err := pwRepo.UpdateAuthMethod(context.Background(), am, am.GetVersion(), []string{"AuthTokenTimeToLiveSeconds"})
+		require.NoError(err)
+
+		// the token was issued with a longer lifetime, but it is older than
+		// the auth method now allows
+		got, err := repo.ValidateToken(context.Background(), at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+	})
+	t.Run("stale", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		org, _ := iam.TestScopes(t, iamRepo)
+		am := newAuthMethod(t, org.GetPublicId(), 0, 1)
+		at := createToken(t, am)
+		time.Sleep(1100 * time.Millisecond)
+
+		got, err := repo.ValidateToken(context.Background(), at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		assert.Nil(got)
+	})
+}
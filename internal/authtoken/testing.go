@@ -34,3 +34,39 @@ func TestAuthToken(t *testing.T, conn *gorm.DB, kms *kms.Kms, scopeId string) *A
 	require.NoError(t, err)
 	return at
 }
+
+// TestApiToken creates a service account in the scope and an api token for it
+// suitable for testing.  The returned api token contains the api token value.
+// Grants restricting the api token may be provided.
+func TestApiToken(t *testing.T, conn *gorm.DB, kms *kms.Kms, scopeId string, grants ...string) *ApiToken {
+	t.Helper()
+	ctx := context.Background()
+	rw := db.New(conn)
+	iamRepo, err := iam.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	u := iam.TestUser(t, iamRepo, scopeId, iam.WithServiceAccount(true))
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	at, err := NewApiToken(u.GetPublicId())
+	require.NoError(t, err)
+	at, _, err = repo.CreateApiToken(ctx, at, grants)
+	require.NoError(t, err)
+	return at
+}
+
+// TestDerivedAuthToken derives an auth token restricted by the provided grants
+// from the parent auth token suitable for testing.  The returned auth token
+// contains the auth token value.
+func TestDerivedAuthToken(t *testing.T, conn *gorm.DB, kms *kms.Kms, parentId string, grants ...string) *AuthToken {
+	t.Helper()
+	rw := db.New(conn)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	at, _, err := repo.DeriveAuthToken(context.Background(), parentId, grants)
+	require.NoError(t, err)
+	return at
+}
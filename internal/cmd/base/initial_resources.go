@@ -60,7 +60,7 @@ func (b *Server) CreateInitialLoginRole(ctx context.Context) (*iam.Role, error)
 	if _, err := iamRepo.AddRoleGrants(cancelCtx, role.PublicId, role.Version, []string{
 		"id=*;type=scope;actions=list,read",
 		"id=*;type=auth-method;actions=authenticate,list",
-		"id={{account.id}};actions=read,change-password",
+		"id={{account.id}};actions=read,change-password,enroll-totp,confirm-totp",
 	}); err != nil {
 		return nil, fmt.Errorf("error creating grant for default generated grants: %w", err)
 	}
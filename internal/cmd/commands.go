@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/commands/accountscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/apitokenscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authenticate"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authmethodscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/authtokenscmd"
@@ -14,6 +15,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostscmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsetscmd"
+	"github.com/hashicorp/boundary/internal/cmd/commands/invitescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/rolescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopescmd"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
@@ -96,6 +98,48 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "change-password",
 			}, nil
 		},
+		"accounts unlock": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "unlock",
+			}, nil
+		},
+		"accounts disable": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "disable",
+			}, nil
+		},
+		"accounts enable": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enable",
+			}, nil
+		},
+		"accounts enroll-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enroll-totp",
+			}, nil
+		},
+		"accounts confirm-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "confirm-totp",
+			}, nil
+		},
+		"accounts remove-totp": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "remove-totp",
+			}, nil
+		},
+		"accounts create-reset-token": func() (cli.Command, error) {
+			return &accountscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create-reset-token",
+			}, nil
+		},
 		"accounts create": func() (cli.Command, error) {
 			return &accountscmd.Command{
 				Command: base.NewCommand(ui),
@@ -121,6 +165,42 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"api-tokens": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"api-tokens create": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"api-tokens read": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"api-tokens delete": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"api-tokens list": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"api-tokens rotate": func() (cli.Command, error) {
+			return &apitokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate",
+			}, nil
+		},
+
 		"auth-methods": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -138,6 +218,24 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "delete",
 			}, nil
 		},
+		"auth-methods reset-password": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "reset-password",
+			}, nil
+		},
+		"auth-methods redeem-invite": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "redeem-invite",
+			}, nil
+		},
+		"auth-methods enroll-totp": func() (cli.Command, error) {
+			return &authmethodscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enroll-totp",
+			}, nil
+		},
 		"auth-methods list": func() (cli.Command, error) {
 			return &authmethodscmd.Command{
 				Command: base.NewCommand(ui),
@@ -192,6 +290,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"auth-tokens derive": func() (cli.Command, error) {
+			return &authtokenscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "derive",
+			}, nil
+		},
 
 		"config": func() (cli.Command, error) {
 			return &config.Command{
@@ -287,6 +391,31 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database status": func() (cli.Command, error) {
+			return &database.StatusCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database backup": func() (cli.Command, error) {
+			return &database.BackupCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database restore": func() (cli.Command, error) {
+			return &database.RestoreCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database verify-oplog": func() (cli.Command, error) {
+			return &database.VerifyOplogCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database replicate": func() (cli.Command, error) {
+			return &database.ReplicateCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groupscmd.Command{
@@ -504,6 +633,36 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"invites": func() (cli.Command, error) {
+			return &invitescmd.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"invites create": func() (cli.Command, error) {
+			return &invitescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"invites read": func() (cli.Command, error) {
+			return &invitescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"invites delete": func() (cli.Command, error) {
+			return &invitescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"invites list": func() (cli.Command, error) {
+			return &invitescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &rolescmd.Command{
 				Command: base.NewCommand(ui),
@@ -611,6 +770,48 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes rotate-keys": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-keys",
+			}, nil
+		},
+		"scopes reencrypt-key-version": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "reencrypt-key-version",
+			}, nil
+		},
+		"scopes destroy-key-version": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "destroy-key-version",
+			}, nil
+		},
+		"scopes list-keys": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-keys",
+			}, nil
+		},
+		"scopes list-changes": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-changes",
+			}, nil
+		},
+		"scopes list-jobs": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-jobs",
+			}, nil
+		},
+		"scopes run-job": func() (cli.Command, error) {
+			return &scopescmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "run-job",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessionscmd.Command{
@@ -761,5 +962,17 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+		"users disable": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "disable",
+			}, nil
+		},
+		"users enable": func() (cli.Command, error) {
+			return &userscmd.Command{
+				Command: base.NewCommand(ui),
+				Func:    "enable",
+			}, nil
+		},
 	}
 }
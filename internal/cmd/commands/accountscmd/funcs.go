@@ -19,18 +19,30 @@ func init() {
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
 	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 type extraCmdVars struct {
 	flagPassword        string
 	flagCurrentPassword string
 	flagNewPassword     string
+	flagCode            string
+	flagTtl             time.Duration
+	totpEnrollment      *accounts.TotpEnrollResult
+	resetToken          *accounts.ResetTokenCreateResult
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"change-password": {"id", "current-password", "new-password", "version"},
-		"set-password":    {"id", "password", "version"},
+		"change-password":    {"id", "current-password", "new-password", "version"},
+		"set-password":       {"id", "password", "version"},
+		"unlock":             {"id"},
+		"disable":            {"id"},
+		"enable":             {"id"},
+		"enroll-totp":        {"id"},
+		"confirm-totp":       {"id", "code"},
+		"remove-totp":        {"id"},
+		"create-reset-token": {"id", "ttl"},
 	}
 }
 
@@ -42,6 +54,27 @@ func extraSynopsisFuncImpl(c *Command) string {
 	case "set-password":
 		return "Directly set the password on an account resource"
 
+	case "unlock":
+		return "Unlock an account resource locked after failed authentication attempts"
+
+	case "disable":
+		return "Disable an account resource, revoking its auth tokens and sessions"
+
+	case "enable":
+		return "Enable a disabled account resource"
+
+	case "enroll-totp":
+		return "Enroll an account resource in TOTP multi-factor authentication"
+
+	case "confirm-totp":
+		return "Confirm the TOTP enrollment of an account resource"
+
+	case "remove-totp":
+		return "Remove the TOTP enrollment of an account resource"
+
+	case "create-reset-token":
+		return "Create a one-time token to reset the password of an account resource"
+
 	default:
 		return ""
 	}
@@ -86,6 +119,90 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"",
 		})
+	case "unlock":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts unlock [options] [args]",
+			"",
+			"  This command allows unlocking an account which was locked after too many failed authentication attempts. Its failed authentication attempts are reset as well. Example:",
+			"",
+			"    Unlock a password-type account:",
+			"",
+			`      $ boundary accounts unlock -id apw_1234567890`,
+			"",
+			"",
+		})
+	case "disable":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts disable [options] [args]",
+			"",
+			"  This command allows disabling an account. A disabled account cannot authenticate, its auth tokens are deleted and the sessions created with them are canceled. Example:",
+			"",
+			"    Disable a password-type account:",
+			"",
+			`      $ boundary accounts disable -id apw_1234567890`,
+			"",
+			"",
+		})
+	case "enable":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enable [options] [args]",
+			"",
+			"  This command allows enabling an account which was disabled, allowing it to authenticate again. Example:",
+			"",
+			"    Enable a password-type account:",
+			"",
+			`      $ boundary accounts enable -id apw_1234567890`,
+			"",
+			"",
+		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts enroll-totp [options] [args]",
+			"",
+			"  This command allows enrolling an account in TOTP multi-factor authentication. The TOTP secret and recovery codes are only shown once. The enrollment must be confirmed with the confirm-totp command before it replaces any existing enrollment and a TOTP code is required to authenticate. Example:",
+			"",
+			"    Enroll a password-type account in TOTP:",
+			"",
+			`      $ boundary accounts enroll-totp -id apw_1234567890`,
+			"",
+			"",
+		})
+	case "confirm-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts confirm-totp [options] [args]",
+			"",
+			"  This command allows confirming the TOTP enrollment of an account with a code generated from the secret returned by the enroll-totp command. Example:",
+			"",
+			"    Confirm the TOTP enrollment of a password-type account:",
+			"",
+			`      $ boundary accounts confirm-totp -id apw_1234567890 -code 123456`,
+			"",
+			"",
+		})
+	case "remove-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts remove-totp [options] [args]",
+			"",
+			"  This command allows removing the TOTP enrollment and recovery codes of an account. Example:",
+			"",
+			"    Remove the TOTP enrollment of a password-type account:",
+			"",
+			`      $ boundary accounts remove-totp -id apw_1234567890`,
+			"",
+			"",
+		})
+	case "create-reset-token":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary accounts create-reset-token [options] [args]",
+			"",
+			"  This command allows creating a one-time token which can be used to reset the password of an account with the auth-methods reset-password command, replacing any existing reset token of the account. The token is only shown once. Example:",
+			"",
+			"    Create a reset token for a password-type account which expires after 30 minutes:",
+			"",
+			`      $ boundary accounts create-reset-token -id apw_1234567890 -ttl 30m`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
@@ -111,6 +228,18 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagNewPassword,
 				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "code":
+			f.StringVar(&base.StringVar{
+				Name:   "code",
+				Target: &c.flagCode,
+				Usage:  "A TOTP code generated from the secret returned when enrolling the account.",
+			})
+		case "ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:   "ttl",
+				Target: &c.flagTtl,
+				Usage:  "How long the reset token can be used. If not specified, the controller's default of 1 hour is used.",
+			})
 		}
 	}
 }
@@ -171,6 +300,11 @@ func extraFlagsHandlingFuncImpl(c *Command, opts *[]accounts.Option) bool {
 		c.flagNewPassword = strings.TrimSpace(value)
 	}
 
+	if strutil.StrListContains(flagsMap[c.Func], "code") && c.flagCode == "" {
+		c.UI.Error("Code must be passed in via -code")
+		return false
+	}
+
 	return true
 }
 
@@ -180,10 +314,96 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return accountClient.SetPassword(c.Context, c.FlagId, c.flagPassword, version, opts...)
 	case "change-password":
 		return accountClient.ChangePassword(c.Context, c.FlagId, c.flagCurrentPassword, c.flagNewPassword, version, opts...)
+	case "unlock":
+		return accountClient.Unlock(c.Context, c.FlagId, opts...)
+	case "disable":
+		return accountClient.Disable(c.Context, c.FlagId, opts...)
+	case "enable":
+		return accountClient.Enable(c.Context, c.FlagId, opts...)
+	case "enroll-totp":
+		var err error
+		c.totpEnrollment, err = accountClient.EnrollTotp(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "confirm-totp":
+		return accountClient.ConfirmTotp(c.Context, c.FlagId, c.flagCode, opts...)
+	case "remove-totp":
+		return accountClient.RemoveTotp(c.Context, c.FlagId, opts...)
+	case "create-reset-token":
+		var err error
+		c.resetToken, err = accountClient.CreateResetToken(c.Context, c.FlagId, uint32(c.flagTtl.Seconds()), opts...)
+		return nil, err
 	}
 	return origResult, origError
 }
 
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "enroll-totp":
+		switch base.Format(c.UI) {
+		case "table":
+			nonAttributeMap := map[string]interface{}{
+				"Account ID":  c.totpEnrollment.Item.Id,
+				"TOTP Secret": c.totpEnrollment.TotpSecret,
+				"TOTP URL":    c.totpEnrollment.TotpUrl,
+			}
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+			ret := []string{
+				"",
+				"TOTP enrollment information:",
+				base.WrapMap(2, maxLength, nonAttributeMap),
+				"",
+				"  Recovery Codes:",
+				base.WrapSlice(4, c.totpEnrollment.RecoveryCodes),
+				"",
+				"  The TOTP secret and recovery codes will not be shown again. Confirm the",
+				"  enrollment with the confirm-totp command using a code generated from the",
+				"  secret.",
+			}
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.totpEnrollment)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "create-reset-token":
+		switch base.Format(c.UI) {
+		case "table":
+			nonAttributeMap := map[string]interface{}{
+				"Account ID":      c.resetToken.Item.Id,
+				"Reset Token":     c.resetToken.ResetToken,
+				"Expiration Time": c.resetToken.ExpirationTime.Local().Format(time.RFC1123),
+			}
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+			ret := []string{
+				"",
+				"Reset token information:",
+				base.WrapMap(2, maxLength, nonAttributeMap),
+				"",
+				"  The reset token will not be shown again. It can be used once with the",
+				"  auth-methods reset-password command before it expires.",
+			}
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.resetToken)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*accounts.Account) string {
 	if len(items) == 0 {
 		return "No accounts found"
@@ -214,6 +434,11 @@ func (c *Command) printListTable(items []*accounts.Account) string {
 				fmt.Sprintf("    Description:         %s", m.Description),
 			)
 		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("    Active:              %t", m.Active),
+			)
+		}
 		if len(m.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -233,6 +458,7 @@ func printItemTable(item *accounts.Account) string {
 		"Created Time":   item.CreatedTime.Local().Format(time.RFC1123),
 		"Updated Time":   item.UpdatedTime.Local().Format(time.RFC1123),
 		"Auth Method ID": item.AuthMethodId,
+		"Active":         item.Active,
 	}
 
 	if item.Name != "" {
@@ -273,5 +499,10 @@ func printItemTable(item *accounts.Account) string {
 }
 
 var keySubstMap = map[string]string{
-	"login_name": "Login Name",
+	"login_name":               "Login Name",
+	"failed_attempt_count":     "Failed Attempt Count",
+	"lockout_count":            "Lockout Count",
+	"last_failed_attempt_time": "Last Failed Attempt Time",
+	"locked_until_time":        "Locked Until Time",
+	"totp_enabled":             "TOTP Enabled",
 }
@@ -19,14 +19,15 @@ func init() {
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"login-name", "password"},
+		"create": {"login-name", "password", "password-hash"},
 		"update": {"login-name"},
 	}
 }
 
 type extraPasswordCmdVars struct {
-	flagLoginName string
-	flagPassword  string
+	flagLoginName    string
+	flagPassword     string
+	flagPasswordHash string
 }
 
 func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string) string {
@@ -40,6 +41,10 @@ func (c *PasswordCommand) extraPasswordHelpFunc(helpMap map[string]func() string
 			"",
 			`    $ boundary accounts create password -login-name prodops -description "Password account for ProdOps"`,
 			"",
+			"  An account can be created with a password hash imported from another system instead of a password. Example:",
+			"",
+			`    $ boundary accounts create password -login-name prodops -password-hash '$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy'`,
+			"",
 			"",
 		})
 
@@ -74,6 +79,12 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagPassword,
 				Usage:  "The password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
 			})
+		case "password-hash":
+			f.StringVar(&base.StringVar{
+				Name:   "password-hash",
+				Target: &c.flagPasswordHash,
+				Usage:  "A bcrypt, PBKDF2, or scrypt password hash imported from another system to use instead of a password. PBKDF2 and scrypt hashes must use the PHC string format. The hash is replaced with an Argon2 hash the next time the account authenticates.",
+			})
 		}
 	}
 }
@@ -92,7 +103,13 @@ func extraPasswordFlagsHandlingFuncImpl(c *PasswordCommand, opts *[]accounts.Opt
 		*opts = append(*opts, accounts.WithPasswordAccountLoginName(c.flagLoginName))
 	}
 
-	if strutil.StrListContains(flagsPasswordMap[c.Func], "password") {
+	if c.flagPasswordHash != "" {
+		if c.flagPassword != "" {
+			c.UI.Error("Only one of -password and -password-hash can be specified")
+			return false
+		}
+		*opts = append(*opts, accounts.WithPasswordAccountPasswordHash(c.flagPasswordHash))
+	} else if strutil.StrListContains(flagsPasswordMap[c.Func], "password") {
 		switch c.flagPassword {
 		case "":
 			fmt.Print("Password is not set as flag, please enter it now (will be hidden): ")
@@ -0,0 +1,321 @@
+// Code generated by "make api"; DO NOT EDIT.
+package apitokenscmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/apitokens"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+func initFlags() {
+	flagsOnce.Do(func() {
+		extraFlags := extraActionsFlagsMapFunc()
+		for k, v := range extraFlags {
+			flagsMap[k] = append(flagsMap[k], v...)
+		}
+	})
+}
+
+var (
+	_ cli.Command             = (*Command)(nil)
+	_ cli.CommandAutocomplete = (*Command)(nil)
+)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	plural string
+
+	extraCmdVars
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	initFlags()
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	initFlags()
+	return c.Flags().Completions()
+}
+
+func (c *Command) Synopsis() string {
+	if extra := extraSynopsisFunc(c); extra != "" {
+		return extra
+	}
+
+	synopsisStr := "api token"
+
+	return common.SynopsisFunc(c.Func, synopsisStr)
+}
+
+func (c *Command) Help() string {
+	initFlags()
+
+	var helpStr string
+	helpMap := common.HelpMap("api token")
+
+	switch c.Func {
+
+	case "create":
+		helpStr = helpMap[c.Func]() + c.Flags().Help()
+
+	case "read":
+		helpStr = helpMap[c.Func]() + c.Flags().Help()
+
+	case "delete":
+		helpStr = helpMap[c.Func]() + c.Flags().Help()
+
+	case "list":
+		helpStr = helpMap[c.Func]() + c.Flags().Help()
+
+	default:
+
+		helpStr = c.extraHelpFunc(helpMap)
+
+	}
+
+	// Keep linter from complaining if we don't actually generate code using it
+	_ = helpMap
+	return helpStr
+}
+
+var flagsMap = map[string][]string{
+
+	"create": {"scope-id", "name", "description"},
+
+	"read": {"id"},
+
+	"delete": {"id"},
+
+	"list": {"scope-id", "filter", "recursive"},
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	if len(flagsMap[c.Func]) == 0 {
+		return c.FlagSet(base.FlagSetNone)
+	}
+
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "api token", flagsMap[c.Func])
+
+	extraFlagsFunc(c, set, f)
+
+	return set
+}
+
+func (c *Command) Run(args []string) int {
+	initFlags()
+
+	switch c.Func {
+	case "":
+		return cli.RunResultHelp
+	}
+
+	c.plural = "api token"
+	switch c.Func {
+	case "list":
+		c.plural = "api tokens"
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.PrintCliError(errors.New("ID is required but not passed in via -id"))
+		return base.CommandUserError
+	}
+
+	var opts []apitokens.Option
+
+	if strutil.StrListContains(flagsMap[c.Func], "scope-id") {
+		switch c.Func {
+
+		case "create":
+			if c.FlagScopeId == "" {
+				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+				return base.CommandUserError
+			}
+
+		case "list":
+			if c.FlagScopeId == "" {
+				c.PrintCliError(errors.New("Scope ID must be passed in via -scope-id or BOUNDARY_SCOPE_ID"))
+				return base.CommandUserError
+			}
+
+		}
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.PrintCliError(fmt.Errorf("Error creating API client: %s", err.Error()))
+		return base.CommandCliError
+	}
+	apitokensClient := apitokens.NewClient(client)
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, apitokens.DefaultName())
+	default:
+		opts = append(opts, apitokens.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, apitokens.DefaultDescription())
+	default:
+		opts = append(opts, apitokens.WithDescription(c.FlagDescription))
+	}
+
+	switch c.FlagRecursive {
+	case true:
+		opts = append(opts, apitokens.WithRecursive(true))
+	}
+
+	if c.FlagFilter != "" {
+		opts = append(opts, apitokens.WithFilter(c.FlagFilter))
+	}
+
+	var version uint32
+
+	if ok := extraFlagsHandlingFunc(c, &opts); !ok {
+		return base.CommandUserError
+	}
+
+	existed := true
+
+	var result api.GenericResult
+
+	var listResult api.GenericListResult
+
+	switch c.Func {
+
+	case "create":
+		result, err = apitokensClient.Create(c.Context, c.FlagScopeId, opts...)
+
+	case "read":
+		result, err = apitokensClient.Read(c.Context, c.FlagId, opts...)
+
+	case "delete":
+		_, err = apitokensClient.Delete(c.Context, c.FlagId, opts...)
+		if apiErr := api.AsServerError(err); apiErr != nil && apiErr.Response().StatusCode() == http.StatusNotFound {
+			existed = false
+			err = nil
+		}
+
+	case "list":
+		listResult, err = apitokensClient.List(c.Context, c.FlagScopeId, opts...)
+
+	}
+
+	result, err = executeExtraActions(c, result, err, apitokensClient, version, opts)
+
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.PrintApiError(apiErr, fmt.Sprintf("Error from controller when performing %s on %s", c.Func, c.plural))
+			return base.CommandApiError
+		}
+		c.PrintCliError(fmt.Errorf("Error trying to %s %s: %s", c.Func, c.plural, err.Error()))
+		return base.CommandCliError
+	}
+
+	output, err := printCustomActionOutput(c)
+	if err != nil {
+		c.PrintCliError(err)
+		return base.CommandUserError
+	}
+	if output {
+		return base.CommandSuccess
+	}
+
+	switch c.Func {
+
+	case "delete":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output(fmt.Sprintf("{ \"existed\": %t }", existed))
+
+		case "table":
+			output := "The delete operation completed successfully"
+			switch existed {
+			case true:
+				output += "."
+			default:
+				output += ", however the resource did not exist at the time."
+			}
+			c.UI.Output(output)
+		}
+
+		return base.CommandSuccess
+
+	case "list":
+		listedItems := listResult.GetItems().([]*apitokens.ApiToken)
+		switch base.Format(c.UI) {
+		case "json":
+			switch {
+
+			case len(listedItems) == 0:
+				c.UI.Output("null")
+
+			default:
+				items := make([]interface{}, len(listedItems))
+				for i, v := range listedItems {
+					items[i] = v
+				}
+				if ok := c.PrintJsonItems(listResult, items); !ok {
+					return base.CommandCliError
+				}
+			}
+
+		case "table":
+			c.UI.Output(c.printListTable(listedItems))
+		}
+
+		return base.CommandSuccess
+
+	}
+
+	item := result.GetItem().(*apitokens.ApiToken)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(printItemTable(item))
+
+	case "json":
+		if ok := c.PrintJsonItem(result, item); !ok {
+			return base.CommandCliError
+		}
+	}
+
+	return base.CommandSuccess
+}
+
+var (
+	flagsOnce = new(sync.Once)
+
+	extraActionsFlagsMapFunc = func() map[string][]string { return nil }
+	extraSynopsisFunc        = func(*Command) string { return "" }
+	extraFlagsFunc           = func(*Command, *base.FlagSets, *base.FlagSet) {}
+	extraFlagsHandlingFunc   = func(*Command, *[]apitokens.Option) bool { return true }
+	executeExtraActions      = func(_ *Command, inResult api.GenericResult, inErr error, _ *apitokens.Client, _ uint32, _ []apitokens.Option) (api.GenericResult, error) {
+		return inResult, inErr
+	}
+	printCustomActionOutput = func(*Command) (bool, error) { return false, nil }
+)
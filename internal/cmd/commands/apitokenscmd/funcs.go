@@ -0,0 +1,252 @@
+package apitokenscmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/apitokens"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagUserId       string
+	flagGrantScopeId string
+	flagGrants       []string
+	flagTtl          time.Duration
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"user-id", "grant-scope-id", "grant", "ttl"},
+		"rotate": {"id", "ttl"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate":
+		return wordwrap.WrapString("Rotate the value of an API token", base.TermWidth)
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary api-tokens [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary API tokens, which are long-lived tokens issued to service accounts. Example:",
+			"",
+			"    Create an API token:",
+			"",
+			`      $ boundary api-tokens create -scope-id o_1234567890 -user-id u_1234567890 -grant "id=*;type=target;actions=authorize-session"`,
+			"",
+			"  Please see the api-tokens subcommand help for detailed usage information.",
+		})
+
+	case "rotate":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary api-tokens rotate [options] [args]",
+			"",
+			"  Replace the value of the API token specified by ID. The previous value can no longer be used. Example:",
+			"",
+			`    $ boundary api-tokens rotate -id apt_1234567890`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "user-id":
+			f.StringVar(&base.StringVar{
+				Name:   "user-id",
+				Target: &c.flagUserId,
+				Usage:  "The ID of the service account to issue the API token to.",
+			})
+		case "grant-scope-id":
+			f.StringVar(&base.StringVar{
+				Name:   "grant-scope-id",
+				Target: &c.flagGrantScopeId,
+				Usage:  "The scope the grants restricting the API token apply to. Defaults to the scope of the service account.",
+			})
+		case "grant":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant",
+				Target: &c.flagGrants,
+				Usage:  "A grant restricting what the API token is allowed to do. May be specified multiple times.",
+			})
+		case "ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:   "ttl",
+				Target: &c.flagTtl,
+				Usage:  "How long until the API token expires. Defaults to 90 days on creation, and to leaving the expiration time unchanged on rotation.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, opts *[]apitokens.Option) bool {
+	switch c.Func {
+	case "create":
+		if c.flagUserId == "" {
+			c.UI.Error("User ID must be passed in via -user-id")
+			return false
+		}
+		*opts = append(*opts, apitokens.WithUserId(c.flagUserId))
+		if c.flagGrantScopeId != "" {
+			*opts = append(*opts, apitokens.WithGrantScopeId(c.flagGrantScopeId))
+		}
+		if len(c.flagGrants) > 0 {
+			*opts = append(*opts, apitokens.WithGrantStrings(c.flagGrants))
+		}
+	}
+
+	switch c.Func {
+	case "create", "rotate":
+		switch {
+		case c.flagTtl < 0:
+			c.UI.Error("The value of -ttl must not be negative")
+			return false
+		case c.flagTtl > 0:
+			*opts = append(*opts, apitokens.WithExpirationTime(time.Now().Add(c.flagTtl)))
+		}
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, apiTokenClient *apitokens.Client, version uint32, opts []apitokens.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "rotate":
+		return apiTokenClient.Rotate(c.Context, c.FlagId, opts...)
+	}
+	return origResult, origError
+}
+
+func (c *Command) printListTable(items []*apitokens.ApiToken) string {
+	if len(items) == 0 {
+		return "No API tokens found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"API Token information:",
+	}
+	for i, t := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("  ID:                            %s", t.Id),
+			)
+		}
+		if c.FlagRecursive {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:                    %s", t.Scope.Id),
+			)
+		}
+		if t.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                        %s", t.Name),
+			)
+		}
+		if t.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:                 %s", t.Description),
+			)
+		}
+		if !t.ApproximateLastUsedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Approximate Last Used Time:  %s", t.ApproximateLastUsedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("    Created Time:                %s", t.CreatedTime.Local().Format(time.RFC1123)),
+				fmt.Sprintf("    Expiration Time:             %s", t.ExpirationTime.Local().Format(time.RFC1123)),
+				fmt.Sprintf("    User ID:                     %s", t.UserId),
+			)
+		}
+		if len(t.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, t.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(in *apitokens.ApiToken) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":              in.Id,
+		"User ID":         in.UserId,
+		"Grant Scope ID":  in.GrantScopeId,
+		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Expiration Time": in.ExpirationTime.Local().Format(time.RFC1123),
+	}
+	if in.Name != "" {
+		nonAttributeMap["Name"] = in.Name
+	}
+	if in.Description != "" {
+		nonAttributeMap["Description"] = in.Description
+	}
+	if !in.ApproximateLastUsedTime.IsZero() {
+		nonAttributeMap["Approximate Last Used Time"] = in.ApproximateLastUsedTime.Local().Format(time.RFC1123)
+	}
+	if in.Token != "" {
+		nonAttributeMap["Token"] = in.Token
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"API Token information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"",
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	if len(in.GrantStrings) > 0 {
+		ret = append(ret,
+			"",
+			"  Grants:",
+			base.WrapSlice(4, in.GrantStrings),
+		)
+	}
+
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
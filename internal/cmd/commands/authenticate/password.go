@@ -35,8 +35,11 @@ var (
 type PasswordCommand struct {
 	*base.Command
 
-	flagLoginName string
-	flagPassword  string
+	flagLoginName    string
+	flagPassword     string
+	flagTotpCode     string
+	flagRecoveryCode string
+	flagNewPassword  string
 }
 
 func (c *PasswordCommand) Synopsis() string {
@@ -51,6 +54,10 @@ func (c *PasswordCommand) Help() string {
 		"",
 		`    $ boundary authenticate password -auth-method-id ampw_1234567890 -login-name foo -password "bar"`,
 		"",
+		"  If the account is enrolled in TOTP and neither -totp-code nor -recovery-code is given, the command will prompt for a TOTP code.",
+		"",
+		"  If the password of the account has expired and -new-password is not given, the command will prompt for a new password.",
+		"",
 		"",
 	}) + c.Flags().Help()
 }
@@ -73,6 +80,24 @@ func (c *PasswordCommand) Flags() *base.FlagSets {
 		Usage:  "The password associated with the login name",
 	})
 
+	f.StringVar(&base.StringVar{
+		Name:   "totp-code",
+		Target: &c.flagTotpCode,
+		Usage:  "A TOTP code for accounts enrolled in TOTP. If not specified and the account requires one, the command will prompt for the code.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "recovery-code",
+		Target: &c.flagRecoveryCode,
+		Usage:  "A recovery code to use instead of a TOTP code. Each recovery code can only be used once.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "new-password",
+		Target: &c.flagNewPassword,
+		Usage:  "A new password for the account if its password has expired. If not specified and the password has expired, the command will prompt for a new password.",
+	})
+
 	f.StringVar(&base.StringVar{
 		Name:   "auth-method-id",
 		EnvVar: "BOUNDARY_AUTH_METHOD_ID",
@@ -128,11 +153,53 @@ func (c *PasswordCommand) Run(args []string) int {
 	// note: Authenticate() calls SetToken() under the hood to set the
 	// auth bearer on the client so we do not need to do anything with the
 	// returned token after this call, so we ignore it
-	result, err := authmethods.NewClient(client).Authenticate(c.Context, c.FlagAuthMethodId, "login",
-		map[string]interface{}{
-			"login_name": c.flagLoginName,
-			"password":   c.flagPassword,
-		})
+	credentials := map[string]interface{}{
+		"login_name": c.flagLoginName,
+		"password":   c.flagPassword,
+	}
+	if c.flagTotpCode != "" {
+		credentials["totp_code"] = c.flagTotpCode
+	}
+	if c.flagRecoveryCode != "" {
+		credentials["recovery_code"] = c.flagRecoveryCode
+	}
+	if c.flagNewPassword != "" {
+		credentials["new_password"] = c.flagNewPassword
+	}
+	amClient := authmethods.NewClient(client)
+	result, err := amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", credentials)
+	if err != nil && c.flagNewPassword == "" && requestFieldError(err, "attributes.new_password") {
+		fmt.Print("The password of this account has expired, please enter a new password (will be hidden): ")
+		value, readErr := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if readErr == nil {
+			fmt.Print("Please enter the new password again (will be hidden): ")
+			var confirm string
+			confirm, readErr = password.Read(os.Stdin)
+			fmt.Print("\n")
+			if readErr == nil && strings.TrimSpace(confirm) != strings.TrimSpace(value) {
+				c.UI.Error("The new passwords do not match.")
+				return base.CommandUserError
+			}
+		}
+		if readErr != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the new password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", readErr.Error()))
+			return base.CommandUserError
+		}
+		credentials["new_password"] = strings.TrimSpace(value)
+		result, err = amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", credentials)
+	}
+	if err != nil && c.flagTotpCode == "" && c.flagRecoveryCode == "" && requestFieldError(err, "attributes.totp_code") {
+		fmt.Print("This account requires a TOTP code, please enter it now (will be hidden): ")
+		value, readErr := password.Read(os.Stdin)
+		fmt.Print("\n")
+		if readErr != nil {
+			c.UI.Error(fmt.Sprintf("An error occurred attempting to read the TOTP code. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", readErr.Error()))
+			return base.CommandUserError
+		}
+		credentials["totp_code"] = strings.TrimSpace(value)
+		result, err = amClient.Authenticate(c.Context, c.FlagAuthMethodId, "login", credentials)
+	}
 	if err != nil {
 		if apiErr := api.AsServerError(err); apiErr != nil {
 			c.PrintApiError(apiErr, "Error from controller when performing authentication")
@@ -215,3 +282,19 @@ func (c *PasswordCommand) Run(args []string) int {
 
 	return base.CommandSuccess
 }
+
+// requestFieldError returns true if err is an error returned by the
+// controller for the request field name, such as "attributes.totp_code" when
+// the account is enrolled in TOTP and no code was given.
+func requestFieldError(err error, name string) bool {
+	apiErr := api.AsServerError(err)
+	if apiErr == nil || apiErr.Details == nil {
+		return false
+	}
+	for _, f := range apiErr.Details.RequestFields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
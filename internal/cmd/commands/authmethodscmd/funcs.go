@@ -2,13 +2,60 @@ package authmethodscmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authmethods"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/hashicorp/vault/sdk/helper/password"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
+}
+
+type extraCmdVars struct {
+	flagToken       string
+	flagNewPassword string
+	flagLoginName   string
+	flagPassword    string
+	flagUserName    string
+	resetResult     *authmethods.ResetPasswordResult
+	redeemResult    *authmethods.RedeemInviteResult
+	enrollResult    *authmethods.EnrollTotpResult
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"reset-password": {"id", "token", "new-password"},
+		"redeem-invite":  {"id", "token", "login-name", "password", "user-name"},
+		"enroll-totp":    {"id", "login-name", "password"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "reset-password":
+		return "Reset the password of an account with a reset token"
+	case "redeem-invite":
+		return "Create an account and user with an invite token"
+	case "enroll-totp":
+		return "Enroll an account in TOTP with its password"
+
+	default:
+		return ""
+	}
+}
+
 func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 	var helpStr string
 	switch c.Func {
@@ -48,10 +95,267 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 			"  Please see the typed subcommand help for detailed usage information.",
 		})
+	case "reset-password":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods reset-password [options] [args]",
+			"",
+			"  This command allows resetting the password of an account with a one-time reset token created with the accounts create-reset-token command. It does not require authentication. The new password must meet the password policy of the auth method. Example:",
+			"",
+			"    Reset the password of an account of a password-type auth method:",
+			"",
+			`      $ boundary auth-methods reset-password -id ampw_1234567890 -token <reset token> -new-password <empty, to be read by stdin>`,
+			"",
+			"",
+		})
+	case "redeem-invite":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods redeem-invite [options] [args]",
+			"",
+			"  This command allows redeeming an invite created with the invites create command. It creates an account with the given login name and password in the auth method, and a user associated with the account which is added to the roles of the invite. It does not require authentication. An invite can only be redeemed once. Example:",
+			"",
+			"    Redeem an invite of a password-type auth method:",
+			"",
+			`      $ boundary auth-methods redeem-invite -id ampw_1234567890 -token <invite token> -login-name jim -password <empty, to be read by stdin>`,
+			"",
+			"",
+		})
+	case "enroll-totp":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-methods enroll-totp [options] [args]",
+			"",
+			"  This command allows an account which has not enrolled in TOTP multi-factor authentication to enroll with its login name and password when the auth method requires TOTP. It does not require authentication. The TOTP secret and recovery codes are only shown once. The enrollment is confirmed by authenticating with a code generated from the secret. Example:",
+			"",
+			"    Enroll an account of a password-type auth method in TOTP:",
+			"",
+			`      $ boundary auth-methods enroll-totp -id ampw_1234567890 -login-name jim -password <empty, to be read by stdin>`,
+			"",
+			"",
+		})
 	}
 	return helpStr + c.Flags().Help()
 }
 
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "token":
+			f.StringVar(&base.StringVar{
+				Name:   "token",
+				Target: &c.flagToken,
+				Usage:  tokenUsage(c.Func),
+			})
+		case "new-password":
+			f.StringVar(&base.StringVar{
+				Name:   "new-password",
+				Target: &c.flagNewPassword,
+				Usage:  "The new password for the account. If not specified, the command will prompt for the password to be entered in a non-echoing way.",
+			})
+		case "login-name":
+			f.StringVar(&base.StringVar{
+				Name:   "login-name",
+				Target: &c.flagLoginName,
+				Usage:  loginNameUsage(c.Func),
+			})
+		case "password":
+			f.StringVar(&base.StringVar{
+				Name:   "password",
+				Target: &c.flagPassword,
+				Usage:  passwordUsage(c.Func),
+			})
+		case "user-name":
+			f.StringVar(&base.StringVar{
+				Name:   "user-name",
+				Target: &c.flagUserName,
+				Usage:  "Name to set on the user created for the account.",
+			})
+		}
+	}
+}
+
+func tokenUsage(fn string) string {
+	switch fn {
+	case "redeem-invite":
+		return "The token of the invite."
+	default:
+		return "The reset token created for the account."
+	}
+}
+
+func loginNameUsage(fn string) string {
+	switch fn {
+	case "enroll-totp":
+		return "The login name of the account to enroll."
+	default:
+		return "The login name of the account to create."
+	}
+}
+
+func passwordUsage(fn string) string {
+	switch fn {
+	case "enroll-totp":
+		return "The password of the account to enroll. If not specified, the command will prompt for the password to be entered in a non-echoing way."
+	default:
+		return "The password of the account to create. If not specified, the command will prompt for the password to be entered in a non-echoing way."
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, opts *[]authmethods.Option) bool {
+	if strutil.StrListContains(flagsMap[c.Func], "token") && c.flagToken == "" {
+		c.UI.Error("Token must be passed in via -token")
+		return false
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "login-name") && c.flagLoginName == "" {
+		c.UI.Error("Login name must be passed in via -login-name")
+		return false
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "new-password") && c.flagNewPassword == "" {
+		value, ok := c.readPassword("New password", true)
+		if !ok {
+			return false
+		}
+		c.flagNewPassword = value
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "password") && c.flagPassword == "" {
+		// the password of an existing account is not confirmed
+		value, ok := c.readPassword("Password", c.Func != "enroll-totp")
+		if !ok {
+			return false
+		}
+		c.flagPassword = value
+	}
+
+	if c.flagUserName != "" {
+		*opts = append(*opts, authmethods.WithName(c.flagUserName))
+	}
+
+	return true
+}
+
+// readPassword prompts for a password without echoing it, and for its
+// confirmation if confirm is true. It returns false if the password could
+// not be read or the values did not match, after reporting the error.
+func (c *Command) readPassword(prompt string, confirm bool) (string, bool) {
+	fmt.Printf("%s is not set as flag, please enter it now (will be hidden): ", prompt)
+	value, err := password.Read(os.Stdin)
+	fmt.Print("\n")
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("An error occurred attempting to read the password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+		return "", false
+	}
+	if !confirm {
+		return strings.TrimSpace(value), true
+	}
+	fmt.Print("Please enter it one more time for confirmation: ")
+	confirmation, err := password.Read(os.Stdin)
+	fmt.Print("\n")
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("An error occurred attempting to read the password. The raw error message is shown below but usually this is because you attempted to pipe a value into the command or you are executing outside of a terminal (TTY). The raw error was:\n\n%s", err.Error()))
+		return "", false
+	}
+	if strings.TrimSpace(value) != strings.TrimSpace(confirmation) {
+		c.UI.Error("Entered password and confirmation value did not match.")
+		return "", false
+	}
+	return strings.TrimSpace(value), true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, amClient *authmethods.Client, _ uint32, opts []authmethods.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "reset-password":
+		var err error
+		c.resetResult, err = amClient.ResetPassword(c.Context, c.FlagId, c.flagToken, c.flagNewPassword, opts...)
+		return nil, err
+	case "redeem-invite":
+		var err error
+		c.redeemResult, err = amClient.RedeemInvite(c.Context, c.FlagId, c.flagToken, c.flagLoginName, c.flagPassword, opts...)
+		return nil, err
+	case "enroll-totp":
+		var err error
+		c.enrollResult, err = amClient.EnrollTotp(c.Context, c.FlagId, c.flagLoginName, c.flagPassword, opts...)
+		return nil, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "reset-password":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output("The password was reset successfully.")
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(struct {
+				StatusCode int `json:"status_code"`
+			}{
+				StatusCode: c.resetResult.GetResponse().HttpResponse().StatusCode,
+			})
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "redeem-invite":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(base.WrapForHelpText([]string{
+				"",
+				"Invite redeemed successfully:",
+				fmt.Sprintf("  Account ID:  %s", c.redeemResult.AccountId),
+				fmt.Sprintf("  User ID:     %s", c.redeemResult.UserId),
+			}))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.redeemResult)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "enroll-totp":
+		switch base.Format(c.UI) {
+		case "table":
+			nonAttributeMap := map[string]interface{}{
+				"TOTP Secret": c.enrollResult.TotpSecret,
+				"TOTP URL":    c.enrollResult.TotpUrl,
+			}
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+			ret := []string{
+				"",
+				"TOTP enrollment information:",
+				base.WrapMap(2, maxLength, nonAttributeMap),
+				"",
+				"  Recovery Codes:",
+				base.WrapSlice(4, c.enrollResult.RecoveryCodes),
+				"",
+				"  The TOTP secret and recovery codes will not be shown again. Confirm the",
+				"  enrollment by authenticating with a code generated from the secret.",
+			}
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.enrollResult)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (c *Command) printListTable(items []*authmethods.AuthMethod) string {
 	if len(items) == 0 {
 		return "No auth methods found"
@@ -173,6 +477,20 @@ func printItemTable(in *authmethods.AuthMethod) string {
 }
 
 var keySubstMap = map[string]string{
-	"min_login_name_length": "Minimum Login Name Length",
-	"min_password_length":   "Minimum Password Length",
+	"min_login_name_length":            "Minimum Login Name Length",
+	"min_password_length":              "Minimum Password Length",
+	"auth_token_time_to_live_seconds":  "Auth Token Time To Live Seconds",
+	"auth_token_time_to_stale_seconds": "Auth Token Time To Stale Seconds",
+	"lockout_threshold":                "Lockout Threshold",
+	"lockout_duration_seconds":         "Lockout Duration Seconds",
+	"mfa_policy":                       "MFA Policy",
+	"password_require_uppercase":       "Password Require Uppercase",
+	"password_require_lowercase":       "Password Require Lowercase",
+	"password_require_digit":           "Password Require Digit",
+	"password_require_symbol":          "Password Require Symbol",
+	"password_history_count":           "Password History Count",
+	"max_password_age_seconds":         "Max Password Age Seconds",
+	"banned_passwords":                 "Banned Passwords",
+	"allowed_client_cidrs":             "Allowed Client CIDRs",
+	"denied_client_cidrs":              "Denied Client CIDRs",
 }
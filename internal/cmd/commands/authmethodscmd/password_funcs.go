@@ -3,6 +3,7 @@ package authmethodscmd
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/hashicorp/boundary/api/authmethods"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -15,14 +16,28 @@ func init() {
 }
 
 type extraPasswordCmdVars struct {
-	flagMinLoginNameLength string
-	flagMinPasswordLength  string
+	flagMinLoginNameLength   string
+	flagMinPasswordLength    string
+	flagAuthTokenTimeToLive  string
+	flagAuthTokenTimeToStale string
+	flagLockoutThreshold     string
+	flagLockoutDuration      string
+	flagMfaPolicy            string
+	flagRequireUppercase     string
+	flagRequireLowercase     string
+	flagRequireDigit         string
+	flagRequireSymbol        string
+	flagPasswordHistoryCount string
+	flagMaxPasswordAge       string
+	flagBannedPasswords      []string
+	flagAllowedClientCidrs   []string
+	flagDeniedClientCidrs    []string
 }
 
 func extraPasswordActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"min-login-name-length", "min-password-length"},
-		"update": {"min-login-name-length", "min-password-length"},
+		"create": {"min-login-name-length", "min-password-length", "auth-token-time-to-live", "auth-token-time-to-stale", "lockout-threshold", "lockout-duration", "mfa-policy", "password-require-uppercase", "password-require-lowercase", "password-require-digit", "password-require-symbol", "password-history-count", "max-password-age", "banned-password", "allowed-client-cidr", "denied-client-cidr"},
+		"update": {"min-login-name-length", "min-password-length", "auth-token-time-to-live", "auth-token-time-to-stale", "lockout-threshold", "lockout-duration", "mfa-policy", "password-require-uppercase", "password-require-lowercase", "password-require-digit", "password-require-symbol", "password-history-count", "max-password-age", "banned-password", "allowed-client-cidr", "denied-client-cidr"},
 	}
 }
 
@@ -71,6 +86,90 @@ func extraPasswordFlagsFuncImpl(c *PasswordCommand, set *base.FlagSets, f *base.
 				Target: &c.flagMinPasswordLength,
 				Usage:  "The minimum length of passwords",
 			})
+		case "auth-token-time-to-live":
+			f.StringVar(&base.StringVar{
+				Name:   "auth-token-time-to-live",
+				Target: &c.flagAuthTokenTimeToLive,
+				Usage:  "The total lifetime of the auth tokens issued by the auth method. Can be specified as an integer number of seconds or a duration string. If unset, the setting of the auth method's scope is used.",
+			})
+		case "auth-token-time-to-stale":
+			f.StringVar(&base.StringVar{
+				Name:   "auth-token-time-to-stale",
+				Target: &c.flagAuthTokenTimeToStale,
+				Usage:  "The time the auth tokens issued by the auth method can go unused before becoming invalid. Can be specified as an integer number of seconds or a duration string. If unset, the setting of the auth method's scope is used.",
+			})
+		case "lockout-threshold":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-threshold",
+				Target: &c.flagLockoutThreshold,
+				Usage:  "The number of consecutive failed authentication attempts after which an account is locked. If unset, accounts are never locked.",
+			})
+		case "lockout-duration":
+			f.StringVar(&base.StringVar{
+				Name:   "lockout-duration",
+				Target: &c.flagLockoutDuration,
+				Usage:  "How long an account is locked for the first time. Each consecutive lockout lasts twice as long, up to 24 hours. Can be specified as an integer number of seconds or a duration string. If unset, 5 minutes is used.",
+			})
+		case "mfa-policy":
+			f.StringVar(&base.StringVar{
+				Name:   "mfa-policy",
+				Target: &c.flagMfaPolicy,
+				Usage:  `Whether accounts must enroll in TOTP multi-factor authentication to authenticate, either "optional" or "required". If unset, "optional" is used.`,
+			})
+		case "password-require-uppercase":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-uppercase",
+				Target: &c.flagRequireUppercase,
+				Usage:  "Whether passwords must contain an uppercase letter.",
+			})
+		case "password-require-lowercase":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-lowercase",
+				Target: &c.flagRequireLowercase,
+				Usage:  "Whether passwords must contain a lowercase letter.",
+			})
+		case "password-require-digit":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-digit",
+				Target: &c.flagRequireDigit,
+				Usage:  "Whether passwords must contain a digit.",
+			})
+		case "password-require-symbol":
+			f.StringVar(&base.StringVar{
+				Name:   "password-require-symbol",
+				Target: &c.flagRequireSymbol,
+				Usage:  "Whether passwords must contain a character which is not a letter or a digit.",
+			})
+		case "password-history-count":
+			f.StringVar(&base.StringVar{
+				Name:   "password-history-count",
+				Target: &c.flagPasswordHistoryCount,
+				Usage:  "The number of most recent passwords of an account, including the current one, which can not be reused. If unset, passwords can be reused.",
+			})
+		case "max-password-age":
+			f.StringVar(&base.StringVar{
+				Name:   "max-password-age",
+				Target: &c.flagMaxPasswordAge,
+				Usage:  "How long a password can be used before it must be changed when authenticating. Can be specified as an integer number of seconds or a duration string. If unset, passwords do not expire.",
+			})
+		case "banned-password":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "banned-password",
+				Target: &c.flagBannedPasswords,
+				Usage:  `A password which can not be used by accounts, compared case insensitively. May be specified multiple times. On update, replaces all banned passwords; use "null" to remove them.`,
+			})
+		case "allowed-client-cidr":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "allowed-client-cidr",
+				Target: &c.flagAllowedClientCidrs,
+				Usage:  `A CIDR block or IP address clients must authenticate from. May be specified multiple times. If unset, clients can authenticate from any address which is not denied. On update, replaces all allowed CIDR blocks; use "null" to remove them.`,
+			})
+		case "denied-client-cidr":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "denied-client-cidr",
+				Target: &c.flagDeniedClientCidrs,
+				Usage:  `A CIDR block or IP address clients can not authenticate from. May be specified multiple times. Takes precedence over the allowed CIDR blocks. On update, replaces all denied CIDR blocks; use "null" to remove them.`,
+			})
 		}
 	}
 }
@@ -109,9 +208,159 @@ func extraPasswordFlagHandlingFuncImpl(c *PasswordCommand, opts *[]authmethods.O
 		addAttribute("min_password_length", uint32(length))
 	}
 
+	switch c.flagAuthTokenTimeToLive {
+	case "":
+	case "null":
+		addAttribute("auth_token_time_to_live_seconds", nil)
+	default:
+		secs, err := parseSeconds(c.flagAuthTokenTimeToLive)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToLive, err))
+			return false
+		}
+		addAttribute("auth_token_time_to_live_seconds", secs)
+	}
+
+	switch c.flagAuthTokenTimeToStale {
+	case "":
+	case "null":
+		addAttribute("auth_token_time_to_stale_seconds", nil)
+	default:
+		secs, err := parseSeconds(c.flagAuthTokenTimeToStale)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToStale, err))
+			return false
+		}
+		addAttribute("auth_token_time_to_stale_seconds", secs)
+	}
+
+	switch c.flagLockoutThreshold {
+	case "":
+	case "null":
+		addAttribute("lockout_threshold", nil)
+	default:
+		threshold, err := strconv.ParseUint(c.flagLockoutThreshold, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagLockoutThreshold, err))
+			return false
+		}
+		addAttribute("lockout_threshold", uint32(threshold))
+	}
+
+	switch c.flagLockoutDuration {
+	case "":
+	case "null":
+		addAttribute("lockout_duration_seconds", nil)
+	default:
+		secs, err := parseSeconds(c.flagLockoutDuration)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagLockoutDuration, err))
+			return false
+		}
+		addAttribute("lockout_duration_seconds", secs)
+	}
+
+	switch c.flagMfaPolicy {
+	case "":
+	case "null":
+		addAttribute("mfa_policy", nil)
+	default:
+		addAttribute("mfa_policy", c.flagMfaPolicy)
+	}
+
+	for _, b := range []struct {
+		name string
+		flag string
+	}{
+		{"password_require_uppercase", c.flagRequireUppercase},
+		{"password_require_lowercase", c.flagRequireLowercase},
+		{"password_require_digit", c.flagRequireDigit},
+		{"password_require_symbol", c.flagRequireSymbol},
+	} {
+		switch b.flag {
+		case "":
+		case "null":
+			addAttribute(b.name, nil)
+		default:
+			v, err := strconv.ParseBool(b.flag)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", b.flag, err))
+				return false
+			}
+			addAttribute(b.name, v)
+		}
+	}
+
+	switch c.flagPasswordHistoryCount {
+	case "":
+	case "null":
+		addAttribute("password_history_count", nil)
+	default:
+		count, err := strconv.ParseUint(c.flagPasswordHistoryCount, 10, 32)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagPasswordHistoryCount, err))
+			return false
+		}
+		addAttribute("password_history_count", uint32(count))
+	}
+
+	switch c.flagMaxPasswordAge {
+	case "":
+	case "null":
+		addAttribute("max_password_age_seconds", nil)
+	default:
+		secs, err := parseSeconds(c.flagMaxPasswordAge)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagMaxPasswordAge, err))
+			return false
+		}
+		addAttribute("max_password_age_seconds", secs)
+	}
+
+	switch len(c.flagBannedPasswords) {
+	case 0:
+	case 1:
+		if c.flagBannedPasswords[0] == "null" {
+			addAttribute("banned_passwords", nil)
+			break
+		}
+		fallthrough
+	default:
+		addAttribute("banned_passwords", c.flagBannedPasswords)
+	}
+
+	for _, l := range []struct {
+		name string
+		flag []string
+	}{
+		{"allowed_client_cidrs", c.flagAllowedClientCidrs},
+		{"denied_client_cidrs", c.flagDeniedClientCidrs},
+	} {
+		switch {
+		case len(l.flag) == 0:
+		case len(l.flag) == 1 && l.flag[0] == "null":
+			addAttribute(l.name, nil)
+		default:
+			addAttribute(l.name, l.flag)
+		}
+	}
+
 	if attributes != nil {
 		*opts = append(*opts, authmethods.WithAttributes(attributes))
 	}
 
 	return true
 }
+
+// parseSeconds parses an integer number of seconds or a duration string.
+func parseSeconds(in string) (uint32, error) {
+	secs, err := strconv.ParseUint(in, 10, 32)
+	if err == nil {
+		return uint32(secs), nil
+	}
+	dur, err := time.ParseDuration(in)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(dur.Seconds()), nil
+}
@@ -4,10 +4,118 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/authtokens"
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/mitchellh/go-wordwrap"
 )
 
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+}
+
+type extraCmdVars struct {
+	flagGrantScopeId string
+	flagGrants       []string
+	flagTtl          time.Duration
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"derive": {"id", "grant-scope-id", "grant", "ttl"},
+	}
+}
+
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "derive":
+		return wordwrap.WrapString("Derive a restricted, short-lived auth token from the auth token in use", base.TermWidth)
+	}
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return helpMap["base"]()
+
+	case "derive":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary auth-tokens derive [options] [args]",
+			"",
+			`  Exchange the auth token in use, specified by ID, for a new auth token which is only allowed to do what is allowed by both the "grant" flags and the grants of the current user. The derived auth token expires after 10 minutes by default, never outlives the auth token it was derived from, and is deleted when that auth token is deleted. Example:`,
+			"",
+			`    $ boundary auth-tokens derive -id at_1234567890 -grant-scope-id p_1234567890 -grant "id=ttcp_1234567890;actions=authorize-session"`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "grant-scope-id":
+			f.StringVar(&base.StringVar{
+				Name:   "grant-scope-id",
+				Target: &c.flagGrantScopeId,
+				Usage:  "The scope the grants restricting the derived auth token apply to. Defaults to the scope of the auth token.",
+			})
+		case "grant":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "grant",
+				Target: &c.flagGrants,
+				Usage:  "A grant restricting what the derived auth token is allowed to do. May be specified multiple times.",
+			})
+		case "ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:   "ttl",
+				Target: &c.flagTtl,
+				Usage:  "How long until the derived auth token expires.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, opts *[]authtokens.Option) bool {
+	switch c.Func {
+	case "derive":
+		if len(c.flagGrants) == 0 {
+			c.UI.Error("At least one grant must be passed in via -grant")
+			return false
+		}
+		if c.flagGrantScopeId != "" {
+			*opts = append(*opts, authtokens.WithGrantScopeId(c.flagGrantScopeId))
+		}
+		switch {
+		case c.flagTtl < 0:
+			c.UI.Error("The value of -ttl must not be negative")
+			return false
+		case c.flagTtl > 0:
+			*opts = append(*opts, authtokens.WithExpirationTime(time.Now().Add(c.flagTtl)))
+		}
+	}
+
+	return true
+}
+
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, authTokenClient *authtokens.Client, version uint32, opts []authtokens.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "derive":
+		return authTokenClient.Derive(c.Context, c.FlagId, c.flagGrants, opts...)
+	}
+	return origResult, origError
+}
+
 func (c *Command) printListTable(items []*authtokens.AuthToken) string {
 	if len(items) == 0 {
 		return "No auth tokens found"
@@ -42,6 +150,11 @@ func (c *Command) printListTable(items []*authtokens.AuthToken) string {
 				fmt.Sprintf("    User ID:                     %s", t.UserId),
 			)
 		}
+		if t.ParentId != "" {
+			output = append(output,
+				fmt.Sprintf("    Parent ID:                   %s", t.ParentId),
+			)
+		}
 		if len(t.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -63,6 +176,13 @@ func printItemTable(in *authtokens.AuthToken) string {
 		"Expiration Time":            in.ExpirationTime.Local().Format(time.RFC1123),
 		"Approximate Last Used Time": in.ApproximateLastUsedTime.Local().Format(time.RFC1123),
 	}
+	if in.ParentId != "" {
+		nonAttributeMap["Parent ID"] = in.ParentId
+		nonAttributeMap["Grant Scope ID"] = in.GrantScopeId
+	}
+	if in.Token != "" {
+		nonAttributeMap["Token"] = in.Token
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -75,6 +195,14 @@ func printItemTable(in *authtokens.AuthToken) string {
 		base.ScopeInfoForOutput(in.Scope, maxLength),
 	}
 
+	if len(in.GrantStrings) > 0 {
+		ret = append(ret,
+			"",
+			"  Grants:",
+			base.WrapSlice(4, in.GrantStrings),
+		)
+	}
+
 	if len(in.AuthorizedActions) > 0 {
 		ret = append(ret,
 			"",
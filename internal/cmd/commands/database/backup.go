@@ -0,0 +1,282 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/backup"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*BackupCommand)(nil)
+	_ cli.CommandAutocomplete = (*BackupCommand)(nil)
+)
+
+type BackupCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagFile      string
+}
+
+func (c *BackupCommand) Synopsis() string {
+	return "Back up Boundary's resources into an encrypted archive"
+}
+
+func (c *BackupCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database backup [options]",
+		"",
+		"  Back up Boundary's resources into an encrypted archive:",
+		"",
+		"    $ boundary database backup -config=/etc/boundary/controller.hcl -file=boundary.backup",
+		"",
+		"  The scopes, IAM, auth, host, target and KMS resources are exported from a consistent snapshot of the database into an archive encrypted with the root KMS. Sessions, auth tokens, workers, jobs, the oplog and the warehouse are not exported. The archive can only be restored with the same root KMS by 'boundary database restore', using a binary expecting the schema version of the database, which must be up to date.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *BackupCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "Path of the archive to create. The file must not exist.",
+	})
+
+	return set
+}
+
+func (c *BackupCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *BackupCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *BackupCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	urlToParse := c.Config.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return base.CommandUserError
+	}
+	var err error
+	c.srv.DatabaseUrl, err = config.ParseAddress(urlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	status, errCode := databaseSchemaStatus(c.Context, c.UI, dialect, c.srv.DatabaseUrl, false)
+	if errCode != 0 {
+		return errCode
+	}
+	switch {
+	case !status.Initialized:
+		c.UI.Error(base.WrapAtLength("The database has not been initialized. Please run 'boundary database init'."))
+		return base.CommandUserError
+	case status.Dirty:
+		c.UI.Error(base.WrapAtLength("Database is in a bad state. Please revert the database into the last known good state."))
+		return base.CommandUserError
+	case status.DatabaseSchemaVersion != status.BinarySchemaVersion:
+		c.UI.Error(base.WrapAtLength(fmt.Sprintf("Database schema version (%d) does not match the version this binary expects (%d). Please use a binary expecting the database's schema version to back it up.", status.DatabaseSchemaVersion, status.BinarySchemaVersion)))
+		return base.CommandUserError
+	}
+
+	if err := c.srv.ConnectToDatabase(dialect); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer c.srv.Database.Close()
+
+	f, err := os.OpenFile(c.flagFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating archive file: %w", err).Error())
+		return base.CommandUserError
+	}
+	defer func() {
+		if retCode != base.CommandSuccess {
+			f.Close()
+			os.Remove(c.flagFile)
+		}
+	}()
+
+	archive, summary, err := backup.Backup(c.Context, db.New(c.srv.Database), c.srv.RootKms, status.DatabaseSchemaVersion)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error backing up database: %w", err).Error())
+		return base.CommandCliError
+	}
+	marshaled, err := json.Marshal(archive)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error marshaling archive: %w", err).Error())
+		return base.CommandCliError
+	}
+	if _, err := f.Write(marshaled); err != nil {
+		c.UI.Error(fmt.Errorf("Error writing archive file: %w", err).Error())
+		return base.CommandCliError
+	}
+	if err := f.Close(); err != nil {
+		c.UI.Error(fmt.Errorf("Error writing archive file: %w", err).Error())
+		return base.CommandCliError
+	}
+	info := newArchiveInfo(c.flagFile, archive, summary)
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generateArchiveTableOutput("Backup", info))
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *BackupCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case len(c.flagFile) == 0:
+		c.UI.Error("Must specify an archive file using -file")
+		return base.CommandUserError
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return base.CommandUserError
+		}
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
@@ -29,6 +29,10 @@ func (c *Command) Help() string {
 		"",
 		`      $ boundary database init`,
 		"",
+		"    Report the state of the database schema:",
+		"",
+		`      $ boundary database status`,
+		"",
 		"  Please see the database subcommand help for detailed usage information.",
 	})
 }
@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/db/backup"
 	"github.com/hashicorp/boundary/internal/db/schema"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog/verify"
 	"github.com/mitchellh/cli"
 )
 
@@ -70,6 +74,105 @@ func migrateDatabase(ctx context.Context, ui cli.Ui, dialect, u string, requireF
 	return unlock, 0
 }
 
+// SchemaStatus is the state of the database schema compared to the schema
+// supported by the binary.
+type SchemaStatus struct {
+	Initialized           bool                `json:"initialized"`
+	Dirty                 bool                `json:"dirty"`
+	DatabaseSchemaVersion int                 `json:"database_schema_version"`
+	BinarySchemaVersion   int                 `json:"binary_schema_version"`
+	PendingMigrations     []*PendingMigration `json:"pending_migrations"`
+}
+
+// PendingMigration is a migration which 'boundary database migrate' would
+// run.
+type PendingMigration struct {
+	Version    int    `json:"version"`
+	Name       string `json:"name"`
+	Statements string `json:"statements,omitempty"`
+}
+
+// databaseSchemaStatus reports the state of the database schema and the
+// migrations which would be run to update it.  The statements of the
+// migrations are only included if withStatements is set.  No migrations are
+// reported as pending if the database is dirty since none can be run.
+// It owns the reporting to the UI any errors and returns an error code where
+// a non-zero value indicates an error happened.
+func databaseSchemaStatus(ctx context.Context, ui cli.Ui, dialect, u string, withStatements bool) (*SchemaStatus, int) {
+	dBase, err := sql.Open(dialect, u)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error establishing db connection: %w", err).Error())
+		return nil, 2
+	}
+	defer dBase.Close()
+	if err := dBase.PingContext(ctx); err != nil {
+		ui.Error(fmt.Sprintf("Unable to connect to the database at %q", u))
+		return nil, 2
+	}
+	man, err := schema.NewManager(ctx, dialect, dBase)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error setting up schema manager: %w", err).Error())
+		return nil, 2
+	}
+	// The shared lock can't be captured while a migration is running.  It is
+	// released when the DB session ends.
+	if err := man.SharedLock(ctx); err != nil {
+		ui.Error("Unable to gain shared access to the database. A migration may be running.")
+		return nil, 2
+	}
+	st, err := man.CurrentState(ctx)
+	if err != nil {
+		ui.Error(fmt.Errorf("Error getting database state: %w", err).Error())
+		return nil, 2
+	}
+
+	status := &SchemaStatus{
+		Initialized:           st.InitializationStarted,
+		Dirty:                 st.Dirty,
+		DatabaseSchemaVersion: st.DatabaseSchemaVersion,
+		BinarySchemaVersion:   st.BinarySchemaVersion,
+		PendingMigrations:     []*PendingMigration{},
+	}
+	if st.Dirty {
+		return status, 0
+	}
+	for _, m := range schema.PendingMigrations(dialect, st.DatabaseSchemaVersion) {
+		pm := &PendingMigration{
+			Version: m.Version,
+			Name:    m.Name,
+		}
+		if withStatements {
+			pm.Statements = string(m.Statements)
+		}
+		status.PendingMigrations = append(status.PendingMigrations, pm)
+	}
+	return status, 0
+}
+
+// ArchiveInfo describes an archive created by 'boundary database backup' or
+// restored by 'boundary database restore'.
+type ArchiveInfo struct {
+	File          string                 `json:"file"`
+	FormatVersion int                    `json:"format_version"`
+	SchemaVersion int                    `json:"schema_version"`
+	CreateTime    time.Time              `json:"create_time"`
+	KeyId         string                 `json:"key_id"`
+	Checksum      string                 `json:"checksum"`
+	Tables        []*backup.TableSummary `json:"tables"`
+}
+
+func newArchiveInfo(file string, a *backup.Archive, s *backup.Summary) *ArchiveInfo {
+	return &ArchiveInfo{
+		File:          file,
+		FormatVersion: a.FormatVersion,
+		SchemaVersion: a.SchemaVersion,
+		CreateTime:    a.CreateTime,
+		KeyId:         a.KeyId,
+		Checksum:      a.Checksum,
+		Tables:        s.Tables,
+	}
+}
+
 type RoleInfo struct {
 	RoleId string `json:"scope_id"`
 	Name   string `json:"name"`
@@ -238,3 +341,155 @@ func generateInitialTargetTableOutput(in *TargetInfo) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func generateVerifyOplogTableOutput(in *verify.Report) string {
+	nonAttributeMap := map[string]interface{}{
+		"Entries Verified":    in.EntriesVerified,
+		"Unversioned Entries": in.UnversionedEntries,
+		"Rows Verified":       in.RowsVerified,
+		"Cascaded Rows":       in.CascadedRows,
+		"Problems Found":      len(in.Problems),
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		"Oplog verification:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(in.Problems) > 0 {
+		ret = append(ret,
+			"",
+			"  Problems:",
+		)
+	}
+	for _, p := range in.Problems {
+		ret = append(ret,
+			fmt.Sprintf("    Entry %d (%s): %s", p.EntryId, p.AggregateName, p.Kind),
+			fmt.Sprintf("      %s", p.Description),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateSchemaStatusTableOutput(in *SchemaStatus) string {
+	nonAttributeMap := map[string]interface{}{
+		"Initialized":             in.Initialized,
+		"Dirty":                   in.Dirty,
+		"Database Schema Version": in.DatabaseSchemaVersion,
+		"Binary Schema Version":   in.BinarySchemaVersion,
+		"Pending Migrations":      len(in.PendingMigrations),
+	}
+	if !in.Initialized {
+		nonAttributeMap["Database Schema Version"] = "none"
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		"Database schema status:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	if len(in.PendingMigrations) > 0 {
+		ret = append(ret,
+			"",
+			"  Pending migrations:",
+		)
+	}
+	for _, m := range in.PendingMigrations {
+		ret = append(ret, fmt.Sprintf("    %d (%s)", m.Version, m.Name))
+	}
+
+	ret = append(ret, "")
+	switch {
+	case in.Dirty:
+		ret = append(ret, "  A previous migration failed and left the database in a bad state. Please revert back to the last known good state.")
+	case !in.Initialized:
+		ret = append(ret, "  The database has not been initialized. Please run 'boundary database init'.")
+	case in.DatabaseSchemaVersion > in.BinarySchemaVersion:
+		ret = append(ret, "  The database schema is newer than this binary supports. Please use a newer version of the boundary binary.")
+	case len(in.PendingMigrations) > 0:
+		ret = append(ret, "  The database schema is older than this binary supports. Please run 'boundary database migrate'.")
+	default:
+		ret = append(ret, "  The database schema is up to date.")
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateDryRunOutput(in *SchemaStatus) string {
+	if len(in.PendingMigrations) == 0 {
+		return "-- The database schema is up to date; no migrations would be run."
+	}
+	var ret []string
+	for _, m := range in.PendingMigrations {
+		ret = append(ret,
+			fmt.Sprintf("-- Migration %d (%s)", m.Version, m.Name),
+			strings.TrimRight(m.Statements, "\n"),
+			"",
+		)
+	}
+	return strings.Join(ret, "\n")
+}
+
+func generateArchiveTableOutput(title string, in *ArchiveInfo) string {
+	var rows int
+	for _, t := range in.Tables {
+		rows += t.Rows
+	}
+	nonAttributeMap := map[string]interface{}{
+		"File":           in.File,
+		"Format Version": in.FormatVersion,
+		"Schema Version": in.SchemaVersion,
+		"Created Time":   in.CreateTime.Local().Format(time.RFC1123),
+		"Key ID":         in.KeyId,
+		"Checksum":       in.Checksum,
+		"Rows":           rows,
+	}
+
+	maxLength := 0
+	for k := range nonAttributeMap {
+		if len(k) > maxLength {
+			maxLength = len(k)
+		}
+	}
+
+	ret := []string{
+		"",
+		fmt.Sprintf("%s:", title),
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	tableMap := make(map[string]interface{}, len(in.Tables))
+	maxLength = 0
+	for _, t := range in.Tables {
+		tableMap[t.Name] = t.Rows
+		if len(t.Name) > maxLength {
+			maxLength = len(t.Name)
+		}
+	}
+	if len(tableMap) > 0 {
+		ret = append(ret,
+			"",
+			"  Tables:",
+			base.WrapMap(4, maxLength+2, tableMap),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
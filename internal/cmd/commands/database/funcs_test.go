@@ -190,3 +190,65 @@ func TestVerifyOplogIsEmpty(t *testing.T) {
 
 	assert.NoError(t, cmd.verifyOplogIsEmpty())
 }
+
+func TestDatabaseSchemaStatus(t *testing.T) {
+	dialect := "postgres"
+	ctx := context.Background()
+
+	t.Run("bad_url", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		status, errCode := databaseSchemaStatus(ctx, ui, dialect, "badurl", false)
+		assert.Nil(t, status)
+		assert.Equal(t, 2, errCode)
+		assert.Equal(t, "Unable to connect to the database at \"badurl\"\n", ui.ErrorWriter.String())
+	})
+
+	c, u, _, err := db.StartDbInDocker(dialect)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, c())
+	})
+
+	t.Run("uninitialized", func(t *testing.T) {
+		status, errCode := databaseSchemaStatus(ctx, cli.NewMockUi(), dialect, u, true)
+		require.Equal(t, 0, errCode)
+		assert.False(t, status.Initialized)
+		assert.False(t, status.Dirty)
+		assert.Equal(t, schema.BinarySchemaVersion(dialect), status.BinarySchemaVersion)
+		all := schema.PendingMigrations(dialect, -1)
+		require.Len(t, status.PendingMigrations, len(all))
+		for i, m := range all {
+			assert.Equal(t, m.Version, status.PendingMigrations[i].Version)
+			assert.Equal(t, m.Name, status.PendingMigrations[i].Name)
+			assert.Equal(t, string(m.Statements), status.PendingMigrations[i].Statements)
+		}
+	})
+
+	t.Run("migrated", func(t *testing.T) {
+		clean, errCode := migrateDatabase(ctx, cli.NewMockUi(), dialect, u, false)
+		clean()
+		require.Equal(t, 0, errCode)
+
+		status, errCode := databaseSchemaStatus(ctx, cli.NewMockUi(), dialect, u, false)
+		require.Equal(t, 0, errCode)
+		assert.True(t, status.Initialized)
+		assert.False(t, status.Dirty)
+		assert.Equal(t, status.BinarySchemaVersion, status.DatabaseSchemaVersion)
+		assert.Empty(t, status.PendingMigrations)
+	})
+
+	t.Run("cant_get_lock", func(t *testing.T) {
+		dBase, err := sql.Open(dialect, u)
+		require.NoError(t, err)
+		defer dBase.Close()
+		man, err := schema.NewManager(ctx, dialect, dBase)
+		require.NoError(t, err)
+		// This is an advisory lock on the DB which is released when the DB session ends.
+		require.NoError(t, man.ExclusiveLock(ctx))
+
+		ui := cli.NewMockUi()
+		_, errCode := databaseSchemaStatus(ctx, ui, dialect, u, false)
+		assert.Equal(t, 2, errCode)
+		assert.Equal(t, "Unable to gain shared access to the database. A migration may be running.\n", ui.ErrorWriter.String())
+	})
+}
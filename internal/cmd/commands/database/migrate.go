@@ -35,6 +35,7 @@ type MigrateCommand struct {
 	flagLogFormat          string
 	flagMigrationUrl       string
 	flagAllowDevMigrations bool
+	flagDryRun             bool
 }
 
 func (c *MigrateCommand) Synopsis() string {
@@ -49,6 +50,10 @@ func (c *MigrateCommand) Help() string {
 		"",
 		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
 		"",
+		"  Print the SQL of the migrations which would be run, without running them:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl -dry-run",
+		"",
 		"  For a full list of examples, please see the documentation.",
 	}) + c.Flags().Help()
 }
@@ -102,6 +107,12 @@ func (c *MigrateCommand) Flags() *base.FlagSets {
 		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for migration. This can allow different permissions for the user running initialization or migration vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
 	})
 
+	f.BoolVar(&base.BoolVar{
+		Name:   "dry-run",
+		Target: &c.flagDryRun,
+		Usage:  "If set, prints the SQL of the migrations which would be run to update the database schema instead of running them.",
+	})
+
 	return set
 }
 
@@ -180,6 +191,29 @@ func (c *MigrateCommand) Run(args []string) (retCode int) {
 		return base.CommandUserError
 	}
 
+	if c.flagDryRun {
+		status, errCode := databaseSchemaStatus(c.Context, c.UI, dialect, migrationUrl, true)
+		if errCode != 0 {
+			return errCode
+		}
+		if status.Dirty {
+			c.UI.Error(base.WrapAtLength("Database is in a bad state.  Please revert back to the last known good state."))
+			return base.CommandCliError
+		}
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := base.JsonFormatter{}.Format(status.PendingMigrations)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return base.CommandCliError
+			}
+			c.UI.Output(string(b))
+		default:
+			c.UI.Output(generateDryRunOutput(status))
+		}
+		return base.CommandSuccess
+	}
+
 	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, false)
 	defer clean()
 	if errCode != 0 {
@@ -0,0 +1,300 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/backup"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*RestoreCommand)(nil)
+	_ cli.CommandAutocomplete = (*RestoreCommand)(nil)
+)
+
+type RestoreCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+	flagFile      string
+
+	flagMigrationUrl string
+}
+
+func (c *RestoreCommand) Synopsis() string {
+	return "Restore Boundary's resources from an encrypted archive into an empty database"
+}
+
+func (c *RestoreCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database restore [options]",
+		"",
+		"  Restore Boundary's resources from an archive created by 'boundary database backup':",
+		"",
+		"    $ boundary database restore -config=/etc/boundary/controller.hcl -file=boundary.backup",
+		"",
+		"  The database must be empty. It is migrated to the schema version this binary expects, which must be the schema version of the archive, and the archive is decrypted with the root KMS and restored in a single transaction. The rows are restored with triggers disabled, so the database user used for migration must be allowed to set the session_replication_role. If the restore fails the database must be recreated before trying again.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *RestoreCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "file",
+		Target:     &c.flagFile,
+		Completion: complete.PredictFiles("*"),
+		Usage:      "Path of the archive to restore.",
+	})
+
+	f = set.NewFlagSet("Migration options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database for migration and restoration. This can allow different permissions for the user running the restoration vs. normal operation. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	return set
+}
+
+func (c *RestoreCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RestoreCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RestoreCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	archiveBytes, err := ioutil.ReadFile(c.flagFile)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading archive file: %w", err).Error())
+		return base.CommandUserError
+	}
+	var archive backup.Archive
+	if err := json.Unmarshal(archiveBytes, &archive); err != nil {
+		c.UI.Error(fmt.Errorf("Error parsing archive file: %w", err).Error())
+		return base.CommandUserError
+	}
+	if binaryVersion := schema.BinarySchemaVersion(dialect); archive.SchemaVersion != binaryVersion {
+		c.UI.Error(base.WrapAtLength(fmt.Sprintf("Archive schema version (%d) does not match the version this binary expects (%d). Please use a binary expecting the archive's schema version to restore it.", archive.SchemaVersion, binaryVersion)))
+		return base.CommandUserError
+	}
+
+	var migrationUrlToParse string
+	if c.Config.Controller.Database.MigrationUrl != "" {
+		migrationUrlToParse = c.Config.Controller.Database.MigrationUrl
+	}
+	if c.flagMigrationUrl != "" {
+		migrationUrlToParse = c.flagMigrationUrl
+	}
+	// Fallback to using database URL for everything
+	if migrationUrlToParse == "" {
+		migrationUrlToParse = c.Config.Controller.Database.Url
+	}
+
+	if migrationUrlToParse == "" {
+		c.UI.Error(base.WrapAtLength(`neither "url" nor "migration_url" correctly set in "database" config block nor was the "migration-url" flag used`))
+		return base.CommandUserError
+	}
+
+	migrationUrl, err := config.ParseAddress(migrationUrlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing migration url: %w", err).Error())
+		return base.CommandUserError
+	}
+
+	status, errCode := databaseSchemaStatus(c.Context, c.UI, dialect, migrationUrl, false)
+	if errCode != 0 {
+		return errCode
+	}
+	if status.Initialized {
+		c.UI.Error(base.WrapAtLength("Database has already been initialized. Archives can only be restored into an empty database."))
+		return base.CommandUserError
+	}
+
+	// The exclusive lock captured by the migration is kept until the archive
+	// is restored, so controllers can't start on the database in between.
+	clean, errCode := migrateDatabase(c.Context, c.UI, dialect, migrationUrl, true)
+	defer clean()
+	switch errCode {
+	case 0:
+	case -1:
+		return base.CommandUserError
+	default:
+		return errCode
+	}
+
+	c.srv.DatabaseUrl = migrationUrl
+	if err := c.srv.ConnectToDatabase(dialect); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer c.srv.Database.Close()
+
+	summary, err := backup.Restore(c.Context, db.New(c.srv.Database), c.srv.RootKms, &archive, status.BinarySchemaVersion)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error restoring archive: %w", err).Error())
+		return base.CommandCliError
+	}
+	info := newArchiveInfo(c.flagFile, &archive, summary)
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(info)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generateArchiveTableOutput("Restore", info))
+	}
+
+	return base.CommandSuccess
+}
+
+func (c *RestoreCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	case len(c.flagFile) == 0:
+		c.UI.Error("Must specify an archive file using -file")
+		return base.CommandUserError
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return base.CommandUserError
+		}
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
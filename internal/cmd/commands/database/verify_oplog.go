@@ -0,0 +1,253 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/verify"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var (
+	_ cli.Command             = (*VerifyOplogCommand)(nil)
+	_ cli.CommandAutocomplete = (*VerifyOplogCommand)(nil)
+)
+
+type VerifyOplogCommand struct {
+	*base.Command
+	srv *base.Server
+
+	Config *config.Config
+
+	configWrapper wrapping.Wrapper
+
+	flagConfig    string
+	flagConfigKms string
+	flagLogLevel  string
+	flagLogFormat string
+}
+
+func (c *VerifyOplogCommand) Synopsis() string {
+	return "Verify the integrity of Boundary's oplog"
+}
+
+func (c *VerifyOplogCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database verify-oplog [options]",
+		"",
+		"  Verify the integrity of Boundary's oplog:",
+		"",
+		"    $ boundary database verify-oplog -config=/etc/boundary/controller.hcl",
+		"",
+		"  Each oplog entry is decrypted with the oplog key of its scope and its messages are unmarshaled. The ticket versions of the entries of each aggregate are checked for gaps and reordering, and the last operation recorded for each row is checked against the current contents of its table. Entries written before ticket versions were recorded are not checked for gaps. Rows removed by the cascading delete of a parent row are counted as cascaded rows rather than reported as missing.",
+		"",
+		"  The command exits with a non-zero status if any problems are found.",
+		"",
+		"  For a full list of examples, please see the documentation.",
+	}) + c.Flags().Help()
+}
+
+func (c *VerifyOplogCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-level",
+		Target:     &c.flagLogLevel,
+		EnvVar:     "BOUNDARY_LOG_LEVEL",
+		Completion: complete.PredictSet("trace", "debug", "info", "warn", "err"),
+		Usage: "Log verbosity level. Supported values (in order of more detail to less) are " +
+			"\"trace\", \"debug\", \"info\", \"warn\", and \"err\".",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "log-format",
+		Target:     &c.flagLogFormat,
+		Completion: complete.PredictSet("standard", "json"),
+		Usage:      `Log format. Supported values are "standard" and "json".`,
+	})
+
+	return set
+}
+
+func (c *VerifyOplogCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *VerifyOplogCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *VerifyOplogCommand) Run(args []string) (retCode int) {
+	if result := c.ParseFlagsAndConfig(args); result > 0 {
+		return result
+	}
+
+	if c.configWrapper != nil {
+		defer func() {
+			if err := c.configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+
+	dialect := "postgres"
+
+	c.srv = base.NewServer(&base.Command{UI: c.UI})
+
+	if err := c.srv.SetupLogging(c.flagLogLevel, c.flagLogFormat, c.Config.LogLevel, c.Config.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if err := c.srv.SetupKMSes(c.UI, c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandCliError
+	}
+
+	if c.srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return base.CommandCliError
+	}
+
+	if c.Config.Controller == nil {
+		c.UI.Error(`"controller" config block not found`)
+		return base.CommandUserError
+	}
+
+	if c.Config.Controller.Database == nil {
+		c.UI.Error(`"controller.database" config block not found`)
+		return base.CommandUserError
+	}
+
+	urlToParse := c.Config.Controller.Database.Url
+	if urlToParse == "" {
+		c.UI.Error(`"url" not specified in "database" config block`)
+		return base.CommandUserError
+	}
+	var err error
+	c.srv.DatabaseUrl, err = config.ParseAddress(urlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return base.CommandUserError
+	}
+	if err := c.srv.ConnectToDatabase(dialect); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return base.CommandCliError
+	}
+	defer c.srv.Database.Close()
+
+	rw := db.New(c.srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return base.CommandCliError
+	}
+	kmsCache, err := kms.NewKms(kmsRepo)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return base.CommandCliError
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(c.srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	verifier, err := verify.NewVerifier(rw, kmsCache)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating oplog verifier: %w", err).Error())
+		return base.CommandCliError
+	}
+	report, err := verifier.Verify(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error verifying oplog: %w", err).Error())
+		return base.CommandCliError
+	}
+
+	switch base.Format(c.UI) {
+	case "json":
+		b, err := base.JsonFormatter{}.Format(report)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return base.CommandCliError
+		}
+		c.UI.Output(string(b))
+	default:
+		c.UI.Output(generateVerifyOplogTableOutput(report))
+	}
+
+	if len(report.Problems) > 0 {
+		return base.CommandCliError
+	}
+	return base.CommandSuccess
+}
+
+func (c *VerifyOplogCommand) ParseFlagsAndConfig(args []string) int {
+	var err error
+
+	f := c.Flags()
+
+	if err = f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+
+	// Validation
+	switch {
+	case len(c.flagConfig) == 0:
+		c.UI.Error("Must specify a config file using -config")
+		return base.CommandUserError
+	}
+
+	wrapperPath := c.flagConfig
+	if c.flagConfigKms != "" {
+		wrapperPath = c.flagConfigKms
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return base.CommandUserError
+	}
+	if wrapper != nil {
+		c.configWrapper = wrapper
+		if err := wrapper.Init(c.Context); err != nil {
+			c.UI.Error(fmt.Errorf("Could not initialize kms: %w", err).Error())
+			return base.CommandUserError
+		}
+	}
+
+	c.Config, err = config.LoadFile(c.flagConfig, wrapper)
+	if err != nil {
+		c.UI.Error("Error parsing config: " + err.Error())
+		return base.CommandUserError
+	}
+
+	return base.CommandSuccess
+}
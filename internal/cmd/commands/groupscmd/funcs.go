@@ -39,9 +39,9 @@ func extraSynopsisFuncImpl(c *Command) string {
 		case strings.HasPrefix(c.Func, "add"):
 			in = "Add members to"
 		case strings.HasPrefix(c.Func, "set"):
-			in = "Set the full contents of the users on"
+			in = "Set the full contents of the members on"
 		case strings.HasPrefix(c.Func, "remove"):
-			in = "Remove users from"
+			in = "Remove members from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a group", in), base.TermWidth)
 
@@ -60,9 +60,9 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups add-members [options] [args]",
 			"",
-			`  Adds members (users) to a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Adds members (users or groups) to a group given its ID. The "member" flag can be specified multiple times. Example:`,
 			"",
-			`    $ boundary groups add-members -id g_1234567890 -user u_1234567890`,
+			`    $ boundary groups add-members -id g_1234567890 -member u_1234567890 -member g_0987654321`,
 			"",
 			"",
 		})
@@ -71,9 +71,9 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups set-members [options] [args]",
 			"",
-			`  Sets the complete set of members (users) on a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Sets the complete set of members (users or groups) on a group given its ID. The "member" flag can be specified multiple times. Example:`,
 			"",
-			`    $ boundary groups set-principals -id g_1234567890 -user u_anon -user u_1234567890`,
+			`    $ boundary groups set-members -id g_1234567890 -member u_anon -member g_0987654321`,
 			"",
 			"",
 		})
@@ -82,9 +82,9 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 		return base.WrapForHelpText([]string{
 			"Usage: boundary groups remove-members [options] [args]",
 			"",
-			`  Removes members (users) from a group given its ID. The "user" flag can be specified multiple times. Example:`,
+			`  Removes members (users or groups) from a group given its ID. The "member" flag can be specified multiple times. Example:`,
 			"",
-			`    $ boundary groups remove-members -id g_1234567890 -user u_1234567890`,
+			`    $ boundary groups remove-members -id g_1234567890 -member u_1234567890`,
 			"",
 			"",
 		})
@@ -99,7 +99,7 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 			f.StringSliceVar(&base.StringSliceVar{
 				Name:   "member",
 				Target: &c.flagMembers,
-				Usage:  "The members (users) to add, remove, or set. May be specified multiple times.",
+				Usage:  "The members (users or groups) to add, remove, or set. May be specified multiple times.",
 			})
 		}
 	}
@@ -220,6 +220,20 @@ func printItemTable(item *groups.Group) string {
 		}
 	}
 
+	var effectiveMaps []map[string]interface{}
+	if len(item.EffectiveMembers) > 0 {
+		for _, member := range item.EffectiveMembers {
+			m := map[string]interface{}{
+				"ID":       member.Id,
+				"Scope ID": member.ScopeId,
+			}
+			effectiveMaps = append(effectiveMaps, m)
+		}
+		if l := len("Scope ID"); l > maxLength {
+			maxLength = l
+		}
+	}
+
 	ret := []string{
 		"",
 		"Group information:",
@@ -250,5 +264,18 @@ func printItemTable(item *groups.Group) string {
 		}
 	}
 
+	if len(item.EffectiveMembers) > 0 {
+		ret = append(ret,
+			"",
+			"  Effective Members:",
+		)
+		for _, m := range effectiveMaps {
+			ret = append(ret,
+				base.WrapMap(4, maxLength, m),
+				"",
+			)
+		}
+	}
+
 	return base.WrapForHelpText(ret)
 }
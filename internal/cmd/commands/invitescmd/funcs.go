@@ -0,0 +1,204 @@
+package invitescmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api/invites"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func init() {
+	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraFlagsFunc = extraFlagsFuncImpl
+	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+}
+
+type extraCmdVars struct {
+	flagRoleIds []string
+	flagTtl     time.Duration
+}
+
+func extraActionsFlagsMapFuncImpl() map[string][]string {
+	return map[string][]string{
+		"create": {"role-id", "ttl"},
+	}
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary invites [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary invite resources, which allow new users to create their own account in a password-type auth method. Example:",
+			"",
+			"    Create an invite adding the new user to a role:",
+			"",
+			`      $ boundary invites create -auth-method-id ampw_1234567890 -role-id r_1234567890`,
+			"",
+			"  Please see the invites subcommand help for detailed usage information.",
+		})
+
+	case "create":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary invites create [options] [args]",
+			"",
+			"  Create an invite for a password-type auth method. The token of the invite is only shown once and can be redeemed a single time with the auth-methods redeem-invite command. Example:",
+			"",
+			`    $ boundary invites create -auth-method-id ampw_1234567890 -role-id r_1234567890 -ttl 48h`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
+	for _, name := range flagsMap[c.Func] {
+		switch name {
+		case "role-id":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "role-id",
+				Target: &c.flagRoleIds,
+				Usage:  "The ID of a role the user created by redeeming the invite is added to. May be specified multiple times.",
+			})
+		case "ttl":
+			f.DurationVar(&base.DurationVar{
+				Name:   "ttl",
+				Target: &c.flagTtl,
+				Usage:  "How long until the invite expires. Defaults to 7 days.",
+			})
+		}
+	}
+}
+
+func extraFlagsHandlingFuncImpl(c *Command, opts *[]invites.Option) bool {
+	switch c.Func {
+	case "create":
+		if len(c.flagRoleIds) > 0 {
+			*opts = append(*opts, invites.WithRoleIds(c.flagRoleIds))
+		}
+		switch {
+		case c.flagTtl < 0:
+			c.UI.Error("The value of -ttl must not be negative")
+			return false
+		case c.flagTtl > 0:
+			*opts = append(*opts, invites.WithExpirationTime(time.Now().Add(c.flagTtl)))
+		}
+	}
+
+	return true
+}
+
+func (c *Command) printListTable(items []*invites.Invite) string {
+	if len(items) == 0 {
+		return "No invites found"
+	}
+
+	var output []string
+	output = []string{
+		"",
+		"Invite information:",
+	}
+	for i, m := range items {
+		if i > 0 {
+			output = append(output, "")
+		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("  ID:                    %s", m.Id),
+			)
+		}
+		if m.Name != "" {
+			output = append(output,
+				fmt.Sprintf("    Name:                %s", m.Name),
+			)
+		}
+		if m.Description != "" {
+			output = append(output,
+				fmt.Sprintf("    Description:         %s", m.Description),
+			)
+		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("    Expiration Time:     %s", m.ExpirationTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if !m.RedeemedTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Redeemed Time:       %s", m.RedeemedTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if len(m.AuthorizedActions) > 0 {
+			output = append(output,
+				"    Authorized Actions:",
+				base.WrapSlice(6, m.AuthorizedActions),
+			)
+		}
+	}
+
+	return base.WrapForHelpText(output)
+}
+
+func printItemTable(in *invites.Invite) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":              in.Id,
+		"Version":         in.Version,
+		"Auth Method ID":  in.AuthMethodId,
+		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Expiration Time": in.ExpirationTime.Local().Format(time.RFC1123),
+	}
+	if in.Name != "" {
+		nonAttributeMap["Name"] = in.Name
+	}
+	if in.Description != "" {
+		nonAttributeMap["Description"] = in.Description
+	}
+	if !in.RedeemedTime.IsZero() {
+		nonAttributeMap["Redeemed Time"] = in.RedeemedTime.Local().Format(time.RFC1123)
+	}
+	if in.AccountId != "" {
+		nonAttributeMap["Account ID"] = in.AccountId
+	}
+	if in.UserId != "" {
+		nonAttributeMap["User ID"] = in.UserId
+	}
+	if in.Token != "" {
+		nonAttributeMap["Token"] = in.Token
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Invite information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"",
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	if len(in.RoleIds) > 0 {
+		ret = append(ret,
+			"",
+			"  Role IDs:",
+			base.WrapSlice(4, in.RoleIds),
+		)
+	}
+
+	if len(in.AuthorizedActions) > 0 {
+		ret = append(ret,
+			"",
+			"  Authorized Actions:",
+			base.WrapSlice(4, in.AuthorizedActions),
+		)
+	}
+
+	return base.WrapForHelpText(ret)
+}
@@ -3,27 +3,168 @@ package scopescmd
 import (
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
 func init() {
 	extraActionsFlagsMapFunc = extraActionsFlagsMapFuncImpl
+	extraSynopsisFunc = extraSynopsisFuncImpl
 	extraFlagsFunc = extraFlagsFuncImpl
 	extraFlagsHandlingFunc = extraFlagsHandlingFuncImpl
+	executeExtraActions = executeExtraActionsImpl
+	printCustomActionOutput = printCustomActionOutputImpl
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"skip-admin-role-creation", "skip-default-role-creation"},
+		"create":                {"skip-admin-role-creation", "skip-default-role-creation", "auth-token-time-to-live", "auth-token-time-to-stale", "session-retention"},
+		"update":                {"auth-token-time-to-live", "auth-token-time-to-stale", "session-retention"},
+		"rotate-keys":           {"id"},
+		"reencrypt-key-version": {"id", "key-version-id"},
+		"destroy-key-version":   {"id", "key-version-id"},
+		"list-keys":             {"id"},
+		"list-changes":          {"id", "cursor", "filter-scope-id", "resource-type", "limit", "follow"},
+		"list-jobs":             {"id", "runs-limit"},
+		"run-job":               {"id", "job-name"},
 	}
 }
 
+func extraSynopsisFuncImpl(c *Command) string {
+	switch c.Func {
+	case "rotate-keys":
+		return "Rotate the encryption keys of a scope within Boundary"
+	case "reencrypt-key-version":
+		return "Re-encrypt the data encrypted with a key version of a scope within Boundary"
+	case "destroy-key-version":
+		return "Destroy a key version of a scope within Boundary"
+	case "list-keys":
+		return "List the encryption keys of a scope within Boundary"
+	case "list-changes":
+		return "List the changes recorded in the oplog of Boundary"
+	case "list-jobs":
+		return "List the jobs run by the controllers of Boundary"
+	case "run-job":
+		return "Run a job of the controllers of Boundary now"
+	}
+
+	return ""
+}
+
+func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
+	var helpStr string
+	switch c.Func {
+	case "rotate-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes rotate-keys [options] [args]",
+			"",
+			"  Creates new versions of the root key and data encryption keys of a scope given its ID. The new versions are used to encrypt data from then on, while the previous versions remain available to decrypt existing data. Example:",
+			"",
+			`    $ boundary scopes rotate-keys -id o_1234567890`,
+			"",
+			"",
+		})
+
+	case "reencrypt-key-version":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes reencrypt-key-version [options] [args]",
+			"",
+			"  Starts re-encrypting the data encrypted with a previous version of a data encryption key of a scope with the current version, and shows the progress of the re-encryption. The data is re-encrypted in the background by the controllers; run the command again to see the progress of a re-encryption already started. Once it has completed the key version can be destroyed with the destroy-key-version command. Example:",
+			"",
+			`    $ boundary scopes reencrypt-key-version -id o_1234567890 -key-version-id kdkv_1234567890`,
+			"",
+			"",
+		})
+
+	case "destroy-key-version":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes destroy-key-version [options] [args]",
+			"",
+			"  Destroys a previous version of the root key or of a data encryption key of a scope. The destruction is refused while any data is still encrypted with a data encryption key version, or while any data encryption key version is still encrypted with a root key version. Example:",
+			"",
+			`    $ boundary scopes destroy-key-version -id o_1234567890 -key-version-id kdkv_1234567890`,
+			"",
+			"",
+		})
+
+	case "list-keys":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-keys [options] [args]",
+			"",
+			"  Lists the root key and data encryption keys of a scope given its ID, with their versions and the number of rows encrypted with each version. Example:",
+			"",
+			`    $ boundary scopes list-keys -id o_1234567890`,
+			"",
+			"",
+		})
+
+	case "list-changes":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-changes [options] [args]",
+			"",
+			"  Lists the changes recorded in the oplog after a cursor, which is the ID of the last change received, in the order they were recorded. Encrypted and hashed values are omitted from the changes. Changes can only be listed in the global scope. Example:",
+			"",
+			`    $ boundary scopes list-changes -id global -cursor 1234 -filter-scope-id o_1234567890 -resource-type target`,
+			"",
+			"  With -follow, the command keeps waiting for new changes and outputs them as they are recorded until it is interrupted; in JSON format each change is output on its own line. Example:",
+			"",
+			`    $ boundary scopes list-changes -id global -follow -format json`,
+			"",
+			"",
+		})
+
+	case "list-jobs":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes list-jobs [options] [args]",
+			"",
+			"  Lists the jobs run by the scheduler of the controllers, with the time they are next due to run and their most recent runs, including which controller ran them, how long they took and the errors of failed runs. Jobs can only be listed in the global scope. Example:",
+			"",
+			`    $ boundary scopes list-jobs -id global -runs-limit 10`,
+			"",
+			"",
+		})
+
+	case "run-job":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary scopes run-job [options] [args]",
+			"",
+			"  Makes a job due to run now, so it is run by the next controller looking for due jobs. A running job is run again once its current run ends. Jobs can only be run in the global scope. Example:",
+			"",
+			`    $ boundary scopes run-job -id global -job-name retention`,
+			"",
+			"",
+		})
+
+	default:
+		helpStr = helpMap["base"]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
 type extraCmdVars struct {
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
+	flagAuthTokenTimeToLive     string
+	flagAuthTokenTimeToStale    string
+	flagSessionRetention        string
+	flagKeyVersionId            string
+	keyVersionReencryption      *scopes.KeyVersionReencryptionResult
+	keys                        *scopes.KeyListResult
+	flagCursor                  uint
+	flagFilterScopeIds          []string
+	flagResourceTypes           []string
+	flagLimit                   uint
+	flagFollow                  bool
+	changes                     *scopes.ChangeListResult
+	flagRunsLimit               uint
+	flagJobName                 string
+	jobs                        *scopes.JobListResult
+	job                         *scopes.JobRunResult
 }
 
 func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
@@ -41,6 +182,72 @@ func extraFlagsFuncImpl(c *Command, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagSkipDefaultRoleCreation,
 				Usage:  "If set, a role granting the anonymous user access to log into auth methods and a few other actions within the newly-created scope will not automatically be created",
 			})
+		case "auth-token-time-to-live":
+			f.StringVar(&base.StringVar{
+				Name:   "auth-token-time-to-live",
+				Target: &c.flagAuthTokenTimeToLive,
+				Usage:  "The default total lifetime of the auth tokens issued by the auth methods in the scope and its child scopes. Can be specified as an integer number of seconds or a duration string.",
+			})
+		case "auth-token-time-to-stale":
+			f.StringVar(&base.StringVar{
+				Name:   "auth-token-time-to-stale",
+				Target: &c.flagAuthTokenTimeToStale,
+				Usage:  "The default time the auth tokens issued by the auth methods in the scope and its child scopes can go unused before becoming invalid. Can be specified as an integer number of seconds or a duration string.",
+			})
+		case "session-retention":
+			f.StringVar(&base.StringVar{
+				Name:   "session-retention",
+				Target: &c.flagSessionRetention,
+				Usage:  "The time terminated sessions in the scope and its child scopes are retained for once their warehouse facts are final. Can be specified as an integer number of seconds or a duration string.",
+			})
+		case "key-version-id":
+			f.StringVar(&base.StringVar{
+				Name:   "key-version-id",
+				Target: &c.flagKeyVersionId,
+				Usage:  "The ID of the key version.",
+			})
+		case "cursor":
+			f.UintVar(&base.UintVar{
+				Name:   "cursor",
+				Target: &c.flagCursor,
+				Usage:  "The ID of the last change received. Only changes recorded after it are listed. If not set, changes are listed from the first one recorded.",
+			})
+		case "filter-scope-id":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "filter-scope-id",
+				Target: &c.flagFilterScopeIds,
+				Usage:  "Only list the changes made in the scope with this ID. May be specified multiple times.",
+			})
+		case "resource-type":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "resource-type",
+				Target: &c.flagResourceTypes,
+				Usage:  "Only list the changes made to resources of this type. May be specified multiple times.",
+			})
+		case "limit":
+			f.UintVar(&base.UintVar{
+				Name:   "limit",
+				Target: &c.flagLimit,
+				Usage:  "The maximum number of changes to list. If not set, the controller's default is used.",
+			})
+		case "follow":
+			f.BoolVar(&base.BoolVar{
+				Name:   "follow",
+				Target: &c.flagFollow,
+				Usage:  "If set, keep waiting for new changes and output them as they are recorded.",
+			})
+		case "job-name":
+			f.StringVar(&base.StringVar{
+				Name:   "job-name",
+				Target: &c.flagJobName,
+				Usage:  "The name of the job.",
+			})
+		case "runs-limit":
+			f.UintVar(&base.UintVar{
+				Name:   "runs-limit",
+				Target: &c.flagRunsLimit,
+				Usage:  "The maximum number of runs to list for each job, from the most recent. If not set, the controller's default is used.",
+			})
 		}
 	}
 }
@@ -53,9 +260,410 @@ func extraFlagsHandlingFuncImpl(c *Command, opts *[]scopes.Option) bool {
 		*opts = append(*opts, scopes.WithSkipDefaultRoleCreation(c.flagSkipDefaultRoleCreation))
 	}
 
+	switch c.Func {
+	case "reencrypt-key-version", "destroy-key-version":
+		if c.flagKeyVersionId == "" {
+			c.UI.Error("Key version ID must be passed in via -key-version-id")
+			return false
+		}
+	case "run-job":
+		if c.flagJobName == "" {
+			c.UI.Error("Job name must be passed in via -job-name")
+			return false
+		}
+	}
+
+	switch c.flagAuthTokenTimeToLive {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultAuthTokenTimeToLiveSeconds())
+	default:
+		secs, err := parseSeconds(c.flagAuthTokenTimeToLive)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToLive, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithAuthTokenTimeToLiveSeconds(secs))
+	}
+
+	switch c.flagAuthTokenTimeToStale {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultAuthTokenTimeToStaleSeconds())
+	default:
+		secs, err := parseSeconds(c.flagAuthTokenTimeToStale)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAuthTokenTimeToStale, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithAuthTokenTimeToStaleSeconds(secs))
+	}
+
+	switch c.flagSessionRetention {
+	case "":
+	case "null":
+		*opts = append(*opts, scopes.DefaultSessionRetentionSeconds())
+	default:
+		secs, err := parseSeconds(c.flagSessionRetention)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagSessionRetention, err))
+			return false
+		}
+		*opts = append(*opts, scopes.WithSessionRetentionSeconds(secs))
+	}
+
 	return true
 }
 
+func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError error, scopeClient *scopes.Client, version uint32, opts []scopes.Option) (api.GenericResult, error) {
+	switch c.Func {
+	case "rotate-keys":
+		return scopeClient.RotateKeys(c.Context, c.FlagId, opts...)
+	case "reencrypt-key-version":
+		var err error
+		c.keyVersionReencryption, err = scopeClient.ReencryptKeyVersion(c.Context, c.FlagId, c.flagKeyVersionId, opts...)
+		return nil, err
+	case "destroy-key-version":
+		return scopeClient.DestroyKeyVersion(c.Context, c.FlagId, c.flagKeyVersionId, opts...)
+	case "list-keys":
+		var err error
+		c.keys, err = scopeClient.ListKeys(c.Context, c.FlagId, opts...)
+		return nil, err
+	case "list-changes":
+		filter := scopes.ChangeFilter{
+			ScopeIds:      c.flagFilterScopeIds,
+			ResourceTypes: c.flagResourceTypes,
+			Limit:         uint32(c.flagLimit),
+		}
+		if c.flagFollow {
+			return nil, c.followChanges(scopeClient, filter, opts)
+		}
+		var err error
+		c.changes, err = scopeClient.ListChanges(c.Context, c.FlagId, uint32(c.flagCursor), filter, opts...)
+		return nil, err
+	case "list-jobs":
+		var err error
+		c.jobs, err = scopeClient.ListJobs(c.Context, c.FlagId, uint32(c.flagRunsLimit), opts...)
+		return nil, err
+	case "run-job":
+		var err error
+		c.job, err = scopeClient.RunJob(c.Context, c.FlagId, c.flagJobName, opts...)
+		return nil, err
+	}
+	return origResult, origError
+}
+
+func printCustomActionOutputImpl(c *Command) (bool, error) {
+	switch c.Func {
+	case "reencrypt-key-version":
+		item := c.keyVersionReencryption.Item
+		switch base.Format(c.UI) {
+		case "table":
+			nonAttributeMap := map[string]interface{}{
+				"Key Version ID": item.KeyVersionId,
+				"Scope ID":       item.ScopeId,
+				"Purpose":        item.Purpose,
+				"Created Time":   item.CreatedTime.Local().Format(time.RFC1123),
+				"Updated Time":   item.UpdatedTime.Local().Format(time.RFC1123),
+				"Completed":      item.Completed,
+			}
+			maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+			ret := []string{
+				"",
+				"Key version re-encryption information:",
+				base.WrapMap(2, maxLength, nonAttributeMap),
+			}
+			if len(item.Tables) > 0 {
+				ret = append(ret, "", "  Tables:")
+				for _, t := range item.Tables {
+					ret = append(ret,
+						fmt.Sprintf("    %s:", t.Name),
+						fmt.Sprintf("      Total Count:     %d", t.TotalCount),
+						fmt.Sprintf("      Remaining Count: %d", t.RemainingCount),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(ret))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.keyVersionReencryption)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "list-keys":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printKeysTable(c.keys.Items))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.keys.Items)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "list-changes":
+		if c.flagFollow {
+			// the changes have already been output as they were received
+			return true, nil
+		}
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printChangesTable(c.changes.Items))
+			if len(c.changes.Items) > 0 {
+				c.UI.Output(fmt.Sprintf("\nCursor: %d", c.changes.Cursor))
+			}
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(struct {
+				Items  []*scopes.Change `json:"items"`
+				Cursor uint32           `json:"cursor"`
+			}{
+				Items:  c.changes.Items,
+				Cursor: c.changes.Cursor,
+			})
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "list-jobs":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printJobsTable(c.jobs.Items))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.jobs.Items)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+
+	case "run-job":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(printJobsTable([]*scopes.Job{c.job.Item}))
+			return true, nil
+
+		case "json":
+			b, err := base.JsonFormatter{}.Format(c.job.Item)
+			if err != nil {
+				return false, fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// followChanges outputs the changes recorded after the cursor as they are
+// received until the command is interrupted.
+func (c *Command) followChanges(scopeClient *scopes.Client, filter scopes.ChangeFilter, opts []scopes.Option) error {
+	feed := scopes.NewChangeFeed(scopeClient, c.FlagId, uint32(c.flagCursor), filter, 0, opts...)
+	for {
+		change, err := feed.Next(c.Context)
+		if err != nil {
+			if c.Context.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := base.JsonFormatter{}.Format(change)
+			if err != nil {
+				return fmt.Errorf("Error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+		default:
+			c.UI.Output(printChangesTable([]*scopes.Change{change}))
+		}
+	}
+}
+
+func printChangesTable(changes []*scopes.Change) string {
+	if len(changes) == 0 {
+		return "No changes found"
+	}
+	output := []string{
+		"",
+		"Change information:",
+	}
+	for i, ch := range changes {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                    %d", ch.Id),
+			fmt.Sprintf("    Created Time:        %s", ch.CreatedTime.Local().Format(time.RFC1123)),
+		)
+		if ch.ScopeId != "" {
+			output = append(output,
+				fmt.Sprintf("    Scope ID:            %s", ch.ScopeId),
+			)
+		}
+		if ch.ResourceType != "" {
+			output = append(output,
+				fmt.Sprintf("    Resource Type:       %s", ch.ResourceType),
+			)
+		}
+		if ch.ResourceId != "" {
+			output = append(output,
+				fmt.Sprintf("    Resource ID:         %s", ch.ResourceId),
+			)
+		}
+		if ch.Operation != "" {
+			output = append(output,
+				fmt.Sprintf("    Operation:           %s", ch.Operation),
+			)
+		}
+		if len(ch.Messages) > 0 {
+			output = append(output, "    Messages:")
+			for _, m := range ch.Messages {
+				output = append(output,
+					fmt.Sprintf("      %s: %s", m.Type, m.Operation),
+				)
+				if len(m.FieldMaskPaths) > 0 {
+					output = append(output,
+						fmt.Sprintf("        Fields:          %s", strings.Join(m.FieldMaskPaths, ", ")),
+					)
+				}
+				if len(m.SetToNullPaths) > 0 {
+					output = append(output,
+						fmt.Sprintf("        Set To Null:     %s", strings.Join(m.SetToNullPaths, ", ")),
+					)
+				}
+			}
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+func printJobsTable(jobs []*scopes.Job) string {
+	if len(jobs) == 0 {
+		return "No jobs found"
+	}
+	output := []string{
+		"",
+		"Job information:",
+	}
+	for i, j := range jobs {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Name:                  %s", j.Name),
+			fmt.Sprintf("    Description:         %s", j.Description),
+			fmt.Sprintf("    Next Scheduled Run:  %s", j.NextScheduledRun.Local().Format(time.RFC1123)),
+		)
+		if len(j.Runs) > 0 {
+			output = append(output, "    Runs:")
+		}
+		for _, r := range j.Runs {
+			output = append(output,
+				fmt.Sprintf("      ID:                %d", r.Id),
+				fmt.Sprintf("        Controller:      %s", r.Controller),
+				fmt.Sprintf("        Status:          %s", r.Status),
+				fmt.Sprintf("        Start Time:      %s", r.StartTime.Local().Format(time.RFC1123)),
+			)
+			if !r.EndTime.IsZero() {
+				output = append(output,
+					fmt.Sprintf("        End Time:        %s", r.EndTime.Local().Format(time.RFC1123)),
+				)
+			}
+			if r.Duration != "" {
+				output = append(output,
+					fmt.Sprintf("        Duration:        %s", r.Duration),
+				)
+			}
+			if r.Error != "" {
+				output = append(output,
+					fmt.Sprintf("        Error:           %s", r.Error),
+				)
+			}
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+func printKeysTable(keys []*scopes.Key) string {
+	if len(keys) == 0 {
+		return "No keys found"
+	}
+	output := []string{
+		"",
+		"Key information:",
+	}
+	for i, k := range keys {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                    %s", k.Id),
+			fmt.Sprintf("    Type:                %s", k.Type),
+		)
+		if k.Purpose != "" {
+			output = append(output,
+				fmt.Sprintf("    Purpose:             %s", k.Purpose),
+			)
+		}
+		output = append(output,
+			fmt.Sprintf("    Created Time:        %s", k.CreatedTime.Local().Format(time.RFC1123)),
+			"    Versions:",
+		)
+		for _, v := range k.Versions {
+			output = append(output,
+				fmt.Sprintf("      ID:                %s", v.Id),
+				fmt.Sprintf("        Version:         %d", v.Version),
+				fmt.Sprintf("        Current:         %t", v.Current),
+				fmt.Sprintf("        Created Time:    %s", v.CreatedTime.Local().Format(time.RFC1123)),
+			)
+			if len(v.ReferenceCounts) > 0 {
+				tables := make([]string, 0, len(v.ReferenceCounts))
+				for t := range v.ReferenceCounts {
+					tables = append(tables, t)
+				}
+				sort.Strings(tables)
+				output = append(output, "        Reference Counts:")
+				for _, t := range tables {
+					output = append(output,
+						fmt.Sprintf("          %s: %d", t, v.ReferenceCounts[t]),
+					)
+				}
+			}
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+// parseSeconds parses an integer number of seconds or a duration string.
+func parseSeconds(in string) (uint32, error) {
+	secs, err := strconv.ParseUint(in, 10, 32)
+	if err == nil {
+		return uint32(secs), nil
+	}
+	dur, err := time.ParseDuration(in)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(dur.Seconds()), nil
+}
+
 func (c *Command) printListTable(items []*scopes.Scope) string {
 	if len(items) == 0 {
 		return "No child scopes found"
@@ -119,6 +727,15 @@ func printItemTable(in *scopes.Scope) string {
 	if in.Description != "" {
 		nonAttributeMap["Description"] = in.Description
 	}
+	if in.AuthTokenTimeToLiveSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Live Seconds"] = in.AuthTokenTimeToLiveSeconds
+	}
+	if in.AuthTokenTimeToStaleSeconds != 0 {
+		nonAttributeMap["Auth Token Time To Stale Seconds"] = in.AuthTokenTimeToStaleSeconds
+	}
+	if in.SessionRetentionSeconds != 0 {
+		nonAttributeMap["Session Retention Seconds"] = in.SessionRetentionSeconds
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -406,13 +406,19 @@ func (c *Command) Run(args []string) int {
 			return base.CommandCliError
 		}
 		if ckState.BinarySchemaVersion > ckState.DatabaseSchemaVersion {
-			c.UI.Error(base.WrapAtLength("Database schema must be updated to use this version. Run 'boundary database migrate' to update the database. NOTE: Boundary does not currently support live migration; ensure all controllers are shut down before running the migration command."))
+			c.UI.Error(base.WrapAtLength(fmt.Sprintf("Database schema version (%d) "+
+				"is older than this binary expects (%d), %d migrations are pending. "+
+				"Run 'boundary database status' to list them and 'boundary database migrate' "+
+				"to update the database. NOTE: Boundary does not currently support live "+
+				"migration; ensure all controllers are shut down before running the migration command.",
+				ckState.DatabaseSchemaVersion, ckState.BinarySchemaVersion,
+				len(schema.PendingMigrations("postgres", ckState.DatabaseSchemaVersion)))))
 			return base.CommandCliError
 		}
 		if ckState.BinarySchemaVersion < ckState.DatabaseSchemaVersion {
-			c.UI.Error(base.WrapAtLength(fmt.Sprintf("Newer schema version (%d) "+
-				"than this binary expects. Please use a newer version of the boundary "+
-				"binary.", ckState.DatabaseSchemaVersion)))
+			c.UI.Error(base.WrapAtLength(fmt.Sprintf("Database schema version (%d) "+
+				"is newer than this binary expects (%d). Please use a newer version "+
+				"of the boundary binary.", ckState.DatabaseSchemaVersion, ckState.BinarySchemaVersion)))
 			return base.CommandCliError
 		}
 		if err := c.verifyKmsSetup(); err != nil {
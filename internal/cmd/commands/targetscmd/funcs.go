@@ -347,6 +347,19 @@ func printItemTable(item *targets.Target) string {
 	if item.WorkerFilter != "" {
 		nonAttributeMap["Worker Filter"] = item.WorkerFilter
 	}
+	if item.AccessWindowSchedule != "" {
+		nonAttributeMap["Access Window Schedule"] = item.AccessWindowSchedule
+		nonAttributeMap["Access Window Duration Seconds"] = item.AccessWindowDurationSeconds
+	}
+	if item.AccessWindowTimeZone != "" {
+		nonAttributeMap["Access Window Time Zone"] = item.AccessWindowTimeZone
+	}
+	if len(item.AllowedClientCidrs) > 0 {
+		nonAttributeMap["Allowed Client CIDRs"] = item.AllowedClientCidrs
+	}
+	if len(item.DeniedClientCidrs) > 0 {
+		nonAttributeMap["Denied Client CIDRs"] = item.DeniedClientCidrs
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, item.Attributes, keySubstMap)
 
@@ -18,8 +18,8 @@ func init() {
 
 func extraTcpActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
-		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
-		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter"},
+		"create": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "allowed-client-cidr", "denied-client-cidr", "access-window-schedule", "access-window-duration", "access-window-time-zone"},
+		"update": {"default-port", "session-max-seconds", "session-connection-limit", "worker-filter", "allowed-client-cidr", "denied-client-cidr", "access-window-schedule", "access-window-duration", "access-window-time-zone"},
 	}
 }
 
@@ -28,6 +28,11 @@ type extraTcpCmdVars struct {
 	flagSessionMaxSeconds      string
 	flagSessionConnectionLimit string
 	flagWorkerFilter           string
+	flagAllowedClientCidrs     []string
+	flagDeniedClientCidrs      []string
+	flagAccessWindowSchedule   string
+	flagAccessWindowDuration   string
+	flagAccessWindowTimeZone   string
 }
 
 func (c *TcpCommand) extraTcpHelpFunc(helpMap map[string]func() string) string {
@@ -87,6 +92,36 @@ func extraTcpFlagsFuncImpl(c *TcpCommand, set *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagWorkerFilter,
 				Usage:  "A boolean expression to filter which workers can handle sessions for this target.",
 			})
+		case "allowed-client-cidr":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "allowed-client-cidr",
+				Target: &c.flagAllowedClientCidrs,
+				Usage:  `A CIDR block or IP address clients must authorize sessions and connect from. May be specified multiple times. If unset, clients can connect from any address which is not denied. On update, replaces all allowed CIDR blocks; use "null" to remove them.`,
+			})
+		case "denied-client-cidr":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "denied-client-cidr",
+				Target: &c.flagDeniedClientCidrs,
+				Usage:  `A CIDR block or IP address clients can not authorize sessions or connect from. May be specified multiple times. Takes precedence over the allowed CIDR blocks. On update, replaces all denied CIDR blocks; use "null" to remove them.`,
+			})
+		case "access-window-schedule":
+			f.StringVar(&base.StringVar{
+				Name:   "access-window-schedule",
+				Target: &c.flagAccessWindowSchedule,
+				Usage:  `A cron expression, e.g. "0 9 * * mon-fri", for the start of the windows during which sessions can be authorized and used. Sessions end when the window closes.`,
+			})
+		case "access-window-duration":
+			f.StringVar(&base.StringVar{
+				Name:   "access-window-duration",
+				Target: &c.flagAccessWindowDuration,
+				Usage:  "How long each access window stays open. Can be specified as an integer number of seconds or a duration string.",
+			})
+		case "access-window-time-zone":
+			f.StringVar(&base.StringVar{
+				Name:   "access-window-time-zone",
+				Target: &c.flagAccessWindowTimeZone,
+				Usage:  `The IANA time zone, e.g. "America/New_York", the access window schedule is evaluated in. Defaults to UTC.`,
+			})
 		}
 	}
 }
@@ -150,5 +185,57 @@ func extraTcpFlagsHandlingFuncImpl(c *TcpCommand, opts *[]targets.Option) bool {
 		*opts = append(*opts, targets.WithWorkerFilter(c.flagWorkerFilter))
 	}
 
+	switch {
+	case len(c.flagAllowedClientCidrs) == 0:
+	case len(c.flagAllowedClientCidrs) == 1 && c.flagAllowedClientCidrs[0] == "null":
+		*opts = append(*opts, targets.DefaultAllowedClientCidrs())
+	default:
+		*opts = append(*opts, targets.WithAllowedClientCidrs(c.flagAllowedClientCidrs))
+	}
+
+	switch {
+	case len(c.flagDeniedClientCidrs) == 0:
+	case len(c.flagDeniedClientCidrs) == 1 && c.flagDeniedClientCidrs[0] == "null":
+		*opts = append(*opts, targets.DefaultDeniedClientCidrs())
+	default:
+		*opts = append(*opts, targets.WithDeniedClientCidrs(c.flagDeniedClientCidrs))
+	}
+
+	switch c.flagAccessWindowSchedule {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAccessWindowSchedule())
+	default:
+		*opts = append(*opts, targets.WithAccessWindowSchedule(c.flagAccessWindowSchedule))
+	}
+
+	switch c.flagAccessWindowDuration {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAccessWindowDurationSeconds())
+	default:
+		var final uint32
+		dur, err := strconv.ParseUint(c.flagAccessWindowDuration, 10, 32)
+		if err == nil {
+			final = uint32(dur)
+		} else {
+			dur, err := time.ParseDuration(c.flagAccessWindowDuration)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error parsing %q: %s", c.flagAccessWindowDuration, err))
+				return false
+			}
+			final = uint32(dur.Seconds())
+		}
+		*opts = append(*opts, targets.WithAccessWindowDurationSeconds(final))
+	}
+
+	switch c.flagAccessWindowTimeZone {
+	case "":
+	case "null":
+		*opts = append(*opts, targets.DefaultAccessWindowTimeZone())
+	default:
+		*opts = append(*opts, targets.WithAccessWindowTimeZone(c.flagAccessWindowTimeZone))
+	}
+
 	return true
 }
@@ -20,14 +20,18 @@ func init() {
 }
 
 type extraCmdVars struct {
-	flagAccounts []string
+	flagAccounts       []string
+	flagServiceAccount bool
 }
 
 func extraActionsFlagsMapFuncImpl() map[string][]string {
 	return map[string][]string{
+		"create":          {"service-account"},
 		"add-accounts":    {"id", "account", "version"},
 		"set-accounts":    {"id", "account", "version"},
 		"remove-accounts": {"id", "account", "version"},
+		"disable":         {"id"},
+		"enable":          {"id"},
 	}
 }
 
@@ -44,6 +48,10 @@ func extraSynopsisFuncImpl(c *Command) string {
 			in = "Remove accounts from"
 		}
 		return wordwrap.WrapString(fmt.Sprintf("%s a user within Boundary", in), base.TermWidth)
+	case "disable":
+		return "Disable a user within Boundary, revoking its auth tokens and sessions"
+	case "enable":
+		return "Enable a disabled user within Boundary"
 	}
 
 	return ""
@@ -85,6 +93,28 @@ func (c *Command) extraHelpFunc(helpMap map[string]func() string) string {
 			"",
 		})
 
+	case "disable":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users disable [options] [args]",
+			"",
+			"  Disables a user given its ID. A disabled user cannot authenticate, its auth tokens are deleted and its pending and active sessions are canceled. Example:",
+			"",
+			`    $ boundary users disable -id u_1234567890`,
+			"",
+			"",
+		})
+
+	case "enable":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary users enable [options] [args]",
+			"",
+			"  Enables a user which was disabled given its ID, allowing it to authenticate again. Example:",
+			"",
+			`    $ boundary users enable -id u_1234567890`,
+			"",
+			"",
+		})
+
 	default:
 		helpStr = helpMap["base"]()
 	}
@@ -100,12 +130,23 @@ func extraFlagsFuncImpl(c *Command, _ *base.FlagSets, f *base.FlagSet) {
 				Target: &c.flagAccounts,
 				Usage:  "The accounts to add, remove, or set. May be specified multiple times.",
 			})
+		case "service-account":
+			f.BoolVar(&base.BoolVar{
+				Name:   "service-account",
+				Target: &c.flagServiceAccount,
+				Usage:  "If set, the user is created as a service account. Service accounts cannot have accounts and authenticate with API tokens instead. This cannot be changed after creation.",
+			})
 		}
 	}
 }
 
 func extraFlagsHandlingFuncImpl(c *Command, opts *[]users.Option) bool {
 	switch c.Func {
+	case "create":
+		if c.flagServiceAccount {
+			*opts = append(*opts, users.WithServiceAccount(true))
+		}
+
 	case "add-accounts", "remove-accounts":
 		if len(c.flagAccounts) == 0 {
 			c.UI.Error("No accounts supplied via -account")
@@ -135,6 +176,10 @@ func executeExtraActionsImpl(c *Command, origResult api.GenericResult, origError
 		return userClient.SetAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
 	case "remove-accounts":
 		return userClient.RemoveAccounts(c.Context, c.FlagId, version, c.flagAccounts, opts...)
+	case "disable":
+		return userClient.Disable(c.Context, c.FlagId, opts...)
+	case "enable":
+		return userClient.Enable(c.Context, c.FlagId, opts...)
 	}
 	return origResult, origError
 }
@@ -179,6 +224,16 @@ func (c *Command) printListTable(items []*users.User) string {
 				fmt.Sprintf("    Description:         %s", u.Description),
 			)
 		}
+		if u.ServiceAccount {
+			output = append(output,
+				fmt.Sprintf("    Service Account:     %t", u.ServiceAccount),
+			)
+		}
+		if true {
+			output = append(output,
+				fmt.Sprintf("    Active:              %t", u.Active),
+			)
+		}
 		if len(u.AuthorizedActions) > 0 {
 			output = append(output,
 				"    Authorized Actions:",
@@ -196,6 +251,7 @@ func printItemTable(in *users.User) string {
 		"Version":      in.Version,
 		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
 		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+		"Active":       in.Active,
 	}
 
 	if in.Name != "" {
@@ -204,6 +260,9 @@ func printItemTable(in *users.User) string {
 	if in.Description != "" {
 		nonAttributeMap["Description"] = in.Description
 	}
+	if in.ServiceAccount {
+		nonAttributeMap["Service Account"] = in.ServiceAccount
+	}
 
 	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
 
@@ -27,9 +27,11 @@ func SynopsisFunc(inFunc, resType string) string {
 func HelpMap(resType string) map[string]func() string {
 	prefixMap := map[string]string{
 		resource.Scope.String():       "o",
+		resource.ApiToken.String():    "apt",
 		resource.AuthToken.String():   "at",
 		resource.AuthMethod.String():  "am",
 		resource.Account.String():     "a",
+		resource.Invite.String():      "ipw",
 		resource.Role.String():        "r",
 		resource.Group.String():       "g",
 		resource.User.String():        "u",
@@ -107,14 +107,39 @@ type Controller struct {
 	Database          *Database `hcl:"database"`
 	PublicClusterAddr string    `hcl:"public_cluster_addr"`
 
-	// AuthTokenTimeToLive is the total valid lifetime of a token denoted by time.Duration
+	// AuthTokenTimeToLive is the total valid lifetime of a token denoted by time.Duration.
+	// It applies unless overridden by the token's auth method or its scopes.
 	AuthTokenTimeToLive         interface{} `hcl:"auth_token_time_to_live"`
 	AuthTokenTimeToLiveDuration time.Duration
 
 	// AuthTokenTimeToStale is the total time a token can go unused before becoming invalid
-	// denoted by time.Duration
+	// denoted by time.Duration. It applies unless overridden by the token's auth method or
+	// its scopes.
 	AuthTokenTimeToStale         interface{} `hcl:"auth_token_time_to_stale"`
 	AuthTokenTimeToStaleDuration time.Duration
+
+	// MaxSessionsPerUserPerTarget, MaxSessionsPerUserPerScope and
+	// MaxSessionsPerTarget are the maximum numbers of active sessions a user
+	// can have for a target, a user can have for the targets in a scope, and
+	// all users can have for a target.  Zero means there is no maximum.
+	MaxSessionsPerUserPerTarget uint32 `hcl:"max_sessions_per_user_per_target"`
+	MaxSessionsPerUserPerScope  uint32 `hcl:"max_sessions_per_user_per_scope"`
+	MaxSessionsPerTarget        uint32 `hcl:"max_sessions_per_target"`
+
+	// SessionRetention is the time terminated sessions are retained for once
+	// their warehouse facts are final, denoted by time.Duration. It applies
+	// unless overridden by the session's scopes. Zero means sessions are
+	// retained forever.
+	SessionRetention         interface{} `hcl:"session_retention"`
+	SessionRetentionDuration time.Duration
+
+	// OplogRetention is the time oplog entries are retained for, denoted by
+	// time.Duration, after which they are deleted or archived according to
+	// OplogRetentionAction, which is "archive" by default. Zero means entries
+	// are retained forever.
+	OplogRetention         interface{} `hcl:"oplog_retention"`
+	OplogRetentionDuration time.Duration
+	OplogRetentionAction   string `hcl:"oplog_retention_action"`
 }
 
 type Worker struct {
@@ -256,6 +281,28 @@ func Parse(d string) (*Config, error) {
 			}
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
+
+		if result.Controller.SessionRetention != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.SessionRetention)
+			if err != nil {
+				return result, err
+			}
+			result.Controller.SessionRetentionDuration = t
+		}
+
+		if result.Controller.OplogRetention != "" {
+			t, err := parseutil.ParseDurationSecond(result.Controller.OplogRetention)
+			if err != nil {
+				return result, err
+			}
+			result.Controller.OplogRetentionDuration = t
+		}
+
+		switch result.Controller.OplogRetentionAction {
+		case "", "archive", "delete":
+		default:
+			return nil, fmt.Errorf("Controller oplog retention action must be %q or %q", "archive", "delete")
+		}
 	}
 
 	// Parse worker tags
@@ -94,15 +94,29 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"apitokens": {
+		{
+			ResourceType:        resource.ApiToken.String(),
+			Pkg:                 "apitokens",
+			StdActions:          []string{"create", "read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			Container:           "Scope",
+			HasId:               true,
+			HasName:             true,
+			HasDescription:      true,
+		},
+	},
 	"authmethods": {
 		{
-			ResourceType:     resource.AuthMethod.String(),
-			Pkg:              "authmethods",
-			StdActions:       []string{"read", "delete", "list"},
-			IsAbstractType:   true,
-			HasExtraHelpFunc: true,
-			Container:        "Scope",
-			HasId:            true,
+			ResourceType:        resource.AuthMethod.String(),
+			Pkg:                 "authmethods",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			IsAbstractType:      true,
+			HasExtraHelpFunc:    true,
+			Container:           "Scope",
+			HasId:               true,
 		},
 		{
 			ResourceType:         resource.AuthMethod.String(),
@@ -122,11 +136,13 @@ var inputStructs = map[string][]*cmdInfo{
 	},
 	"authtokens": {
 		{
-			ResourceType: resource.AuthToken.String(),
-			Pkg:          "authtokens",
-			StdActions:   []string{"read", "delete", "list"},
-			Container:    "Scope",
-			HasId:        true,
+			ResourceType:        resource.AuthToken.String(),
+			Pkg:                 "authtokens",
+			StdActions:          []string{"read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			Container:           "Scope",
+			HasId:               true,
 		},
 	},
 	"groups": {
@@ -223,6 +239,19 @@ var inputStructs = map[string][]*cmdInfo{
 			VersionedActions:    []string{"update"},
 		},
 	},
+	"invites": {
+		{
+			ResourceType:        resource.Invite.String(),
+			Pkg:                 "invites",
+			StdActions:          []string{"create", "read", "delete", "list"},
+			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
+			Container:           "AuthMethod",
+			HasId:               true,
+			HasName:             true,
+			HasDescription:      true,
+		},
+	},
 	"roles": {
 		{
 			ResourceType:        resource.Role.String(),
@@ -243,6 +272,7 @@ var inputStructs = map[string][]*cmdInfo{
 			Pkg:                 "scopes",
 			StdActions:          []string{"create", "read", "update", "delete", "list"},
 			HasExtraCommandVars: true,
+			HasExtraHelpFunc:    true,
 			HasId:               true,
 			Container:           "Scope",
 			HasName:             true,
@@ -0,0 +1,379 @@
+// Package backup exports Boundary's resources into an archive encrypted with
+// the root kms wrapper, and restores them into an empty database.
+//
+// The archive contains the rows of the scope, iam, auth, host, target and kms
+// tables.  Sessions, auth tokens, workers, jobs, the oplog and the warehouse
+// are not exported.  The kms tables contain the data encryption keys
+// encrypted by the root keys, which are themselves encrypted by the root kms
+// wrapper, so an archive can only be used with the root kms it was created
+// with.
+//
+// An archive records the schema version of the database it was created from
+// and can only be restored into a database with the same schema version.  The
+// rows are restored with triggers disabled so they are written as they were
+// exported, which requires the database user to be allowed to set the
+// session_replication_role.
+package backup
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// FormatVersion is the version of the archive format written by Backup.
+const FormatVersion = 1
+
+// Tables are the tables exported into an archive, in the order they are
+// restored.
+var Tables = []string{
+	// scopes
+	"iam_scope",
+	"iam_scope_global",
+	"iam_scope_org",
+	"iam_scope_project",
+
+	// kms
+	"kms_root_key",
+	"kms_root_key_version",
+	"kms_database_key",
+	"kms_database_key_version",
+	"kms_oplog_key",
+	"kms_oplog_key_version",
+	"kms_session_key",
+	"kms_session_key_version",
+	"kms_token_key",
+	"kms_token_key_version",
+	"kms_oidc_key",
+	"kms_oidc_key_version",
+	"kms_key_version_reencryption",
+	"kms_key_version_reencryption_table",
+
+	// iam
+	"iam_user",
+	"iam_group",
+	"iam_role",
+	"iam_role_grant",
+	"iam_group_member_user",
+	"iam_group_member_group",
+	"iam_user_role",
+	"iam_group_role",
+
+	// auth
+	"auth_method",
+	"auth_password_method",
+	"auth_password_conf",
+	"auth_password_argon2_conf",
+	"auth_password_method_banned_password",
+	"auth_password_method_client_cidr",
+	"auth_account",
+	"auth_password_account",
+	"auth_password_account_lockout",
+	"auth_password_account_totp",
+	"auth_password_account_totp_recovery_code",
+	"auth_password_account_totp_pending",
+	"auth_password_account_totp_pending_recovery_code",
+	"auth_password_credential",
+	"auth_password_argon2_cred",
+	"auth_password_argon2_cred_history",
+	"auth_password_imported_cred",
+	"auth_password_invite",
+	"auth_password_invite_role",
+	"auth_password_reset_token",
+	"auth_api_token",
+	"auth_api_token_grant",
+
+	// hosts
+	"host_catalog",
+	"static_host_catalog",
+	"host",
+	"static_host",
+	"host_set",
+	"static_host_set",
+	"static_host_set_member",
+
+	// targets
+	"target",
+	"target_tcp",
+	"target_host_set",
+	"target_client_cidr",
+}
+
+const (
+	snapshotQuery    = `set transaction isolation level repeatable read, read only;`
+	disableTriggers  = `set local session_replication_role = replica;`
+	exportQuery      = `select row_to_json(t) from %s t;`
+	deleteQuery      = `delete from %s;`
+	restoreStatement = `insert into %[1]s select * from json_populate_recordset(null::%[1]s, ?::json);`
+)
+
+// Archive is an archive of Boundary's resources.  The rows of the tables are
+// encrypted with the root kms wrapper.
+type Archive struct {
+	// FormatVersion is the version of the archive format.
+	FormatVersion int `json:"format_version"`
+	// SchemaVersion is the schema version of the database the archive was
+	// created from.
+	SchemaVersion int `json:"schema_version"`
+	// CreateTime is when the archive was created.
+	CreateTime time.Time `json:"create_time"`
+	// KeyId is the id of the key of the wrapper which encrypted the archive.
+	KeyId string `json:"key_id"`
+	// Checksum is the hex encoded SHA-256 checksum of the archive's contents
+	// before they were encrypted.
+	Checksum string `json:"checksum"`
+	// Contents are the marshaled wrapping.EncryptedBlobInfo of the
+	// compressed rows of the tables.
+	Contents []byte `json:"contents"`
+}
+
+// Summary contains the number of rows of each table in an archive.
+type Summary struct {
+	Tables []*TableSummary `json:"tables"`
+}
+
+// TableSummary contains the number of rows of a table in an archive.
+type TableSummary struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+// contents are the contents of an archive before they are encrypted.
+type contents struct {
+	Tables []*table `json:"tables"`
+}
+
+// table is a table exported into an archive.  Each row is the JSON object of
+// the row's columns.
+type table struct {
+	Name string            `json:"name"`
+	Rows []json.RawMessage `json:"rows"`
+}
+
+func (c *contents) summary() *Summary {
+	s := &Summary{Tables: make([]*TableSummary, 0, len(c.Tables))}
+	for _, t := range c.Tables {
+		s.Tables = append(s.Tables, &TableSummary{Name: t.Name, Rows: len(t.Rows)})
+	}
+	return s
+}
+
+// Backup exports the rows of the Tables from a consistent snapshot of the
+// database and returns them in an archive encrypted with the wrapper.  The
+// schema version is the version of the database, recorded in the archive.
+func Backup(ctx context.Context, w db.Writer, wrapper wrapping.Wrapper, schemaVersion int) (*Archive, *Summary, error) {
+	const op = "backup.Backup"
+	if w == nil {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	if wrapper == nil {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	if schemaVersion <= 0 {
+		return nil, nil, errors.New(errors.InvalidParameter, op, "missing schema version")
+	}
+
+	var c *contents
+	_, err := w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			c = &contents{Tables: make([]*table, 0, len(Tables))}
+			if _, err := w.Exec(ctx, snapshotQuery, nil); err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, name := range Tables {
+				t, err := exportTable(ctx, reader, name)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				c.Tables = append(c.Tables, t)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+
+	a, err := seal(ctx, wrapper, schemaVersion, c)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return a, c.summary(), nil
+}
+
+// exportTable returns the rows of the table.
+func exportTable(ctx context.Context, r db.Reader, name string) (*table, error) {
+	const op = "backup.exportTable"
+	rows, err := r.Query(ctx, fmt.Sprintf(exportQuery, name), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to export %s", name)))
+	}
+	defer rows.Close()
+	t := &table{Name: name, Rows: []json.RawMessage{}}
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to export %s", name)))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to export %s", name)))
+	}
+	return t, nil
+}
+
+// Restore decrypts the archive with the wrapper and restores its rows into
+// the database, replacing the rows written by the migrations.  The database
+// must be empty apart from the rows written by the migrations and have the
+// schema version of the archive.  The rows are restored in a single
+// transaction with triggers disabled.
+func Restore(ctx context.Context, w db.Writer, wrapper wrapping.Wrapper, a *Archive, schemaVersion int) (*Summary, error) {
+	const op = "backup.Restore"
+	if w == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	if wrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	if a == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing archive")
+	}
+	if a.SchemaVersion != schemaVersion {
+		return nil, errors.New(errors.InvalidParameter, op,
+			fmt.Sprintf("archive schema version %d does not match database schema version %d", a.SchemaVersion, schemaVersion))
+	}
+	c, err := open(ctx, wrapper, a)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	known := make(map[string]bool, len(Tables))
+	for _, name := range Tables {
+		known[name] = true
+	}
+	for _, t := range c.Tables {
+		if !known[t.Name] {
+			return nil, errors.New(errors.NotSpecificIntegrity, op, fmt.Sprintf("archive contains unknown table %q", t.Name))
+		}
+	}
+
+	_, err = w.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, disableTriggers, nil); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to disable triggers"))
+			}
+			for _, t := range c.Tables {
+				if _, err := w.Exec(ctx, fmt.Sprintf(deleteQuery, t.Name), nil); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to clear %s", t.Name)))
+				}
+			}
+			for _, t := range c.Tables {
+				if len(t.Rows) == 0 {
+					continue
+				}
+				rows, err := json.Marshal(t.Rows)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				n, err := w.Exec(ctx, fmt.Sprintf(restoreStatement, t.Name), []interface{}{string(rows)})
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to restore %s", t.Name)))
+				}
+				if n != len(t.Rows) {
+					return errors.New(errors.NotSpecificIntegrity, op, fmt.Sprintf("restored %d rows of %s, expected %d", n, t.Name, len(t.Rows)))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return c.summary(), nil
+}
+
+// aad returns the additional authenticated data of the archive's contents,
+// which binds the archive's metadata to its contents.
+func (a *Archive) aad() []byte {
+	return []byte(fmt.Sprintf("boundary-backup:%d:%d:%d:%s", a.FormatVersion, a.SchemaVersion, a.CreateTime.UnixNano(), a.Checksum))
+}
+
+// seal compresses the contents and encrypts them with the wrapper.
+func seal(ctx context.Context, wrapper wrapping.Wrapper, schemaVersion int, c *contents) (*Archive, error) {
+	const op = "backup.seal"
+	marshaled, err := json.Marshal(c)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write(marshaled); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+
+	a := &Archive{
+		FormatVersion: FormatVersion,
+		SchemaVersion: schemaVersion,
+		CreateTime:    time.Now().UTC(),
+		KeyId:         wrapper.KeyID(),
+		Checksum:      hex.EncodeToString(sum[:]),
+	}
+	blobInfo, err := wrapper.Encrypt(ctx, buf.Bytes(), a.aad())
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	if a.Contents, err = proto.Marshal(blobInfo); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return a, nil
+}
+
+// open decrypts the archive's contents with the wrapper, verifies their
+// checksum and decompresses them.
+func open(ctx context.Context, wrapper wrapping.Wrapper, a *Archive) (*contents, error) {
+	const op = "backup.open"
+	if a.FormatVersion != FormatVersion {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unsupported archive format version %d", a.FormatVersion))
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(a.Contents, blobInfo); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	compressed, err := wrapper.Decrypt(ctx, blobInfo, a.aad())
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decrypt),
+			errors.WithMsg(fmt.Sprintf("unable to decrypt archive encrypted with key %q", a.KeyId)))
+	}
+	sum := sha256.Sum256(compressed)
+	if hex.EncodeToString(sum[:]) != a.Checksum {
+		return nil, errors.New(errors.NotSpecificIntegrity, op, "archive checksum mismatch")
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	marshaled, err := ioutil.ReadAll(gz)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	var c contents
+	if err := json.Unmarshal(marshaled, &c); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	return &c, nil
+}
@@ -0,0 +1,129 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/schema"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealOpen(t *testing.T) {
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	c := &contents{Tables: []*table{
+		{Name: "iam_scope", Rows: []json.RawMessage{json.RawMessage(`{"public_id":"global"}`)}},
+		{Name: "iam_user", Rows: []json.RawMessage{}},
+	}}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a, err := seal(ctx, wrapper, 1022, c)
+		require.NoError(err)
+		assert.Equal(FormatVersion, a.FormatVersion)
+		assert.Equal(1022, a.SchemaVersion)
+		assert.Equal(wrapper.KeyID(), a.KeyId)
+		assert.NotEmpty(a.Checksum)
+		assert.NotContains(string(a.Contents), "global")
+
+		got, err := open(ctx, wrapper, a)
+		require.NoError(err)
+		assert.Equal(c, got)
+		assert.Equal(&Summary{Tables: []*TableSummary{
+			{Name: "iam_scope", Rows: 1},
+			{Name: "iam_user", Rows: 0},
+		}}, got.summary())
+	})
+
+	t.Run("wrong-wrapper", func(t *testing.T) {
+		a, err := seal(ctx, wrapper, 1022, c)
+		require.NoError(t, err)
+		_, err = open(ctx, db.TestWrapper(t), a)
+		assert.True(t, errors.Match(errors.T(errors.Decrypt), err))
+	})
+
+	t.Run("tampered-metadata", func(t *testing.T) {
+		a, err := seal(ctx, wrapper, 1022, c)
+		require.NoError(t, err)
+		a.SchemaVersion = 1021
+		_, err = open(ctx, wrapper, a)
+		assert.True(t, errors.Match(errors.T(errors.Decrypt), err))
+	})
+
+	t.Run("tampered-contents", func(t *testing.T) {
+		a, err := seal(ctx, wrapper, 1022, c)
+		require.NoError(t, err)
+		a.Contents[len(a.Contents)-1] ^= 0xff
+		_, err = open(ctx, wrapper, a)
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported-format", func(t *testing.T) {
+		a, err := seal(ctx, wrapper, 1022, c)
+		require.NoError(t, err)
+		a.FormatVersion = FormatVersion + 1
+		_, err = open(ctx, wrapper, a)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
+func TestBackupRestore(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	wrapper := db.TestWrapper(t)
+	version := schema.BinarySchemaVersion("postgres")
+
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, prj := iam.TestScopes(t, iamRepo)
+	user := iam.TestUser(t, iamRepo, org.PublicId)
+	role := iam.TestRole(t, conn, prj.PublicId)
+	iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=*;actions=read")
+	iam.TestUserRole(t, conn, role.PublicId, user.PublicId)
+
+	_, _, err := Backup(ctx, nil, wrapper, version)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = Backup(ctx, rw, nil, version)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, _, err = Backup(ctx, rw, wrapper, 0)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	a, summary, err := Backup(ctx, rw, wrapper, version)
+	require.NoError(err)
+	require.Len(summary.Tables, len(Tables))
+	rows := make(map[string]int)
+	for _, ts := range summary.Tables {
+		rows[ts.Name] = ts.Rows
+	}
+	assert.Equal(3, rows["iam_scope"])
+	assert.Equal(1, rows["iam_role_grant"])
+	assert.Equal(1, rows["iam_user_role"])
+
+	restoreConn, _ := db.TestSetup(t, "postgres")
+	restoreRw := db.New(restoreConn)
+	_, err = Restore(ctx, restoreRw, wrapper, a, version+1)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	restored, err := Restore(ctx, restoreRw, wrapper, a, version)
+	require.NoError(err)
+	assert.Equal(summary, restored)
+
+	restoredIamRepo := iam.TestRepo(t, restoreConn, wrapper)
+	gotPrj, err := restoredIamRepo.LookupScope(ctx, prj.PublicId)
+	require.NoError(err)
+	assert.Equal(prj.ParentId, gotPrj.ParentId)
+	gotUser, _, err := restoredIamRepo.LookupUser(ctx, user.PublicId)
+	require.NoError(err)
+	assert.Equal(org.PublicId, gotUser.ScopeId)
+
+	// The restored keys decrypt the values encrypted before the backup.
+	kmsCache := kms.TestKms(t, restoreConn, wrapper)
+	_, err = kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeDatabase)
+	assert.NoError(err)
+}
@@ -30,8 +30,9 @@ func generate(dialect string) {
 	sort.Strings(versions)
 
 	type ContentValues struct {
-		Name    string
-		Content string
+		Name     string
+		FileName string
+		Content  string
 	}
 	var upContents []ContentValues
 
@@ -97,8 +98,9 @@ func generate(dialect string) {
 			contents = strings.TrimSpace(contents)
 
 			cv := ContentValues{
-				Name:    fmt.Sprint(fullV),
-				Content: contents,
+				Name:     fmt.Sprint(fullV),
+				FileName: fmt.Sprintf("%s/%s", ver, name),
+				Content:  contents,
 			}
 			switch {
 			case strings.Contains(nameParts[1], ".up."):
@@ -128,8 +130,10 @@ func generate(dialect string) {
 	}
 }
 
-var migrationsTemplate = template.Must(template.Must(template.New("Content").Parse(
+var migrationsTemplate = template.Must(template.Must(template.Must(template.New("Content").Parse(
 	`{{ .Name }}: []byte(` + "`\n{{ .Content }}\n`" + `),
+`)).New("FileName").Parse(
+	`{{ .Name }}: "{{ .FileName }}",
 `)).New("MainPage").Parse(`package schema
 
 // Code generated by "make migrations"; DO NOT EDIT.
@@ -140,6 +144,9 @@ func init() {
 		upMigrations: map[int][]byte{
 			{{range .UpValues }}{{ template "Content" . }}{{end}}
 		},
+		upMigrationNames: map[int]string{
+			{{range .UpValues }}{{ template "FileName" . }}{{end}}
+		},
 	}
 }
 `))
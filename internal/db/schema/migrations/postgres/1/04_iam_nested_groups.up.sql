@@ -0,0 +1,145 @@
+begin;
+
+-- iam_group_member_group is an association table that represents groups with
+-- associated groups.  A group which is a member of another group is often
+-- referred to as a nested group.
+create table iam_group_member_group (
+  create_time wt_timestamp,
+  group_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  member_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (group_id, member_id),
+  constraint iam_group_member_group_not_self
+    check(group_id <> member_id)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on iam_group_member_group
+  for each row execute procedure default_create_time();
+
+create trigger iam_immutable_group_member
+before
+update on iam_group_member_group
+  for each row execute procedure iam_immutable_group_member();
+
+-- iam_group_member_group_no_cycle() ensures that adding a group as a member of
+-- another group will not create a membership cycle.  The table is locked in a
+-- self-conflicting mode so concurrent inserts cannot each pass the check and
+-- together form a cycle.
+create or replace function
+  iam_group_member_group_no_cycle()
+  returns trigger
+as $$
+begin
+  lock table iam_group_member_group in share row exclusive mode;
+  if exists (
+    with recursive
+    descendant_groups (group_id) as (
+      select new.member_id
+       union
+      select gmg.member_id
+        from iam_group_member_group gmg,
+             descendant_groups dg
+       where gmg.group_id = dg.group_id
+    )
+    select 1
+      from descendant_groups
+     where group_id = new.group_id
+  ) then
+    raise exception 'group % is a member of group %', new.group_id, new.member_id
+      using errcode = '23514', -- check_violation
+            constraint = 'iam_group_member_group_no_cycle';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger iam_group_member_group_no_cycle
+before
+insert on iam_group_member_group
+  for each row execute procedure iam_group_member_group_no_cycle();
+
+-- Replaces the view created in 06_iam to include groups which are members of
+-- groups.
+drop view iam_group_member;
+create view iam_group_member as
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.member_id = u.public_id and
+  gm.group_id = g.public_id
+union
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  mg.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, mg.scope_id, gm.member_id) as scoped_member_id,
+  'group' as type
+from
+  iam_group_member_group gm,
+  iam_group mg,
+  iam_group g
+where
+  gm.member_id = mg.public_id and
+  gm.group_id = g.public_id;
+
+-- iam_group_member_effective provides the effective user members of every
+-- group: the users which are members of the group directly or which are
+-- members of any group nested (at any depth) within the group.  create_time
+-- is the earliest time the user became a member through any path.
+create view iam_group_member_effective as
+with recursive
+group_tree (group_id, member_group_id) as (
+  select public_id,
+         public_id
+    from iam_group
+   union
+  select gt.group_id,
+         gmg.member_id
+    from group_tree gt,
+         iam_group_member_group gmg
+   where gmg.group_id = gt.member_group_id
+)
+select
+  min(gm.create_time) as create_time,
+  gt.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  group_tree gt,
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.group_id = gt.member_group_id and
+  gm.member_id = u.public_id and
+  gt.group_id = g.public_id
+group by
+  gt.group_id,
+  gm.member_id,
+  u.scope_id,
+  g.scope_id;
+
+commit;
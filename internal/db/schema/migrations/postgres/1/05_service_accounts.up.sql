@@ -0,0 +1,195 @@
+begin;
+
+-- A service account is a user intended for use by automation rather than by
+-- a person.  Service accounts cannot be associated with auth accounts and
+-- therefore cannot authenticate with an auth method; they authenticate with
+-- api tokens instead.  Whether or not a user is a service account is set when
+-- the user is created and cannot be changed.
+alter table iam_user
+  add column service_account boolean not null default false,
+  add constraint iam_user_predefined_not_service_account
+    check(
+      not service_account or
+      public_id not in ('u_anon', 'u_auth', 'u_recovery')
+    );
+
+create trigger
+  immutable_service_account_column
+before
+update on iam_user
+  for each row execute procedure immutable_columns('service_account');
+
+-- auth_account_iam_user_not_service_account() ensures an auth account is never
+-- associated with a service account.
+create or replace function
+  auth_account_iam_user_not_service_account()
+  returns trigger
+as $$
+begin
+  if new.iam_user_id is not null then
+    perform
+      from iam_user
+     where public_id = new.iam_user_id
+       and service_account;
+    if found then
+      raise exception 'user % is a service account', new.iam_user_id
+        using errcode = '23514', -- check_violation
+              constraint = 'auth_account_iam_user_not_service_account';
+    end if;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_account_iam_user_not_service_account
+before
+insert or update of iam_user_id on auth_account
+  for each row execute procedure auth_account_iam_user_not_service_account();
+
+-- an api token belongs to 1 and only 1 service account
+-- a service account can have 0 to many api tokens
+create table auth_api_token (
+  public_id wt_public_id primary key,
+  token bytea not null unique,
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  scope_id wt_scope_id not null,
+  iam_user_id wt_user_id not null,
+  name text,
+  description text,
+  -- grant_scope_id is the scope the grants restricting the api token are
+  -- applied to.
+  grant_scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  -- approximate_last_access_time is null until the api token is first used.
+  -- It is not updated every time this api token is used.  It is updated
+  -- after X minutes from the last time it was updated on a per row basis.
+  -- Any update which sets this column sets it to the current time.
+  approximate_last_access_time timestamp with time zone
+    constraint last_access_time_must_not_be_after_expiration_time
+    check(
+      approximate_last_access_time <= expiration_time
+    ),
+  expiration_time timestamp with time zone not null
+    constraint create_time_must_be_before_expiration_time
+    check(
+      create_time < expiration_time
+    ),
+  foreign key (scope_id, iam_user_id)
+    references iam_user (scope_id, public_id)
+    on delete cascade
+    on update cascade,
+  unique(iam_user_id, name)
+);
+
+-- auth_api_token_service_account_only() ensures api tokens are only issued to
+-- service accounts.
+create or replace function
+  auth_api_token_service_account_only()
+  returns trigger
+as $$
+begin
+  perform
+    from iam_user
+   where public_id = new.iam_user_id
+     and service_account;
+  if not found then
+    raise exception 'user % is not a service account', new.iam_user_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_api_token_service_account_only';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_api_token_service_account_only
+before
+insert on auth_api_token
+  for each row execute procedure auth_api_token_service_account_only();
+
+-- grant_scope_id_valid() is defined in 0/06_iam.up.sql and applies the same
+-- rules to the grant scope of api tokens that it applies to roles.
+create trigger
+  ensure_grant_scope_id_valid
+before
+insert on auth_api_token
+  for each row execute procedure grant_scope_id_valid();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_api_token
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_time_column
+before
+update on auth_api_token
+  for each row execute procedure update_time_column();
+
+-- update_api_token_last_access_time() sets approximate_last_access_time to
+-- the current time.  Unlike update_last_access_time() it does not depend on the
+-- new value being distinct from the old value, since the column is null until
+-- the api token is first used.
+create or replace function
+  update_api_token_last_access_time()
+  returns trigger
+as $$
+begin
+  new.approximate_last_access_time = now();
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  update_api_token_last_access_time
+before
+update of approximate_last_access_time on auth_api_token
+  for each row execute procedure update_api_token_last_access_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_api_token
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'iam_user_id', 'grant_scope_id', 'create_time');
+
+-- auth_api_token_grant contains the grants which restrict what an api token
+-- is allowed to do.  An api token without any grants is allowed to do
+-- everything its service account is allowed to do.  An api token with grants
+-- is only allowed to do what is allowed by both its grants and the grants of
+-- its service account.
+create table auth_api_token_grant (
+  create_time wt_timestamp,
+  api_token_id wt_public_id not null
+    references auth_api_token(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text not null
+    constraint canonical_grant_must_not_be_empty
+    check(length(trim(canonical_grant)) > 0),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(length(trim(raw_grant)) > 0),
+  primary key(api_token_id, canonical_grant)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_api_token_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_api_token_grant
+  for each row execute procedure immutable_columns('create_time', 'api_token_id', 'canonical_grant', 'raw_grant');
+
+commit;
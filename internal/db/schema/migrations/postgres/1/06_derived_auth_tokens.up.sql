@@ -0,0 +1,120 @@
+begin;
+
+-- A derived auth token is a short-lived auth token which was exchanged for an
+-- existing (parent) auth token.  It belongs to the same auth account as its
+-- parent and is only allowed to do what is allowed by both its grants and the
+-- grants of the user of its parent.  Deleting the parent auth token deletes
+-- all of the auth tokens derived from it.
+alter table auth_token
+  add column parent_id wt_public_id
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  -- grant_scope_id is the scope the grants restricting a derived auth token
+  -- are applied to.
+  add column grant_scope_id wt_scope_id
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  add constraint auth_token_derived_grant_scope_id
+    check(
+      (parent_id is null) = (grant_scope_id is null)
+    );
+
+-- auth_token_derived_from_parent() ensures a derived auth token belongs to the
+-- same auth account as its parent, does not outlive its parent, and is not
+-- derived from another derived auth token.
+create or replace function
+  auth_token_derived_from_parent()
+  returns trigger
+as $$
+declare parent record;
+begin
+  if new.parent_id is null then
+    return new;
+  end if;
+  select auth_account_id, parent_id, expiration_time
+    into parent
+    from auth_token
+   where public_id = new.parent_id;
+  if not found then
+    raise exception 'parent auth token % not found', new.parent_id;
+  end if;
+  if parent.parent_id is not null then
+    raise exception 'auth token % is a derived auth token', new.parent_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_token_derived_from_parent';
+  end if;
+  if parent.auth_account_id is distinct from new.auth_account_id then
+    raise exception 'auth account does not match parent auth token %', new.parent_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_token_derived_from_parent';
+  end if;
+  if new.expiration_time > parent.expiration_time then
+    new.expiration_time = parent.expiration_time;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_token_derived_from_parent
+before
+insert on auth_token
+  for each row execute procedure auth_token_derived_from_parent();
+
+drop trigger immutable_columns on auth_token;
+
+create trigger
+  immutable_columns
+before
+update on auth_token
+  for each row execute procedure immutable_columns('public_id', 'auth_account_id', 'create_time', 'parent_id', 'grant_scope_id');
+
+create or replace view auth_token_account as
+      select at.public_id,
+             at.token,
+             at.auth_account_id,
+             at.create_time,
+             at.update_time,
+             at.approximate_last_access_time,
+             at.expiration_time,
+             aa.scope_id,
+             aa.iam_user_id,
+             aa.auth_method_id,
+             at.parent_id,
+             at.grant_scope_id
+        from auth_token as at
+  inner join auth_account as aa
+          on at.auth_account_id = aa.public_id;
+
+-- auth_token_grant contains the grants which restrict what a derived auth
+-- token is allowed to do.
+create table auth_token_grant (
+  create_time wt_timestamp,
+  auth_token_id wt_public_id not null
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text not null
+    constraint canonical_grant_must_not_be_empty
+    check(length(trim(canonical_grant)) > 0),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(length(trim(raw_grant)) > 0),
+  primary key(auth_token_id, canonical_grant)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_token_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_token_grant
+  for each row execute procedure immutable_columns('create_time', 'auth_token_id', 'canonical_grant', 'raw_grant');
+
+commit;
@@ -0,0 +1,50 @@
+begin;
+
+-- The lifetime of the auth tokens issued by an auth method is controlled by
+-- two durations: auth_token_time_to_live_seconds, the total time an auth token
+-- is valid for, and auth_token_time_to_stale_seconds, the time an auth token can
+-- go unused before it becomes invalid.  Both can be set on an auth method and,
+-- as defaults for the auth methods within it, on a scope.  A null value means
+-- the setting is inherited.
+alter table auth_password_method
+  add column auth_token_time_to_live_seconds int
+    constraint auth_token_time_to_live_seconds_must_be_positive
+    check(auth_token_time_to_live_seconds > 0),
+  add column auth_token_time_to_stale_seconds int
+    constraint auth_token_time_to_stale_seconds_must_be_positive
+    check(auth_token_time_to_stale_seconds > 0);
+
+alter table iam_scope
+  add column auth_token_time_to_live_seconds int
+    constraint auth_token_time_to_live_seconds_must_be_positive
+    check(auth_token_time_to_live_seconds > 0),
+  add column auth_token_time_to_stale_seconds int
+    constraint auth_token_time_to_stale_seconds_must_be_positive
+    check(auth_token_time_to_stale_seconds > 0);
+
+-- auth_token_policy resolves the auth token durations for each auth method.  A
+-- setting on the auth method takes precedence over a setting on the scope of
+-- the auth method, which takes precedence over a setting on the parent of that
+-- scope.  A null value means the controller's configured default applies.
+create view auth_token_policy as
+select
+  am.public_id as auth_method_id,
+  am.scope_id  as scope_id,
+  coalesce(
+    am.auth_token_time_to_live_seconds,
+    s.auth_token_time_to_live_seconds,
+    p.auth_token_time_to_live_seconds
+  ) as time_to_live_seconds,
+  coalesce(
+    am.auth_token_time_to_stale_seconds,
+    s.auth_token_time_to_stale_seconds,
+    p.auth_token_time_to_stale_seconds
+  ) as time_to_stale_seconds
+from
+  auth_password_method am
+  join iam_scope s
+    on am.scope_id = s.public_id
+  left join iam_scope p
+    on s.parent_id = p.public_id;
+
+commit;
@@ -0,0 +1,56 @@
+begin;
+
+-- An account of a password auth method is locked after lockout_threshold
+-- consecutive failed authentication attempts.  The first lockout lasts
+-- lockout_duration_seconds and each consecutive lockout lasts twice as long as
+-- the previous one, up to a maximum of 24 hours.  A null lockout_threshold
+-- disables lockouts and a null lockout_duration_seconds means the default
+-- duration is used.
+alter table auth_password_method
+  add column lockout_threshold int
+    constraint lockout_threshold_must_be_positive
+    check(lockout_threshold > 0),
+  add column lockout_duration_seconds int
+    constraint lockout_duration_seconds_must_be_positive
+    check(lockout_duration_seconds > 0);
+
+-- auth_password_account_lockout contains the failed authentication attempts
+-- and lockouts of an account.  It is maintained by the password repository
+-- while authenticating and is not replicated, so changes to it do not change
+-- the version of the account.
+create table auth_password_account_lockout (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  failed_attempt_count int not null default 0
+    constraint failed_attempt_count_must_not_be_negative
+    check(failed_attempt_count >= 0),
+  lockout_count int not null default 0
+    constraint lockout_count_must_not_be_negative
+    check(lockout_count >= 0),
+  last_failed_attempt_time timestamp with time zone,
+  locked_until_time timestamp with time zone,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_lockout
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_lockout
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_lockout
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+
+commit;
@@ -0,0 +1,85 @@
+begin;
+
+-- mfa_policy controls whether the accounts of a password auth method must
+-- use a time-based one-time password (TOTP) as a second factor.  With the
+-- 'optional' policy only accounts which have enrolled in TOTP must provide a
+-- TOTP code.  With the 'required' policy accounts which have not enrolled in
+-- TOTP can not authenticate.  A null mfa_policy is the same as 'optional'.
+alter table auth_password_method
+  add column mfa_policy text
+    constraint mfa_policy_must_be_optional_or_required
+    check(mfa_policy in ('optional', 'required'));
+
+-- auth_password_account_totp contains the TOTP secret of an account.  An
+-- enrollment is not used to authenticate until it is confirmed with a valid
+-- TOTP code.  last_counter is the time step of the last TOTP code used to
+-- authenticate, which prevents a code from being used more than once.
+create table auth_password_account_totp (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  secret bytea not null  -- encrypted value
+    constraint secret_must_not_be_empty
+    check(length(secret) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  confirm_time timestamp with time zone,
+  last_counter bigint,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_totp
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp
+  for each row execute procedure immutable_columns('account_id', 'secret', 'create_time');
+
+-- auth_password_account_totp_recovery_code contains the hashes of the
+-- single-use recovery codes issued when an account enrolls in TOTP.  A
+-- recovery code can be used instead of a TOTP code and is deleted when used.
+create table auth_password_account_totp_recovery_code (
+  account_id wt_public_id
+    references auth_password_account_totp(account_id)
+    on delete cascade
+    on update cascade,
+  code_hash bytea not null
+    constraint code_hash_must_not_be_empty
+    check(length(code_hash) > 0),
+  create_time wt_timestamp,
+  primary key(account_id, code_hash)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_recovery_code
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_recovery_code
+  for each row execute procedure immutable_columns('account_id', 'code_hash', 'create_time');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_account_totp', 1),
+  ('auth_password_account_totp_recovery_code', 1);
+
+commit;
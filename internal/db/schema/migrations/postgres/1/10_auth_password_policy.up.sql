@@ -0,0 +1,108 @@
+begin;
+
+-- The password policy of a password auth method.  The password_require_*
+-- columns require passwords to contain at least one character of the
+-- respective class.  password_history_count is the number of most recent
+-- passwords of an account, including the current one, which can not be
+-- reused.  max_password_age_seconds is how long a password can be used before
+-- it must be changed.  A null value disables the respective rule.
+alter table auth_password_method
+  add column password_require_uppercase boolean,
+  add column password_require_lowercase boolean,
+  add column password_require_digit boolean,
+  add column password_require_symbol boolean,
+  add column password_history_count int
+    constraint password_history_count_must_be_positive
+    check(password_history_count > 0),
+  add column max_password_age_seconds int
+    constraint max_password_age_seconds_must_be_positive
+    check(max_password_age_seconds > 0);
+
+-- auth_password_method_banned_password contains the passwords which can not
+-- be used by the accounts of a password auth method.  Passwords are compared
+-- case insensitively so they are stored in lower case.
+create table auth_password_method_banned_password (
+  password_method_id wt_public_id
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  password text not null
+    constraint password_must_not_be_empty
+    check(length(password) > 0)
+    constraint password_must_be_lower_case
+    check(password = lower(password)),
+  create_time wt_timestamp,
+  primary key(password_method_id, password)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_method_banned_password
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_method_banned_password
+  for each row execute procedure immutable_columns('password_method_id', 'password', 'create_time');
+
+-- auth_password_argon2_cred_history contains the previous passwords of an
+-- account.  When the password of an account is changed, the salt and derived
+-- key of the replaced credential are copied into it.  It is maintained by the
+-- password repository and is not replicated.
+create table auth_password_argon2_cred_history (
+  password_account_id wt_public_id
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  password_conf_id wt_private_id not null
+    references auth_password_argon2_conf(private_id)
+    on delete cascade
+    on update cascade,
+  salt bytea not null -- encrypted value
+    constraint salt_must_not_be_empty
+    check(length(salt) > 0),
+  derived_key bytea not null
+    constraint derived_key_must_not_be_empty
+    check(length(derived_key) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  primary key(password_account_id, derived_key)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_argon2_cred_history
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('password_account_id', 'password_conf_id', 'salt', 'derived_key', 'key_id', 'create_time');
+
+-- auth_password_current_conf is recreated to include the password policy of
+-- each password auth method.
+drop view auth_password_current_conf;
+create view auth_password_current_conf as
+    select pm.min_login_name_length, pm.min_password_length,
+           coalesce(pm.password_require_uppercase, false) as password_require_uppercase,
+           coalesce(pm.password_require_lowercase, false) as password_require_lowercase,
+           coalesce(pm.password_require_digit, false)     as password_require_digit,
+           coalesce(pm.password_require_symbol, false)    as password_require_symbol,
+           coalesce(pm.password_history_count, 0)         as password_history_count,
+           c.*
+      from auth_password_method pm
+inner join auth_password_conf_union c
+        on pm.password_conf_id = c.password_conf_id;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_method_banned_password', 1);
+
+commit;
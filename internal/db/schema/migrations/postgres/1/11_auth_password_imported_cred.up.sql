@@ -0,0 +1,89 @@
+begin;
+
+-- auth_password_imported_cred is a subtype of auth_password_credential for
+-- password hashes imported from other systems.  An imported credential does
+-- not have a password configuration, it is replaced with an
+-- auth_password_argon2_cred the next time the account authenticates.
+create table auth_password_imported_cred (
+  private_id wt_private_id primary key
+    references auth_password_credential (private_id)
+    on delete cascade
+    on update cascade,
+  password_account_id wt_public_id not null,
+  -- The password_method_id type is not wt_public_id because the domain check
+  -- is executed before the insert trigger which retrieves the
+  -- password_method_id causing an insert to fail.
+  password_method_id text not null,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  hash_type text not null
+    constraint hash_type_must_be_supported
+    check(hash_type in ('bcrypt', 'pbkdf2-sha1', 'pbkdf2-sha256', 'pbkdf2-sha512', 'scrypt')),
+  password_hash bytea not null -- encrypted value
+    constraint password_hash_must_not_be_empty
+    check(length(password_hash) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  foreign key (password_method_id, password_account_id)
+    references auth_password_account (auth_method_id, public_id)
+    on delete cascade
+    on update cascade
+);
+
+-- insert_auth_password_imported_cred_subtype() is a before insert trigger
+-- function for auth_password_imported_cred.  It differs from
+-- insert_auth_password_credential_subtype() in that imported credentials do
+-- not have a password_conf_id.
+create function
+  insert_auth_password_imported_cred_subtype()
+  returns trigger
+as $$
+begin
+  select auth_password_account.auth_method_id
+    into new.password_method_id
+  from auth_password_account
+  where auth_password_account.public_id = new.password_account_id;
+
+  insert into auth_password_credential
+    (private_id, password_account_id, password_method_id)
+  values
+    (new.private_id, new.password_account_id, new.password_method_id);
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  insert_auth_password_imported_cred_subtype
+before insert on auth_password_imported_cred
+  for each row execute procedure insert_auth_password_imported_cred_subtype();
+
+create trigger
+  delete_auth_password_credential_subtype
+after delete on auth_password_imported_cred
+  for each row execute procedure delete_auth_password_credential_subtype();
+
+create trigger
+  update_time_column
+before
+update on auth_password_imported_cred
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_imported_cred
+  for each row execute procedure immutable_columns('create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_imported_cred
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_imported_cred', 1);
+
+commit;
@@ -0,0 +1,37 @@
+begin;
+
+-- auth_password_reset_token contains the hashes of the one-time tokens which
+-- can be used to reset the password of an account without authenticating.
+-- A token is deleted when it is used and can not be used after its
+-- expiration_time.  Reset tokens are not replicated, so the table does not
+-- have an oplog ticket.
+create table auth_password_reset_token (
+  token_hash bytea primary key
+    constraint token_hash_must_not_be_empty
+    check(length(token_hash) > 0),
+  account_id wt_public_id not null
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  expiration_time wt_timestamp
+    constraint expiration_time_must_be_after_create_time
+    check(expiration_time > create_time)
+);
+
+create index auth_password_reset_token_account_id_ix
+  on auth_password_reset_token(account_id);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_reset_token
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_reset_token
+  for each row execute procedure immutable_columns('token_hash', 'account_id', 'create_time', 'expiration_time');
+
+commit;
@@ -0,0 +1,94 @@
+begin;
+
+-- auth_password_invite contains the invitations to enroll in a password auth
+-- method.  Redeeming an invite creates an account in the auth method, a user
+-- in the scope of the auth method, associates the account with the user, and
+-- adds the user to the roles of the invite.  An invite can only be redeemed
+-- once, before its expiration_time.  Only the hash of the invite token is
+-- stored.
+create table auth_password_invite (
+  public_id wt_public_id primary key,
+  auth_method_id wt_public_id not null
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  name text,
+  description text,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  token_hash bytea not null unique
+    constraint token_hash_must_not_be_empty
+    check(length(token_hash) > 0),
+  expiration_time wt_timestamp
+    constraint expiration_time_must_be_after_create_time
+    check(expiration_time > create_time),
+  -- redeem_time, account_id and iam_user_id are null until the invite is
+  -- redeemed.  account_id and iam_user_id are set to null if the account or
+  -- user is deleted but redeem_time is kept so the invite can not be
+  -- redeemed again.
+  redeem_time timestamp with time zone,
+  account_id wt_public_id
+    references auth_password_account(public_id)
+    on delete set null
+    on update cascade,
+  iam_user_id wt_user_id
+    references iam_user(public_id)
+    on delete set null
+    on update cascade,
+  unique(auth_method_id, name)
+);
+
+create index auth_password_invite_auth_method_id_ix
+  on auth_password_invite(auth_method_id);
+
+create trigger
+  update_version_column
+after update on auth_password_invite
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before
+update on auth_password_invite
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_invite
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_invite
+  for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'create_time', 'token_hash', 'expiration_time');
+
+-- auth_password_invite_role contains the roles the user created by redeeming
+-- an invite is added to.
+create table auth_password_invite_role (
+  invite_id wt_public_id not null
+    references auth_password_invite(public_id)
+    on delete cascade
+    on update cascade,
+  role_id wt_role_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key(invite_id, role_id)
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_password_invite_role
+  for each row execute procedure immutable_columns('invite_id', 'role_id');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_invite', 1),
+  ('auth_password_invite_role', 1);
+
+commit;
@@ -0,0 +1,12 @@
+begin;
+
+-- A user or a password account which is not active is disabled: it cannot
+-- authenticate and the auth tokens issued to it are deleted when it is
+-- disabled.  Re-enabling it restores access.
+alter table iam_user
+  add column active boolean not null default true;
+
+alter table auth_password_account
+  add column active boolean not null default true;
+
+commit;
@@ -0,0 +1,176 @@
+begin;
+
+-- access revoked is the termination reason of sessions which were canceled
+-- because their user no longer has access to them: the user lost the
+-- authorize-session grant on the target, or the auth token or the target of
+-- the session was deleted.
+alter table session_termination_reason_enm
+  drop constraint only_predefined_session_termination_reasons_allowed;
+
+alter table session_termination_reason_enm
+  add constraint only_predefined_session_termination_reasons_allowed
+  check (
+    name in (
+      'unknown',
+      'timed out',
+      'closed by end-user',
+      'terminated',
+      'network error',
+      'system error',
+      'connection limit',
+      'canceled',
+      'access revoked'
+    )
+  );
+
+insert into session_termination_reason_enm (name)
+values
+  ('access revoked');
+
+-- session_access_revoked records the sessions which were canceled because
+-- access to them was revoked, so they are terminated with the 'access
+-- revoked' reason instead of 'canceled'.
+create table session_access_revoked (
+  session_id wt_public_id primary key
+    references session (public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp
+);
+
+create trigger
+  default_create_time_column
+before
+insert on session_access_revoked
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on session_access_revoked
+  for each row execute procedure immutable_columns('session_id', 'create_time');
+
+-- cancel_session_with_null_fk replaces the function defined in
+-- 0/50_session.up.sql so sessions are also recorded as revoked when their
+-- user, target or auth token is deleted.
+create or replace function
+  cancel_session_with_null_fk()
+  returns trigger
+as $$
+begin
+  case
+    when new.user_id is null or new.target_id is null or new.auth_token_id is null then
+      insert into session_access_revoked (session_id)
+      values
+        (new.public_id)
+      on conflict do nothing;
+      perform cancel_session(new.public_id);
+    when new.host_id is null then
+      perform cancel_session(new.public_id);
+    when new.host_set_id is null then
+      perform cancel_session(new.public_id);
+    when new.scope_id is null then
+      perform cancel_session(new.public_id);
+  end case;
+  return new;
+end;
+$$ language plpgsql;
+
+-- terminate_session_if_possible replaces the function defined in
+-- 0/51_connection.up.sql so revoked sessions are terminated with the 'access
+-- revoked' reason once all their connections are closed.
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- sessions canceled because access to them was revoked
+        when us.public_id in (
+          select
+            session_id
+          from
+            session_access_revoked sar
+          where
+            us.public_id = sar.session_id
+          ) then 'access revoked'
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          (
+            select count (*) 
+              from session_connection sc 
+            where 
+              sc.session_id = us.public_id
+          ) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+
+commit;
@@ -0,0 +1,92 @@
+begin;
+
+-- Client cidr restrictions limit the client addresses which can authenticate
+-- with a password auth method, or authorize and connect to a session of a
+-- target.  A client address must not be within any of the deny rules and, if
+-- there are any allow rules, must be within one of them.
+create table auth_password_method_client_cidr (
+  password_method_id wt_public_id
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(password_method_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_method_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_method_client_cidr
+  for each row execute procedure immutable_columns('password_method_id', 'cidr', 'rule', 'create_time');
+
+create table target_client_cidr (
+  target_id wt_public_id
+    references target(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(target_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on target_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on target_client_cidr
+  for each row execute procedure immutable_columns('target_id', 'cidr', 'rule', 'create_time');
+
+-- session_client_cidr contains the client cidr restrictions of the target of
+-- a session when the session was authorized.  Workers check the address of
+-- each client connecting to the session against them.  Like the worker
+-- filter, they are not stored in the warehouse.
+create table session_client_cidr (
+  session_id wt_public_id
+    references session(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(session_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on session_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on session_client_cidr
+  for each row execute procedure immutable_columns('session_id', 'cidr', 'rule', 'create_time');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_method_client_cidr', 1),
+  ('target_client_cidr', 1);
+
+commit;
@@ -0,0 +1,49 @@
+begin;
+
+-- An access window limits when sessions of a tcp target can be authorized
+-- and used.  A window opens each time the cron expression in
+-- access_window_schedule fires, evaluated in access_window_time_zone, and
+-- stays open for access_window_duration_seconds.  Targets without a schedule
+-- can be accessed at any time.
+alter table target_tcp
+  add column access_window_schedule text
+    constraint access_window_schedule_must_not_be_empty
+    check(length(trim(access_window_schedule)) > 0),
+  add column access_window_duration_seconds int
+    constraint access_window_duration_seconds_must_be_greater_than_0
+    check(access_window_duration_seconds > 0),
+  add column access_window_time_zone text
+    constraint access_window_time_zone_must_not_be_empty
+    check(length(trim(access_window_time_zone)) > 0),
+  add constraint access_window_schedule_and_duration_must_be_set_together
+    check(
+      (access_window_schedule is null and access_window_duration_seconds is null)
+        or
+      (access_window_schedule is not null and access_window_duration_seconds is not null)
+    ),
+  add constraint access_window_time_zone_requires_schedule
+    check(access_window_time_zone is null or access_window_schedule is not null);
+
+-- Replaces the view created in 01 to include the access window
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  access_window_schedule,
+  access_window_duration_seconds,
+  access_window_time_zone,
+  'tcp' as type
+from target_tcp;
+
+commit;
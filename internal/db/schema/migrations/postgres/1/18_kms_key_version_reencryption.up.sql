@@ -0,0 +1,168 @@
+begin;
+
+-- Data encryption key (DEK) versions are retired by re-encrypting the values
+-- encrypted with them with the current version of the key, after which they
+-- can be destroyed.  Each table holding encrypted values records the id of the
+-- key version used in a key_id column, which is indexed so the values
+-- encrypted with a key version can be found.
+
+-- oplog_entry records the oplog key version used to encrypt the entry's data.
+-- The key_id of entries written before this migration is null until the
+-- controller backfills it from the encrypted data.
+alter table oplog_entry
+  add column key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0);
+
+create index oplog_entry_key_id_ix
+  on oplog_entry (key_id);
+
+-- Replaces the trigger created in 0/02_oplog.up.sql to allow the data of an
+-- entry to be re-encrypted.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+-- session.key_id is the sessions key version the session's certificate key
+-- is derived from.  tofu_token_key_id is the database key version used to
+-- encrypt the tofu token.  It is null for tokens encrypted before this
+-- migration until the controller backfills it from the encrypted token.
+alter table session
+  add column tofu_token_key_id text
+    constraint tofu_token_key_id_must_not_be_empty
+    check(length(trim(tofu_token_key_id)) > 0);
+
+create index session_key_id_ix
+  on session (key_id);
+create index session_tofu_token_key_id_ix
+  on session (tofu_token_key_id);
+
+-- Replaces the function defined in 0/11_auth_token.up.sql to allow the token
+-- to be re-encrypted, which also changes its key_id.
+create or replace function
+  immutable_auth_token_columns()
+  returns trigger
+as $$
+begin
+  if new.auth_account_id is distinct from old.auth_account_id then
+    raise exception 'auth_account_id is read-only';
+  end if;
+  if new.token is distinct from old.token and new.key_id is not distinct from old.key_id then
+    raise exception 'token is read-only';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create index auth_token_key_id_ix
+  on auth_token (key_id);
+create index auth_api_token_key_id_ix
+  on auth_api_token (key_id);
+create index auth_password_argon2_cred_key_id_ix
+  on auth_password_argon2_cred (key_id);
+create index auth_password_imported_cred_key_id_ix
+  on auth_password_imported_cred (key_id);
+
+-- Replaces the trigger created in 09_auth_password_totp.up.sql to allow the
+-- secret to be re-encrypted.
+drop trigger immutable_columns on auth_password_account_totp;
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+
+create index auth_password_account_totp_key_id_ix
+  on auth_password_account_totp (key_id);
+
+-- Replaces the trigger created in 10_auth_password_policy.up.sql to allow the
+-- salt to be re-encrypted.
+drop trigger immutable_columns on auth_password_argon2_cred_history;
+
+create trigger
+  immutable_columns
+before
+update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('password_account_id', 'password_conf_id', 'derived_key', 'create_time');
+
+create index auth_password_argon2_cred_history_key_id_ix
+  on auth_password_argon2_cred_history (key_id);
+
+-- kms_key_version_reencryption contains the key versions whose encrypted
+-- values are being re-encrypted by the controllers.  key_version_id is not a
+-- foreign key since it can reference the versions of any DEK; the row is
+-- deleted when the key version is destroyed.
+create table kms_key_version_reencryption (
+  key_version_id wt_private_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on kms_key_version_reencryption
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on kms_key_version_reencryption
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on kms_key_version_reencryption
+  for each row execute procedure immutable_columns('key_version_id', 'scope_id', 'create_time');
+
+-- kms_key_version_reencryption_table tracks the progress of a re-encryption
+-- for each table containing values encrypted with the key version.
+-- total_count is the number of rows which referenced the key version when
+-- the re-encryption was started and remaining_count is the number of rows
+-- which still reference it.
+create table kms_key_version_reencryption_table (
+  key_version_id wt_private_id
+    references kms_key_version_reencryption(key_version_id)
+    on delete cascade
+    on update cascade,
+  table_name text not null
+    constraint table_name_must_not_be_empty
+    check(length(trim(table_name)) > 0),
+  total_count bigint not null default 0
+    constraint total_count_must_not_be_negative
+    check(total_count >= 0),
+  remaining_count bigint not null default 0
+    constraint remaining_count_must_not_be_negative
+    check(remaining_count >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  primary key(key_version_id, table_name)
+);
+
+create trigger
+  update_time_column
+before update on kms_key_version_reencryption_table
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on kms_key_version_reencryption_table
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on kms_key_version_reencryption_table
+  for each row execute procedure immutable_columns('key_version_id', 'table_name', 'create_time');
+
+commit;
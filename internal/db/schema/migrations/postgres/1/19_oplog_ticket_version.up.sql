@@ -0,0 +1,26 @@
+begin;
+
+-- ticket_version records the version of the aggregate's oplog ticket that was
+-- redeemed when the entry was written.  Since each redemption increments the
+-- ticket's version by one, the entries of an aggregate form a sequence that
+-- can be verified for gaps.  It is null for entries written before this
+-- migration.
+alter table oplog_entry
+  add column ticket_version bigint
+    constraint ticket_version_must_be_greater_than_0
+    check(ticket_version > 0);
+
+create index oplog_entry_aggregate_name_ticket_version_ix
+  on oplog_entry (aggregate_name, ticket_version);
+
+-- Replaces the trigger created in 1/18_kms_key_version_reencryption.up.sql to
+-- make the ticket version immutable.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version');
+
+commit;
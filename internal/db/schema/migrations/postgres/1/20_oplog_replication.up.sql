@@ -0,0 +1,35 @@
+begin;
+
+-- oplog_replication tracks the oplog entries of a primary database replayed
+-- into this database by "boundary database replicate".  Entries are replayed
+-- into the tables ending with table_suffix, so a database can hold several
+-- replicas; the suffix is empty when replaying into the tables themselves.
+-- last_entry_id is the id of the last entry replayed, which is updated in the
+-- same transaction as the replay of the entry.
+create table oplog_replication (
+  table_suffix text primary key,
+  last_entry_id bigint not null
+    constraint last_entry_id_must_be_greater_than_or_equal_to_0
+    check(last_entry_id >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on oplog_replication
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on oplog_replication
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on oplog_replication
+  for each row execute procedure immutable_columns('table_suffix', 'create_time');
+
+commit;
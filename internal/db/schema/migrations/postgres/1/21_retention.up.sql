@@ -0,0 +1,91 @@
+begin;
+
+-- session_retention_seconds is the time terminated sessions are retained for
+-- once their warehouse facts are final.  It can be set on any scope as a
+-- default for the sessions in that scope and its child scopes.  A null value
+-- means the setting is inherited.
+alter table iam_scope
+  add column session_retention_seconds int
+    constraint session_retention_seconds_must_be_positive
+    check(session_retention_seconds > 0);
+
+-- session_retention_policy resolves the session retention for each scope.  A
+-- setting on a project takes precedence over a setting on its org, which takes
+-- precedence over a setting on the global scope.  A null value means the
+-- controller's configured default applies.
+create view session_retention_policy as
+select
+  s.public_id as scope_id,
+  coalesce(
+    s.session_retention_seconds,
+    p.session_retention_seconds,
+    g.session_retention_seconds
+  ) as retention_seconds
+from
+  iam_scope s
+  left join iam_scope p
+    on s.parent_id = p.public_id
+  left join iam_scope g
+    on p.parent_id = g.public_id;
+
+-- The session states are searched by state and start time to find the
+-- sessions terminated before the retention period.
+create index session_state_state_start_time_ix
+  on session_state (state, start_time);
+
+-- oplog_entry_archive and oplog_metadata_archive hold the oplog entries, and
+-- their metadata, moved out of oplog_entry by the controller once they are
+-- older than the configured oplog retention.  Entries keep their ids.  There
+-- are no foreign keys so entries can be archived in batches without locking
+-- the oplog tables.
+create table oplog_entry_archive (
+  id bigint primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version text not null,
+  aggregate_name text not null,
+  "data" bytea not null,
+  key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  ticket_version bigint
+    constraint ticket_version_must_be_greater_than_0
+    check(ticket_version > 0),
+  archive_time wt_timestamp
+);
+
+create index oplog_entry_archive_key_id_ix
+  on oplog_entry_archive (key_id);
+
+-- Like oplog_entry, only the data and key_id of an archived entry can be
+-- updated, so it can be re-encrypted.
+create trigger
+  immutable_columns
+before
+update on oplog_entry_archive
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version', 'archive_time');
+
+create table oplog_metadata_archive (
+  id bigint primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  entry_id bigint not null,
+  "key" text not null,
+  value text null
+);
+
+create index oplog_metadata_archive_entry_id_ix
+  on oplog_metadata_archive (entry_id);
+
+create trigger
+  immutable_columns
+before
+update on oplog_metadata_archive
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'entry_id', 'key', 'value');
+
+-- oplog_entry is searched by create time to find the entries older than the
+-- retention period.
+create index oplog_entry_create_time_ix
+  on oplog_entry (create_time);
+
+commit;
@@ -0,0 +1,96 @@
+begin;
+
+-- job holds the jobs registered with the scheduler by the controllers.  A job
+-- is due once next_scheduled_run has passed, and is then run by a single
+-- controller.
+create table job (
+  name text primary key
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  description text not null,
+  next_scheduled_run timestamp with time zone not null default current_timestamp,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on job
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on job
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on job
+  for each row execute procedure immutable_columns('name', 'create_time');
+
+create table job_run_status_enm (
+  name text primary key
+    constraint only_predefined_job_run_statuses_allowed
+    check(name in ('running', 'completed', 'failed', 'interrupted'))
+);
+
+insert into job_run_status_enm (name)
+values
+  ('running'),
+  ('completed'),
+  ('failed'),
+  ('interrupted');
+
+-- job_run records the runs of the jobs.  server_id is the name of the
+-- controller running the job; a run is interrupted when its controller stops
+-- reporting its status before the run ends.
+create table job_run (
+  id bigint generated always as identity primary key,
+  job_name text not null
+    references job (name)
+    on delete cascade
+    on update cascade,
+  server_id text not null
+    constraint server_id_must_not_be_empty
+    check(length(trim(server_id)) > 0),
+  status text not null default 'running'
+    references job_run_status_enm (name)
+    on delete restrict
+    on update cascade,
+  error text,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  end_time timestamp with time zone,
+  constraint end_time_set_only_when_finished
+    check((status = 'running') = (end_time is null))
+);
+
+-- A job can only have one running run, which is how the controllers agree on
+-- which of them runs a job.
+create unique index job_run_running_uq
+  on job_run (job_name)
+  where status = 'running';
+
+create index job_run_job_name_id_ix
+  on job_run (job_name, id);
+
+create trigger
+  update_time_column
+before update on job_run
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on job_run
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on job_run
+  for each row execute procedure immutable_columns('id', 'job_name', 'server_id', 'create_time');
+
+commit;
@@ -0,0 +1,50 @@
+begin;
+
+-- transaction_id is the id of the transaction which wrote the entry.
+-- snapshot_xmax is the first transaction id which was not yet assigned once
+-- the entry's id was allocated.  Entry ids are allocated from a sequence
+-- before the transactions writing them commit, so a transaction can commit
+-- an entry with a lower id than an entry already committed.  The entries are
+-- written by the transactions of the changes they record, which have been
+-- assigned a transaction id by then, so every transaction which can still
+-- commit an entry with a lower id has a transaction id lower than the entry's
+-- snapshot_xmax.  Once the oldest running transaction is not older than an
+-- entry's snapshot_xmax, no entry with a lower id can be committed anymore.
+-- Both are null for entries written before this migration.
+alter table oplog_entry
+  add column transaction_id bigint,
+  add column snapshot_xmax bigint;
+
+create index oplog_entry_snapshot_xmax_ix
+  on oplog_entry (snapshot_xmax);
+
+create or replace function
+  oplog_entry_transaction()
+  returns trigger
+as $$
+begin
+  new.transaction_id = txid_current();
+  -- The expression is evaluated with a new snapshot in read committed
+  -- transactions, which is taken after the entry's id was allocated.
+  new.snapshot_xmax = txid_snapshot_xmax(txid_current_snapshot());
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  oplog_entry_transaction
+before
+insert on oplog_entry
+  for each row execute procedure oplog_entry_transaction();
+
+-- Replaces the trigger created in 1/19_oplog_ticket_version.up.sql to make the
+-- transaction columns immutable.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version', 'transaction_id', 'snapshot_xmax');
+
+commit;
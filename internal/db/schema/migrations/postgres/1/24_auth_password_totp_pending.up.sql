@@ -0,0 +1,97 @@
+begin;
+
+-- auth_password_account_totp_pending contains the TOTP secret of a new
+-- enrollment of an account which has not been confirmed with a valid TOTP
+-- code.  A pending enrollment is not used to authenticate, so an account
+-- keeps using its confirmed enrollment in auth_password_account_totp until
+-- the pending enrollment is confirmed and replaces it.
+create table auth_password_account_totp_pending (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  secret bytea not null  -- encrypted value
+    constraint secret_must_not_be_empty
+    check(length(secret) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_totp_pending
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_pending
+  for each row execute procedure default_create_time();
+
+-- the secret is not immutable so it can be re-encrypted
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_pending
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+
+create index auth_password_account_totp_pending_key_id_ix
+  on auth_password_account_totp_pending (key_id);
+
+-- auth_password_account_totp_pending_recovery_code contains the hashes of the
+-- recovery codes of a pending enrollment.  They replace the recovery codes of
+-- the confirmed enrollment when the pending enrollment is confirmed.
+create table auth_password_account_totp_pending_recovery_code (
+  account_id wt_public_id
+    references auth_password_account_totp_pending(account_id)
+    on delete cascade
+    on update cascade,
+  code_hash bytea not null
+    constraint code_hash_must_not_be_empty
+    check(length(code_hash) > 0),
+  create_time wt_timestamp,
+  primary key(account_id, code_hash)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_pending_recovery_code
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_pending_recovery_code
+  for each row execute procedure immutable_columns('account_id', 'code_hash', 'create_time');
+
+-- Enrollments which were never confirmed become pending enrollments, so
+-- auth_password_account_totp only contains confirmed enrollments.
+insert into auth_password_account_totp_pending
+  (account_id, secret, key_id, create_time)
+select account_id, secret, key_id, create_time
+  from auth_password_account_totp
+ where confirm_time is null;
+
+insert into auth_password_account_totp_pending_recovery_code
+  (account_id, code_hash, create_time)
+select code.account_id, code.code_hash, code.create_time
+  from auth_password_account_totp_recovery_code code
+  join auth_password_account_totp totp
+         on totp.account_id = code.account_id
+ where totp.confirm_time is null;
+
+delete from auth_password_account_totp
+ where confirm_time is null;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_account_totp_pending', 1),
+  ('auth_password_account_totp_pending_recovery_code', 1);
+
+commit;
@@ -4,7 +4,7 @@ package schema
 
 func init() {
 	migrationStates["postgres"] = migrationState{
-		binarySchemaVersion: 1003,
+		binarySchemaVersion: 1024,
 		upMigrations: map[int][]byte{
 			1: []byte(`
 create domain wt_public_id as text
@@ -4987,6 +4987,1885 @@ create trigger
 before insert on kms_oidc_key_version
 	for each row execute procedure kms_version_column('oidc_key_id');
 `),
+			1004: []byte(`
+-- iam_group_member_group is an association table that represents groups with
+-- associated groups.  A group which is a member of another group is often
+-- referred to as a nested group.
+create table iam_group_member_group (
+  create_time wt_timestamp,
+  group_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  member_id wt_public_id
+    references iam_group(public_id)
+    on delete cascade
+    on update cascade,
+  primary key (group_id, member_id),
+  constraint iam_group_member_group_not_self
+    check(group_id <> member_id)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on iam_group_member_group
+  for each row execute procedure default_create_time();
+
+create trigger iam_immutable_group_member
+before
+update on iam_group_member_group
+  for each row execute procedure iam_immutable_group_member();
+
+-- iam_group_member_group_no_cycle() ensures that adding a group as a member of
+-- another group will not create a membership cycle.  The table is locked in a
+-- self-conflicting mode so concurrent inserts cannot each pass the check and
+-- together form a cycle.
+create or replace function
+  iam_group_member_group_no_cycle()
+  returns trigger
+as $$
+begin
+  lock table iam_group_member_group in share row exclusive mode;
+  if exists (
+    with recursive
+    descendant_groups (group_id) as (
+      select new.member_id
+       union
+      select gmg.member_id
+        from iam_group_member_group gmg,
+             descendant_groups dg
+       where gmg.group_id = dg.group_id
+    )
+    select 1
+      from descendant_groups
+     where group_id = new.group_id
+  ) then
+    raise exception 'group % is a member of group %', new.group_id, new.member_id
+      using errcode = '23514', -- check_violation
+            constraint = 'iam_group_member_group_no_cycle';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger iam_group_member_group_no_cycle
+before
+insert on iam_group_member_group
+  for each row execute procedure iam_group_member_group_no_cycle();
+
+-- Replaces the view created in 06_iam to include groups which are members of
+-- groups.
+drop view iam_group_member;
+create view iam_group_member as
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.member_id = u.public_id and
+  gm.group_id = g.public_id
+union
+select
+  gm.create_time,
+  gm.group_id,
+  gm.member_id,
+  mg.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, mg.scope_id, gm.member_id) as scoped_member_id,
+  'group' as type
+from
+  iam_group_member_group gm,
+  iam_group mg,
+  iam_group g
+where
+  gm.member_id = mg.public_id and
+  gm.group_id = g.public_id;
+
+-- iam_group_member_effective provides the effective user members of every
+-- group: the users which are members of the group directly or which are
+-- members of any group nested (at any depth) within the group.  create_time
+-- is the earliest time the user became a member through any path.
+create view iam_group_member_effective as
+with recursive
+group_tree (group_id, member_group_id) as (
+  select public_id,
+         public_id
+    from iam_group
+   union
+  select gt.group_id,
+         gmg.member_id
+    from group_tree gt,
+         iam_group_member_group gmg
+   where gmg.group_id = gt.member_group_id
+)
+select
+  min(gm.create_time) as create_time,
+  gt.group_id,
+  gm.member_id,
+  u.scope_id as member_scope_id,
+  g.scope_id as group_scope_id,
+  get_scoped_member_id(g.scope_id, u.scope_id, gm.member_id) as scoped_member_id,
+  'user' as type
+from
+  group_tree gt,
+  iam_group_member_user gm,
+  iam_user u,
+  iam_group g
+where
+  gm.group_id = gt.member_group_id and
+  gm.member_id = u.public_id and
+  gt.group_id = g.public_id
+group by
+  gt.group_id,
+  gm.member_id,
+  u.scope_id,
+  g.scope_id;
+`),
+			1005: []byte(`
+-- A service account is a user intended for use by automation rather than by
+-- a person.  Service accounts cannot be associated with auth accounts and
+-- therefore cannot authenticate with an auth method; they authenticate with
+-- api tokens instead.  Whether or not a user is a service account is set when
+-- the user is created and cannot be changed.
+alter table iam_user
+  add column service_account boolean not null default false,
+  add constraint iam_user_predefined_not_service_account
+    check(
+      not service_account or
+      public_id not in ('u_anon', 'u_auth', 'u_recovery')
+    );
+
+create trigger
+  immutable_service_account_column
+before
+update on iam_user
+  for each row execute procedure immutable_columns('service_account');
+
+-- auth_account_iam_user_not_service_account() ensures an auth account is never
+-- associated with a service account.
+create or replace function
+  auth_account_iam_user_not_service_account()
+  returns trigger
+as $$
+begin
+  if new.iam_user_id is not null then
+    perform
+      from iam_user
+     where public_id = new.iam_user_id
+       and service_account;
+    if found then
+      raise exception 'user % is a service account', new.iam_user_id
+        using errcode = '23514', -- check_violation
+              constraint = 'auth_account_iam_user_not_service_account';
+    end if;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_account_iam_user_not_service_account
+before
+insert or update of iam_user_id on auth_account
+  for each row execute procedure auth_account_iam_user_not_service_account();
+
+-- an api token belongs to 1 and only 1 service account
+-- a service account can have 0 to many api tokens
+create table auth_api_token (
+  public_id wt_public_id primary key,
+  token bytea not null unique,
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  scope_id wt_scope_id not null,
+  iam_user_id wt_user_id not null,
+  name text,
+  description text,
+  -- grant_scope_id is the scope the grants restricting the api token are
+  -- applied to.
+  grant_scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  -- approximate_last_access_time is null until the api token is first used.
+  -- It is not updated every time this api token is used.  It is updated
+  -- after X minutes from the last time it was updated on a per row basis.
+  -- Any update which sets this column sets it to the current time.
+  approximate_last_access_time timestamp with time zone
+    constraint last_access_time_must_not_be_after_expiration_time
+    check(
+      approximate_last_access_time <= expiration_time
+    ),
+  expiration_time timestamp with time zone not null
+    constraint create_time_must_be_before_expiration_time
+    check(
+      create_time < expiration_time
+    ),
+  foreign key (scope_id, iam_user_id)
+    references iam_user (scope_id, public_id)
+    on delete cascade
+    on update cascade,
+  unique(iam_user_id, name)
+);
+
+-- auth_api_token_service_account_only() ensures api tokens are only issued to
+-- service accounts.
+create or replace function
+  auth_api_token_service_account_only()
+  returns trigger
+as $$
+begin
+  perform
+    from iam_user
+   where public_id = new.iam_user_id
+     and service_account;
+  if not found then
+    raise exception 'user % is not a service account', new.iam_user_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_api_token_service_account_only';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_api_token_service_account_only
+before
+insert on auth_api_token
+  for each row execute procedure auth_api_token_service_account_only();
+
+-- grant_scope_id_valid() is defined in 0/06_iam.up.sql and applies the same
+-- rules to the grant scope of api tokens that it applies to roles.
+create trigger
+  ensure_grant_scope_id_valid
+before
+insert on auth_api_token
+  for each row execute procedure grant_scope_id_valid();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_api_token
+  for each row execute procedure default_create_time();
+
+create trigger
+  update_time_column
+before
+update on auth_api_token
+  for each row execute procedure update_time_column();
+
+-- update_api_token_last_access_time() sets approximate_last_access_time to
+-- the current time.  Unlike update_last_access_time() it does not depend on the
+-- new value being distinct from the old value, since the column is null until
+-- the api token is first used.
+create or replace function
+  update_api_token_last_access_time()
+  returns trigger
+as $$
+begin
+  new.approximate_last_access_time = now();
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  update_api_token_last_access_time
+before
+update of approximate_last_access_time on auth_api_token
+  for each row execute procedure update_api_token_last_access_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_api_token
+  for each row execute procedure immutable_columns('public_id', 'scope_id', 'iam_user_id', 'grant_scope_id', 'create_time');
+
+-- auth_api_token_grant contains the grants which restrict what an api token
+-- is allowed to do.  An api token without any grants is allowed to do
+-- everything its service account is allowed to do.  An api token with grants
+-- is only allowed to do what is allowed by both its grants and the grants of
+-- its service account.
+create table auth_api_token_grant (
+  create_time wt_timestamp,
+  api_token_id wt_public_id not null
+    references auth_api_token(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text not null
+    constraint canonical_grant_must_not_be_empty
+    check(length(trim(canonical_grant)) > 0),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(length(trim(raw_grant)) > 0),
+  primary key(api_token_id, canonical_grant)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_api_token_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_api_token_grant
+  for each row execute procedure immutable_columns('create_time', 'api_token_id', 'canonical_grant', 'raw_grant');
+`),
+			1006: []byte(`
+-- A derived auth token is a short-lived auth token which was exchanged for an
+-- existing (parent) auth token.  It belongs to the same auth account as its
+-- parent and is only allowed to do what is allowed by both its grants and the
+-- grants of the user of its parent.  Deleting the parent auth token deletes
+-- all of the auth tokens derived from it.
+alter table auth_token
+  add column parent_id wt_public_id
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  -- grant_scope_id is the scope the grants restricting a derived auth token
+  -- are applied to.
+  add column grant_scope_id wt_scope_id
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  add constraint auth_token_derived_grant_scope_id
+    check(
+      (parent_id is null) = (grant_scope_id is null)
+    );
+
+-- auth_token_derived_from_parent() ensures a derived auth token belongs to the
+-- same auth account as its parent, does not outlive its parent, and is not
+-- derived from another derived auth token.
+create or replace function
+  auth_token_derived_from_parent()
+  returns trigger
+as $$
+declare parent record;
+begin
+  if new.parent_id is null then
+    return new;
+  end if;
+  select auth_account_id, parent_id, expiration_time
+    into parent
+    from auth_token
+   where public_id = new.parent_id;
+  if not found then
+    raise exception 'parent auth token % not found', new.parent_id;
+  end if;
+  if parent.parent_id is not null then
+    raise exception 'auth token % is a derived auth token', new.parent_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_token_derived_from_parent';
+  end if;
+  if parent.auth_account_id is distinct from new.auth_account_id then
+    raise exception 'auth account does not match parent auth token %', new.parent_id
+      using errcode = '23514', -- check_violation
+            constraint = 'auth_token_derived_from_parent';
+  end if;
+  if new.expiration_time > parent.expiration_time then
+    new.expiration_time = parent.expiration_time;
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  auth_token_derived_from_parent
+before
+insert on auth_token
+  for each row execute procedure auth_token_derived_from_parent();
+
+drop trigger immutable_columns on auth_token;
+
+create trigger
+  immutable_columns
+before
+update on auth_token
+  for each row execute procedure immutable_columns('public_id', 'auth_account_id', 'create_time', 'parent_id', 'grant_scope_id');
+
+create or replace view auth_token_account as
+      select at.public_id,
+             at.token,
+             at.auth_account_id,
+             at.create_time,
+             at.update_time,
+             at.approximate_last_access_time,
+             at.expiration_time,
+             aa.scope_id,
+             aa.iam_user_id,
+             aa.auth_method_id,
+             at.parent_id,
+             at.grant_scope_id
+        from auth_token as at
+  inner join auth_account as aa
+          on at.auth_account_id = aa.public_id;
+
+-- auth_token_grant contains the grants which restrict what a derived auth
+-- token is allowed to do.
+create table auth_token_grant (
+  create_time wt_timestamp,
+  auth_token_id wt_public_id not null
+    references auth_token(public_id)
+    on delete cascade
+    on update cascade,
+  canonical_grant text not null
+    constraint canonical_grant_must_not_be_empty
+    check(length(trim(canonical_grant)) > 0),
+  raw_grant text not null
+    constraint raw_grant_must_not_be_empty
+    check(length(trim(raw_grant)) > 0),
+  primary key(auth_token_id, canonical_grant)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_token_grant
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_token_grant
+  for each row execute procedure immutable_columns('create_time', 'auth_token_id', 'canonical_grant', 'raw_grant');
+`),
+			1007: []byte(`
+-- The lifetime of the auth tokens issued by an auth method is controlled by
+-- two durations: auth_token_time_to_live_seconds, the total time an auth token
+-- is valid for, and auth_token_time_to_stale_seconds, the time an auth token can
+-- go unused before it becomes invalid.  Both can be set on an auth method and,
+-- as defaults for the auth methods within it, on a scope.  A null value means
+-- the setting is inherited.
+alter table auth_password_method
+  add column auth_token_time_to_live_seconds int
+    constraint auth_token_time_to_live_seconds_must_be_positive
+    check(auth_token_time_to_live_seconds > 0),
+  add column auth_token_time_to_stale_seconds int
+    constraint auth_token_time_to_stale_seconds_must_be_positive
+    check(auth_token_time_to_stale_seconds > 0);
+
+alter table iam_scope
+  add column auth_token_time_to_live_seconds int
+    constraint auth_token_time_to_live_seconds_must_be_positive
+    check(auth_token_time_to_live_seconds > 0),
+  add column auth_token_time_to_stale_seconds int
+    constraint auth_token_time_to_stale_seconds_must_be_positive
+    check(auth_token_time_to_stale_seconds > 0);
+
+-- auth_token_policy resolves the auth token durations for each auth method.  A
+-- setting on the auth method takes precedence over a setting on the scope of
+-- the auth method, which takes precedence over a setting on the parent of that
+-- scope.  A null value means the controller's configured default applies.
+create view auth_token_policy as
+select
+  am.public_id as auth_method_id,
+  am.scope_id  as scope_id,
+  coalesce(
+    am.auth_token_time_to_live_seconds,
+    s.auth_token_time_to_live_seconds,
+    p.auth_token_time_to_live_seconds
+  ) as time_to_live_seconds,
+  coalesce(
+    am.auth_token_time_to_stale_seconds,
+    s.auth_token_time_to_stale_seconds,
+    p.auth_token_time_to_stale_seconds
+  ) as time_to_stale_seconds
+from
+  auth_password_method am
+  join iam_scope s
+    on am.scope_id = s.public_id
+  left join iam_scope p
+    on s.parent_id = p.public_id;
+`),
+			1008: []byte(`
+-- An account of a password auth method is locked after lockout_threshold
+-- consecutive failed authentication attempts.  The first lockout lasts
+-- lockout_duration_seconds and each consecutive lockout lasts twice as long as
+-- the previous one, up to a maximum of 24 hours.  A null lockout_threshold
+-- disables lockouts and a null lockout_duration_seconds means the default
+-- duration is used.
+alter table auth_password_method
+  add column lockout_threshold int
+    constraint lockout_threshold_must_be_positive
+    check(lockout_threshold > 0),
+  add column lockout_duration_seconds int
+    constraint lockout_duration_seconds_must_be_positive
+    check(lockout_duration_seconds > 0);
+
+-- auth_password_account_lockout contains the failed authentication attempts
+-- and lockouts of an account.  It is maintained by the password repository
+-- while authenticating and is not replicated, so changes to it do not change
+-- the version of the account.
+create table auth_password_account_lockout (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  failed_attempt_count int not null default 0
+    constraint failed_attempt_count_must_not_be_negative
+    check(failed_attempt_count >= 0),
+  lockout_count int not null default 0
+    constraint lockout_count_must_not_be_negative
+    check(lockout_count >= 0),
+  last_failed_attempt_time timestamp with time zone,
+  locked_until_time timestamp with time zone,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_lockout
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_lockout
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_lockout
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+`),
+			1009: []byte(`
+-- mfa_policy controls whether the accounts of a password auth method must
+-- use a time-based one-time password (TOTP) as a second factor.  With the
+-- 'optional' policy only accounts which have enrolled in TOTP must provide a
+-- TOTP code.  With the 'required' policy accounts which have not enrolled in
+-- TOTP can not authenticate.  A null mfa_policy is the same as 'optional'.
+alter table auth_password_method
+  add column mfa_policy text
+    constraint mfa_policy_must_be_optional_or_required
+    check(mfa_policy in ('optional', 'required'));
+
+-- auth_password_account_totp contains the TOTP secret of an account.  An
+-- enrollment is not used to authenticate until it is confirmed with a valid
+-- TOTP code.  last_counter is the time step of the last TOTP code used to
+-- authenticate, which prevents a code from being used more than once.
+create table auth_password_account_totp (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  secret bytea not null  -- encrypted value
+    constraint secret_must_not_be_empty
+    check(length(secret) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  confirm_time timestamp with time zone,
+  last_counter bigint,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_totp
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp
+  for each row execute procedure immutable_columns('account_id', 'secret', 'create_time');
+
+-- auth_password_account_totp_recovery_code contains the hashes of the
+-- single-use recovery codes issued when an account enrolls in TOTP.  A
+-- recovery code can be used instead of a TOTP code and is deleted when used.
+create table auth_password_account_totp_recovery_code (
+  account_id wt_public_id
+    references auth_password_account_totp(account_id)
+    on delete cascade
+    on update cascade,
+  code_hash bytea not null
+    constraint code_hash_must_not_be_empty
+    check(length(code_hash) > 0),
+  create_time wt_timestamp,
+  primary key(account_id, code_hash)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_recovery_code
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_recovery_code
+  for each row execute procedure immutable_columns('account_id', 'code_hash', 'create_time');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_account_totp', 1),
+  ('auth_password_account_totp_recovery_code', 1);
+`),
+			1010: []byte(`
+-- The password policy of a password auth method.  The password_require_*
+-- columns require passwords to contain at least one character of the
+-- respective class.  password_history_count is the number of most recent
+-- passwords of an account, including the current one, which can not be
+-- reused.  max_password_age_seconds is how long a password can be used before
+-- it must be changed.  A null value disables the respective rule.
+alter table auth_password_method
+  add column password_require_uppercase boolean,
+  add column password_require_lowercase boolean,
+  add column password_require_digit boolean,
+  add column password_require_symbol boolean,
+  add column password_history_count int
+    constraint password_history_count_must_be_positive
+    check(password_history_count > 0),
+  add column max_password_age_seconds int
+    constraint max_password_age_seconds_must_be_positive
+    check(max_password_age_seconds > 0);
+
+-- auth_password_method_banned_password contains the passwords which can not
+-- be used by the accounts of a password auth method.  Passwords are compared
+-- case insensitively so they are stored in lower case.
+create table auth_password_method_banned_password (
+  password_method_id wt_public_id
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  password text not null
+    constraint password_must_not_be_empty
+    check(length(password) > 0)
+    constraint password_must_be_lower_case
+    check(password = lower(password)),
+  create_time wt_timestamp,
+  primary key(password_method_id, password)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_method_banned_password
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_method_banned_password
+  for each row execute procedure immutable_columns('password_method_id', 'password', 'create_time');
+
+-- auth_password_argon2_cred_history contains the previous passwords of an
+-- account.  When the password of an account is changed, the salt and derived
+-- key of the replaced credential are copied into it.  It is maintained by the
+-- password repository and is not replicated.
+create table auth_password_argon2_cred_history (
+  password_account_id wt_public_id
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  password_conf_id wt_private_id not null
+    references auth_password_argon2_conf(private_id)
+    on delete cascade
+    on update cascade,
+  salt bytea not null -- encrypted value
+    constraint salt_must_not_be_empty
+    check(length(salt) > 0),
+  derived_key bytea not null
+    constraint derived_key_must_not_be_empty
+    check(length(derived_key) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  primary key(password_account_id, derived_key)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_argon2_cred_history
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('password_account_id', 'password_conf_id', 'salt', 'derived_key', 'key_id', 'create_time');
+
+-- auth_password_current_conf is recreated to include the password policy of
+-- each password auth method.
+drop view auth_password_current_conf;
+create view auth_password_current_conf as
+    select pm.min_login_name_length, pm.min_password_length,
+           coalesce(pm.password_require_uppercase, false) as password_require_uppercase,
+           coalesce(pm.password_require_lowercase, false) as password_require_lowercase,
+           coalesce(pm.password_require_digit, false)     as password_require_digit,
+           coalesce(pm.password_require_symbol, false)    as password_require_symbol,
+           coalesce(pm.password_history_count, 0)         as password_history_count,
+           c.*
+      from auth_password_method pm
+inner join auth_password_conf_union c
+        on pm.password_conf_id = c.password_conf_id;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_method_banned_password', 1);
+`),
+			1011: []byte(`
+-- auth_password_imported_cred is a subtype of auth_password_credential for
+-- password hashes imported from other systems.  An imported credential does
+-- not have a password configuration, it is replaced with an
+-- auth_password_argon2_cred the next time the account authenticates.
+create table auth_password_imported_cred (
+  private_id wt_private_id primary key
+    references auth_password_credential (private_id)
+    on delete cascade
+    on update cascade,
+  password_account_id wt_public_id not null,
+  -- The password_method_id type is not wt_public_id because the domain check
+  -- is executed before the insert trigger which retrieves the
+  -- password_method_id causing an insert to fail.
+  password_method_id text not null,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  hash_type text not null
+    constraint hash_type_must_be_supported
+    check(hash_type in ('bcrypt', 'pbkdf2-sha1', 'pbkdf2-sha256', 'pbkdf2-sha512', 'scrypt')),
+  password_hash bytea not null -- encrypted value
+    constraint password_hash_must_not_be_empty
+    check(length(password_hash) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  foreign key (password_method_id, password_account_id)
+    references auth_password_account (auth_method_id, public_id)
+    on delete cascade
+    on update cascade
+);
+
+-- insert_auth_password_imported_cred_subtype() is a before insert trigger
+-- function for auth_password_imported_cred.  It differs from
+-- insert_auth_password_credential_subtype() in that imported credentials do
+-- not have a password_conf_id.
+create function
+  insert_auth_password_imported_cred_subtype()
+  returns trigger
+as $$
+begin
+  select auth_password_account.auth_method_id
+    into new.password_method_id
+  from auth_password_account
+  where auth_password_account.public_id = new.password_account_id;
+
+  insert into auth_password_credential
+    (private_id, password_account_id, password_method_id)
+  values
+    (new.private_id, new.password_account_id, new.password_method_id);
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  insert_auth_password_imported_cred_subtype
+before insert on auth_password_imported_cred
+  for each row execute procedure insert_auth_password_imported_cred_subtype();
+
+create trigger
+  delete_auth_password_credential_subtype
+after delete on auth_password_imported_cred
+  for each row execute procedure delete_auth_password_credential_subtype();
+
+create trigger
+  update_time_column
+before
+update on auth_password_imported_cred
+  for each row execute procedure update_time_column();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_imported_cred
+  for each row execute procedure immutable_columns('create_time');
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_imported_cred
+  for each row execute procedure default_create_time();
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_imported_cred', 1);
+`),
+			1012: []byte(`
+-- auth_password_reset_token contains the hashes of the one-time tokens which
+-- can be used to reset the password of an account without authenticating.
+-- A token is deleted when it is used and can not be used after its
+-- expiration_time.  Reset tokens are not replicated, so the table does not
+-- have an oplog ticket.
+create table auth_password_reset_token (
+  token_hash bytea primary key
+    constraint token_hash_must_not_be_empty
+    check(length(token_hash) > 0),
+  account_id wt_public_id not null
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  expiration_time wt_timestamp
+    constraint expiration_time_must_be_after_create_time
+    check(expiration_time > create_time)
+);
+
+create index auth_password_reset_token_account_id_ix
+  on auth_password_reset_token(account_id);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_reset_token
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_reset_token
+  for each row execute procedure immutable_columns('token_hash', 'account_id', 'create_time', 'expiration_time');
+`),
+			1013: []byte(`
+-- auth_password_invite contains the invitations to enroll in a password auth
+-- method.  Redeeming an invite creates an account in the auth method, a user
+-- in the scope of the auth method, associates the account with the user, and
+-- adds the user to the roles of the invite.  An invite can only be redeemed
+-- once, before its expiration_time.  Only the hash of the invite token is
+-- stored.
+create table auth_password_invite (
+  public_id wt_public_id primary key,
+  auth_method_id wt_public_id not null
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  name text,
+  description text,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version wt_version,
+  token_hash bytea not null unique
+    constraint token_hash_must_not_be_empty
+    check(length(token_hash) > 0),
+  expiration_time wt_timestamp
+    constraint expiration_time_must_be_after_create_time
+    check(expiration_time > create_time),
+  -- redeem_time, account_id and iam_user_id are null until the invite is
+  -- redeemed.  account_id and iam_user_id are set to null if the account or
+  -- user is deleted but redeem_time is kept so the invite can not be
+  -- redeemed again.
+  redeem_time timestamp with time zone,
+  account_id wt_public_id
+    references auth_password_account(public_id)
+    on delete set null
+    on update cascade,
+  iam_user_id wt_user_id
+    references iam_user(public_id)
+    on delete set null
+    on update cascade,
+  unique(auth_method_id, name)
+);
+
+create index auth_password_invite_auth_method_id_ix
+  on auth_password_invite(auth_method_id);
+
+create trigger
+  update_version_column
+after update on auth_password_invite
+  for each row execute procedure update_version_column();
+
+create trigger
+  update_time_column
+before
+update on auth_password_invite
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_invite
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_invite
+  for each row execute procedure immutable_columns('public_id', 'auth_method_id', 'create_time', 'token_hash', 'expiration_time');
+
+-- auth_password_invite_role contains the roles the user created by redeeming
+-- an invite is added to.
+create table auth_password_invite_role (
+  invite_id wt_public_id not null
+    references auth_password_invite(public_id)
+    on delete cascade
+    on update cascade,
+  role_id wt_role_id not null
+    references iam_role(public_id)
+    on delete cascade
+    on update cascade,
+  primary key(invite_id, role_id)
+);
+
+create trigger
+  immutable_columns
+before
+update on auth_password_invite_role
+  for each row execute procedure immutable_columns('invite_id', 'role_id');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_invite', 1),
+  ('auth_password_invite_role', 1);
+`),
+			1014: []byte(`
+-- A user or a password account which is not active is disabled: it cannot
+-- authenticate and the auth tokens issued to it are deleted when it is
+-- disabled.  Re-enabling it restores access.
+alter table iam_user
+  add column active boolean not null default true;
+
+alter table auth_password_account
+  add column active boolean not null default true;
+`),
+			1015: []byte(`
+-- access revoked is the termination reason of sessions which were canceled
+-- because their user no longer has access to them: the user lost the
+-- authorize-session grant on the target, or the auth token or the target of
+-- the session was deleted.
+alter table session_termination_reason_enm
+  drop constraint only_predefined_session_termination_reasons_allowed;
+
+alter table session_termination_reason_enm
+  add constraint only_predefined_session_termination_reasons_allowed
+  check (
+    name in (
+      'unknown',
+      'timed out',
+      'closed by end-user',
+      'terminated',
+      'network error',
+      'system error',
+      'connection limit',
+      'canceled',
+      'access revoked'
+    )
+  );
+
+insert into session_termination_reason_enm (name)
+values
+  ('access revoked');
+
+-- session_access_revoked records the sessions which were canceled because
+-- access to them was revoked, so they are terminated with the 'access
+-- revoked' reason instead of 'canceled'.
+create table session_access_revoked (
+  session_id wt_public_id primary key
+    references session (public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp
+);
+
+create trigger
+  default_create_time_column
+before
+insert on session_access_revoked
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on session_access_revoked
+  for each row execute procedure immutable_columns('session_id', 'create_time');
+
+-- cancel_session_with_null_fk replaces the function defined in
+-- 0/50_session.up.sql so sessions are also recorded as revoked when their
+-- user, target or auth token is deleted.
+create or replace function
+  cancel_session_with_null_fk()
+  returns trigger
+as $$
+begin
+  case
+    when new.user_id is null or new.target_id is null or new.auth_token_id is null then
+      insert into session_access_revoked (session_id)
+      values
+        (new.public_id)
+      on conflict do nothing;
+      perform cancel_session(new.public_id);
+    when new.host_id is null then
+      perform cancel_session(new.public_id);
+    when new.host_set_id is null then
+      perform cancel_session(new.public_id);
+    when new.scope_id is null then
+      perform cancel_session(new.public_id);
+  end case;
+  return new;
+end;
+$$ language plpgsql;
+
+-- terminate_session_if_possible replaces the function defined in
+-- 0/51_connection.up.sql so revoked sessions are terminated with the 'access
+-- revoked' reason once all their connections are closed.
+--
+--      Note: this function should align closely with the domain function
+--      TerminateCompletedSessions
+create or replace function 
+    terminate_session_if_possible(terminate_session_id text)
+    returns void
+  as $$
+  begin 
+    -- is terminate_session_id in a canceling state
+    with canceling_session(session_id) as
+    (
+      select 
+        session_id
+      from
+        session_state ss
+      where 
+        ss.session_id = terminate_session_id and
+        ss.state = 'canceling' and 
+        ss.end_time is null
+    )
+    update session us
+      set termination_reason = 
+      case 
+        -- sessions canceled because access to them was revoked
+        when us.public_id in (
+          select
+            session_id
+          from
+            session_access_revoked sar
+          where
+            us.public_id = sar.session_id
+          ) then 'access revoked'
+        -- timed out sessions
+        when now() > us.expiration_time then 'timed out'
+        -- canceling sessions
+        when us.public_id in(
+          select 
+            session_id 
+          from 
+            canceling_session cs 
+          where
+            us.public_id = cs.session_id
+          ) then 'canceled' 
+        -- default: session connection limit reached.
+        else 'connection limit'
+      end
+    where
+      -- limit update to just the terminating_session_id
+      us.public_id = terminate_session_id and
+      termination_reason is null and
+      -- session expired or connection limit reached
+      (
+        -- expired sessions...
+        now() > us.expiration_time or 
+        -- connection limit reached...
+        (
+          -- handle unlimited connections...
+          connection_limit != -1 and
+          (
+            select count (*) 
+              from session_connection sc 
+            where 
+              sc.session_id = us.public_id
+          ) >= connection_limit
+        ) or 
+        -- canceled sessions
+        us.public_id in (
+          select 
+            session_id
+          from
+            canceling_session cs
+          where 
+            us.public_id = cs.session_id 
+        )
+      ) and 
+      -- make sure there are no existing connections
+      us.public_id not in (
+        select 
+          session_id 
+        from 
+            session_connection
+          where public_id in (
+          select 
+            connection_id
+          from 
+            session_connection_state
+          where 
+            state != 'closed' and
+            end_time is null
+        )
+    );
+ end;
+  $$ language plpgsql;
+`),
+			1016: []byte(`
+-- Client cidr restrictions limit the client addresses which can authenticate
+-- with a password auth method, or authorize and connect to a session of a
+-- target.  A client address must not be within any of the deny rules and, if
+-- there are any allow rules, must be within one of them.
+create table auth_password_method_client_cidr (
+  password_method_id wt_public_id
+    references auth_password_method(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(password_method_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_method_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_method_client_cidr
+  for each row execute procedure immutable_columns('password_method_id', 'cidr', 'rule', 'create_time');
+
+create table target_client_cidr (
+  target_id wt_public_id
+    references target(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(target_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on target_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on target_client_cidr
+  for each row execute procedure immutable_columns('target_id', 'cidr', 'rule', 'create_time');
+
+-- session_client_cidr contains the client cidr restrictions of the target of
+-- a session when the session was authorized.  Workers check the address of
+-- each client connecting to the session against them.  Like the worker
+-- filter, they are not stored in the warehouse.
+create table session_client_cidr (
+  session_id wt_public_id
+    references session(public_id)
+    on delete cascade
+    on update cascade,
+  cidr cidr not null,
+  rule text not null
+    constraint rule_must_be_allow_or_deny
+    check(rule in ('allow', 'deny')),
+  create_time wt_timestamp,
+  primary key(session_id, cidr, rule)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on session_client_cidr
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on session_client_cidr
+  for each row execute procedure immutable_columns('session_id', 'cidr', 'rule', 'create_time');
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_method_client_cidr', 1),
+  ('target_client_cidr', 1);
+`),
+			1017: []byte(`
+-- An access window limits when sessions of a tcp target can be authorized
+-- and used.  A window opens each time the cron expression in
+-- access_window_schedule fires, evaluated in access_window_time_zone, and
+-- stays open for access_window_duration_seconds.  Targets without a schedule
+-- can be accessed at any time.
+alter table target_tcp
+  add column access_window_schedule text
+    constraint access_window_schedule_must_not_be_empty
+    check(length(trim(access_window_schedule)) > 0),
+  add column access_window_duration_seconds int
+    constraint access_window_duration_seconds_must_be_greater_than_0
+    check(access_window_duration_seconds > 0),
+  add column access_window_time_zone text
+    constraint access_window_time_zone_must_not_be_empty
+    check(length(trim(access_window_time_zone)) > 0),
+  add constraint access_window_schedule_and_duration_must_be_set_together
+    check(
+      (access_window_schedule is null and access_window_duration_seconds is null)
+        or
+      (access_window_schedule is not null and access_window_duration_seconds is not null)
+    ),
+  add constraint access_window_time_zone_requires_schedule
+    check(access_window_time_zone is null or access_window_schedule is not null);
+
+-- Replaces the view created in 01 to include the access window
+drop view target_all_subtypes;
+create view target_all_subtypes
+as
+select
+  public_id,
+  scope_id,
+  name,
+  description,
+  default_port,
+  session_max_seconds,
+  session_connection_limit,
+  version,
+  create_time,
+  update_time,
+  worker_filter,
+  access_window_schedule,
+  access_window_duration_seconds,
+  access_window_time_zone,
+  'tcp' as type
+from target_tcp;
+`),
+			1018: []byte(`
+-- Data encryption key (DEK) versions are retired by re-encrypting the values
+-- encrypted with them with the current version of the key, after which they
+-- can be destroyed.  Each table holding encrypted values records the id of the
+-- key version used in a key_id column, which is indexed so the values
+-- encrypted with a key version can be found.
+
+-- oplog_entry records the oplog key version used to encrypt the entry's data.
+-- The key_id of entries written before this migration is null until the
+-- controller backfills it from the encrypted data.
+alter table oplog_entry
+  add column key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0);
+
+create index oplog_entry_key_id_ix
+  on oplog_entry (key_id);
+
+-- Replaces the trigger created in 0/02_oplog.up.sql to allow the data of an
+-- entry to be re-encrypted.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+-- session.key_id is the sessions key version the session's certificate key
+-- is derived from.  tofu_token_key_id is the database key version used to
+-- encrypt the tofu token.  It is null for tokens encrypted before this
+-- migration until the controller backfills it from the encrypted token.
+alter table session
+  add column tofu_token_key_id text
+    constraint tofu_token_key_id_must_not_be_empty
+    check(length(trim(tofu_token_key_id)) > 0);
+
+create index session_key_id_ix
+  on session (key_id);
+create index session_tofu_token_key_id_ix
+  on session (tofu_token_key_id);
+
+-- Replaces the function defined in 0/11_auth_token.up.sql to allow the token
+-- to be re-encrypted, which also changes its key_id.
+create or replace function
+  immutable_auth_token_columns()
+  returns trigger
+as $$
+begin
+  if new.auth_account_id is distinct from old.auth_account_id then
+    raise exception 'auth_account_id is read-only';
+  end if;
+  if new.token is distinct from old.token and new.key_id is not distinct from old.key_id then
+    raise exception 'token is read-only';
+  end if;
+  return new;
+end;
+$$ language plpgsql;
+
+create index auth_token_key_id_ix
+  on auth_token (key_id);
+create index auth_api_token_key_id_ix
+  on auth_api_token (key_id);
+create index auth_password_argon2_cred_key_id_ix
+  on auth_password_argon2_cred (key_id);
+create index auth_password_imported_cred_key_id_ix
+  on auth_password_imported_cred (key_id);
+
+-- Replaces the trigger created in 09_auth_password_totp.up.sql to allow the
+-- secret to be re-encrypted.
+drop trigger immutable_columns on auth_password_account_totp;
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+
+create index auth_password_account_totp_key_id_ix
+  on auth_password_account_totp (key_id);
+
+-- Replaces the trigger created in 10_auth_password_policy.up.sql to allow the
+-- salt to be re-encrypted.
+drop trigger immutable_columns on auth_password_argon2_cred_history;
+
+create trigger
+  immutable_columns
+before
+update on auth_password_argon2_cred_history
+  for each row execute procedure immutable_columns('password_account_id', 'password_conf_id', 'derived_key', 'create_time');
+
+create index auth_password_argon2_cred_history_key_id_ix
+  on auth_password_argon2_cred_history (key_id);
+
+-- kms_key_version_reencryption contains the key versions whose encrypted
+-- values are being re-encrypted by the controllers.  key_version_id is not a
+-- foreign key since it can reference the versions of any DEK; the row is
+-- deleted when the key version is destroyed.
+create table kms_key_version_reencryption (
+  key_version_id wt_private_id primary key,
+  scope_id wt_scope_id not null
+    references iam_scope(public_id)
+    on delete cascade
+    on update cascade,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on kms_key_version_reencryption
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on kms_key_version_reencryption
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on kms_key_version_reencryption
+  for each row execute procedure immutable_columns('key_version_id', 'scope_id', 'create_time');
+
+-- kms_key_version_reencryption_table tracks the progress of a re-encryption
+-- for each table containing values encrypted with the key version.
+-- total_count is the number of rows which referenced the key version when
+-- the re-encryption was started and remaining_count is the number of rows
+-- which still reference it.
+create table kms_key_version_reencryption_table (
+  key_version_id wt_private_id
+    references kms_key_version_reencryption(key_version_id)
+    on delete cascade
+    on update cascade,
+  table_name text not null
+    constraint table_name_must_not_be_empty
+    check(length(trim(table_name)) > 0),
+  total_count bigint not null default 0
+    constraint total_count_must_not_be_negative
+    check(total_count >= 0),
+  remaining_count bigint not null default 0
+    constraint remaining_count_must_not_be_negative
+    check(remaining_count >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  primary key(key_version_id, table_name)
+);
+
+create trigger
+  update_time_column
+before update on kms_key_version_reencryption_table
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on kms_key_version_reencryption_table
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on kms_key_version_reencryption_table
+  for each row execute procedure immutable_columns('key_version_id', 'table_name', 'create_time');
+`),
+			1019: []byte(`
+-- ticket_version records the version of the aggregate's oplog ticket that was
+-- redeemed when the entry was written.  Since each redemption increments the
+-- ticket's version by one, the entries of an aggregate form a sequence that
+-- can be verified for gaps.  It is null for entries written before this
+-- migration.
+alter table oplog_entry
+  add column ticket_version bigint
+    constraint ticket_version_must_be_greater_than_0
+    check(ticket_version > 0);
+
+create index oplog_entry_aggregate_name_ticket_version_ix
+  on oplog_entry (aggregate_name, ticket_version);
+
+-- Replaces the trigger created in 1/18_kms_key_version_reencryption.up.sql to
+-- make the ticket version immutable.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version');
+`),
+			1020: []byte(`
+-- oplog_replication tracks the oplog entries of a primary database replayed
+-- into this database by "boundary database replicate".  Entries are replayed
+-- into the tables ending with table_suffix, so a database can hold several
+-- replicas; the suffix is empty when replaying into the tables themselves.
+-- last_entry_id is the id of the last entry replayed, which is updated in the
+-- same transaction as the replay of the entry.
+create table oplog_replication (
+  table_suffix text primary key,
+  last_entry_id bigint not null
+    constraint last_entry_id_must_be_greater_than_or_equal_to_0
+    check(last_entry_id >= 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on oplog_replication
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on oplog_replication
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on oplog_replication
+  for each row execute procedure immutable_columns('table_suffix', 'create_time');
+`),
+			1021: []byte(`
+-- session_retention_seconds is the time terminated sessions are retained for
+-- once their warehouse facts are final.  It can be set on any scope as a
+-- default for the sessions in that scope and its child scopes.  A null value
+-- means the setting is inherited.
+alter table iam_scope
+  add column session_retention_seconds int
+    constraint session_retention_seconds_must_be_positive
+    check(session_retention_seconds > 0);
+
+-- session_retention_policy resolves the session retention for each scope.  A
+-- setting on a project takes precedence over a setting on its org, which takes
+-- precedence over a setting on the global scope.  A null value means the
+-- controller's configured default applies.
+create view session_retention_policy as
+select
+  s.public_id as scope_id,
+  coalesce(
+    s.session_retention_seconds,
+    p.session_retention_seconds,
+    g.session_retention_seconds
+  ) as retention_seconds
+from
+  iam_scope s
+  left join iam_scope p
+    on s.parent_id = p.public_id
+  left join iam_scope g
+    on p.parent_id = g.public_id;
+
+-- The session states are searched by state and start time to find the
+-- sessions terminated before the retention period.
+create index session_state_state_start_time_ix
+  on session_state (state, start_time);
+
+-- oplog_entry_archive and oplog_metadata_archive hold the oplog entries, and
+-- their metadata, moved out of oplog_entry by the controller once they are
+-- older than the configured oplog retention.  Entries keep their ids.  There
+-- are no foreign keys so entries can be archived in batches without locking
+-- the oplog tables.
+create table oplog_entry_archive (
+  id bigint primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  version text not null,
+  aggregate_name text not null,
+  "data" bytea not null,
+  key_id text
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  ticket_version bigint
+    constraint ticket_version_must_be_greater_than_0
+    check(ticket_version > 0),
+  archive_time wt_timestamp
+);
+
+create index oplog_entry_archive_key_id_ix
+  on oplog_entry_archive (key_id);
+
+-- Like oplog_entry, only the data and key_id of an archived entry can be
+-- updated, so it can be re-encrypted.
+create trigger
+  immutable_columns
+before
+update on oplog_entry_archive
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version', 'archive_time');
+
+create table oplog_metadata_archive (
+  id bigint primary key,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  entry_id bigint not null,
+  "key" text not null,
+  value text null
+);
+
+create index oplog_metadata_archive_entry_id_ix
+  on oplog_metadata_archive (entry_id);
+
+create trigger
+  immutable_columns
+before
+update on oplog_metadata_archive
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'entry_id', 'key', 'value');
+
+-- oplog_entry is searched by create time to find the entries older than the
+-- retention period.
+create index oplog_entry_create_time_ix
+  on oplog_entry (create_time);
+`),
+			1022: []byte(`
+-- job holds the jobs registered with the scheduler by the controllers.  A job
+-- is due once next_scheduled_run has passed, and is then run by a single
+-- controller.
+create table job (
+  name text primary key
+    constraint name_must_not_be_empty
+    check(length(trim(name)) > 0),
+  description text not null,
+  next_scheduled_run timestamp with time zone not null default current_timestamp,
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on job
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on job
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on job
+  for each row execute procedure immutable_columns('name', 'create_time');
+
+create table job_run_status_enm (
+  name text primary key
+    constraint only_predefined_job_run_statuses_allowed
+    check(name in ('running', 'completed', 'failed', 'interrupted'))
+);
+
+insert into job_run_status_enm (name)
+values
+  ('running'),
+  ('completed'),
+  ('failed'),
+  ('interrupted');
+
+-- job_run records the runs of the jobs.  server_id is the name of the
+-- controller running the job; a run is interrupted when its controller stops
+-- reporting its status before the run ends.
+create table job_run (
+  id bigint generated always as identity primary key,
+  job_name text not null
+    references job (name)
+    on delete cascade
+    on update cascade,
+  server_id text not null
+    constraint server_id_must_not_be_empty
+    check(length(trim(server_id)) > 0),
+  status text not null default 'running'
+    references job_run_status_enm (name)
+    on delete restrict
+    on update cascade,
+  error text,
+  create_time wt_timestamp,
+  update_time wt_timestamp,
+  end_time timestamp with time zone,
+  constraint end_time_set_only_when_finished
+    check((status = 'running') = (end_time is null))
+);
+
+-- A job can only have one running run, which is how the controllers agree on
+-- which of them runs a job.
+create unique index job_run_running_uq
+  on job_run (job_name)
+  where status = 'running';
+
+create index job_run_job_name_id_ix
+  on job_run (job_name, id);
+
+create trigger
+  update_time_column
+before update on job_run
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on job_run
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on job_run
+  for each row execute procedure immutable_columns('id', 'job_name', 'server_id', 'create_time');
+`),
+			1023: []byte(`
+-- transaction_id is the id of the transaction which wrote the entry.
+-- snapshot_xmax is the first transaction id which was not yet assigned once
+-- the entry's id was allocated.  Entry ids are allocated from a sequence
+-- before the transactions writing them commit, so a transaction can commit
+-- an entry with a lower id than an entry already committed.  The entries are
+-- written by the transactions of the changes they record, which have been
+-- assigned a transaction id by then, so every transaction which can still
+-- commit an entry with a lower id has a transaction id lower than the entry's
+-- snapshot_xmax.  Once the oldest running transaction is not older than an
+-- entry's snapshot_xmax, no entry with a lower id can be committed anymore.
+-- Both are null for entries written before this migration.
+alter table oplog_entry
+  add column transaction_id bigint,
+  add column snapshot_xmax bigint;
+
+create index oplog_entry_snapshot_xmax_ix
+  on oplog_entry (snapshot_xmax);
+
+create or replace function
+  oplog_entry_transaction()
+  returns trigger
+as $$
+begin
+  new.transaction_id = txid_current();
+  -- The expression is evaluated with a new snapshot in read committed
+  -- transactions, which is taken after the entry's id was allocated.
+  new.snapshot_xmax = txid_snapshot_xmax(txid_current_snapshot());
+  return new;
+end;
+$$ language plpgsql;
+
+create trigger
+  oplog_entry_transaction
+before
+insert on oplog_entry
+  for each row execute procedure oplog_entry_transaction();
+
+-- Replaces the trigger created in 1/19_oplog_ticket_version.up.sql to make the
+-- transaction columns immutable.
+drop trigger immutable_columns on oplog_entry;
+
+create trigger
+  immutable_columns
+before
+update on oplog_entry
+  for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'ticket_version', 'transaction_id', 'snapshot_xmax');
+`),
+			1024: []byte(`
+-- auth_password_account_totp_pending contains the TOTP secret of a new
+-- enrollment of an account which has not been confirmed with a valid TOTP
+-- code.  A pending enrollment is not used to authenticate, so an account
+-- keeps using its confirmed enrollment in auth_password_account_totp until
+-- the pending enrollment is confirmed and replaces it.
+create table auth_password_account_totp_pending (
+  account_id wt_public_id
+    primary key
+    references auth_password_account(public_id)
+    on delete cascade
+    on update cascade,
+  secret bytea not null  -- encrypted value
+    constraint secret_must_not_be_empty
+    check(length(secret) > 0),
+  key_id text not null
+    constraint key_id_must_not_be_empty
+    check(length(trim(key_id)) > 0),
+  create_time wt_timestamp,
+  update_time wt_timestamp
+);
+
+create trigger
+  update_time_column
+before update on auth_password_account_totp_pending
+  for each row execute procedure update_time_column();
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_pending
+  for each row execute procedure default_create_time();
+
+-- the secret is not immutable so it can be re-encrypted
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_pending
+  for each row execute procedure immutable_columns('account_id', 'create_time');
+
+create index auth_password_account_totp_pending_key_id_ix
+  on auth_password_account_totp_pending (key_id);
+
+-- auth_password_account_totp_pending_recovery_code contains the hashes of the
+-- recovery codes of a pending enrollment.  They replace the recovery codes of
+-- the confirmed enrollment when the pending enrollment is confirmed.
+create table auth_password_account_totp_pending_recovery_code (
+  account_id wt_public_id
+    references auth_password_account_totp_pending(account_id)
+    on delete cascade
+    on update cascade,
+  code_hash bytea not null
+    constraint code_hash_must_not_be_empty
+    check(length(code_hash) > 0),
+  create_time wt_timestamp,
+  primary key(account_id, code_hash)
+);
+
+create trigger
+  default_create_time_column
+before
+insert on auth_password_account_totp_pending_recovery_code
+  for each row execute procedure default_create_time();
+
+create trigger
+  immutable_columns
+before
+update on auth_password_account_totp_pending_recovery_code
+  for each row execute procedure immutable_columns('account_id', 'code_hash', 'create_time');
+
+-- Enrollments which were never confirmed become pending enrollments, so
+-- auth_password_account_totp only contains confirmed enrollments.
+insert into auth_password_account_totp_pending
+  (account_id, secret, key_id, create_time)
+select account_id, secret, key_id, create_time
+  from auth_password_account_totp
+ where confirm_time is null;
+
+insert into auth_password_account_totp_pending_recovery_code
+  (account_id, code_hash, create_time)
+select code.account_id, code.code_hash, code.create_time
+  from auth_password_account_totp_recovery_code code
+  join auth_password_account_totp totp
+         on totp.account_id = code.account_id
+ where totp.confirm_time is null;
+
+delete from auth_password_account_totp
+ where confirm_time is null;
+
+insert into oplog_ticket
+  (name, version)
+values
+  ('auth_password_account_totp_pending', 1),
+  ('auth_password_account_totp_pending_recovery_code', 1);
+`),
+		},
+		upMigrationNames: map[int]string{
+			1:    "0/01_domain_types.up.sql",
+			2:    "0/02_oplog.up.sql",
+			3:    "0/03_db.up.sql",
+			6:    "0/06_iam.up.sql",
+			7:    "0/07_auth.up.sql",
+			8:    "0/08_servers.up.sql",
+			11:   "0/11_auth_token.up.sql",
+			12:   "0/12_auth_password.up.sql",
+			13:   "0/13_auth_password_argon.up.sql",
+			14:   "0/14_auth_password_views.up.sql",
+			20:   "0/20_host.up.sql",
+			22:   "0/22_static_host.up.sql",
+			30:   "0/30_keys.up.sql",
+			31:   "0/31_keys.up.sql",
+			40:   "0/40_targets.up.sql",
+			41:   "0/41_targets.up.sql",
+			50:   "0/50_session.up.sql",
+			51:   "0/51_connection.up.sql",
+			60:   "0/60_wh_domain_types.up.sql",
+			62:   "0/62_wh_datetime.up.sql",
+			65:   "0/65_wh_session_dimensions.up.sql",
+			66:   "0/66_wh_session_dimensions.up.sql",
+			68:   "0/68_wh_session_facts.up.sql",
+			69:   "0/69_wh_session_facts.up.sql",
+			1001: "1/01_server_tags_migrations.up.sql",
+			1002: "1/02_domains.up.sql",
+			1003: "1/03_kms.up.sql",
+			1004: "1/04_iam_nested_groups.up.sql",
+			1005: "1/05_service_accounts.up.sql",
+			1006: "1/06_derived_auth_tokens.up.sql",
+			1007: "1/07_auth_token_policy.up.sql",
+			1008: "1/08_auth_password_lockout.up.sql",
+			1009: "1/09_auth_password_totp.up.sql",
+			1010: "1/10_auth_password_policy.up.sql",
+			1011: "1/11_auth_password_imported_cred.up.sql",
+			1012: "1/12_auth_password_reset_token.up.sql",
+			1013: "1/13_auth_password_invite.up.sql",
+			1014: "1/14_active_users_accounts.up.sql",
+			1015: "1/15_session_access_revoked.up.sql",
+			1016: "1/16_client_cidr_restrictions.up.sql",
+			1017: "1/17_target_access_windows.up.sql",
+			1018: "1/18_kms_key_version_reencryption.up.sql",
+			1019: "1/19_oplog_ticket_version.up.sql",
+			1020: "1/20_oplog_replication.up.sql",
+			1021: "1/21_retention.up.sql",
+			1022: "1/22_job.up.sql",
+			1023: "1/23_oplog_entry_transaction.up.sql",
+			1024: "1/24_auth_password_totp_pending.up.sql",
 		},
 	}
 }
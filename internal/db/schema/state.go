@@ -10,6 +10,9 @@ type migrationState struct {
 	binarySchemaVersion int
 
 	upMigrations map[int][]byte
+	// upMigrationNames contains the file name of each up migration, relative
+	// to the dialect's migrations directory.
+	upMigrationNames map[int]string
 }
 
 // migrationStates is populated by the generated migration code with the key being the dialect.
@@ -32,3 +35,36 @@ func BinarySchemaVersion(dialect string) int {
 	}
 	return ms.binarySchemaVersion
 }
+
+// Migration is a migration of the schema contained in the binary.
+type Migration struct {
+	// Version is the schema version of the database once the migration has
+	// run.
+	Version int
+	// Name is the file name of the migration, relative to the dialect's
+	// migrations directory.
+	Name string
+	// Statements are the SQL statements run by the migration.
+	Statements []byte
+}
+
+// PendingMigrations returns the migrations this binary runs for the provided
+// dialect to update a database at the provided schema version, in the order
+// they are run.  A version of -1 indicates no version is set, in which case
+// all the migrations are returned.
+func PendingMigrations(dialect string, fromVersion int) []Migration {
+	var names map[int]string
+	if ms, ok := migrationStates[dialect]; ok {
+		names = ms.upMigrationNames
+	}
+	var migrations []Migration
+	qp := newStatementProvider(dialect, fromVersion)
+	for qp.Next() {
+		migrations = append(migrations, Migration{
+			Version:    qp.Version(),
+			Name:       names[qp.Version()],
+			Statements: qp.ReadUp(),
+		})
+	}
+	return migrations
+}
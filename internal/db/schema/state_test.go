@@ -12,3 +12,28 @@ func TestBinarySchemaVersion(t *testing.T) {
 	assert.Equal(t, 3, BinarySchemaVersion(dialect))
 	assert.Equal(t, nilVersion, BinarySchemaVersion("unknown_dialect"))
 }
+
+func TestPendingMigrations(t *testing.T) {
+	dialect := "test_pendingmigrations"
+	migrationStates[dialect] = migrationState{
+		binarySchemaVersion: 3,
+		upMigrations: map[int][]byte{
+			1: []byte("one"),
+			2: []byte("two"),
+			3: []byte("three"),
+		},
+		upMigrationNames: map[int]string{
+			1: "0/01_one.up.sql",
+			2: "0/02_two.up.sql",
+			3: "0/03_three.up.sql",
+		},
+	}
+
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "0/02_two.up.sql", Statements: []byte("two")},
+		{Version: 3, Name: "0/03_three.up.sql", Statements: []byte("three")},
+	}, PendingMigrations(dialect, 1))
+	assert.Len(t, PendingMigrations(dialect, nilVersion), 3)
+	assert.Empty(t, PendingMigrations(dialect, 3))
+	assert.Empty(t, PendingMigrations("unknown_dialect", nilVersion))
+}
@@ -36,6 +36,9 @@ const (
 	TokenMismatch            Code = 112 // TokenMismatch represents that there was a token mismatch
 	TooShort                 Code = 113 // TooShort represents an error that means the provided input is not meeting minimum length requirements
 	AccountAlreadyAssociated Code = 114 // AccountAlreadyAssociated represents an attempt to associate an account failed since it was already associated.
+	SessionQuotaExceeded     Code = 115 // SessionQuotaExceeded represents that creating a session would exceed a session quota
+	KeyVersionInUse          Code = 116 // KeyVersionInUse represents that a key version can not be destroyed since it is still in use
+	DerivedAuthToken         Code = 117 // DerivedAuthToken represents an attempt to derive an auth token from a derived auth token
 
 	// PasswordTooShort results from attempting to set a password which is to short.
 	PasswordTooShort Code = 200
@@ -52,6 +55,40 @@ const (
 	// new passwords are equal.
 	PasswordsEqual Code = 203
 
+	// TotpCodeRequired is returned from Authenticate when the account has
+	// enrolled in TOTP and neither a TOTP code nor a recovery code is
+	// provided.
+	TotpCodeRequired Code = 204
+
+	// TotpEnrollmentRequired is returned from Authenticate when the auth
+	// method requires TOTP and the account has not enrolled in TOTP.
+	TotpEnrollmentRequired Code = 205
+
+	// TotpInvalidCode is returned from ConfirmTotp when the provided TOTP
+	// code is not valid.
+	TotpInvalidCode Code = 206
+
+	// PasswordPolicyViolation results from attempting to set a password which
+	// violates the password policy of the auth method.
+	PasswordPolicyViolation Code = 207
+
+	// PasswordExpired is returned from Authenticate when the password of the
+	// account has expired and no new password is provided.
+	PasswordExpired Code = 208
+
+	// PasswordUnsupportedHash results from attempting to import a password
+	// hash which is malformed or uses an unsupported algorithm.
+	PasswordUnsupportedHash Code = 209
+
+	// PasswordResetTokenInvalid results from attempting to reset a password
+	// with a reset token which does not exist, has expired, or has already
+	// been used.
+	PasswordResetTokenInvalid Code = 210
+
+	// PasswordInviteInvalid results from attempting to redeem an invite which
+	// does not exist, has expired, or has already been redeemed.
+	PasswordInviteInvalid Code = 211
+
 	Encrypt Code = 300 // Encrypt represents an error occurred during the underlying encryption process
 	Decrypt Code = 301 // Decrypt represents an error occurred during the underlying decryption process
 	Encode  Code = 302 // Encode represents an error occurred during the underlying encoding/marshaling process
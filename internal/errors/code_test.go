@@ -102,6 +102,21 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    AccountAlreadyAssociated,
 			want: AccountAlreadyAssociated,
 		},
+		{
+			name: "SessionQuotaExceeded",
+			c:    SessionQuotaExceeded,
+			want: SessionQuotaExceeded,
+		},
+		{
+			name: "KeyVersionInUse",
+			c:    KeyVersionInUse,
+			want: KeyVersionInUse,
+		},
+		{
+			name: "DerivedAuthToken",
+			c:    DerivedAuthToken,
+			want: DerivedAuthToken,
+		},
 		{
 			name: "InternalError",
 			c:    Internal,
@@ -132,6 +147,46 @@ func TestCode_Both_String_Info(t *testing.T) {
 			c:    PasswordsEqual,
 			want: PasswordsEqual,
 		},
+		{
+			name: "TotpCodeRequired",
+			c:    TotpCodeRequired,
+			want: TotpCodeRequired,
+		},
+		{
+			name: "TotpEnrollmentRequired",
+			c:    TotpEnrollmentRequired,
+			want: TotpEnrollmentRequired,
+		},
+		{
+			name: "TotpInvalidCode",
+			c:    TotpInvalidCode,
+			want: TotpInvalidCode,
+		},
+		{
+			name: "PasswordPolicyViolation",
+			c:    PasswordPolicyViolation,
+			want: PasswordPolicyViolation,
+		},
+		{
+			name: "PasswordExpired",
+			c:    PasswordExpired,
+			want: PasswordExpired,
+		},
+		{
+			name: "PasswordUnsupportedHash",
+			c:    PasswordUnsupportedHash,
+			want: PasswordUnsupportedHash,
+		},
+		{
+			name: "PasswordResetTokenInvalid",
+			c:    PasswordResetTokenInvalid,
+			want: PasswordResetTokenInvalid,
+		},
+		{
+			name: "PasswordInviteInvalid",
+			c:    PasswordInviteInvalid,
+			want: PasswordInviteInvalid,
+		},
 		{
 			name: "Encrypt",
 			c:    Encrypt,
@@ -76,6 +76,18 @@ var errorCodeInfo = map[Code]Info{
 		Message: "account already associated with another user",
 		Kind:    Parameter,
 	},
+	SessionQuotaExceeded: {
+		Message: "session quota exceeded",
+		Kind:    Integrity,
+	},
+	KeyVersionInUse: {
+		Message: "key version in use",
+		Kind:    Integrity,
+	},
+	DerivedAuthToken: {
+		Message: "derived auth token",
+		Kind:    Parameter,
+	},
 	PasswordTooShort: {
 		Message: "too short",
 		Kind:    Password,
@@ -92,6 +104,38 @@ var errorCodeInfo = map[Code]Info{
 		Message: "old and new password are equal",
 		Kind:    Password,
 	},
+	TotpCodeRequired: {
+		Message: "totp code required",
+		Kind:    Password,
+	},
+	TotpEnrollmentRequired: {
+		Message: "totp enrollment required",
+		Kind:    Password,
+	},
+	TotpInvalidCode: {
+		Message: "invalid totp code",
+		Kind:    Password,
+	},
+	PasswordPolicyViolation: {
+		Message: "password policy violation",
+		Kind:    Password,
+	},
+	PasswordExpired: {
+		Message: "password expired",
+		Kind:    Password,
+	},
+	PasswordUnsupportedHash: {
+		Message: "unsupported password hash",
+		Kind:    Password,
+	},
+	PasswordResetTokenInvalid: {
+		Message: "invalid password reset token",
+		Kind:    Password,
+	},
+	PasswordInviteInvalid: {
+		Message: "invalid invite",
+		Kind:    Password,
+	},
 	Encrypt: {
 		Message: "error occurred during encrypt",
 		Kind:    Encryption,
@@ -51,6 +51,26 @@ type AuthMethodServiceClient interface {
 	// AuthenticateLogin validates credentials provided for a single-step login
 	// action and returns an auth token.
 	AuthenticateLogin(ctx context.Context, in *AuthenticateLoginRequest, opts ...grpc.CallOption) (*AuthenticateLoginResponse, error)
+	// ResetPassword sets the password of the Account a reset token was created
+	// for. The reset token can only be used once. The new password must meet
+	// the password policy of the Auth Method. It does not require an
+	// authenticated user and is authorized like Authenticate.
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	// RedeemInvite redeems an Invite of the Auth Method, creating an Account
+	// with the provided login name and password and a User associated with the
+	// Account which is added to the Roles of the Invite. An Invite can only be
+	// redeemed once. The password must meet the password policy of the Auth
+	// Method. It does not require an authenticated user and is authorized like
+	// Authenticate.
+	RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*RedeemInviteResponse, error)
+	// EnrollAccountTotp creates a pending TOTP enrollment for the Account with
+	// the provided login name and password when the Auth Method requires TOTP
+	// and the Account has not enrolled. The TOTP secret and the recovery codes
+	// are only returned in the response to this request. The enrollment is
+	// confirmed by authenticating with a TOTP code generated from the secret.
+	// It does not require an authenticated user and is authorized like
+	// Authenticate.
+	EnrollAccountTotp(ctx context.Context, in *EnrollAccountTotpRequest, opts ...grpc.CallOption) (*EnrollAccountTotpResponse, error)
 }
 
 type authMethodServiceClient struct {
@@ -125,6 +145,33 @@ func (c *authMethodServiceClient) AuthenticateLogin(ctx context.Context, in *Aut
 	return out, nil
 }
 
+func (c *authMethodServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) RedeemInvite(ctx context.Context, in *RedeemInviteRequest, opts ...grpc.CallOption) (*RedeemInviteResponse, error) {
+	out := new(RedeemInviteResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/RedeemInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authMethodServiceClient) EnrollAccountTotp(ctx context.Context, in *EnrollAccountTotpRequest, opts ...grpc.CallOption) (*EnrollAccountTotpResponse, error) {
+	out := new(EnrollAccountTotpResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthMethodService/EnrollAccountTotp", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthMethodServiceServer is the server API for AuthMethodService service.
 // All implementations must embed UnimplementedAuthMethodServiceServer
 // for forward compatibility
@@ -162,6 +209,26 @@ type AuthMethodServiceServer interface {
 	// AuthenticateLogin validates credentials provided for a single-step login
 	// action and returns an auth token.
 	AuthenticateLogin(context.Context, *AuthenticateLoginRequest) (*AuthenticateLoginResponse, error)
+	// ResetPassword sets the password of the Account a reset token was created
+	// for. The reset token can only be used once. The new password must meet
+	// the password policy of the Auth Method. It does not require an
+	// authenticated user and is authorized like Authenticate.
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// RedeemInvite redeems an Invite of the Auth Method, creating an Account
+	// with the provided login name and password and a User associated with the
+	// Account which is added to the Roles of the Invite. An Invite can only be
+	// redeemed once. The password must meet the password policy of the Auth
+	// Method. It does not require an authenticated user and is authorized like
+	// Authenticate.
+	RedeemInvite(context.Context, *RedeemInviteRequest) (*RedeemInviteResponse, error)
+	// EnrollAccountTotp creates a pending TOTP enrollment for the Account with
+	// the provided login name and password when the Auth Method requires TOTP
+	// and the Account has not enrolled. The TOTP secret and the recovery codes
+	// are only returned in the response to this request. The enrollment is
+	// confirmed by authenticating with a TOTP code generated from the secret.
+	// It does not require an authenticated user and is authorized like
+	// Authenticate.
+	EnrollAccountTotp(context.Context, *EnrollAccountTotpRequest) (*EnrollAccountTotpResponse, error)
 	mustEmbedUnimplementedAuthMethodServiceServer()
 }
 
@@ -190,6 +257,15 @@ func (UnimplementedAuthMethodServiceServer) Authenticate(context.Context, *Authe
 func (UnimplementedAuthMethodServiceServer) AuthenticateLogin(context.Context, *AuthenticateLoginRequest) (*AuthenticateLoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthenticateLogin not implemented")
 }
+func (UnimplementedAuthMethodServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) RedeemInvite(context.Context, *RedeemInviteRequest) (*RedeemInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemInvite not implemented")
+}
+func (UnimplementedAuthMethodServiceServer) EnrollAccountTotp(context.Context, *EnrollAccountTotpRequest) (*EnrollAccountTotpResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnrollAccountTotp not implemented")
+}
 func (UnimplementedAuthMethodServiceServer) mustEmbedUnimplementedAuthMethodServiceServer() {}
 
 // UnsafeAuthMethodServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -329,6 +405,60 @@ func _AuthMethodService_AuthenticateLogin_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthMethodService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_RedeemInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).RedeemInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/RedeemInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).RedeemInvite(ctx, req.(*RedeemInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthMethodService_EnrollAccountTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollAccountTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthMethodServiceServer).EnrollAccountTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthMethodService/EnrollAccountTotp",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthMethodServiceServer).EnrollAccountTotp(ctx, req.(*EnrollAccountTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthMethodService_ServiceDesc is the grpc.ServiceDesc for AuthMethodService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -364,6 +494,18 @@ var AuthMethodService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AuthenticateLogin",
 			Handler:    _AuthMethodService_AuthenticateLogin_Handler,
 		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _AuthMethodService_ResetPassword_Handler,
+		},
+		{
+			MethodName: "RedeemInvite",
+			Handler:    _AuthMethodService_RedeemInvite_Handler,
+		},
+		{
+			MethodName: "EnrollAccountTotp",
+			Handler:    _AuthMethodService_EnrollAccountTotp_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/auth_method_service.proto",
@@ -30,6 +30,13 @@ type AuthTokenServiceClient interface {
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(ctx context.Context, in *DeleteAuthTokenRequest, opts ...grpc.CallOption) (*DeleteAuthTokenResponse, error)
+	// DeriveAuthToken exchanges the Auth Token used to make the request for a
+	// new, short-lived Auth Token which is only allowed to do what is allowed by
+	// both the provided grants and the grants of the original Auth Token's User.
+	// The provided id must be the id of the Auth Token used to make the request,
+	// and Auth Tokens cannot be derived from derived Auth Tokens.  The new token
+	// value is returned in the response.
+	DeriveAuthToken(ctx context.Context, in *DeriveAuthTokenRequest, opts ...grpc.CallOption) (*DeriveAuthTokenResponse, error)
 }
 
 type authTokenServiceClient struct {
@@ -67,6 +74,15 @@ func (c *authTokenServiceClient) DeleteAuthToken(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *authTokenServiceClient) DeriveAuthToken(ctx context.Context, in *DeriveAuthTokenRequest, opts ...grpc.CallOption) (*DeriveAuthTokenResponse, error) {
+	out := new(DeriveAuthTokenResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.AuthTokenService/DeriveAuthToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthTokenServiceServer is the server API for AuthTokenService service.
 // All implementations must embed UnimplementedAuthTokenServiceServer
 // for forward compatibility
@@ -83,6 +99,13 @@ type AuthTokenServiceServer interface {
 	// DeleteAuthToken removes a Auth Token from Boundary. If the provided
 	// Auth Token id is malformed or not provided an error is returned.
 	DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error)
+	// DeriveAuthToken exchanges the Auth Token used to make the request for a
+	// new, short-lived Auth Token which is only allowed to do what is allowed by
+	// both the provided grants and the grants of the original Auth Token's User.
+	// The provided id must be the id of the Auth Token used to make the request,
+	// and Auth Tokens cannot be derived from derived Auth Tokens.  The new token
+	// value is returned in the response.
+	DeriveAuthToken(context.Context, *DeriveAuthTokenRequest) (*DeriveAuthTokenResponse, error)
 	mustEmbedUnimplementedAuthTokenServiceServer()
 }
 
@@ -99,6 +122,9 @@ func (UnimplementedAuthTokenServiceServer) ListAuthTokens(context.Context, *List
 func (UnimplementedAuthTokenServiceServer) DeleteAuthToken(context.Context, *DeleteAuthTokenRequest) (*DeleteAuthTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAuthToken not implemented")
 }
+func (UnimplementedAuthTokenServiceServer) DeriveAuthToken(context.Context, *DeriveAuthTokenRequest) (*DeriveAuthTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeriveAuthToken not implemented")
+}
 func (UnimplementedAuthTokenServiceServer) mustEmbedUnimplementedAuthTokenServiceServer() {}
 
 // UnsafeAuthTokenServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -166,6 +192,24 @@ func _AuthTokenService_DeleteAuthToken_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthTokenService_DeriveAuthToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeriveAuthTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthTokenServiceServer).DeriveAuthToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.AuthTokenService/DeriveAuthToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthTokenServiceServer).DeriveAuthToken(ctx, req.(*DeriveAuthTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthTokenService_ServiceDesc is the grpc.ServiceDesc for AuthTokenService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,6 +229,10 @@ var AuthTokenService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAuthToken",
 			Handler:    _AuthTokenService_DeleteAuthToken_Handler,
 		},
+		{
+			MethodName: "DeriveAuthToken",
+			Handler:    _AuthTokenService_DeriveAuthToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/authtokens_service.proto",
@@ -0,0 +1,239 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package services
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// InviteServiceClient is the client API for InviteService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InviteServiceClient interface {
+	// GetInvite returns a stored Invite if present. The provided request must
+	// include the id for the Invite be retrieved. If missing, malformed or
+	// referencing a non existing Invite an error is returned.
+	GetInvite(ctx context.Context, in *GetInviteRequest, opts ...grpc.CallOption) (*GetInviteResponse, error)
+	// ListInvites returns a list of stored Invites which exist inside the
+	// provided Auth Method, including Invites which have been redeemed or have
+	// expired. The request must include the Auth Method id which contains the
+	// Invites being listed. If missing or malformed an error is returned.
+	ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error)
+	// CreateInvite creates and stores an Invite in boundary. The provided
+	// request must include the Auth Method ID in which the Account of the
+	// invitee will be created. The token of the Invite is only returned in the
+	// response of this call. The caller must be allowed to add principals to
+	// every Role of the Invite.
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error)
+	// DeleteInvite removes an Invite from Boundary. Deleting an Invite which
+	// has not been redeemed revokes it. If the provided Invite Id is malformed
+	// or not provided an error is returned.
+	DeleteInvite(ctx context.Context, in *DeleteInviteRequest, opts ...grpc.CallOption) (*DeleteInviteResponse, error)
+}
+
+type inviteServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInviteServiceClient(cc grpc.ClientConnInterface) InviteServiceClient {
+	return &inviteServiceClient{cc}
+}
+
+func (c *inviteServiceClient) GetInvite(ctx context.Context, in *GetInviteRequest, opts ...grpc.CallOption) (*GetInviteResponse, error) {
+	out := new(GetInviteResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.InviteService/GetInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inviteServiceClient) ListInvites(ctx context.Context, in *ListInvitesRequest, opts ...grpc.CallOption) (*ListInvitesResponse, error) {
+	out := new(ListInvitesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.InviteService/ListInvites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inviteServiceClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error) {
+	out := new(CreateInviteResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.InviteService/CreateInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inviteServiceClient) DeleteInvite(ctx context.Context, in *DeleteInviteRequest, opts ...grpc.CallOption) (*DeleteInviteResponse, error) {
+	out := new(DeleteInviteResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.InviteService/DeleteInvite", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InviteServiceServer is the server API for InviteService service.
+// All implementations must embed UnimplementedInviteServiceServer
+// for forward compatibility
+type InviteServiceServer interface {
+	// GetInvite returns a stored Invite if present. The provided request must
+	// include the id for the Invite be retrieved. If missing, malformed or
+	// referencing a non existing Invite an error is returned.
+	GetInvite(context.Context, *GetInviteRequest) (*GetInviteResponse, error)
+	// ListInvites returns a list of stored Invites which exist inside the
+	// provided Auth Method, including Invites which have been redeemed or have
+	// expired. The request must include the Auth Method id which contains the
+	// Invites being listed. If missing or malformed an error is returned.
+	ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error)
+	// CreateInvite creates and stores an Invite in boundary. The provided
+	// request must include the Auth Method ID in which the Account of the
+	// invitee will be created. The token of the Invite is only returned in the
+	// response of this call. The caller must be allowed to add principals to
+	// every Role of the Invite.
+	CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error)
+	// DeleteInvite removes an Invite from Boundary. Deleting an Invite which
+	// has not been redeemed revokes it. If the provided Invite Id is malformed
+	// or not provided an error is returned.
+	DeleteInvite(context.Context, *DeleteInviteRequest) (*DeleteInviteResponse, error)
+	mustEmbedUnimplementedInviteServiceServer()
+}
+
+// UnimplementedInviteServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInviteServiceServer struct {
+}
+
+func (UnimplementedInviteServiceServer) GetInvite(context.Context, *GetInviteRequest) (*GetInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvite not implemented")
+}
+func (UnimplementedInviteServiceServer) ListInvites(context.Context, *ListInvitesRequest) (*ListInvitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvites not implemented")
+}
+func (UnimplementedInviteServiceServer) CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedInviteServiceServer) DeleteInvite(context.Context, *DeleteInviteRequest) (*DeleteInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteInvite not implemented")
+}
+func (UnimplementedInviteServiceServer) mustEmbedUnimplementedInviteServiceServer() {}
+
+// UnsafeInviteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InviteServiceServer will
+// result in compilation errors.
+type UnsafeInviteServiceServer interface {
+	mustEmbedUnimplementedInviteServiceServer()
+}
+
+func RegisterInviteServiceServer(s grpc.ServiceRegistrar, srv InviteServiceServer) {
+	s.RegisterService(&InviteService_ServiceDesc, srv)
+}
+
+func _InviteService_GetInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InviteServiceServer).GetInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.InviteService/GetInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InviteServiceServer).GetInvite(ctx, req.(*GetInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InviteService_ListInvites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InviteServiceServer).ListInvites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.InviteService/ListInvites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InviteServiceServer).ListInvites(ctx, req.(*ListInvitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InviteService_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InviteServiceServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.InviteService/CreateInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InviteServiceServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InviteService_DeleteInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InviteServiceServer).DeleteInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.InviteService/DeleteInvite",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InviteServiceServer).DeleteInvite(ctx, req.(*DeleteInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InviteService_ServiceDesc is the grpc.ServiceDesc for InviteService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InviteService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "controller.api.services.v1.InviteService",
+	HandlerType: (*InviteServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInvite",
+			Handler:    _InviteService_GetInvite_Handler,
+		},
+		{
+			MethodName: "ListInvites",
+			Handler:    _InviteService_ListInvites_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _InviteService_CreateInvite_Handler,
+		},
+		{
+			MethodName: "DeleteInvite",
+			Handler:    _InviteService_DeleteInvite_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "controller/api/services/v1/invite_service.proto",
+}
@@ -44,6 +44,47 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// RotateKeys creates new versions of the root key and data encryption keys
+	// of a Scope. The new versions are used to encrypt data from then on, while
+	// the previous versions remain available to decrypt existing data. If the
+	// provided Scope ID is malformed or not provided an error is returned.
+	RotateKeys(ctx context.Context, in *RotateKeysRequest, opts ...grpc.CallOption) (*RotateKeysResponse, error)
+	// ReencryptKeyVersion starts re-encrypting the data encrypted with a
+	// previous version of a data encryption key of a Scope with the current
+	// version, and returns the progress of the re-encryption. The data is
+	// re-encrypted in the background by the controllers. Calling it again
+	// returns the progress of the re-encryption already started. If the
+	// provided Scope ID or key version ID is malformed or not provided an error
+	// is returned.
+	ReencryptKeyVersion(ctx context.Context, in *ReencryptKeyVersionRequest, opts ...grpc.CallOption) (*ReencryptKeyVersionResponse, error)
+	// DestroyKeyVersion destroys a previous version of the root key or of a
+	// data encryption key of a Scope. The destruction is refused while any
+	// data is still encrypted with the version. If the provided Scope ID or key
+	// version ID is malformed or not provided an error is returned.
+	DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error)
+	// ListKeys returns the root key and data encryption keys of a Scope with
+	// their versions and the number of rows encrypted with each version. The
+	// key material is never returned. If the provided Scope ID is malformed or
+	// not provided an error is returned.
+	ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error)
+	// ListChanges returns the changes recorded in the oplog after the provided
+	// cursor, which is the ID of the last change received, in the order they
+	// were recorded. Changes can be filtered by the Scope they were made in and
+	// by the type of the resource changed. Only the global Scope supports
+	// listing changes. If the provided Scope ID is malformed or not provided an
+	// error is returned.
+	ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error)
+	// ListJobs returns the jobs run by the controllers' scheduler, ordered by
+	// name, with their most recent runs. Only the global Scope supports
+	// listing jobs. If the provided Scope ID is malformed or not provided an
+	// error is returned.
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// RunJob makes a job due, so it is run by the next controller looking for
+	// due jobs, and returns the job. A job which is running is run again once
+	// its current run ends. Only the global Scope supports running jobs. If the
+	// provided Scope ID or job name is malformed or not provided an error is
+	// returned.
+	RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -99,6 +140,69 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) RotateKeys(ctx context.Context, in *RotateKeysRequest, opts ...grpc.CallOption) (*RotateKeysResponse, error) {
+	out := new(RotateKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RotateKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ReencryptKeyVersion(ctx context.Context, in *ReencryptKeyVersionRequest, opts ...grpc.CallOption) (*ReencryptKeyVersionResponse, error) {
+	out := new(ReencryptKeyVersionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ReencryptKeyVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) DestroyKeyVersion(ctx context.Context, in *DestroyKeyVersionRequest, opts ...grpc.CallOption) (*DestroyKeyVersionResponse, error) {
+	out := new(DestroyKeyVersionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/DestroyKeyVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListKeys(ctx context.Context, in *ListKeysRequest, opts ...grpc.CallOption) (*ListKeysResponse, error) {
+	out := new(ListKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error) {
+	out := new(ListChangesResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListChanges", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) RunJob(ctx context.Context, in *RunJobRequest, opts ...grpc.CallOption) (*RunJobResponse, error) {
+	out := new(RunJobResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RunJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -129,6 +233,47 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// RotateKeys creates new versions of the root key and data encryption keys
+	// of a Scope. The new versions are used to encrypt data from then on, while
+	// the previous versions remain available to decrypt existing data. If the
+	// provided Scope ID is malformed or not provided an error is returned.
+	RotateKeys(context.Context, *RotateKeysRequest) (*RotateKeysResponse, error)
+	// ReencryptKeyVersion starts re-encrypting the data encrypted with a
+	// previous version of a data encryption key of a Scope with the current
+	// version, and returns the progress of the re-encryption. The data is
+	// re-encrypted in the background by the controllers. Calling it again
+	// returns the progress of the re-encryption already started. If the
+	// provided Scope ID or key version ID is malformed or not provided an error
+	// is returned.
+	ReencryptKeyVersion(context.Context, *ReencryptKeyVersionRequest) (*ReencryptKeyVersionResponse, error)
+	// DestroyKeyVersion destroys a previous version of the root key or of a
+	// data encryption key of a Scope. The destruction is refused while any
+	// data is still encrypted with the version. If the provided Scope ID or key
+	// version ID is malformed or not provided an error is returned.
+	DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error)
+	// ListKeys returns the root key and data encryption keys of a Scope with
+	// their versions and the number of rows encrypted with each version. The
+	// key material is never returned. If the provided Scope ID is malformed or
+	// not provided an error is returned.
+	ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error)
+	// ListChanges returns the changes recorded in the oplog after the provided
+	// cursor, which is the ID of the last change received, in the order they
+	// were recorded. Changes can be filtered by the Scope they were made in and
+	// by the type of the resource changed. Only the global Scope supports
+	// listing changes. If the provided Scope ID is malformed or not provided an
+	// error is returned.
+	ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error)
+	// ListJobs returns the jobs run by the controllers' scheduler, ordered by
+	// name, with their most recent runs. Only the global Scope supports
+	// listing jobs. If the provided Scope ID is malformed or not provided an
+	// error is returned.
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// RunJob makes a job due, so it is run by the next controller looking for
+	// due jobs, and returns the job. A job which is running is run again once
+	// its current run ends. Only the global Scope supports running jobs. If the
+	// provided Scope ID or job name is malformed or not provided an error is
+	// returned.
+	RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -151,6 +296,27 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) RotateKeys(context.Context, *RotateKeysRequest) (*RotateKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateKeys not implemented")
+}
+func (UnimplementedScopeServiceServer) ReencryptKeyVersion(context.Context, *ReencryptKeyVersionRequest) (*ReencryptKeyVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReencryptKeyVersion not implemented")
+}
+func (UnimplementedScopeServiceServer) DestroyKeyVersion(context.Context, *DestroyKeyVersionRequest) (*DestroyKeyVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyKeyVersion not implemented")
+}
+func (UnimplementedScopeServiceServer) ListKeys(context.Context, *ListKeysRequest) (*ListKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListKeys not implemented")
+}
+func (UnimplementedScopeServiceServer) ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChanges not implemented")
+}
+func (UnimplementedScopeServiceServer) ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedScopeServiceServer) RunJob(context.Context, *RunJobRequest) (*RunJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -254,6 +420,132 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_RotateKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RotateKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RotateKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RotateKeys(ctx, req.(*RotateKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ReencryptKeyVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReencryptKeyVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ReencryptKeyVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ReencryptKeyVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ReencryptKeyVersion(ctx, req.(*ReencryptKeyVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_DestroyKeyVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyKeyVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).DestroyKeyVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/DestroyKeyVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).DestroyKeyVersion(ctx, req.(*DestroyKeyVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListKeys(ctx, req.(*ListKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListChanges(ctx, req.(*ListChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RunJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RunJob(ctx, req.(*RunJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScopeService_ServiceDesc is the grpc.ServiceDesc for ScopeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -281,6 +573,34 @@ var ScopeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "RotateKeys",
+			Handler:    _ScopeService_RotateKeys_Handler,
+		},
+		{
+			MethodName: "ReencryptKeyVersion",
+			Handler:    _ScopeService_ReencryptKeyVersion_Handler,
+		},
+		{
+			MethodName: "DestroyKeyVersion",
+			Handler:    _ScopeService_DestroyKeyVersion_Handler,
+		},
+		{
+			MethodName: "ListKeys",
+			Handler:    _ScopeService_ListKeys_Handler,
+		},
+		{
+			MethodName: "ListChanges",
+			Handler:    _ScopeService_ListChanges_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _ScopeService_ListJobs_Handler,
+		},
+		{
+			MethodName: "RunJob",
+			Handler:    _ScopeService_RunJob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -10,25 +10,28 @@ import (
 )
 
 // MemberType defines the possible membership types for groups. We don't surface
-// this in the API as of yet as it's always user and we don't have plans for
-// others currently.
+// this in the API as of yet as the type is derivable from the member's id.
 type MemberType uint32
 
 const (
 	UnknownMemberType MemberType = 0
 	UserMemberType    MemberType = 1
+	GroupMemberType   MemberType = 2
 )
 
 func (m MemberType) String() string {
 	return [...]string{
 		"unknown",
 		"user",
+		"group",
 	}[m]
 }
 
 const (
-	groupMemberViewDefaultTableName = "iam_group_member"
-	groupMemberUserDefaultTable     = "iam_group_member_user"
+	groupMemberViewDefaultTableName          = "iam_group_member"
+	groupMemberEffectiveViewDefaultTableName = "iam_group_member_effective"
+	groupMemberUserDefaultTable              = "iam_group_member_user"
+	groupMemberGroupDefaultTable             = "iam_group_member_group"
 )
 
 // GroupMember provides a common way to return members.
@@ -56,6 +59,33 @@ func (v *GroupMember) SetTableName(n string) {
 	}
 }
 
+// EffectiveGroupMember is a user which is a member of a group either directly
+// or through one of the groups nested within the group.
+type EffectiveGroupMember struct {
+	*store.GroupMemberView
+	tableName string `gorm:"-"`
+}
+
+// TableName provides an overridden gorm table name for effective group
+// members.
+func (v *EffectiveGroupMember) TableName() string {
+	if v.tableName != "" {
+		return v.tableName
+	}
+	return groupMemberEffectiveViewDefaultTableName
+}
+
+// SetTableName sets the table name for the resource.  If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (v *EffectiveGroupMember) SetTableName(n string) {
+	switch n {
+	case "":
+		v.tableName = groupMemberEffectiveViewDefaultTableName
+	default:
+		v.tableName = n
+	}
+}
+
 // GroupMemberUser is a group member that's a User
 type GroupMemberUser struct {
 	*store.GroupMemberUser
@@ -129,3 +159,83 @@ func (m *GroupMemberUser) SetTableName(n string) {
 		m.tableName = n
 	}
 }
+
+// GroupMemberGroup is a group member that's a Group
+type GroupMemberGroup struct {
+	*store.GroupMemberGroup
+	tableName string `gorm:"-"`
+}
+
+// ensure that GroupMemberGroup implements the interfaces of: Cloneable, db.VetForWriter
+var (
+	_ Cloneable       = (*GroupMemberGroup)(nil)
+	_ db.VetForWriter = (*GroupMemberGroup)(nil)
+)
+
+// NewGroupMemberGroup creates a new in memory group member of the group. No
+// options are currently supported.
+func NewGroupMemberGroup(groupId, memberGroupId string, _ ...Option) (*GroupMemberGroup, error) {
+	const op = "iam.NewGroupMemberGroup"
+	if groupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	if memberGroupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing member group id")
+	}
+	if groupId == memberGroupId {
+		return nil, errors.New(errors.InvalidParameter, op, "group cannot be a member of itself")
+	}
+	return &GroupMemberGroup{
+		GroupMemberGroup: &store.GroupMemberGroup{
+			MemberId: memberGroupId,
+			GroupId:  groupId,
+		},
+	}, nil
+}
+
+func allocGroupMemberGroup() GroupMemberGroup {
+	return GroupMemberGroup{
+		GroupMemberGroup: &store.GroupMemberGroup{},
+	}
+}
+
+// Clone creates a clone of the GroupMemberGroup
+func (m *GroupMemberGroup) Clone() interface{} {
+	cp := proto.Clone(m.GroupMemberGroup)
+	return &GroupMemberGroup{
+		GroupMemberGroup: cp.(*store.GroupMemberGroup),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface for group members.
+func (m *GroupMemberGroup) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	const op = "iam.(GroupMemberGroup).VetForWrite"
+	if m.GroupId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	if m.MemberId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing member id")
+	}
+	if m.GroupId == m.MemberId {
+		return errors.New(errors.InvalidParameter, op, "group cannot be a member of itself")
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (m *GroupMemberGroup) TableName() string {
+	if m.tableName != "" {
+		return m.tableName
+	}
+	return groupMemberGroupDefaultTable
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage interface
+func (m *GroupMemberGroup) SetTableName(n string) {
+	switch n {
+	case "":
+		m.tableName = groupMemberGroupDefaultTable
+	default:
+		m.tableName = n
+	}
+}
@@ -434,3 +434,107 @@ func TestGroupMemberUser_SetTableName(t *testing.T) {
 		})
 	}
 }
+
+func Test_NewGroupMemberGroup(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		groupId       string
+		memberGroupId string
+		want          *GroupMemberGroup
+		wantIsErr     errors.Code
+	}{
+		{
+			name:          "valid",
+			groupId:       "g_1234567890",
+			memberGroupId: "g_0987654321",
+			want: func() *GroupMemberGroup {
+				gm := allocGroupMemberGroup()
+				gm.GroupId = "g_1234567890"
+				gm.MemberId = "g_0987654321"
+				return &gm
+			}(),
+		},
+		{
+			name:          "missing-group",
+			memberGroupId: "g_0987654321",
+			wantIsErr:     errors.InvalidParameter,
+		},
+		{
+			name:      "missing-member",
+			groupId:   "g_1234567890",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:          "self",
+			groupId:       "g_1234567890",
+			memberGroupId: "g_1234567890",
+			wantIsErr:     errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := NewGroupMemberGroup(tt.groupId, tt.memberGroupId)
+			if tt.wantIsErr != 0 {
+				require.Error(err)
+				assert.True(errors.Match(errors.T(tt.wantIsErr), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func Test_GroupMemberGroupCreate(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	rw := db.New(conn)
+	org, proj := TestScopes(t, repo)
+
+	t.Run("cross-scope", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestGroup(t, conn, org.PublicId)
+		child := TestGroup(t, conn, proj.PublicId)
+		gm, err := NewGroupMemberGroup(parent.PublicId, child.PublicId)
+		require.NoError(err)
+		require.NoError(rw.Create(context.Background(), gm))
+		assert.NotEmpty(gm.CreateTime)
+
+		members, err := repo.ListGroupMembers(context.Background(), parent.PublicId)
+		require.NoError(err)
+		require.Len(members, 1)
+		assert.Equal(child.PublicId, members[0].MemberId)
+		assert.Equal(GroupMemberType.String(), members[0].Type)
+		assert.Equal(proj.PublicId, members[0].MemberScopeId)
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		a := TestGroup(t, conn, org.PublicId)
+		b := TestGroup(t, conn, org.PublicId)
+		c := TestGroup(t, conn, org.PublicId)
+		TestGroupMemberGroup(t, conn, a.PublicId, b.PublicId)
+		TestGroupMemberGroup(t, conn, b.PublicId, c.PublicId)
+
+		gm, err := NewGroupMemberGroup(c.PublicId, a.PublicId)
+		require.NoError(err)
+		err = rw.Create(context.Background(), gm)
+		require.Error(err)
+		assert.True(errors.IsCheckConstraintError(err), "unexpected error %s", err.Error())
+	})
+	t.Run("delete-group-cascades", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		parent := TestGroup(t, conn, org.PublicId)
+		child := TestGroup(t, conn, org.PublicId)
+		TestGroupMemberGroup(t, conn, parent.PublicId, child.PublicId)
+
+		_, err := repo.DeleteGroup(context.Background(), child.PublicId)
+		require.NoError(err)
+		members, err := repo.ListGroupMembers(context.Background(), parent.PublicId)
+		require.NoError(err)
+		assert.Empty(members)
+	})
+}
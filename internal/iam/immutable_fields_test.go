@@ -186,6 +186,15 @@ func TestUser_ImmutableFields(t *testing.T) {
 			}(),
 			fieldMask: []string{"ScopeId"},
 		},
+		{
+			name: "service account",
+			update: func() *User {
+				c := new.Clone().(*User)
+				c.ServiceAccount = true
+				return c
+			}(),
+			fieldMask: []string{"ServiceAccount"},
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
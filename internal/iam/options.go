@@ -28,6 +28,10 @@ type options struct {
 	withSkipDefaultRoleCreation bool
 	withUserId                  string
 	withRandomReader            io.Reader
+	withServiceAccount          bool
+	withAuthTokenTimeToLive     uint32
+	withAuthTokenTimeToStale    uint32
+	withSessionRetention        uint32
 }
 
 func getDefaultOptions() options {
@@ -133,3 +137,37 @@ func WithRandomReader(reader io.Reader) Option {
 		o.withRandomReader = reader
 	}
 }
+
+// WithServiceAccount provides an option to create a user which is a service
+// account.
+func WithServiceAccount(enable bool) Option {
+	return func(o *options) {
+		o.withServiceAccount = enable
+	}
+}
+
+// WithAuthTokenTimeToLiveSeconds provides an option to specify the default
+// total lifetime, in seconds, of the auth tokens issued by the auth methods
+// in a scope.
+func WithAuthTokenTimeToLiveSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.withAuthTokenTimeToLive = secs
+	}
+}
+
+// WithAuthTokenTimeToStaleSeconds provides an option to specify the default
+// time, in seconds, the auth tokens issued by the auth methods in a scope can
+// go unused before becoming invalid.
+func WithAuthTokenTimeToStaleSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.withAuthTokenTimeToStale = secs
+	}
+}
+
+// WithSessionRetentionSeconds provides an option to specify the time, in
+// seconds, terminated sessions in a scope are retained for.
+func WithSessionRetentionSeconds(secs uint32) Option {
+	return func(o *options) {
+		o.withSessionRetention = secs
+	}
+}
@@ -74,4 +74,17 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withDisassociate = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithServiceAccount", func(t *testing.T) {
+		assert := assert.New(t)
+		// test default of false
+		opts := getOpts()
+		testOpts := getDefaultOptions()
+		testOpts.withServiceAccount = false
+		assert.Equal(opts, testOpts)
+
+		opts = getOpts(WithServiceAccount(true))
+		testOpts = getDefaultOptions()
+		testOpts.withServiceAccount = true
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -18,6 +18,10 @@ const (
 	// is valid by returning a count of matching rows.
 	whereValidAuthMethod = `select count(*) from auth_method where public_id = $1 and scope_id = $2`
 
+	// deleteUserAuthTokens - delete the auth tokens issued to the accounts of
+	// a user.
+	deleteUserAuthTokensQuery = `delete from auth_token where auth_account_id in (select public_id from auth_account where iam_user_id = ?)`
+
 	// insertAuthMethod - insert a row directly into auth_method (TODO - this
 	// should be replaced with calls to the auth method repo).
 	insertAuthMethod = `insert into auth_method (public_id, scope_id) values ($1, $2)`
@@ -73,6 +77,11 @@ const (
 		from iam_user
 	   where
 	   	public_id in (%s)
+	   union
+	  select public_id
+		from iam_group
+	   where
+	   	public_id in (%s)
 	),
 	current_members (member_id) as (
 	  -- returns the current list
@@ -197,17 +197,33 @@ func (r *Repository) ListGroupMembers(ctx context.Context, withGroupId string, o
 	return members, nil
 }
 
-// AddGroupMembers provides the ability to add members (userIds) to a group
-// (groupId).  The group's current db version must match the groupVersion or an
-// error will be returned.  Zero is not a valid value for the WithVersion option
-// and will return an error.
-func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, error) {
+// ListEffectiveGroupMembers lists the users which are members of a group
+// either directly or through any of the groups nested within the group.
+// Supports the WithLimit option.
+func (r *Repository) ListEffectiveGroupMembers(ctx context.Context, withGroupId string, opt ...Option) ([]*EffectiveGroupMember, error) {
+	const op = "iam.(Repository).ListEffectiveGroupMembers"
+	if withGroupId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
+	}
+	members := []*EffectiveGroupMember{}
+	if err := r.list(ctx, &members, "group_id = ?", []interface{}{withGroupId}, opt...); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return members, nil
+}
+
+// AddGroupMembers provides the ability to add members (userIds and groupIds)
+// to a group (groupId).  The group's current db version must match the
+// groupVersion or an error will be returned.  Adding a group which would
+// create a membership cycle will return an error.  Zero is not a valid value
+// for the WithVersion option and will return an error.
+func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) ([]*GroupMember, error) {
 	const op = "iam.(Repository).AddGroupMembers"
 	if groupId == "" {
 		return nil, errors.New(errors.InvalidParameter, op, "missing group id")
 	}
-	if len(userIds) == 0 {
-		return nil, errors.New(errors.InvalidParameter, op, "missing user ids")
+	if len(memberIds) == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing member ids")
 	}
 	if groupVersion == 0 {
 		return nil, errors.New(errors.InvalidParameter, op, "missing version")
@@ -219,13 +235,9 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get group members %s scope", groupId)))
 	}
 
-	newGroupMembers := make([]interface{}, 0, len(userIds))
-	for _, id := range userIds {
-		gm, err := NewGroupMemberUser(groupId, id)
-		if err != nil {
-			return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
-		}
-		newGroupMembers = append(newGroupMembers, gm)
+	newGroupMembers, err := newGroupMemberSet(groupId, memberIds)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group members"))
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
@@ -256,11 +268,20 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
 			msgs = append(msgs, &groupOplogMsg)
-			memberOplogMsgs := make([]*oplog.Message, 0, len(newGroupMembers))
-			if err := w.CreateItems(ctx, newGroupMembers, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
-				return errors.Wrap(err, op, errors.WithMsg("unable to add users"))
+			if len(newGroupMembers.users) > 0 {
+				userOplogMsgs := make([]*oplog.Message, 0, len(newGroupMembers.users))
+				if err := w.CreateItems(ctx, newGroupMembers.users, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add users"))
+				}
+				msgs = append(msgs, userOplogMsgs...)
+			}
+			if len(newGroupMembers.groups) > 0 {
+				grpOplogMsgs := make([]*oplog.Message, 0, len(newGroupMembers.groups))
+				if err := w.CreateItems(ctx, newGroupMembers.groups, db.NewOplogMsgs(&grpOplogMsgs)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add groups"))
+				}
+				msgs = append(msgs, grpOplogMsgs...)
 			}
-			msgs = append(msgs, memberOplogMsgs...)
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -291,15 +312,16 @@ func (r *Repository) AddGroupMembers(ctx context.Context, groupId string, groupV
 	return currentMembers, nil
 }
 
-// DeleteGroupMembers (userIds) from a group (groupId). The group's current db version
-// must match the groupVersion or an error will be returned. Zero is not a valid
-// value for the WithVersion option and will return an error.
-func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) (int, error) {
+// DeleteGroupMembers (userIds and groupIds) from a group (groupId). The group's
+// current db version must match the groupVersion or an error will be returned.
+// Zero is not a valid value for the WithVersion option and will return an
+// error.
+func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteGroupMembers"
 	if groupId == "" {
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing group id")
 	}
-	if len(userIds) == 0 {
+	if len(memberIds) == 0 {
 		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing either user or groups to delete")
 	}
 	if groupVersion == 0 {
@@ -312,13 +334,9 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get group members %s scope", groupId)))
 	}
 
-	deleteMembers := make([]interface{}, 0, len(userIds))
-	for _, id := range userIds {
-		member, err := NewGroupMemberUser(groupId, id)
-		if err != nil {
-			return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member"))
-		}
-		deleteMembers = append(deleteMembers, member)
+	deleteMembers, err := newGroupMemberSet(groupId, memberIds)
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group members"))
 	}
 
 	oplogWrapper, err := r.kms.GetWrapper(ctx, scope.GetPublicId(), kms.KeyPurposeOplog)
@@ -349,16 +367,30 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
 			msgs = append(msgs, &groupOplogMsg)
-			userOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers))
-			rowsDeleted, err := w.DeleteItems(ctx, deleteMembers, db.NewOplogMsgs(&userOplogMsgs))
-			if err != nil {
-				return errors.Wrap(err, op, errors.WithMsg("unable to delete group members"))
+			if len(deleteMembers.users) > 0 {
+				userOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers.users))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteMembers.users, db.NewOplogMsgs(&userOplogMsgs))
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete group members"))
+				}
+				if rowsDeleted != len(deleteMembers.users) {
+					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("group members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers.users)))
+				}
+				totalRowsDeleted += rowsDeleted
+				msgs = append(msgs, userOplogMsgs...)
 			}
-			if rowsDeleted != len(deleteMembers) {
-				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("group members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers)))
+			if len(deleteMembers.groups) > 0 {
+				grpOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers.groups))
+				rowsDeleted, err := w.DeleteItems(ctx, deleteMembers.groups, db.NewOplogMsgs(&grpOplogMsgs))
+				if err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete group members"))
+				}
+				if rowsDeleted != len(deleteMembers.groups) {
+					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("group members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers.groups)))
+				}
+				totalRowsDeleted += rowsDeleted
+				msgs = append(msgs, grpOplogMsgs...)
 			}
-			totalRowsDeleted += rowsDeleted
-			msgs = append(msgs, userOplogMsgs...)
 			metadata := oplog.Metadata{
 				"op-type":            []string{oplog.OpType_OP_TYPE_DELETE.String()},
 				"scope-id":           []string{scope.PublicId},
@@ -377,10 +409,10 @@ func (r *Repository) DeleteGroupMembers(ctx context.Context, groupId string, gro
 	return totalRowsDeleted, nil
 }
 
-// SetGroupMembers will set the group's members.  If userIds is empty, the
-// members will be cleared. Zero is not a valid value for the WithVersion option
-// and will return an error.
-func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, userIds []string, _ ...Option) ([]*GroupMember, int, error) {
+// SetGroupMembers will set the group's members (userIds and groupIds).  If
+// memberIds is empty, the members will be cleared. Zero is not a valid value
+// for the WithVersion option and will return an error.
+func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupVersion uint32, memberIds []string, _ ...Option) ([]*GroupMember, int, error) {
 	const op = "iam.(Repository).SetGroupMembers"
 	if groupId == "" {
 		return nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing group id")
@@ -415,12 +447,12 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 				// intentionally not setting the defaultLimit, so we'll get all
 				// the members without a limit
 			}
-			addMembers, deleteMembers, err := groupMemberChanges(ctx, reader, groupId, userIds)
+			addMembers, deleteMembers, err := groupMemberChanges(ctx, reader, groupId, memberIds)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
 			// handle no change to existing group members
-			if len(addMembers) == 0 && len(deleteMembers) == 0 {
+			if addMembers.len() == 0 && deleteMembers.len() == 0 {
 				currentMembers, err = txRepo.ListGroupMembers(ctx, groupId)
 				if err != nil {
 					return errors.Wrap(err, op, errors.WithMsg("unable to retrieve current group members after sets"))
@@ -453,28 +485,37 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 			if rowsUpdated != 1 {
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated group and %d rows updated", rowsUpdated))
 			}
-			if len(deleteMembers) > 0 {
-				userOplogMsgs := make([]*oplog.Message, 0, len(deleteMembers))
-				rowsDeleted, err := w.DeleteItems(ctx, deleteMembers, db.NewOplogMsgs(&userOplogMsgs))
-				if err != nil {
-					return errors.Wrap(err, op, errors.WithMsg("unable to delete group member"))
+			if deleteMembers.len() > 0 {
+				for _, items := range [][]interface{}{deleteMembers.users, deleteMembers.groups} {
+					if len(items) == 0 {
+						continue
+					}
+					memberOplogMsgs := make([]*oplog.Message, 0, len(items))
+					rowsDeleted, err := w.DeleteItems(ctx, items, db.NewOplogMsgs(&memberOplogMsgs))
+					if err != nil {
+						return errors.Wrap(err, op, errors.WithMsg("unable to delete group member"))
+					}
+					if rowsDeleted != len(items) {
+						return errors.New(errors.MultipleRecords, op, fmt.Sprintf("members deleted %d did not match request for %d", rowsDeleted, len(items)))
+					}
+					totalRowsAffected += rowsDeleted
+					msgs = append(msgs, memberOplogMsgs...)
 				}
-				if rowsDeleted != len(deleteMembers) {
-					return errors.New(errors.MultipleRecords, op, fmt.Sprintf("members deleted %d did not match request for %d", rowsDeleted, len(deleteMembers)))
-				}
-				totalRowsAffected += rowsDeleted
-				msgs = append(msgs, userOplogMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_DELETE.String())
 			}
-			if len(addMembers) > 0 {
-				userOplogMsgs := make([]*oplog.Message, 0, len(addMembers))
-				if err := w.CreateItems(ctx, addMembers, db.NewOplogMsgs(&userOplogMsgs)); err != nil {
-					return errors.Wrap(err, op, errors.WithMsg("unable to add users"))
+			if addMembers.len() > 0 {
+				for _, items := range [][]interface{}{addMembers.users, addMembers.groups} {
+					if len(items) == 0 {
+						continue
+					}
+					memberOplogMsgs := make([]*oplog.Message, 0, len(items))
+					if err := w.CreateItems(ctx, items, db.NewOplogMsgs(&memberOplogMsgs)); err != nil {
+						return errors.Wrap(err, op, errors.WithMsg("unable to add members"))
+					}
+					totalRowsAffected += len(items)
+					msgs = append(msgs, memberOplogMsgs...)
 				}
-				totalRowsAffected += len(addMembers)
-				msgs = append(msgs, userOplogMsgs...)
 				metadata["op-type"] = append(metadata["op-type"], oplog.OpType_OP_TYPE_CREATE.String())
-
 			}
 			// we're done with all the membership writes, so let's write the
 			// group's update oplog message
@@ -493,26 +534,73 @@ func (r *Repository) SetGroupMembers(ctx context.Context, groupId string, groupV
 	return currentMembers, totalRowsAffected, nil
 }
 
-// groupMemberChanges returns two slices: members to add and delete
-func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, userIds []string) ([]interface{}, []interface{}, error) {
+// groupMemberSet is a set of in memory group members.  Members are separated
+// by type since each type is stored in its own table.
+type groupMemberSet struct {
+	users  []interface{}
+	groups []interface{}
+}
+
+// newGroupMemberSet creates a set of in memory members of the group, using the
+// prefix of each of the memberIds to determine if the member is a user or a
+// group.
+func newGroupMemberSet(groupId string, memberIds []string) (*groupMemberSet, error) {
+	const op = "iam.newGroupMemberSet"
+	set := &groupMemberSet{}
+	for _, id := range memberIds {
+		if err := set.add(groupId, id); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	return set, nil
+}
+
+func (s *groupMemberSet) add(groupId, memberId string) error {
+	const op = "iam.(groupMemberSet).add"
+	userIds, groupIds, err := splitPrincipals([]string{memberId})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	switch {
+	case len(userIds) > 0:
+		gm, err := NewGroupMemberUser(groupId, memberId)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		s.users = append(s.users, gm)
+	case len(groupIds) > 0:
+		gm, err := NewGroupMemberGroup(groupId, memberId)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		s.groups = append(s.groups, gm)
+	}
+	return nil
+}
+
+func (s *groupMemberSet) len() int {
+	return len(s.users) + len(s.groups)
+}
+
+// groupMemberChanges returns two sets: members to add and delete
+func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, memberIds []string) (*groupMemberSet, *groupMemberSet, error) {
 	const op = "iam.groupMemberChanges"
 	var inClauseSpots []string
 	// starts at 2 because there is already a $1 in the query
-	for i := 2; i < len(userIds)+2; i++ {
+	for i := 2; i < len(memberIds)+2; i++ {
 		inClauseSpots = append(inClauseSpots, fmt.Sprintf("$%d", i))
 	}
 	inClause := strings.Join(inClauseSpots, ",")
 	if inClause == "" {
 		inClause = "''"
 	}
-	query := fmt.Sprintf(grpMemberChangesQuery, inClause)
+	query := fmt.Sprintf(grpMemberChangesQuery, inClause, inClause)
 
 	var params []interface{}
 	params = append(params, groupId)
-	for _, v := range userIds {
+	for _, v := range memberIds {
 		params = append(params, v)
 	}
-	// fmt.Println(query, params)
 	rows, err := reader.Query(ctx, query, params)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op)
@@ -531,29 +619,24 @@ func groupMemberChanges(ctx context.Context, reader db.Reader, groupId string, u
 		}
 		changes = append(changes, &chg)
 	}
-	addMembers := []interface{}{}
-	deleteMembers := []interface{}{}
+	addMembers := &groupMemberSet{}
+	deleteMembers := &groupMemberSet{}
 	for _, c := range changes {
 		if c.MemberId == "" {
-			return nil, nil, errors.New(errors.InvalidParameter, op, "missing user id in change result")
+			return nil, nil, errors.New(errors.InvalidParameter, op, "missing member id in change result")
 		}
 		switch c.Action {
 		case "add":
-			gm, err := NewGroupMemberUser(groupId, c.MemberId)
-			if err != nil {
+			if err := addMembers.add(groupId, c.MemberId); err != nil {
 				return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member for add"))
 			}
-			addMembers = append(addMembers, gm)
 		case "delete":
-			gm, err := NewGroupMemberUser(groupId, c.MemberId)
-			if err != nil {
+			if err := deleteMembers.add(groupId, c.MemberId); err != nil {
 				return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory group member for delete"))
 			}
-			deleteMembers = append(deleteMembers, gm)
 		default:
 			return nil, nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown action %s for %s", c.Action, c.MemberId))
 		}
-
 	}
 	return addMembers, deleteMembers, nil
 }
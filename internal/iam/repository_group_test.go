@@ -1157,3 +1157,131 @@ func TestRepository_SetGroupMembers(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_NestedGroupMembers(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	parent := TestGroup(t, conn, org.PublicId)
+	child := TestGroup(t, conn, org.PublicId)
+	grandchild := TestGroup(t, conn, proj.PublicId)
+	u1 := TestUser(t, repo, org.PublicId)
+	u2 := TestUser(t, repo, org.PublicId)
+	u3 := TestUser(t, repo, org.PublicId)
+	TestGroupMember(t, conn, parent.PublicId, u1.PublicId)
+	TestGroupMember(t, conn, child.PublicId, u2.PublicId)
+	TestGroupMember(t, conn, grandchild.PublicId, u3.PublicId)
+	// u2 is also a member of grandchild, so it's reachable twice from parent
+	TestGroupMember(t, conn, grandchild.PublicId, u2.PublicId)
+
+	got, err := repo.AddGroupMembers(ctx, parent.PublicId, parent.Version, []string{child.PublicId})
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+	_, err = repo.AddGroupMembers(ctx, child.PublicId, child.Version, []string{grandchild.PublicId})
+	require.NoError(t, err)
+
+	t.Run("effective-members", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		tests := []struct {
+			groupId string
+			want    []string
+		}{
+			{groupId: parent.PublicId, want: []string{u1.PublicId, u2.PublicId, u3.PublicId}},
+			{groupId: child.PublicId, want: []string{u2.PublicId, u3.PublicId}},
+			{groupId: grandchild.PublicId, want: []string{u2.PublicId, u3.PublicId}},
+		}
+		for _, tt := range tests {
+			members, err := repo.ListEffectiveGroupMembers(ctx, tt.groupId)
+			require.NoError(err)
+			var ids []string
+			for _, m := range members {
+				ids = append(ids, m.MemberId)
+				assert.Equal(tt.groupId, m.GroupId)
+				assert.Equal(UserMemberType.String(), m.Type)
+			}
+			assert.ElementsMatch(tt.want, ids)
+		}
+	})
+	t.Run("cycle", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		g, _, err := repo.LookupGroup(ctx, grandchild.PublicId)
+		require.NoError(err)
+		_, err = repo.AddGroupMembers(ctx, g.PublicId, g.Version, []string{parent.PublicId})
+		require.Error(err)
+		assert.True(errors.IsCheckConstraintError(err), "unexpected error %s", err.Error())
+
+		_, _, err = repo.SetGroupMembers(ctx, g.PublicId, g.Version, []string{u3.PublicId, child.PublicId})
+		require.Error(err)
+		assert.True(errors.IsCheckConstraintError(err), "unexpected error %s", err.Error())
+	})
+	t.Run("set-and-delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		g, _, err := repo.LookupGroup(ctx, child.PublicId)
+		require.NoError(err)
+		members, affected, err := repo.SetGroupMembers(ctx, g.PublicId, g.Version, []string{u1.PublicId, grandchild.PublicId})
+		require.NoError(err)
+		// add u1, delete u2
+		assert.Equal(2, affected)
+		var ids []string
+		for _, m := range members {
+			ids = append(ids, m.MemberId)
+		}
+		assert.ElementsMatch([]string{u1.PublicId, grandchild.PublicId}, ids)
+
+		deleted, err := repo.DeleteGroupMembers(ctx, g.PublicId, g.Version+1, []string{u1.PublicId, grandchild.PublicId})
+		require.NoError(err)
+		assert.Equal(2, deleted)
+		effective, err := repo.ListEffectiveGroupMembers(ctx, parent.PublicId)
+		require.NoError(err)
+		require.Len(effective, 1)
+		assert.Equal(u1.PublicId, effective[0].MemberId)
+	})
+}
+
+func TestRepository_GrantsForUser_NestedGroups(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, proj := TestScopes(t, repo)
+	ctx := context.Background()
+
+	user := TestUser(t, repo, org.PublicId)
+	parent := TestGroup(t, conn, org.PublicId)
+	child := TestGroup(t, conn, org.PublicId)
+	TestGroupMember(t, conn, child.PublicId, user.PublicId)
+	TestGroupMemberGroup(t, conn, parent.PublicId, child.PublicId)
+
+	parentRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, parentRole.PublicId, "id=*;type=*;actions=read")
+	TestGroupRole(t, conn, parentRole.PublicId, parent.PublicId)
+	childRole := TestRole(t, conn, proj.PublicId)
+	TestRoleGrant(t, conn, childRole.PublicId, "id=*;type=*;actions=update")
+	TestGroupRole(t, conn, childRole.PublicId, child.PublicId)
+
+	grants, err := repo.GrantsForUser(ctx, user.PublicId)
+	require.NoError(t, err)
+	var got []string
+	for _, g := range grants {
+		if g.ScopeId == proj.PublicId {
+			got = append(got, g.Grant)
+		}
+	}
+	assert.ElementsMatch(t, []string{"id=*;type=*;actions=read", "id=*;type=*;actions=update"}, got)
+
+	_, err = repo.DeleteGroupMembers(ctx, parent.PublicId, parent.Version, []string{child.PublicId})
+	require.NoError(t, err)
+	grants, err = repo.GrantsForUser(ctx, user.PublicId)
+	require.NoError(t, err)
+	got = nil
+	for _, g := range grants {
+		if g.ScopeId == proj.PublicId {
+			got = append(got, g.Grant)
+		}
+	}
+	assert.ElementsMatch(t, []string{"id=*;type=*;actions=update"}, got)
+}
@@ -385,6 +385,10 @@ func (r *Repository) ListRoleGrants(ctx context.Context, roleId string, opt ...O
 	return roleGrants, nil
 }
 
+// GrantsForUser returns the grants of every role assigned to the user, either
+// directly or through the user's membership in a group.  Group membership is
+// transitive: a user in a group nested within another group also receives the
+// grants of roles assigned to the containing group.
 func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Option) ([]perms.GrantPair, error) {
 	const op = "iam.(Repository).GrantsForUser"
 	if userId == "" {
@@ -395,7 +399,7 @@ func (r *Repository) GrantsForUser(ctx context.Context, userId string, _ ...Opti
 		anonUser    = `where public_id in ($1)`
 		authUser    = `where public_id in ('u_anon', 'u_auth', $1)`
 		grantsQuery = `
-with
+with recursive
 users (id) as (
   select public_id
     from iam_user
@@ -406,6 +410,12 @@ user_groups (id) as (
     from iam_group_member_user,
          users
    where member_id in (users.id)
+   union
+  -- groups which contain any of the user's groups as a nested group
+  select iam_group_member_group.group_id
+    from iam_group_member_group,
+         user_groups
+   where iam_group_member_group.member_id = user_groups.id
 ),
 group_roles (role_id) as (
   select role_id
@@ -308,7 +308,7 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 						}
 						grants = append(grants, roleGrant)
 
-						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id={{account.id}};actions=read,change-password")
+						roleGrant, err = NewRoleGrant(defaultRolePublicId, "id={{account.id}};actions=read,change-password,enroll-totp,confirm-totp")
 						if err != nil {
 							return errors.Wrap(err, op, errors.WithMsg("unable to create in memory role grant"))
 						}
@@ -369,8 +369,9 @@ func (r *Repository) CreateScope(ctx context.Context, s *Scope, userId string, o
 // UpdateScope will update a scope in the repository and return the written
 // scope.  fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name and Description are the only updatable fields,
-// and everything else is ignored.  If no updatable fields are included in the
+// included in fieldMask. Name, Description, AuthTokenTimeToLiveSeconds,
+// AuthTokenTimeToStaleSeconds and SessionRetentionSeconds are the only
+// updatable fields, and everything else is ignored.  If no updatable fields are included in the
 // fieldMaskPaths, then an error is returned.
 func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint32, fieldMaskPaths []string, _ ...Option) (*Scope, int, error) {
 	const op = "iam.(Repository).UpdateScope"
@@ -386,8 +387,11 @@ func (r *Repository) UpdateScope(ctx context.Context, scope *Scope, version uint
 	var dbMask, nullFields []string
 	dbMask, nullFields = dbcommon.BuildUpdatePaths(
 		map[string]interface{}{
-			"name":        scope.Name,
-			"description": scope.Description,
+			"name":                        scope.Name,
+			"description":                 scope.Description,
+			"AuthTokenTimeToLiveSeconds":  scope.AuthTokenTimeToLiveSeconds,
+			"AuthTokenTimeToStaleSeconds": scope.AuthTokenTimeToStaleSeconds,
+			"SessionRetentionSeconds":     scope.SessionRetentionSeconds,
 		},
 		fieldMaskPaths,
 		nil,
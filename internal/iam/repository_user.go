@@ -180,6 +180,67 @@ func (r *Repository) LookupUser(ctx context.Context, userId string, _ ...Option)
 	return &user, currentAccountIds, nil
 }
 
+// SetUserActive sets whether the user is active and returns the user. A user
+// which is not active is disabled and cannot authenticate. Disabling a user
+// deletes the auth tokens issued to it in the same transaction, and the
+// database cancels the sessions created with them. Setting the state a user is
+// already in is not an error. All options are ignored.
+func (r *Repository) SetUserActive(ctx context.Context, userId string, active bool, _ ...Option) (*User, error) {
+	const op = "iam.(Repository).SetUserActive"
+	if userId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing public id")
+	}
+	user := allocUser()
+	user.PublicId = userId
+	if err := r.reader.LookupByPublicId(ctx, &user); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", userId)))
+	}
+	if user.Active == active {
+		return &user, nil
+	}
+
+	metadata, err := r.stdMetadata(ctx, &user)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	metadata["op-type"] = []string{oplog.OpType_OP_TYPE_UPDATE.String()}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, user.GetScopeId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+
+	var returnedUser *User
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, w db.Writer) error {
+			returnedUser = user.Clone().(*User)
+			returnedUser.Active = active
+			// the version is not checked as the active state does not depend
+			// on the other fields of the user.
+			rowsUpdated, err := w.Update(ctx, returnedUser, []string{"Active"}, nil, db.WithOplog(oplogWrapper, metadata))
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated > 1 {
+				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
+			}
+			if !active {
+				// tokens are not replicated, so they don't need oplog entries.
+				if _, err := w.Exec(ctx, deleteUserAuthTokensQuery, []interface{}{userId}); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to delete auth tokens"))
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", userId)))
+	}
+	return returnedUser, nil
+}
+
 // DeleteUser will delete a user from the repository
 func (r *Repository) DeleteUser(ctx context.Context, withPublicId string, _ ...Option) (int, error) {
 	const op = "iam.(Repository).DeleteUser"
@@ -375,6 +436,9 @@ func (r *Repository) AddUserAccounts(ctx context.Context, userId string, userVer
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", userId)))
 	}
+	if user.ServiceAccount {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("user %s is a service account and cannot have accounts", userId))
+	}
 	oplogWrapper, err := r.kms.GetWrapper(ctx, user.ScopeId, kms.KeyPurposeOplog)
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
@@ -534,6 +598,9 @@ func (r *Repository) SetUserAccounts(ctx context.Context, userId string, userVer
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup user %s", userId)))
 	}
+	if user.ServiceAccount && len(accountIds) > 0 {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("user %s is a service account and cannot have accounts", userId))
+	}
 	oplogWrapper, err := r.kms.GetWrapper(ctx, user.ScopeId, kms.KeyPurposeOplog)
 	if err != nil {
 		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
@@ -623,6 +690,105 @@ func (r *Repository) SetUserAccounts(ctx context.Context, userId string, userVer
 	return currentAccountIds, nil
 }
 
+// CreateUserWithAccount creates a user in scopeId, associates the account
+// (accountId) with the user and adds the user as a principal of the roles
+// (roleIds) within the writer's database. It allows other repositories to
+// create a user as part of their own transactions. WithName and
+// WithDescription are the only supported options.
+func CreateUserWithAccount(ctx context.Context, repoKms *kms.Kms, reader db.Reader, writer db.Writer, scopeId, accountId string, roleIds []string, opt ...Option) (*User, error) {
+	const op = "iam.CreateUserWithAccount"
+	switch {
+	case repoKms == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "nil kms")
+	case reader == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "nil reader")
+	case writer == nil:
+		return nil, errors.New(errors.InvalidParameter, op, "nil writer")
+	case accountId == "":
+		return nil, errors.New(errors.InvalidParameter, op, "missing account id")
+	}
+	opts := getOpts(opt...)
+	u, err := NewUser(scopeId, WithName(opts.withName), WithDescription(opts.withDescription))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if u.PublicId, err = newUserId(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	userScope, err := u.GetScope(ctx, reader)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get user scope"))
+	}
+	oplogWrapper, err := repoKms.GetWrapper(ctx, userScope.GetPublicId(), kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+	}
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{u.PublicId},
+		"scope-id":           []string{userScope.PublicId},
+		"scope-type":         []string{userScope.Type},
+		"resource-type":      []string{u.ResourceType().String()},
+		"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+	}
+	if err := writer.Create(ctx, u, db.WithOplog(oplogWrapper, metadata)); err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, errors.New(errors.NotUnique, op, fmt.Sprintf("user %s already exists in org %s", u.Name, u.ScopeId))
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("for %s", u.PublicId)))
+	}
+	if err := associateUserWithAccounts(ctx, repoKms, reader, writer, u.PublicId, []string{accountId}); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	for _, roleId := range roleIds {
+		role := allocRole()
+		role.PublicId = roleId
+		if err := reader.LookupByPublicId(ctx, &role); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup role %s", roleId)))
+		}
+		roleScope, err := role.GetScope(ctx, reader)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get role %s scope", roleId)))
+		}
+		roleWrapper, err := repoKms.GetWrapper(ctx, roleScope.GetPublicId(), kms.KeyPurposeOplog)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to get oplog wrapper"))
+		}
+		roleTicket, err := writer.GetTicket(&role)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to get ticket"))
+		}
+		updatedRole := allocRole()
+		updatedRole.PublicId = roleId
+		updatedRole.Version = role.Version + 1
+		var roleOplogMsg oplog.Message
+		rowsUpdated, err := writer.Update(ctx, &updatedRole, []string{"Version"}, nil, db.NewOplogMsg(&roleOplogMsg), db.WithVersion(&role.Version))
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to update role version"))
+		}
+		if rowsUpdated != 1 {
+			return nil, errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated role and %d rows updated", rowsUpdated))
+		}
+		userRole, err := NewUserRole(roleId, u.PublicId)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to create in memory user role"))
+		}
+		var userRoleOplogMsg oplog.Message
+		if err := writer.Create(ctx, userRole, db.NewOplogMsg(&userRoleOplogMsg)); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to add user to role %s", roleId)))
+		}
+		roleMetadata := oplog.Metadata{
+			"op-type":            []string{oplog.OpType_OP_TYPE_CREATE.String()},
+			"scope-id":           []string{roleScope.PublicId},
+			"scope-type":         []string{roleScope.Type},
+			"resource-public-id": []string{roleId},
+		}
+		if err := writer.WriteOplogEntryWith(ctx, roleWrapper, roleTicket, roleMetadata, []*oplog.Message{&roleOplogMsg, &userRoleOplogMsg}); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
+		}
+	}
+	return u, nil
+}
+
 // associateUserWithAccounts will associate the accounts (accountIds) with
 // the user (userId) within the writer's database
 func associateUserWithAccounts(ctx context.Context, repoKms *kms.Kms, reader db.Reader, writer db.Writer, userId string, accountIds []string, _ ...Option) error {
@@ -460,6 +460,57 @@ func TestRepository_DeleteUser(t *testing.T) {
 	}
 }
 
+func TestRepository_SetUserActive(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+
+	t.Run("disable-and-enable", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		u := TestUser(t, repo, org.PublicId)
+		assert.True(u.Active)
+
+		got, err := repo.SetUserActive(context.Background(), u.PublicId, false)
+		require.NoError(err)
+		assert.False(got.Active)
+		assert.Equal(u.Version+1, got.Version)
+		err = db.TestVerifyOplog(t, rw, u.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_UPDATE), db.WithCreateNotBefore(10*time.Second))
+		require.NoError(err)
+
+		// setting the current state again is a no-op
+		got, err = repo.SetUserActive(context.Background(), u.PublicId, false)
+		require.NoError(err)
+		assert.False(got.Active)
+		assert.Equal(u.Version+1, got.Version)
+
+		got, err = repo.SetUserActive(context.Background(), u.PublicId, true)
+		require.NoError(err)
+		assert.True(got.Active)
+		found, _, err := repo.LookupUser(context.Background(), u.PublicId)
+		require.NoError(err)
+		assert.True(found.Active)
+	})
+	t.Run("no-public-id", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.SetUserActive(context.Background(), "", false)
+		require.Error(err)
+		assert.Nil(got)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("not-found", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := newUserId()
+		require.NoError(err)
+		got, err := repo.SetUserActive(context.Background(), id, false)
+		require.Error(err)
+		assert.Nil(got)
+		assert.True(errors.IsNotFoundError(err))
+	})
+}
+
 func TestRepository_ListUsers(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -699,6 +750,58 @@ func TestRepository_LookupUserWithLogin(t *testing.T) {
 	}
 }
 
+func TestCreateUserWithAccount(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo := TestRepo(t, conn, wrapper)
+	org, _ := TestScopes(t, repo)
+	authMethodId := testAuthMethod(t, conn, org.PublicId)
+	role := TestRole(t, conn, org.PublicId)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		acct := testAccount(t, conn, org.PublicId, authMethodId, "")
+		var u *User
+		_, err := rw.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+			var err error
+			u, err = CreateUserWithAccount(ctx, kms, r, w, org.PublicId, acct.PublicId, []string{role.PublicId}, WithName("enrolled"))
+			return err
+		})
+		require.NoError(err)
+		assert.Equal("enrolled", u.Name)
+
+		_, accountIds, err := repo.LookupUser(ctx, u.PublicId)
+		require.NoError(err)
+		assert.Equal([]string{acct.PublicId}, accountIds)
+		principals, err := repo.ListPrincipalRoles(ctx, role.PublicId)
+		require.NoError(err)
+		require.Len(principals, 1)
+		assert.Equal(u.PublicId, principals[0].PrincipalId)
+		err = db.TestVerifyOplog(t, rw, role.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
+		assert.NoError(err)
+	})
+
+	t.Run("associated-account", func(t *testing.T) {
+		assert := assert.New(t)
+		u := TestUser(t, repo, org.PublicId)
+		acct := testAccount(t, conn, org.PublicId, authMethodId, u.PublicId)
+		_, err := rw.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(r db.Reader, w db.Writer) error {
+			_, err := CreateUserWithAccount(ctx, kms, r, w, org.PublicId, acct.PublicId, nil)
+			return err
+		})
+		assert.Truef(errors.Match(errors.T(errors.AccountAlreadyAssociated), err), "unexpected error %v", err)
+	})
+
+	t.Run("missing-account", func(t *testing.T) {
+		_, err := CreateUserWithAccount(ctx, kms, rw, rw, org.PublicId, "", nil)
+		assert.Truef(t, errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error %v", err)
+	})
+}
+
 func TestRepository_associateUserWithAccounts(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1008,6 +1111,7 @@ func TestRepository_AssociateAccounts(t *testing.T) {
 	org, _ := TestScopes(t, repo)
 	authMethodId := testAuthMethod(t, conn, org.PublicId)
 	user := TestUser(t, repo, org.PublicId)
+	serviceAccount := TestUser(t, repo, org.PublicId, WithServiceAccount(true))
 
 	createAccountsFn := func() []string {
 		require.NoError(t, conn.Where("iam_user_id = ?", user.PublicId).Delete(allocAccount()).Error)
@@ -1092,6 +1196,15 @@ func TestRepository_AssociateAccounts(t *testing.T) {
 			wantErr:     true,
 			wantErrCode: errors.InvalidParameter,
 		},
+		{
+			name: "service-account",
+			args: args{
+				userId:       serviceAccount.PublicId,
+				accountIdsFn: createAccountsFn,
+			},
+			wantErr:     true,
+			wantErrCode: errors.InvalidParameter,
+		},
 		{
 			name: "no-accounts",
 			args: args{
@@ -52,7 +52,10 @@ func NewProject(orgPublicId string, opt ...Option) (*Scope, error) {
 }
 
 // newScope creates a new Scope with options: WithName specifies the Scope's
-// friendly name. WithDescription specifies the scope's description. WithScope
+// friendly name. WithDescription specifies the scope's description.
+// WithAuthTokenTimeToLiveSeconds and WithAuthTokenTimeToStaleSeconds specify
+// the scope's default auth token durations. WithSessionRetentionSeconds
+// specifies the scope's session retention. WithScope
 // specifies the Scope's parent and must be filled in. The type of the parent is
 // used to determine the type of the child.
 func newScope(parent *Scope, opt ...Option) (*Scope, error) {
@@ -78,6 +81,10 @@ func newScope(parent *Scope, opt ...Option) (*Scope, error) {
 			Name:        opts.withName,
 			Description: opts.withDescription,
 			ParentId:    parent.PublicId,
+
+			AuthTokenTimeToLiveSeconds:  opts.withAuthTokenTimeToLive,
+			AuthTokenTimeToStaleSeconds: opts.withAuthTokenTimeToStale,
+			SessionRetentionSeconds:     opts.withSessionRetention,
 		},
 	}
 
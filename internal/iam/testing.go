@@ -197,6 +197,21 @@ func TestGroupMember(t *testing.T, conn *gorm.DB, groupId, userId string, opt ..
 	return gm
 }
 
+// TestGroupMemberGroup creates a nested group membership, adding the group
+// memberGroupId as a member of the group groupId.
+func TestGroupMemberGroup(t *testing.T, conn *gorm.DB, groupId, memberGroupId string, opt ...Option) *GroupMemberGroup {
+	t.Helper()
+	require := require.New(t)
+	rw := db.New(conn)
+	gm, err := NewGroupMemberGroup(groupId, memberGroupId)
+	require.NoError(err)
+	require.NotNil(gm)
+	err = rw.Create(context.Background(), gm)
+	require.NoError(err)
+	require.NotEmpty(gm.CreateTime)
+	return gm
+}
+
 func TestUserRole(t *testing.T, conn *gorm.DB, roleId, userId string, opt ...Option) *UserRole {
 	t.Helper()
 	require := require.New(t)
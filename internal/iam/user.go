@@ -30,8 +30,9 @@ var (
 )
 
 // NewUser creates a new in memory user and allows options:
-// WithName - to specify the user's friendly name and WithDescription - to
-// specify a user description
+// WithName - to specify the user's friendly name, WithDescription - to
+// specify a user description and WithServiceAccount - to specify the user is a
+// service account
 func NewUser(scopeId string, opt ...Option) (*User, error) {
 	const op = "iam.NewUser"
 	opts := getOpts(opt...)
@@ -40,9 +41,10 @@ func NewUser(scopeId string, opt ...Option) (*User, error) {
 	}
 	u := &User{
 		User: &store.User{
-			Name:        opts.withName,
-			Description: opts.withDescription,
-			ScopeId:     scopeId,
+			Name:           opts.withName,
+			Description:    opts.withDescription,
+			ScopeId:        scopeId,
+			ServiceAccount: opts.withServiceAccount,
 		},
 	}
 	return u, nil
@@ -25,12 +25,13 @@ func TestNewUser(t *testing.T) {
 		opt         []Option
 	}
 	tests := []struct {
-		name            string
-		args            args
-		wantErr         bool
-		wantErrMsg      string
-		wantName        string
-		wantDescription string
+		name               string
+		args               args
+		wantErr            bool
+		wantErrMsg         string
+		wantName           string
+		wantDescription    string
+		wantServiceAccount bool
 	}{
 		{
 			name: "valid",
@@ -49,6 +50,16 @@ func TestNewUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid-service-account",
+			args: args{
+				orgPublicId: org.PublicId,
+				opt:         []Option{WithName(id), WithServiceAccount(true)},
+			},
+			wantErr:            false,
+			wantName:           id,
+			wantServiceAccount: true,
+		},
 		{
 			name: "no-org",
 			args: args{
@@ -69,6 +80,7 @@ func TestNewUser(t *testing.T) {
 			}
 			require.NoError(err)
 			assert.Equal(tt.wantName, got.Name)
+			assert.Equal(tt.wantServiceAccount, got.ServiceAccount)
 			assert.Empty(got.PublicId)
 		})
 	}
@@ -0,0 +1,39 @@
+package kms
+
+import (
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+// KeyInfo describes a key of a scope and its versions, without their key
+// material.
+type KeyInfo struct {
+	// Id of the key
+	Id string
+	// ScopeId of the key
+	ScopeId string
+	// Type of the key, either KeyTypeRootKey or the key type of a DEK
+	Type KeyType
+	// Purpose of the key, KeyPurposeUnknown for the root key
+	Purpose KeyPurpose
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp
+	// Versions of the key, ordered from the current version to the oldest
+	Versions []*KeyVersionInfo
+}
+
+// KeyVersionInfo describes a version of a key, without its key material.
+type KeyVersionInfo struct {
+	// Id of the key version
+	Id string
+	// Version number of the key version
+	Version uint32
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp
+	// Current reports whether the key version is used to encrypt new values
+	Current bool
+	// References contains the number of rows of each table encrypted with
+	// the key version.  For a root key version, it contains the number of
+	// versions of each DEK encrypted with it, keyed by the table of the
+	// versions.
+	References map[string]int64
+}
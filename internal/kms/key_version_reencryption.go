@@ -0,0 +1,245 @@
+package kms
+
+import (
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+const (
+	keyVersionReencryptionTableName      = "kms_key_version_reencryption"
+	keyVersionReencryptionTableTableName = "kms_key_version_reencryption_table"
+)
+
+// KeyVersionReencryption is the re-encryption of the values encrypted with a
+// DEK version with the current version of the DEK, after which the DEK
+// version can be destroyed.
+type KeyVersionReencryption struct {
+	// KeyVersionId is the id of the DEK version being re-encrypted
+	KeyVersionId string `gorm:"primary_key"`
+	// ScopeId is the scope of the DEK
+	ScopeId string `gorm:"default:null"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// UpdateTime from the RDBMS
+	UpdateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+
+	// Tables contains the progress of the re-encryption for each table
+	// containing values encrypted with the DEK version.  They are read only
+	// and ignored during write operations.
+	Tables []*KeyVersionReencryptionTable `gorm:"-"`
+}
+
+// TableName returns the table name of the key version re-encryption.
+func (r *KeyVersionReencryption) TableName() string {
+	return keyVersionReencryptionTableName
+}
+
+// Purpose returns the purpose of the DEK being re-encrypted.
+func (r *KeyVersionReencryption) Purpose() KeyPurpose {
+	return keyVersionPurpose(r.KeyVersionId)
+}
+
+// Completed reports whether none of the values of the tables reference the
+// DEK version anymore.
+func (r *KeyVersionReencryption) Completed() bool {
+	for _, t := range r.Tables {
+		if t.RemainingCount > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// KeyVersionReencryptionTable is the progress of a key version
+// re-encryption for a single table.
+type KeyVersionReencryptionTable struct {
+	// KeyVersionId is the id of the DEK version being re-encrypted
+	KeyVersionId string `gorm:"primary_key"`
+	// Name of the table containing values encrypted with the DEK version
+	Name string `gorm:"column:table_name;primary_key"`
+	// TotalCount is the number of rows of the table which referenced the DEK
+	// version when the re-encryption was started
+	TotalCount int64
+	// RemainingCount is the number of rows of the table which still
+	// reference the DEK version
+	RemainingCount int64
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// UpdateTime from the RDBMS
+	UpdateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the table name of the key version re-encryption table.
+func (t *KeyVersionReencryptionTable) TableName() string {
+	return keyVersionReencryptionTableTableName
+}
+
+// encryptedTable describes a table containing values encrypted with the
+// versions of a DEK.
+type encryptedTable struct {
+	// name of the table
+	name string
+	// purpose of the DEK used to encrypt the values
+	purpose KeyPurpose
+	// idColumns uniquely identify a row of the table
+	idColumns []string
+	// keyIdColumn contains the id of the key version used for the row
+	keyIdColumn string
+	// ctColumns contain the values encrypted with the key version, as
+	// marshaled wrapping.EncryptedBlobInfo.  When empty, the values of the
+	// rows are derived from the key version and can not be re-encrypted.
+	ctColumns []string
+	// referenceWhere optionally restricts the rows which still need the key
+	// version in keyIdColumn
+	referenceWhere string
+	// reencryptWhere optionally restricts the rows which can be re-encrypted.
+	// Rows which do not match it are re-encrypted once they do.
+	reencryptWhere string
+}
+
+// encryptedTables are the tables containing values encrypted with the
+// versions of a DEK.  Values encrypted with a tokens key version are held by
+// clients and can not be re-encrypted, and oidc keys are not used to encrypt
+// values stored in the database, so neither has tables here.
+var encryptedTables = []encryptedTable{
+	{
+		name:        "auth_token",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"public_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"token"},
+	},
+	{
+		name:        "auth_api_token",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"public_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"token"},
+	},
+	{
+		name:        "auth_password_argon2_cred",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"private_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"salt"},
+	},
+	{
+		name:        "auth_password_argon2_cred_history",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"password_account_id", "derived_key"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"salt"},
+	},
+	{
+		name:        "auth_password_account_totp",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"account_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"secret"},
+	},
+	{
+		name:        "auth_password_account_totp_pending",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"account_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"secret"},
+	},
+	{
+		name:        "auth_password_imported_cred",
+		purpose:     KeyPurposeDatabase,
+		idColumns:   []string{"private_id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"password_hash"},
+	},
+	{
+		// Re-encrypting the tofu token of a session increments its version,
+		// which workers use when updating the session, so it is only done
+		// once the session is terminated.
+		name:           "session",
+		purpose:        KeyPurposeDatabase,
+		idColumns:      []string{"public_id"},
+		keyIdColumn:    "tofu_token_key_id",
+		ctColumns:      []string{"tofu_token"},
+		reencryptWhere: "termination_reason is not null",
+	},
+	{
+		// The certificate key of a session is derived from the sessions key
+		// version, so it is needed until the session is terminated.
+		name:           "session",
+		purpose:        KeyPurposeSessions,
+		idColumns:      []string{"public_id"},
+		keyIdColumn:    "key_id",
+		referenceWhere: "termination_reason is null",
+	},
+	{
+		name:        "oplog_entry",
+		purpose:     KeyPurposeOplog,
+		idColumns:   []string{"id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"data"},
+	},
+	{
+		name:        "oplog_entry_archive",
+		purpose:     KeyPurposeOplog,
+		idColumns:   []string{"id"},
+		keyIdColumn: "key_id",
+		ctColumns:   []string{"data"},
+	},
+}
+
+// encryptedTablesFor returns the tables containing values encrypted with
+// the versions of the DEK with the purpose.
+func encryptedTablesFor(purpose KeyPurpose) []encryptedTable {
+	var tables []encryptedTable
+	for _, t := range encryptedTables {
+		if t.purpose == purpose {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// dekVersionTable describes the tables of the versions of a DEK.
+type dekVersionTable struct {
+	keyTable     string
+	versionTable string
+	keyIdColumn  string
+}
+
+var dekVersionTables = map[KeyPurpose]dekVersionTable{
+	KeyPurposeDatabase: {keyTable: "kms_database_key", versionTable: "kms_database_key_version", keyIdColumn: "database_key_id"},
+	KeyPurposeOplog:    {keyTable: "kms_oplog_key", versionTable: "kms_oplog_key_version", keyIdColumn: "oplog_key_id"},
+	KeyPurposeTokens:   {keyTable: "kms_token_key", versionTable: "kms_token_key_version", keyIdColumn: "token_key_id"},
+	KeyPurposeSessions: {keyTable: "kms_session_key", versionTable: "kms_session_key_version", keyIdColumn: "session_key_id"},
+	KeyPurposeOidc:     {keyTable: "kms_oidc_key", versionTable: "kms_oidc_key_version", keyIdColumn: "oidc_key_id"},
+}
+
+// keyVersionPurpose returns the purpose of the DEK of the key version, based
+// on the prefix of its id.  KeyPurposeUnknown is returned for root key
+// versions and unknown ids.
+func keyVersionPurpose(keyVersionId string) KeyPurpose {
+	prefix := keyVersionId
+	if i := strings.Index(keyVersionId, "_"); i > 0 {
+		prefix = keyVersionId[:i]
+	}
+	switch prefix {
+	case DatabaseKeyVersionPrefix:
+		return KeyPurposeDatabase
+	case OplogKeyVersionPrefix:
+		return KeyPurposeOplog
+	case TokenKeyVersionPrefix:
+		return KeyPurposeTokens
+	case SessionKeyVersionPrefix:
+		return KeyPurposeSessions
+	case OidcKeyVersionPrefix:
+		return KeyPurposeOidc
+	default:
+		return KeyPurposeUnknown
+	}
+}
+
+// isRootKeyVersionId reports whether the id is the id of a root key version.
+func isRootKeyVersionId(keyVersionId string) bool {
+	return strings.HasPrefix(keyVersionId, RootKeyVersionPrefix+"_")
+}
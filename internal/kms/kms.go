@@ -2,10 +2,13 @@ package kms
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-hclog"
@@ -54,13 +57,21 @@ type Kms struct {
 	// current encrypting key and all previous key versions, for decryption
 	scopePurposeCache sync.Map
 
+	// keyVersionScopeCache holds the scope of DEK versions, which never
+	// changes
+	keyVersionScopeCache sync.Map
+
 	externalScopeCache      map[string]*ExternalWrappers
 	externalScopeCacheMutex sync.RWMutex
 
+	// randomReader is used to generate the keys created when rotating
+	randomReader io.Reader
+
 	repo *Repository
 }
 
-// NewKms takes in a repo and returns a Kms. Supported options: WithLogger.
+// NewKms takes in a repo and returns a Kms. Supported options: WithLogger,
+// WithRandomReader.
 func NewKms(repo *Repository, opt ...Option) (*Kms, error) {
 	const op = "kms.NewKms"
 	if repo == nil {
@@ -68,10 +79,14 @@ func NewKms(repo *Repository, opt ...Option) (*Kms, error) {
 	}
 
 	opts := getOpts(opt...)
+	if opts.withRandomReader == nil {
+		opts.withRandomReader = rand.Reader
+	}
 
 	return &Kms{
 		logger:             opts.withLogger,
 		externalScopeCache: make(map[string]*ExternalWrappers),
+		randomReader:       opts.withRandomReader,
 		repo:               repo,
 	}, nil
 }
@@ -202,6 +217,272 @@ func (k *Kms) GetWrapper(ctx context.Context, scopeId string, purpose KeyPurpose
 	return wrapper, nil
 }
 
+// RotateKeys creates a new version of the root key and of each DEK in the
+// scope. The new versions are used for encryption from then on, while the
+// previous versions remain available for decryption. The cached wrappers for
+// the scope are dropped so this Kms picks up the new versions immediately;
+// other controllers pick them up when their caches are next cleared.
+// Supported options: WithRepository.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string, opt ...Option) (Keys, error) {
+	const op = "kms.(Kms).RotateKeys"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	repo := opts.withRepository
+	if repo == nil {
+		repo = k.repo
+	}
+	rootWrapper := k.GetExternalWrappers().Root()
+	if rootWrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing root key wrapper")
+	}
+	keys, err := repo.RotateKeys(ctx, rootWrapper, k.randomReader, scopeId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	k.clearScopeCache(scopeId)
+	return keys, nil
+}
+
+// CreateKeyVersionReencryption starts re-encrypting the values encrypted with
+// the DEK version of the scope with the current version of the DEK and
+// returns the re-encryption.  The values are re-encrypted in the background
+// by RunKeyVersionReencryptions.  Supported options: WithRepository.
+func (k *Kms) CreateKeyVersionReencryption(ctx context.Context, scopeId, keyVersionId string, opt ...Option) (*KeyVersionReencryption, error) {
+	const op = "kms.(Kms).CreateKeyVersionReencryption"
+	opts := getOpts(opt...)
+	repo := opts.withRepository
+	if repo == nil {
+		repo = k.repo
+	}
+	reencryption, err := repo.CreateKeyVersionReencryption(ctx, scopeId, keyVersionId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return reencryption, nil
+}
+
+// RunKeyVersionReencryptions records the key version ids of values encrypted
+// before their tables recorded them, then re-encrypts the values of every
+// re-encryption which has not completed with the current version of its DEK.
+// Supported options: WithRepository, WithLimit, which sets the number of rows
+// re-encrypted in a single transaction.
+func (k *Kms) RunKeyVersionReencryptions(ctx context.Context, opt ...Option) error {
+	const op = "kms.(Kms).RunKeyVersionReencryptions"
+	opts := getOpts(opt...)
+	repo := opts.withRepository
+	if repo == nil {
+		repo = k.repo
+	}
+	var limitOpt []Option
+	if opts.withLimit > 0 {
+		limitOpt = append(limitOpt, WithLimit(opts.withLimit))
+	}
+	if _, err := repo.BackfillKeyIds(ctx, limitOpt...); err != nil {
+		return errors.Wrap(err, op)
+	}
+	reencryptions, err := repo.ListKeyVersionReencryptions(ctx)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	for _, ke := range reencryptions {
+		if len(ke.Tables) == 0 {
+			continue
+		}
+		// reload the versions of the DEK so that the wrapper encrypts with
+		// the current version and can decrypt with the one re-encrypted
+		k.clearScopeCache(ke.ScopeId)
+		wrapper, err := k.GetWrapper(ctx, ke.ScopeId, ke.Purpose(), WithRepository(repo))
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get wrapper for key version %s", ke.KeyVersionId)))
+		}
+		if wrapper.KeyID() == ke.KeyVersionId {
+			// the key has not been rotated since; nothing to re-encrypt with
+			continue
+		}
+		if _, err := repo.ReencryptKeyVersion(ctx, wrapper, ke.KeyVersionId, limitOpt...); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	return nil
+}
+
+// DestroyKeyVersion destroys the key version of the scope, refusing to do so
+// while it is still in use.  See Repository.DestroyKeyVersion.  Other
+// controllers keep the destroyed version in their caches until they are next
+// cleared.  Supported options: WithRepository.
+func (k *Kms) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string, opt ...Option) error {
+	const op = "kms.(Kms).DestroyKeyVersion"
+	opts := getOpts(opt...)
+	repo := opts.withRepository
+	if repo == nil {
+		repo = k.repo
+	}
+	if err := repo.DestroyKeyVersion(ctx, scopeId, keyVersionId); err != nil {
+		return errors.Wrap(err, op)
+	}
+	k.clearScopeCache(scopeId)
+	return nil
+}
+
+// GetWrapperForKeyVersion returns the wrapper for the DEK version, which can
+// be used to decrypt values encrypted with it when their scope is not known,
+// e.g. from the key id of an encrypted blob.  Supported options:
+// WithRepository.
+func (k *Kms) GetWrapperForKeyVersion(ctx context.Context, keyVersionId string, opt ...Option) (wrapping.Wrapper, error) {
+	const op = "kms.(Kms).GetWrapperForKeyVersion"
+	if keyVersionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	var scopeId string
+	if val, ok := k.keyVersionScopeCache.Load(keyVersionId); ok {
+		scopeId = val.(string)
+	} else {
+		opts := getOpts(opt...)
+		repo := opts.withRepository
+		if repo == nil {
+			repo = k.repo
+		}
+		var err error
+		if scopeId, err = repo.LookupKeyVersionScope(ctx, keyVersionId); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		k.keyVersionScopeCache.Store(keyVersionId, scopeId)
+	}
+	wrapper, err := k.GetWrapper(ctx, scopeId, keyVersionPurpose(keyVersionId), append(opt, WithKeyId(keyVersionId))...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return wrapper, nil
+}
+
+// ListKeys returns the root key and the DEKs of the scope with their
+// versions and the number of rows each version encrypts.  The key material
+// is not returned.  Supported options: WithRepository.
+func (k *Kms) ListKeys(ctx context.Context, scopeId string, opt ...Option) ([]*KeyInfo, error) {
+	const op = "kms.(Kms).ListKeys"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	opts := getOpts(opt...)
+	repo := opts.withRepository
+	if repo == nil {
+		repo = k.repo
+	}
+
+	rootKeys, err := repo.ListRootKeys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	var rootKey *RootKey
+	for _, rk := range rootKeys {
+		if rk.GetScopeId() == scopeId {
+			rootKey = rk
+			break
+		}
+	}
+	if rootKey == nil {
+		return nil, errors.New(errors.RecordNotFound, op, fmt.Sprintf("missing root key for scope %s", scopeId))
+	}
+	rootWrapper := k.GetExternalWrappers().Root()
+	if rootWrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing root key wrapper")
+	}
+	rootKeyVersions, err := repo.ListRootKeyVersions(ctx, rootWrapper, rootKey.GetPrivateId(), WithOrderByVersion(db.DescendingOrderBy))
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error looking up root key versions for scope %s", scopeId)))
+	}
+	rootInfo := &KeyInfo{
+		Id:         rootKey.GetPrivateId(),
+		ScopeId:    scopeId,
+		Type:       KeyTypeRootKey,
+		CreateTime: rootKey.GetCreateTime(),
+	}
+	for i, v := range rootKeyVersions {
+		refs, err := repo.CountKeyVersionReferences(ctx, v.GetPrivateId())
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		rootInfo.Versions = append(rootInfo.Versions, &KeyVersionInfo{
+			Id:         v.GetPrivateId(),
+			Version:    v.GetVersion(),
+			CreateTime: v.GetCreateTime(),
+			Current:    i == 0,
+			References: refs,
+		})
+	}
+	infos := []*KeyInfo{rootInfo}
+
+	rootKeyVersionsWrapper, _, err := k.loadRoot(ctx, scopeId, opt...)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error loading root key for scope %s", scopeId)))
+	}
+	for _, dek := range []struct {
+		purpose      KeyPurpose
+		keyType      KeyType
+		listKeys     func(context.Context, ...Option) ([]Dek, error)
+		listVersions func(context.Context, wrapping.Wrapper, string, ...Option) ([]DekVersion, error)
+	}{
+		{KeyPurposeDatabase, KeyTypeDatabaseKey, repo.ListDatabaseKeys, repo.ListDatabaseKeyVersions},
+		{KeyPurposeOplog, KeyTypeOplogKey, repo.ListOplogKeys, repo.ListOplogKeyVersions},
+		{KeyPurposeTokens, KeyTypeTokenKey, repo.ListTokenKeys, repo.ListTokenKeyVersions},
+		{KeyPurposeSessions, KeyTypeSessionKey, repo.ListSessionKeys, repo.ListSessionKeyVersions},
+		{KeyPurposeOidc, KeyTypeOidcKey, repo.ListOidcKeys, repo.ListOidcKeyVersions},
+	} {
+		keys, err := dek.listKeys(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error listing %s keys", dek.purpose.String())))
+		}
+		for _, key := range keys {
+			if key.GetRootKeyId() != rootKey.GetPrivateId() {
+				continue
+			}
+			versions, err := dek.listVersions(ctx, rootKeyVersionsWrapper, key.GetPrivateId(), WithOrderByVersion(db.DescendingOrderBy))
+			if err != nil {
+				return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error looking up %s key versions for scope %s", dek.purpose.String(), scopeId)))
+			}
+			info := &KeyInfo{
+				Id:         key.GetPrivateId(),
+				ScopeId:    scopeId,
+				Type:       dek.keyType,
+				Purpose:    dek.purpose,
+				CreateTime: key.GetCreateTime(),
+			}
+			for i, v := range versions {
+				refs, err := repo.CountKeyVersionReferences(ctx, v.GetPrivateId())
+				if err != nil {
+					return nil, errors.Wrap(err, op)
+				}
+				info.Versions = append(info.Versions, &KeyVersionInfo{
+					Id:         v.GetPrivateId(),
+					Version:    v.GetVersion(),
+					CreateTime: v.GetCreateTime(),
+					Current:    i == 0,
+					References: refs,
+				})
+			}
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+// ClearCache drops all cached wrappers so that the next call to GetWrapper
+// for each scope and purpose reloads the key versions from the database.
+func (k *Kms) ClearCache() {
+	k.scopePurposeCache.Range(func(key, _ interface{}) bool {
+		k.scopePurposeCache.Delete(key)
+		return true
+	})
+}
+
+func (k *Kms) clearScopeCache(scopeId string) {
+	for _, purpose := range []KeyPurpose{KeyPurposeDatabase, KeyPurposeOplog, KeyPurposeTokens, KeyPurposeSessions, KeyPurposeOidc} {
+		k.scopePurposeCache.Delete(scopeId + purpose.String())
+	}
+}
+
 func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*multiwrapper.MultiWrapper, string, error) {
 	const op = "kms.loadRoot"
 	opts := getOpts(opt...)
@@ -272,12 +553,15 @@ func (k *Kms) loadRoot(ctx context.Context, scopeId string, opt ...Option) (*mul
 type Dek interface {
 	GetRootKeyId() string
 	GetPrivateId() string
+	GetCreateTime() *timestamp.Timestamp
 }
 
 // DekVersion is an interface wrapping versioned dek types to allow a lot less switching in loadDek
 type DekVersion interface {
 	GetPrivateId() string
 	GetKey() []byte
+	GetVersion() uint32
+	GetCreateTime() *timestamp.Timestamp
 }
 
 func (k *Kms) loadDek(ctx context.Context, scopeId string, purpose KeyPurpose, rootWrapper wrapping.Wrapper, rootKeyId string, opt ...Option) (*multiwrapper.MultiWrapper, error) {
@@ -125,3 +125,49 @@ func TestKms(t *testing.T) {
 		})
 	}
 }
+
+func TestKms_ListKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	t.Run("missing scope", func(t *testing.T) {
+		_, err := kmsCache.ListKeys(ctx, "")
+		assert.Error(t, err)
+	})
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := kmsCache.ListKeys(ctx, "o_doesnotexist")
+		assert.Error(t, err)
+	})
+
+	oldKeys, err := kmsCache.ListKeys(ctx, org.GetPublicId())
+	require.NoError(t, err)
+	_, err = kmsCache.RotateKeys(ctx, org.GetPublicId())
+	require.NoError(t, err)
+
+	assert, require := assert.New(t), require.New(t)
+	keys, err := kmsCache.ListKeys(ctx, org.GetPublicId())
+	require.NoError(err)
+	require.Len(keys, 6)
+	require.Len(oldKeys, 6)
+	assert.Equal(kms.KeyTypeRootKey, keys[0].Type)
+	for i, k := range keys {
+		assert.Equal(org.GetPublicId(), k.ScopeId)
+		assert.Equal(oldKeys[i].Id, k.Id)
+		require.Len(k.Versions, 2)
+		assert.True(k.Versions[0].Current)
+		assert.Equal(uint32(2), k.Versions[0].Version)
+		assert.False(k.Versions[1].Current)
+		assert.Equal(oldKeys[i].Versions[0].Id, k.Versions[1].Id)
+	}
+
+	// every DEK version is encrypted with a root key version
+	var rootRefs int64
+	for _, n := range keys[0].Versions[1].References {
+		rootRefs += n
+	}
+	assert.Equal(int64(5), rootRefs)
+}
@@ -1,6 +1,8 @@
 package kms
 
 import (
+	"io"
+
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -28,6 +30,7 @@ type options struct {
 	withRepository        *Repository
 	withOrderByVersion    db.OrderBy
 	withKeyId             string
+	withRandomReader      io.Reader
 }
 
 func getDefaultOptions() options {
@@ -96,3 +99,10 @@ func WithKeyId(keyId string) Option {
 		o.withKeyId = keyId
 	}
 }
+
+// WithRandomReader sets the random reader used to generate new keys
+func WithRandomReader(randomReader io.Reader) Option {
+	return func(o *options) {
+		o.withRandomReader = randomReader
+	}
+}
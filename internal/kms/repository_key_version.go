@@ -0,0 +1,713 @@
+package kms
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// lookupDekVersionQuery returns whether the DEK version ($2) of the scope
+	// ($1) is the current version of its DEK.  It is formatted with the
+	// tables of a dekVersionTable.
+	lookupDekVersionQuery = `
+select kv.version = (select max(v.version) from %[2]s v where v.%[3]s = kv.%[3]s) as current
+  from %[2]s kv
+  join %[1]s k on k.private_id = kv.%[3]s
+  join kms_root_key rk on rk.private_id = k.root_key_id
+ where rk.scope_id = $1
+   and kv.private_id = $2;
+`
+	// lookupRootKeyVersionQuery returns whether the root key version ($2) of
+	// the scope ($1) is the current version of its root key.
+	lookupRootKeyVersionQuery = `
+select rkv.version = (select max(v.version) from kms_root_key_version v where v.root_key_id = rkv.root_key_id) as current
+  from kms_root_key_version rkv
+  join kms_root_key rk on rk.private_id = rkv.root_key_id
+ where rk.scope_id = $1
+   and rkv.private_id = $2;
+`
+	// lookupDekVersionScopeQuery returns the scope of the DEK version ($1).
+	// It is formatted with the tables of a dekVersionTable.
+	lookupDekVersionScopeQuery = `
+select rk.scope_id
+  from %[2]s kv
+  join %[1]s k on k.private_id = kv.%[3]s
+  join kms_root_key rk on rk.private_id = k.root_key_id
+ where kv.private_id = $1;
+`
+	// countDekVersionsQuery returns the number of versions of a DEK which are
+	// encrypted with the root key version ($1).  It is formatted with the
+	// version table of a dekVersionTable.
+	countDekVersionsQuery = `select count(*) from %s where root_key_version_id = $1`
+	// countEncryptedRowsQuery returns the number of rows of a table matching
+	// a where clause.  It is formatted with the table and the where clause.
+	countEncryptedRowsQuery = `select count(*) from %s where %s`
+	// selectEncryptedRowsQuery locks and returns a batch of rows of a table
+	// matching a where clause.  It is formatted with the columns, the table,
+	// the where clause and the size of the batch.
+	selectEncryptedRowsQuery = `select %s from %s where %s limit %d for update skip locked`
+	// updateEncryptedRowQuery updates the encrypted values and key version id
+	// of a row.  It is formatted with the table, the set clause and the where
+	// clause.
+	updateEncryptedRowQuery = `update %s set %s where %s`
+
+	updateKeyVersionReencryptionTableQuery = `
+update kms_key_version_reencryption_table
+   set remaining_count = $1,
+       total_count = greatest(total_count, $1)
+ where key_version_id = $2
+   and table_name = $3;
+`
+	deleteKeyVersionReencryptionQuery = `delete from kms_key_version_reencryption where key_version_id = $1`
+	deleteKeyVersionQuery             = `delete from %s where private_id = $1`
+)
+
+// dekPurposes are the purposes of the DEKs of a scope
+var dekPurposes = []KeyPurpose{KeyPurposeDatabase, KeyPurposeOplog, KeyPurposeTokens, KeyPurposeSessions, KeyPurposeOidc}
+
+// CreateKeyVersionReencryption starts re-encrypting the values encrypted with
+// the DEK version of the scope and returns the re-encryption. If the
+// re-encryption was already started, it is returned as is. The current
+// version of a DEK and the versions of the tokens DEK can not be re-encrypted.
+// There are no valid options at this time.
+func (r *Repository) CreateKeyVersionReencryption(ctx context.Context, scopeId, keyVersionId string, _ ...Option) (*KeyVersionReencryption, error) {
+	const op = "kms.(Repository).CreateKeyVersionReencryption"
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	if keyVersionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	purpose := keyVersionPurpose(keyVersionId)
+	switch {
+	case isRootKeyVersionId(keyVersionId):
+		return nil, errors.New(errors.InvalidParameter, op, "root key versions can not be re-encrypted, destroy the key versions they encrypt instead")
+	case purpose == KeyPurposeUnknown:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is not a key version id", keyVersionId))
+	case purpose == KeyPurposeTokens:
+		return nil, errors.New(errors.InvalidParameter, op, "tokens key versions can not be re-encrypted")
+	}
+
+	var reencryption *KeyVersionReencryption
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current, err := lookupKeyVersion(ctx, reader, scopeId, keyVersionId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if current {
+				return errors.New(errors.InvalidParameter, op, "the current key version can not be re-encrypted")
+			}
+			if reencryption, err = lookupKeyVersionReencryption(ctx, reader, keyVersionId); err != nil {
+				return errors.Wrap(err, op)
+			}
+			if reencryption != nil {
+				return nil
+			}
+
+			if err := w.Create(ctx, &KeyVersionReencryption{KeyVersionId: keyVersionId, ScopeId: scopeId}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to create re-encryption of key version %s", keyVersionId)))
+			}
+			counts, err := countKeyVersionReferences(ctx, reader, keyVersionId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, t := range encryptedTablesFor(purpose) {
+				rt := &KeyVersionReencryptionTable{
+					KeyVersionId:   keyVersionId,
+					Name:           t.name,
+					TotalCount:     counts[t.name],
+					RemainingCount: counts[t.name],
+				}
+				if err := w.Create(ctx, rt); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to create re-encryption of key version %s for table %s", keyVersionId, t.name)))
+				}
+			}
+			if reencryption, err = lookupKeyVersionReencryption(ctx, reader, keyVersionId); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for key version %s", keyVersionId)))
+	}
+	return reencryption, nil
+}
+
+// LookupKeyVersionReencryption returns the re-encryption of the key version,
+// or nil when it was not started. There are no valid options at this time.
+func (r *Repository) LookupKeyVersionReencryption(ctx context.Context, keyVersionId string, _ ...Option) (*KeyVersionReencryption, error) {
+	const op = "kms.(Repository).LookupKeyVersionReencryption"
+	if keyVersionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	reencryption, err := lookupKeyVersionReencryption(ctx, r.reader, keyVersionId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return reencryption, nil
+}
+
+// ListKeyVersionReencryptions returns the re-encryptions of all the scopes.
+// There are no valid options at this time.
+func (r *Repository) ListKeyVersionReencryptions(ctx context.Context, _ ...Option) ([]*KeyVersionReencryption, error) {
+	const op = "kms.(Repository).ListKeyVersionReencryptions"
+	var reencryptions []*KeyVersionReencryption
+	if err := r.reader.SearchWhere(ctx, &reencryptions, "1=1", nil, db.WithLimit(-1), db.WithOrder("create_time")); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	var tables []*KeyVersionReencryptionTable
+	if err := r.reader.SearchWhere(ctx, &tables, "1=1", nil, db.WithLimit(-1), db.WithOrder("table_name")); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	byId := make(map[string]*KeyVersionReencryption, len(reencryptions))
+	for _, ke := range reencryptions {
+		byId[ke.KeyVersionId] = ke
+	}
+	for _, t := range tables {
+		if ke, ok := byId[t.KeyVersionId]; ok {
+			ke.Tables = append(ke.Tables, t)
+		}
+	}
+	return reencryptions, nil
+}
+
+// ReencryptKeyVersion re-encrypts the values still encrypted with the DEK
+// version being re-encrypted with the current version of the DEK, and
+// updates the progress of the re-encryption.  The wrapper must be the
+// multiwrapper of the DEK, encrypting with its current version.  The values
+// are re-encrypted in batches, each in its own transaction.  Supported
+// options: WithLimit, which sets the size of the batches.
+func (r *Repository) ReencryptKeyVersion(ctx context.Context, wrapper wrapping.Wrapper, keyVersionId string, opt ...Option) (*KeyVersionReencryption, error) {
+	const op = "kms.(Repository).ReencryptKeyVersion"
+	if wrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing wrapper")
+	}
+	if keyVersionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	if wrapper.KeyID() == keyVersionId {
+		return nil, errors.New(errors.InvalidParameter, op, "wrapper encrypts with the key version being re-encrypted")
+	}
+	limit := r.batchLimit(opt...)
+
+	for _, t := range encryptedTablesFor(keyVersionPurpose(keyVersionId)) {
+		if len(t.ctColumns) == 0 {
+			continue
+		}
+		for {
+			n, err := r.reencryptBatch(ctx, wrapper, t, keyVersionId, limit)
+			if err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			if n < limit {
+				break
+			}
+		}
+	}
+
+	var reencryption *KeyVersionReencryption
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			counts, err := countKeyVersionReferences(ctx, reader, keyVersionId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, t := range encryptedTablesFor(keyVersionPurpose(keyVersionId)) {
+				if _, err := w.Exec(ctx, updateKeyVersionReencryptionTableQuery, []interface{}{counts[t.name], keyVersionId, t.name}); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to update re-encryption of key version %s for table %s", keyVersionId, t.name)))
+				}
+			}
+			if reencryption, err = lookupKeyVersionReencryption(ctx, reader, keyVersionId); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for key version %s", keyVersionId)))
+	}
+	return reencryption, nil
+}
+
+// BackfillKeyIds sets the key version id of the rows whose values were
+// encrypted before their table recorded it, reading it from the encrypted
+// values, and returns the number of rows updated.  The rows are updated in
+// batches, each in its own transaction.  Supported options: WithLimit, which
+// sets the size of the batches.
+func (r *Repository) BackfillKeyIds(ctx context.Context, opt ...Option) (int, error) {
+	const op = "kms.(Repository).BackfillKeyIds"
+	limit := r.batchLimit(opt...)
+	var total int
+	for _, t := range encryptedTables {
+		if len(t.ctColumns) == 0 {
+			continue
+		}
+		for {
+			n, err := r.backfillBatch(ctx, t, limit)
+			if err != nil {
+				return total, errors.Wrap(err, op)
+			}
+			total += n
+			if n < limit {
+				break
+			}
+		}
+	}
+	return total, nil
+}
+
+// DestroyKeyVersion destroys the key version of the scope.  The current
+// version of a key can not be destroyed, nor can a version which is still in
+// use, which fails with errors.KeyVersionInUse: the values encrypted with a
+// DEK version must be re-encrypted first, and the DEK versions encrypted with
+// a root key version must be destroyed first.  The versions of the tokens DEK
+// can not be destroyed since the values they encrypt are held by clients.
+// There are no valid options at this time.
+func (r *Repository) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string, _ ...Option) error {
+	const op = "kms.(Repository).DestroyKeyVersion"
+	if scopeId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+	if keyVersionId == "" {
+		return errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	purpose := keyVersionPurpose(keyVersionId)
+	isRoot := isRootKeyVersionId(keyVersionId)
+	switch {
+	case !isRoot && purpose == KeyPurposeUnknown:
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is not a key version id", keyVersionId))
+	case purpose == KeyPurposeTokens:
+		return errors.New(errors.InvalidParameter, op, "tokens key versions can not be destroyed")
+	}
+
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			current, err := lookupKeyVersion(ctx, reader, scopeId, keyVersionId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if current {
+				return errors.New(errors.InvalidParameter, op, "the current key version can not be destroyed")
+			}
+
+			versionTable := "kms_root_key_version"
+			if isRoot {
+				counts, err := countRootKeyVersionReferences(ctx, reader, keyVersionId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				var count int64
+				for _, c := range counts {
+					count += c
+				}
+				if count > 0 {
+					return errors.New(errors.KeyVersionInUse, op, fmt.Sprintf("key version %s still encrypts %d data key versions", keyVersionId, count))
+				}
+			} else {
+				counts, err := countKeyVersionReferences(ctx, reader, keyVersionId)
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				var inUse []string
+				for _, t := range encryptedTablesFor(purpose) {
+					if counts[t.name] > 0 {
+						inUse = append(inUse, fmt.Sprintf("%s (%d)", t.name, counts[t.name]))
+					}
+				}
+				if len(inUse) > 0 {
+					return errors.New(errors.KeyVersionInUse, op, fmt.Sprintf("key version %s is still referenced by %s", keyVersionId, strings.Join(inUse, ", ")))
+				}
+				versionTable = dekVersionTables[purpose].versionTable
+			}
+
+			rowsDeleted, err := w.Exec(ctx, fmt.Sprintf(deleteKeyVersionQuery, versionTable), []interface{}{keyVersionId})
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to delete key version %s", keyVersionId)))
+			}
+			if rowsDeleted != 1 {
+				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("%d key versions would have been deleted", rowsDeleted))
+			}
+			if _, err := w.Exec(ctx, deleteKeyVersionReencryptionQuery, []interface{}{keyVersionId}); err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to delete re-encryption of key version %s", keyVersionId)))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for key version %s", keyVersionId)))
+	}
+	return nil
+}
+
+// CountKeyVersionReferences returns the number of rows of each table which
+// still reference the key version.  Rows with encrypted values which do not
+// record their key version yet are counted as references of every version of
+// their DEK.  For a root key version, it returns the number of versions of
+// each DEK encrypted with it, keyed by the table of the versions.  There are
+// no valid options at this time.
+func (r *Repository) CountKeyVersionReferences(ctx context.Context, keyVersionId string, _ ...Option) (map[string]int64, error) {
+	const op = "kms.(Repository).CountKeyVersionReferences"
+	if keyVersionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	var counts map[string]int64
+	var err error
+	if isRootKeyVersionId(keyVersionId) {
+		counts, err = countRootKeyVersionReferences(ctx, r.reader, keyVersionId)
+	} else {
+		counts, err = countKeyVersionReferences(ctx, r.reader, keyVersionId)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return counts, nil
+}
+
+// LookupKeyVersionScope returns the id of the scope of the DEK version.
+// There are no valid options at this time.
+func (r *Repository) LookupKeyVersionScope(ctx context.Context, keyVersionId string, _ ...Option) (string, error) {
+	const op = "kms.(Repository).LookupKeyVersionScope"
+	if keyVersionId == "" {
+		return "", errors.New(errors.InvalidParameter, op, "missing key version id")
+	}
+	t, ok := dekVersionTables[keyVersionPurpose(keyVersionId)]
+	if !ok {
+		return "", errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is not a data key version id", keyVersionId))
+	}
+	rows, err := r.reader.Query(ctx, fmt.Sprintf(lookupDekVersionScopeQuery, t.keyTable, t.versionTable, t.keyIdColumn), []interface{}{keyVersionId})
+	if err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", errors.Wrap(err, op)
+		}
+		return "", errors.New(errors.RecordNotFound, op, fmt.Sprintf("key version %s not found", keyVersionId))
+	}
+	var scopeId string
+	if err := rows.Scan(&scopeId); err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return scopeId, nil
+}
+
+// batchLimit returns the size of the batches of rows updated in a single
+// transaction, honoring the WithLimit option.
+func (r *Repository) batchLimit(opt ...Option) int {
+	opts := getOpts(opt...)
+	if opts.withLimit > 0 {
+		return opts.withLimit
+	}
+	if r.defaultLimit > 0 {
+		return r.defaultLimit
+	}
+	return db.DefaultLimit
+}
+
+// reencryptBatch re-encrypts a batch of the rows of the table which
+// reference the key version and returns the number of rows re-encrypted.
+func (r *Repository) reencryptBatch(ctx context.Context, wrapper wrapping.Wrapper, t encryptedTable, keyVersionId string, limit int) (int, error) {
+	const op = "kms.(Repository).reencryptBatch"
+	where := []string{fmt.Sprintf("%s = $1", t.keyIdColumn)}
+	if t.referenceWhere != "" {
+		where = append(where, fmt.Sprintf("(%s)", t.referenceWhere))
+	}
+	if t.reencryptWhere != "" {
+		where = append(where, fmt.Sprintf("(%s)", t.reencryptWhere))
+	}
+	columns := append(append([]string{}, t.idColumns...), t.ctColumns...)
+	query := fmt.Sprintf(selectEncryptedRowsQuery, strings.Join(columns, ", "), t.name, strings.Join(where, " and "), limit)
+
+	var count int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rows, err := reader.Query(ctx, query, []interface{}{keyVersionId})
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			encRows, err := scanEncryptedRows(rows, t)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, row := range encRows {
+				for i, ct := range row.cts {
+					if ct == nil {
+						continue
+					}
+					if row.cts[i], err = reencryptValue(ctx, wrapper, ct); err != nil {
+						return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to re-encrypt %s.%s", t.name, t.ctColumns[i])))
+					}
+				}
+				if err := updateEncryptedRow(ctx, w, t, row, wrapper.KeyID(), true); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			count = len(encRows)
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for table %s", t.name)))
+	}
+	return count, nil
+}
+
+// backfillBatch sets the key version id of a batch of the rows of the table
+// which have encrypted values but no key version id, and returns the number
+// of rows updated.
+func (r *Repository) backfillBatch(ctx context.Context, t encryptedTable, limit int) (int, error) {
+	const op = "kms.(Repository).backfillBatch"
+	where := fmt.Sprintf("%s is null and %s is not null", t.keyIdColumn, t.ctColumns[0])
+	columns := append(append([]string{}, t.idColumns...), t.ctColumns...)
+	query := fmt.Sprintf(selectEncryptedRowsQuery, strings.Join(columns, ", "), t.name, where, limit)
+
+	var count int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rows, err := reader.Query(ctx, query, nil)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			encRows, err := scanEncryptedRows(rows, t)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			for _, row := range encRows {
+				blobInfo := new(wrapping.EncryptedBlobInfo)
+				if err := proto.Unmarshal(row.cts[0], blobInfo); err != nil {
+					return errors.Wrap(err, op, errors.WithCode(errors.Decode), errors.WithMsg(fmt.Sprintf("unable to unmarshal %s.%s", t.name, t.ctColumns[0])))
+				}
+				if blobInfo.GetKeyInfo().GetKeyID() == "" {
+					return errors.New(errors.KeyNotFound, op, fmt.Sprintf("%s.%s has no key id", t.name, t.ctColumns[0]))
+				}
+				if err := updateEncryptedRow(ctx, w, t, row, blobInfo.GetKeyInfo().GetKeyID(), false); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			count = len(encRows)
+			return nil
+		},
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for table %s", t.name)))
+	}
+	return count, nil
+}
+
+// encryptedRow is a row of an encryptedTable
+type encryptedRow struct {
+	ids []interface{}
+	cts [][]byte
+}
+
+// scanEncryptedRows reads and closes rows containing the id columns followed
+// by the ct columns of the table.
+func scanEncryptedRows(rows *sql.Rows, t encryptedTable) ([]*encryptedRow, error) {
+	const op = "kms.scanEncryptedRows"
+	defer rows.Close()
+	var encRows []*encryptedRow
+	for rows.Next() {
+		row := &encryptedRow{
+			ids: make([]interface{}, len(t.idColumns)),
+			cts: make([][]byte, len(t.ctColumns)),
+		}
+		dest := make([]interface{}, 0, len(t.idColumns)+len(t.ctColumns))
+		for i := range row.ids {
+			dest = append(dest, &row.ids[i])
+		}
+		for i := range row.cts {
+			dest = append(dest, &row.cts[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to scan row of %s", t.name)))
+		}
+		encRows = append(encRows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to read rows of %s", t.name)))
+	}
+	return encRows, nil
+}
+
+// updateEncryptedRow sets the key version id of the row and, when withCts is
+// true, its encrypted values.
+func updateEncryptedRow(ctx context.Context, w db.Writer, t encryptedTable, row *encryptedRow, keyVersionId string, withCts bool) error {
+	const op = "kms.updateEncryptedRow"
+	var set, where []string
+	var args []interface{}
+	if withCts {
+		for i, c := range t.ctColumns {
+			args = append(args, row.cts[i])
+			set = append(set, fmt.Sprintf("%s = $%d", c, len(args)))
+		}
+	}
+	args = append(args, keyVersionId)
+	set = append(set, fmt.Sprintf("%s = $%d", t.keyIdColumn, len(args)))
+	for i, c := range t.idColumns {
+		args = append(args, row.ids[i])
+		where = append(where, fmt.Sprintf("%s = $%d", c, len(args)))
+	}
+	rowsUpdated, err := w.Exec(ctx, fmt.Sprintf(updateEncryptedRowQuery, t.name, strings.Join(set, ", "), strings.Join(where, " and ")), args)
+	if err != nil {
+		return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to update row of %s", t.name)))
+	}
+	if rowsUpdated != 1 {
+		return errors.New(errors.MultipleRecords, op, fmt.Sprintf("%d rows of %s would have been updated", rowsUpdated, t.name))
+	}
+	return nil
+}
+
+// reencryptValue decrypts the marshaled wrapping.EncryptedBlobInfo with the
+// wrapper and encrypts it again with the wrapper's current key.
+func reencryptValue(ctx context.Context, wrapper wrapping.Wrapper, ct []byte) ([]byte, error) {
+	const op = "kms.reencryptValue"
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(ct, blobInfo); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	pt, err := wrapper.Decrypt(ctx, blobInfo, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decrypt))
+	}
+	if blobInfo, err = wrapper.Encrypt(ctx, pt, nil); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
+	}
+	newCt, err := proto.Marshal(blobInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Encode))
+	}
+	return newCt, nil
+}
+
+// lookupKeyVersion returns whether the key version of the scope is the
+// current version of its key.
+func lookupKeyVersion(ctx context.Context, reader db.Reader, scopeId, keyVersionId string) (bool, error) {
+	const op = "kms.lookupKeyVersion"
+	query := lookupRootKeyVersionQuery
+	if !isRootKeyVersionId(keyVersionId) {
+		t, ok := dekVersionTables[keyVersionPurpose(keyVersionId)]
+		if !ok {
+			return false, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is not a key version id", keyVersionId))
+		}
+		query = fmt.Sprintf(lookupDekVersionQuery, t.keyTable, t.versionTable, t.keyIdColumn)
+	}
+	rows, err := reader.Query(ctx, query, []interface{}{scopeId, keyVersionId})
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		return false, errors.New(errors.RecordNotFound, op, fmt.Sprintf("key version %s not found in scope %s", keyVersionId, scopeId))
+	}
+	var current bool
+	if err := rows.Scan(&current); err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	return current, nil
+}
+
+// lookupKeyVersionReencryption returns the re-encryption of the key version
+// with its tables, or nil when it was not started.
+func lookupKeyVersionReencryption(ctx context.Context, reader db.Reader, keyVersionId string) (*KeyVersionReencryption, error) {
+	const op = "kms.lookupKeyVersionReencryption"
+	reencryption := &KeyVersionReencryption{}
+	if err := reader.LookupWhere(ctx, reencryption, "key_version_id = ?", keyVersionId); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op)
+	}
+	if err := reader.SearchWhere(ctx, &reencryption.Tables, "key_version_id = ?", []interface{}{keyVersionId}, db.WithLimit(-1), db.WithOrder("table_name")); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return reencryption, nil
+}
+
+// countKeyVersionReferences returns the number of rows of each table which
+// still reference the DEK version.
+func countKeyVersionReferences(ctx context.Context, reader db.Reader, keyVersionId string) (map[string]int64, error) {
+	const op = "kms.countKeyVersionReferences"
+	counts := make(map[string]int64)
+	for _, t := range encryptedTablesFor(keyVersionPurpose(keyVersionId)) {
+		where := fmt.Sprintf("%s = $1", t.keyIdColumn)
+		if len(t.ctColumns) > 0 {
+			// rows which do not record their key version yet may be
+			// encrypted with it
+			where = fmt.Sprintf("(%s or (%s is null and %s is not null))", where, t.keyIdColumn, t.ctColumns[0])
+		}
+		if t.referenceWhere != "" {
+			where = fmt.Sprintf("%s and (%s)", where, t.referenceWhere)
+		}
+		count, err := queryCount(ctx, reader, fmt.Sprintf(countEncryptedRowsQuery, t.name, where), keyVersionId)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to count rows of %s", t.name)))
+		}
+		counts[t.name] += count
+	}
+	return counts, nil
+}
+
+// countRootKeyVersionReferences returns the number of versions of each DEK,
+// keyed by the table of the versions, which are encrypted with the root key
+// version.
+func countRootKeyVersionReferences(ctx context.Context, reader db.Reader, keyVersionId string) (map[string]int64, error) {
+	const op = "kms.countRootKeyVersionReferences"
+	counts := make(map[string]int64, len(dekPurposes))
+	for _, purpose := range dekPurposes {
+		versionTable := dekVersionTables[purpose].versionTable
+		count, err := queryCount(ctx, reader, fmt.Sprintf(countDekVersionsQuery, versionTable), keyVersionId)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to count %s key versions", purpose.String())))
+		}
+		counts[versionTable] = count
+	}
+	return counts, nil
+}
+
+// queryCount runs a query returning a single count
+func queryCount(ctx context.Context, reader db.Reader, query string, args ...interface{}) (int64, error) {
+	const op = "kms.queryCount"
+	rows, err := reader.Query(ctx, query, args)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, errors.Wrap(err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	return count, nil
+}
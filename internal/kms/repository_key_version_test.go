@@ -0,0 +1,145 @@
+package kms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKms_KeyVersionReencryption(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+	atRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+
+	oldKeys := make(map[kms.KeyPurpose]string)
+	for _, purpose := range []kms.KeyPurpose{kms.KeyPurposeDatabase, kms.KeyPurposeOplog, kms.KeyPurposeTokens, kms.KeyPurposeSessions} {
+		w, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), purpose)
+		require.NoError(t, err)
+		oldKeys[purpose] = w.KeyID()
+	}
+	keys, err := kmsCache.RotateKeys(ctx, org.GetPublicId())
+	require.NoError(t, err)
+	oldDatabaseKeyId := oldKeys[kms.KeyPurposeDatabase]
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			name         string
+			scopeId      string
+			keyVersionId string
+			wantCode     errors.Code
+		}{
+			{name: "missing scope", keyVersionId: oldDatabaseKeyId, wantCode: errors.InvalidParameter},
+			{name: "missing key version", scopeId: org.GetPublicId(), wantCode: errors.InvalidParameter},
+			{name: "not a key version", scopeId: org.GetPublicId(), keyVersionId: org.GetPublicId(), wantCode: errors.InvalidParameter},
+			{name: "tokens key version", scopeId: org.GetPublicId(), keyVersionId: oldKeys[kms.KeyPurposeTokens], wantCode: errors.InvalidParameter},
+			{name: "current key version", scopeId: org.GetPublicId(), keyVersionId: keys[kms.KeyTypeDatabaseKeyVersion].GetPrivateId(), wantCode: errors.InvalidParameter},
+			{name: "other scope", scopeId: "global", keyVersionId: oldDatabaseKeyId, wantCode: errors.RecordNotFound},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert := assert.New(t)
+				_, err := kmsCache.CreateKeyVersionReencryption(ctx, tt.scopeId, tt.keyVersionId)
+				assert.Truef(errors.Match(errors.T(tt.wantCode), err), "unexpected error: %v", err)
+				err = kmsCache.DestroyKeyVersion(ctx, tt.scopeId, tt.keyVersionId)
+				assert.Truef(errors.Match(errors.T(tt.wantCode), err), "unexpected error: %v", err)
+			})
+		}
+	})
+
+	t.Run("root key version in use", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rootKeys, err := repo.ListRootKeys(ctx)
+		require.NoError(err)
+		for _, rk := range rootKeys {
+			if rk.GetScopeId() != org.GetPublicId() {
+				continue
+			}
+			versions, err := repo.ListRootKeyVersions(ctx, wrapper, rk.GetPrivateId(), kms.WithOrderByVersion(db.AscendingOrderBy))
+			require.NoError(err)
+			require.Len(versions, 2)
+			err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), versions[0].GetPrivateId())
+			assert.Truef(errors.Match(errors.T(errors.KeyVersionInUse), err), "unexpected error: %v", err)
+			_, err = kmsCache.CreateKeyVersionReencryption(ctx, org.GetPublicId(), versions[0].GetPrivateId())
+			assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+		}
+	})
+
+	t.Run("database key version", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		err := kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldDatabaseKeyId)
+		assert.Truef(errors.Match(errors.T(errors.KeyVersionInUse), err), "unexpected error: %v", err)
+
+		ke, err := kmsCache.CreateKeyVersionReencryption(ctx, org.GetPublicId(), oldDatabaseKeyId)
+		require.NoError(err)
+		assert.Equal(kms.KeyPurposeDatabase, ke.Purpose())
+		assert.Equal(org.GetPublicId(), ke.ScopeId)
+		assert.False(ke.Completed())
+		var found bool
+		for _, table := range ke.Tables {
+			if table.Name == "auth_token" {
+				found = true
+				assert.GreaterOrEqual(table.TotalCount, int64(1))
+				assert.Equal(table.TotalCount, table.RemainingCount)
+			}
+		}
+		assert.True(found)
+
+		// starting it again returns the same re-encryption
+		again, err := kmsCache.CreateKeyVersionReencryption(ctx, org.GetPublicId(), oldDatabaseKeyId)
+		require.NoError(err)
+		assert.Equal(ke.CreateTime, again.CreateTime)
+
+		require.NoError(kmsCache.RunKeyVersionReencryptions(ctx, kms.WithLimit(1)))
+		ke, err = repo.LookupKeyVersionReencryption(ctx, oldDatabaseKeyId)
+		require.NoError(err)
+		require.NotNil(ke)
+		assert.True(ke.Completed())
+		counts, err := repo.CountKeyVersionReferences(ctx, oldDatabaseKeyId)
+		require.NoError(err)
+		for table, count := range counts {
+			assert.Zerof(count, "table %s", table)
+		}
+
+		// the re-encrypted token is still valid
+		got, err := atRepo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+		assert.Equal(at.GetPublicId(), got.GetPublicId())
+
+		require.NoError(kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldDatabaseKeyId))
+		ke, err = repo.LookupKeyVersionReencryption(ctx, oldDatabaseKeyId)
+		require.NoError(err)
+		assert.Nil(ke)
+		err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldDatabaseKeyId)
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+
+		got, err = atRepo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+		require.NoError(err)
+		require.NotNil(got)
+	})
+
+	t.Run("oplog key version", func(t *testing.T) {
+		require := require.New(t)
+		oldOplogKeyId := oldKeys[kms.KeyPurposeOplog]
+		_, err := kmsCache.CreateKeyVersionReencryption(ctx, org.GetPublicId(), oldOplogKeyId)
+		require.NoError(err)
+		require.NoError(kmsCache.RunKeyVersionReencryptions(ctx))
+		require.NoError(kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldOplogKeyId))
+	})
+}
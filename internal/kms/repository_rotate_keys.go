@@ -0,0 +1,201 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+)
+
+// RotateKeys creates a new version of the root key and of each DEK in the
+// scope and returns a map of the new key versions.  The new versions become
+// the versions used for encryption, while the previous versions remain
+// available for decryption.  There are no valid options at this time.
+func (r *Repository) RotateKeys(ctx context.Context, rootWrapper wrapping.Wrapper, randomReader io.Reader, scopeId string, _ ...Option) (Keys, error) {
+	const op = "kms.(Repository).RotateKeys"
+	var keys Keys
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			keys, err = RotateKeysTx(ctx, reader, w, rootWrapper, randomReader, scopeId)
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for scope %s", scopeId)))
+	}
+	return keys, nil
+}
+
+// RotateKeysTx creates a new version of the root key and of each DEK in the
+// scope and returns a map of the new key versions.  Each new DEK version is
+// encrypted with the new root key version.  This function encapsulates all
+// the work required within a db.TxHandler.
+func RotateKeysTx(ctx context.Context, dbReader db.Reader, dbWriter db.Writer, rootWrapper wrapping.Wrapper, randomReader io.Reader, scopeId string) (Keys, error) {
+	const op = "kms.RotateKeysTx"
+	if dbReader == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db reader")
+	}
+	if dbWriter == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	if rootWrapper == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing root wrapper")
+	}
+	if randomReader == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing random reader")
+	}
+	if scopeId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing scope id")
+	}
+
+	rk := AllocRootKey()
+	if err := dbReader.LookupWhere(ctx, &rk, "scope_id = ?", scopeId); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup root key for scope %s", scopeId)))
+	}
+
+	k, err := generateKey(randomReader)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error generating random bytes for root key version in scope %s", scopeId)))
+	}
+	rkv := AllocRootKeyVersion()
+	id, err := newRootKeyVersionId()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	rkv.PrivateId = id
+	rkv.RootKeyId = rk.GetPrivateId()
+	rkv.Key = k
+	if err := rkv.Encrypt(ctx, rootWrapper); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	// no oplog entries for root key versions
+	if err := dbWriter.Create(ctx, &rkv); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to create root key version in scope %s", scopeId)))
+	}
+
+	rkvWrapper := aead.NewWrapper(nil)
+	if _, err := rkvWrapper.SetConfig(map[string]string{
+		"key_id": rkv.GetPrivateId(),
+	}); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error setting config on aead root wrapper in scope %s", scopeId)))
+	}
+	if err := rkvWrapper.SetAESGCMKeyBytes(rkv.GetKey()); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error setting key bytes on aead root wrapper in scope %s", scopeId)))
+	}
+
+	keys := Keys{
+		KeyTypeRootKeyVersion: &rkv,
+	}
+	for _, dek := range []struct {
+		purpose    KeyPurpose
+		keyType    KeyType
+		key        Dek
+		newVersion func(keyId string, key []byte) (dekVersion, error)
+	}{
+		{
+			purpose: KeyPurposeDatabase,
+			keyType: KeyTypeDatabaseKeyVersion,
+			key:     func() Dek { k := AllocDatabaseKey(); return &k }(),
+			newVersion: func(keyId string, key []byte) (dekVersion, error) {
+				kv, err := NewDatabaseKeyVersion(keyId, key, rkv.GetPrivateId())
+				if err != nil {
+					return nil, err
+				}
+				kv.PrivateId, err = newDatabaseKeyVersionId()
+				return kv, err
+			},
+		},
+		{
+			purpose: KeyPurposeOplog,
+			keyType: KeyTypeOplogKeyVersion,
+			key:     func() Dek { k := AllocOplogKey(); return &k }(),
+			newVersion: func(keyId string, key []byte) (dekVersion, error) {
+				kv, err := NewOplogKeyVersion(keyId, key, rkv.GetPrivateId())
+				if err != nil {
+					return nil, err
+				}
+				kv.PrivateId, err = newOplogKeyVersionId()
+				return kv, err
+			},
+		},
+		{
+			purpose: KeyPurposeSessions,
+			keyType: KeyTypeSessionKeyVersion,
+			key:     func() Dek { k := AllocSessionKey(); return &k }(),
+			newVersion: func(keyId string, key []byte) (dekVersion, error) {
+				kv, err := NewSessionKeyVersion(keyId, key, rkv.GetPrivateId())
+				if err != nil {
+					return nil, err
+				}
+				kv.PrivateId, err = newSessionKeyVersionId()
+				return kv, err
+			},
+		},
+		{
+			purpose: KeyPurposeTokens,
+			keyType: KeyTypeTokenKeyVersion,
+			key:     func() Dek { k := AllocTokenKey(); return &k }(),
+			newVersion: func(keyId string, key []byte) (dekVersion, error) {
+				kv, err := NewTokenKeyVersion(keyId, key, rkv.GetPrivateId())
+				if err != nil {
+					return nil, err
+				}
+				kv.PrivateId, err = newTokenKeyVersionId()
+				return kv, err
+			},
+		},
+		{
+			purpose: KeyPurposeOidc,
+			keyType: KeyTypeOidcKeyVersion,
+			key:     func() Dek { k := AllocOidcKey(); return &k }(),
+			newVersion: func(keyId string, key []byte) (dekVersion, error) {
+				kv, err := NewOidcKeyVersion(keyId, key, rkv.GetPrivateId())
+				if err != nil {
+					return nil, err
+				}
+				kv.PrivateId, err = newOidcKeyVersionId()
+				return kv, err
+			},
+		},
+	} {
+		if err := dbReader.LookupWhere(ctx, dek.key, "root_key_id = ?", rk.GetPrivateId()); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to lookup %s key for scope %s", dek.purpose.String(), scopeId)))
+		}
+
+		k, err := generateKey(randomReader)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("error generating random bytes for %s key version in scope %s", dek.purpose.String(), scopeId)))
+		}
+		kv, err := dek.newVersion(dek.key.GetPrivateId(), k)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		// no oplog entries for key versions
+		if err := dbWriter.Create(ctx, kv); err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to create %s key version in scope %s", dek.purpose.String(), scopeId)))
+		}
+		keys[dek.keyType] = kv
+	}
+	return keys, nil
+}
+
+// dekVersion is an interface wrapping versioned dek types to allow creating
+// them without switching on their type in RotateKeysTx
+type dekVersion interface {
+	GetPrivateId() string
+	Encrypt(context.Context, wrapping.Wrapper) error
+}
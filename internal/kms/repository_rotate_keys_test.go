@@ -0,0 +1,87 @@
+package kms_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	t.Run("missing scope", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := kmsCache.RotateKeys(ctx, "")
+		assert.Truef(errors.Match(errors.T(errors.InvalidParameter), err), "unexpected error: %v", err)
+	})
+	t.Run("unknown scope", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := kmsCache.RotateKeys(ctx, "o_doesnotexist")
+		assert.Truef(errors.Match(errors.T(errors.RecordNotFound), err), "unexpected error: %v", err)
+	})
+
+	for _, scopeId := range []string{scope.Global.String(), org.GetPublicId(), proj.GetPublicId()} {
+		t.Run(scopeId, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			purposes := []kms.KeyPurpose{kms.KeyPurposeDatabase, kms.KeyPurposeOplog, kms.KeyPurposeSessions, kms.KeyPurposeTokens}
+
+			// encrypt a value with each current key so we can show it can
+			// still be decrypted after rotating
+			blobs := make(map[kms.KeyPurpose]*wrapping.EncryptedBlobInfo, len(purposes))
+			oldKeyIds := make(map[kms.KeyPurpose]string, len(purposes))
+			for _, purpose := range purposes {
+				w, err := kmsCache.GetWrapper(ctx, scopeId, purpose)
+				require.NoError(err)
+				oldKeyIds[purpose] = w.KeyID()
+				blobs[purpose], err = w.Encrypt(ctx, []byte(purpose.String()), nil)
+				require.NoError(err)
+			}
+
+			keys, err := kmsCache.RotateKeys(ctx, scopeId)
+			require.NoError(err)
+			for _, kt := range []kms.KeyType{kms.KeyTypeRootKeyVersion, kms.KeyTypeDatabaseKeyVersion, kms.KeyTypeOplogKeyVersion, kms.KeyTypeSessionKeyVersion, kms.KeyTypeTokenKeyVersion, kms.KeyTypeOidcKeyVersion} {
+				assert.NotNil(keys[kt], kt.String())
+			}
+
+			for _, purpose := range purposes {
+				w, err := kmsCache.GetWrapper(ctx, scopeId, purpose)
+				require.NoError(err)
+				multi, ok := w.(*multiwrapper.MultiWrapper)
+				require.True(ok)
+				assert.NotEqual(oldKeyIds[purpose], multi.KeyID())
+				assert.NotNil(multi.WrapperForKeyID(oldKeyIds[purpose]))
+				decrypted, err := w.Decrypt(ctx, blobs[purpose], nil)
+				require.NoError(err)
+				assert.Equal(purpose.String(), string(decrypted))
+			}
+
+			rootKeys, err := repo.ListRootKeys(ctx)
+			require.NoError(err)
+			for _, rk := range rootKeys {
+				if rk.GetScopeId() != scopeId {
+					continue
+				}
+				versions, err := repo.ListRootKeyVersions(ctx, wrapper, rk.GetPrivateId())
+				require.NoError(err)
+				assert.Len(versions, 2)
+			}
+		})
+	}
+}
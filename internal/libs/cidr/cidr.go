@@ -0,0 +1,82 @@
+// Package cidr evaluates client addresses against the CIDR allow and deny
+// lists of targets and auth methods.
+package cidr
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Normalize parses the CIDR blocks in cidrs and returns them in canonical
+// form, without duplicates and in their original order. A bare IP address is
+// treated as a single address block. An error is returned for the first entry
+// which is neither.
+func Normalize(cidrs []string) ([]string, error) {
+	var out []string
+	seen := make(map[string]bool, len(cidrs))
+	for _, c := range cidrs {
+		n, err := parse(c)
+		if err != nil {
+			return nil, err
+		}
+		s := n.String()
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// Allowed reports whether the client with the provided IP address is allowed
+// by the allow and deny lists. A client matching a block in deny is never
+// allowed. If allow is empty, every client not denied is allowed, otherwise
+// the client must match a block in allow. When either list is set a client
+// with an unknown or invalid address is not allowed.
+func Allowed(clientIp string, allow, deny []string) bool {
+	if len(allow) == 0 && len(deny) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIp)
+	if ip == nil {
+		return false
+	}
+	if contains(deny, ip) {
+		return false
+	}
+	return len(allow) == 0 || contains(allow, ip)
+}
+
+func contains(cidrs []string, ip net.IP) bool {
+	for _, c := range cidrs {
+		n, err := parse(c)
+		if err != nil {
+			continue
+		}
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parse(c string) (*net.IPNet, error) {
+	c = strings.TrimSpace(c)
+	if !strings.Contains(c, "/") {
+		ip := net.ParseIP(c)
+		if ip == nil {
+			return nil, fmt.Errorf("%q is not a valid CIDR block or IP address", c)
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+	}
+	_, n, err := net.ParseCIDR(c)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a valid CIDR block or IP address", c)
+	}
+	return n, nil
+}
@@ -0,0 +1,121 @@
+package cidr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "canonical",
+			in:   []string{"10.0.0.1/8", " 192.168.1.0/24", "2001:db8::1/32"},
+			want: []string{"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"},
+		},
+		{
+			name: "bare-addresses",
+			in:   []string{"127.0.0.1", "::1"},
+			want: []string{"127.0.0.1/32", "::1/128"},
+		},
+		{
+			name: "duplicates",
+			in:   []string{"10.0.0.0/8", "10.1.0.0/8", "127.0.0.1/32", "127.0.0.1"},
+			want: []string{"10.0.0.0/8", "127.0.0.1/32"},
+		},
+		{
+			name:    "invalid-block",
+			in:      []string{"10.0.0.0/8", "10.0.0.0/33"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid-address",
+			in:      []string{"not-an-address"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := Normalize(tt.in)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
+
+func TestAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		clientIp string
+		allow    []string
+		deny     []string
+		want     bool
+	}{
+		{
+			name:     "no-lists",
+			clientIp: "10.0.0.1",
+			want:     true,
+		},
+		{
+			name: "no-lists-unknown-address",
+			want: true,
+		},
+		{
+			name:     "allowed",
+			clientIp: "10.0.0.1",
+			allow:    []string{"192.168.0.0/16", "10.0.0.0/8"},
+			want:     true,
+		},
+		{
+			name:     "not-allowed",
+			clientIp: "172.16.0.1",
+			allow:    []string{"192.168.0.0/16", "10.0.0.0/8"},
+		},
+		{
+			name:     "denied",
+			clientIp: "10.0.0.1",
+			deny:     []string{"10.0.0.0/24"},
+		},
+		{
+			name:     "not-denied",
+			clientIp: "10.0.1.1",
+			deny:     []string{"10.0.0.0/24"},
+			want:     true,
+		},
+		{
+			name:     "deny-wins",
+			clientIp: "10.0.0.1",
+			allow:    []string{"10.0.0.0/8"},
+			deny:     []string{"10.0.0.0/24"},
+		},
+		{
+			name:     "ipv6",
+			clientIp: "2001:db8::1",
+			allow:    []string{"2001:db8::/32"},
+			want:     true,
+		},
+		{
+			name:  "unknown-address",
+			allow: []string{"0.0.0.0/0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Allowed(tt.clientIp, tt.allow, tt.deny))
+		})
+	}
+}
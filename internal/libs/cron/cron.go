@@ -0,0 +1,232 @@
+// Package cron parses standard five field cron expressions and computes the
+// times at which they fire.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search for the next activation of a schedule
+// which can never fire, e.g. "0 0 30 2 *".
+const maxSearchYears = 5
+
+// A Schedule is a parsed cron expression. The fields are, in order, the
+// minute, hour, day of month, month and day of week. Each field is either
+// "*", a value, a range "a-b" or a comma separated list of them, optionally
+// followed by a step "/n". Months and days of week can also be given by their
+// three letter English names. The descriptors @yearly, @annually, @monthly,
+// @weekly, @daily, @midnight and @hourly are supported as well.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record whether the day of month and day of week
+	// fields are unrestricted: when both are restricted a day matches if
+	// either of them does.
+	domStar, dowStar bool
+	spec             string
+}
+
+type bounds struct {
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as an alias for sunday.
+	dowBounds = bounds{0, 7, map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	descriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// Parse parses the cron expression spec.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expanded := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if expanded, ok = descriptors[strings.ToLower(spec)]; !ok {
+			return nil, fmt.Errorf("unknown descriptor %q", spec)
+		}
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, found %d in %q", len(fields), spec)
+	}
+	s := &Schedule{spec: spec}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of month field: %w", err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("invalid day of week field: %w", err)
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*" || fields[2] == "?"
+	s.dowStar = fields[4] == "*" || fields[4] == "?"
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first time after t, truncated to the minute, at which the
+// schedule fires. The schedule is evaluated in the location of t. The zero
+// time is returned if the schedule does not fire within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Add(time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// the hour was repeated by a daylight saving time change
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+			t = next
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Matches reports whether the schedule fires during the minute of t. The
+// schedule is evaluated in the location of t.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.month&(1<<uint(t.Month())) != 0 &&
+		s.dayMatches(t) &&
+		s.hour&(1<<uint(t.Hour())) != 0 &&
+		s.minute&(1<<uint(t.Minute())) != 0
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseField returns the bits set by the comma separated list of ranges in
+// field.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, r := range strings.Split(field, ",") {
+		rb, err := parseRange(r, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= rb
+	}
+	return bits, nil
+}
+
+func parseRange(r string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(r, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("invalid range %q", r)
+	}
+	var start, end uint
+	switch lowAndHigh := strings.Split(rangeAndStep[0], "-"); {
+	case rangeAndStep[0] == "*" || rangeAndStep[0] == "?":
+		start, end = b.min, b.max
+	case len(lowAndHigh) == 1:
+		v, err := parseValue(lowAndHigh[0], b)
+		if err != nil {
+			return 0, err
+		}
+		start, end = v, v
+		if len(rangeAndStep) == 2 {
+			end = b.max
+		}
+	case len(lowAndHigh) == 2:
+		var err error
+		if start, err = parseValue(lowAndHigh[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(lowAndHigh[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("range %q starts after it ends", r)
+		}
+	default:
+		return 0, fmt.Errorf("invalid range %q", r)
+	}
+
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		n, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || n == 0 {
+			return 0, fmt.Errorf("invalid step in %q", r)
+		}
+		step = uint(n)
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << v
+	}
+	return bits, nil
+}
+
+func parseValue(v string, b bounds) (uint, error) {
+	if n, ok := b.names[strings.ToLower(v)]; ok {
+		return n, nil
+	}
+	n, err := strconv.ParseUint(v, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", v)
+	}
+	if uint(n) < b.min || uint(n) > b.max {
+		return 0, fmt.Errorf("value %d is out of range [%d, %d]", n, b.min, b.max)
+	}
+	return uint(n), nil
+}
@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "every-minute", spec: "* * * * *"},
+		{name: "lists-ranges-steps", spec: "0,30 9-17/2 1-15 */3 1-5"},
+		{name: "names", spec: "0 9 * jan-mar MON-fri"},
+		{name: "sunday-alias", spec: "0 0 * * 7"},
+		{name: "descriptor", spec: "@daily"},
+		{name: "unknown-descriptor", spec: "@fortnightly", wantErr: true},
+		{name: "too-few-fields", spec: "* * * *", wantErr: true},
+		{name: "too-many-fields", spec: "* * * * * *", wantErr: true},
+		{name: "out-of-range", spec: "60 * * * *", wantErr: true},
+		{name: "zero-day-of-month", spec: "0 0 0 * *", wantErr: true},
+		{name: "reversed-range", spec: "0 17-9 * * *", wantErr: true},
+		{name: "zero-step", spec: "*/0 * * * *", wantErr: true},
+		{name: "invalid-value", spec: "0 noon * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.spec, s.String())
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "next-minute",
+			spec: "* * * * *",
+			from: time.Date(2021, 3, 1, 10, 15, 30, 0, time.UTC),
+			want: time.Date(2021, 3, 1, 10, 16, 0, 0, time.UTC),
+		},
+		{
+			name: "strictly-after",
+			spec: "0 9 * * *",
+			from: time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			want: time.Date(2021, 3, 2, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "weekdays",
+			spec: "0 9 * * mon-fri",
+			from: time.Date(2021, 3, 5, 10, 0, 0, 0, time.UTC), // friday
+			want: time.Date(2021, 3, 8, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day-of-month-or-day-of-week",
+			spec: "0 0 15 * sun",
+			from: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), // monday
+			want: time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap-day",
+			spec: "0 0 29 2 *",
+			from: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			spec: "0 0 30 2 *",
+			from: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time-zone",
+			spec: "0 9 * * *",
+			from: time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC).In(ny),
+			want: time.Date(2021, 3, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "skipped-by-daylight-saving",
+			spec: "30 2 * * *",
+			from: time.Date(2021, 3, 14, 0, 0, 0, 0, ny),
+			want: time.Date(2021, 3, 15, 2, 30, 0, 0, ny),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			got := s.Next(tt.from)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	s, err := Parse("*/15 9-17 * * mon-fri")
+	require.NoError(t, err)
+	assert.True(t, s.Matches(time.Date(2021, 3, 1, 9, 45, 10, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2021, 3, 1, 9, 46, 0, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2021, 3, 1, 18, 0, 0, 0, time.UTC)))
+	assert.False(t, s.Matches(time.Date(2021, 3, 6, 9, 45, 0, 0, time.UTC)))
+}
@@ -0,0 +1,138 @@
+// Package catalog provides the oplog.TypeCatalog of the messages Boundary's
+// domain repositories write to the oplog.
+package catalog
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	passwordStore "github.com/hashicorp/boundary/internal/auth/password/store"
+	authStore "github.com/hashicorp/boundary/internal/auth/store"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static"
+	staticStore "github.com/hashicorp/boundary/internal/host/static/store"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	targetStore "github.com/hashicorp/boundary/internal/target/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// authAccountTableName is the table the iam repository writes auth accounts
+// to when associating them with users.
+const authAccountTableName = "auth_account"
+
+// authAccount is the domain type of the auth accounts written by the iam
+// repository, which does not export its own.
+type authAccount struct {
+	*authStore.Account
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the tablename to override the default gorm table name.
+func (a *authAccount) TableName() string {
+	if a.tableName != "" {
+		return a.tableName
+	}
+	return authAccountTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface.
+func (a *authAccount) SetTableName(n string) {
+	a.tableName = n
+}
+
+// domainTypes pairs the store messages written to the oplog with the domain
+// types which embed them.  The domain types name the tables the messages are
+// written to.
+var domainTypes = []struct {
+	store  proto.Message
+	domain oplog.ReplayableMessage
+}{
+	{&iamStore.Scope{}, &iam.Scope{}},
+	{&iamStore.User{}, &iam.User{}},
+	{&iamStore.Group{}, &iam.Group{}},
+	{&iamStore.Role{}, &iam.Role{}},
+	{&iamStore.RoleGrant{}, &iam.RoleGrant{}},
+	{&iamStore.UserRole{}, &iam.UserRole{}},
+	{&iamStore.GroupRole{}, &iam.GroupRole{}},
+	{&iamStore.GroupMemberUser{}, &iam.GroupMemberUser{}},
+	{&iamStore.GroupMemberGroup{}, &iam.GroupMemberGroup{}},
+	{&authStore.Account{}, &authAccount{}},
+
+	{&passwordStore.AuthMethod{}, &password.AuthMethod{}},
+	{&passwordStore.Account{}, &password.Account{}},
+	{&passwordStore.AccountLockout{}, &password.AccountLockout{}},
+	{&passwordStore.AccountTotp{}, &password.AccountTotp{}},
+	{&passwordStore.AccountTotpRecoveryCode{}, &password.AccountTotpRecoveryCode{}},
+	{&passwordStore.AccountTotpPending{}, &password.AccountTotpPending{}},
+	{&passwordStore.AccountTotpPendingRecoveryCode{}, &password.AccountTotpPendingRecoveryCode{}},
+	{&passwordStore.Argon2Configuration{}, &password.Argon2Configuration{}},
+	{&passwordStore.Argon2Credential{}, &password.Argon2Credential{}},
+	{&passwordStore.BannedPassword{}, &password.BannedPassword{}},
+	{&passwordStore.ClientCidr{}, &password.ClientCidr{}},
+	{&passwordStore.Credential{}, &password.Credential{}},
+	{&passwordStore.ImportedCredential{}, &password.ImportedCredential{}},
+	{&passwordStore.Invite{}, &password.Invite{}},
+	{&passwordStore.InviteRole{}, &password.InviteRole{}},
+	{&passwordStore.ResetToken{}, &password.ResetToken{}},
+
+	{&staticStore.HostCatalog{}, &static.HostCatalog{}},
+	{&staticStore.Host{}, &static.Host{}},
+	{&staticStore.HostSet{}, &static.HostSet{}},
+	{&staticStore.HostSetMember{}, &static.HostSetMember{}},
+
+	{&targetStore.TcpTarget{}, &target.TcpTarget{}},
+	{&targetStore.TargetHostSet{}, &target.TargetHostSet{}},
+	{&targetStore.TargetClientCidr{}, &target.TargetClientCidr{}},
+}
+
+// Types returns the oplog types of the messages written to the oplog.  Each
+// type is the store message of a domain type, named by the table the domain
+// type is written to, which is the type name of the messages in oplog
+// entries.
+func Types() []oplog.Type {
+	types := make([]oplog.Type, 0, len(domainTypes))
+	for _, t := range domainTypes {
+		types = append(types, oplog.Type{Interface: t.store, Name: t.domain.TableName()})
+	}
+	return types
+}
+
+// Replayable returns the domain type embedding a store message of Types, so
+// the message can be replayed into the table of the domain type.  The
+// returned domain type shares the store message.
+func Replayable(m proto.Message) (oplog.ReplayableMessage, error) {
+	const op = "catalog.Replayable"
+	if m == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing message")
+	}
+	storeType := reflect.TypeOf(m)
+	for _, t := range domainTypes {
+		if reflect.TypeOf(t.store) != storeType {
+			continue
+		}
+		domain := reflect.New(reflect.TypeOf(t.domain).Elem())
+		for i := 0; i < domain.Elem().NumField(); i++ {
+			if f := domain.Elem().Type().Field(i); f.Anonymous && f.Type == storeType {
+				domain.Elem().Field(i).Set(reflect.ValueOf(m))
+				return domain.Interface().(oplog.ReplayableMessage), nil
+			}
+		}
+		return nil, errors.New(errors.Internal, op, fmt.Sprintf("%T does not embed %T", t.domain, m))
+	}
+	return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown message type %T", m))
+}
+
+// New returns a TypeCatalog of the messages written to the oplog.
+func New() (*oplog.TypeCatalog, error) {
+	const op = "catalog.New"
+	types, err := oplog.NewTypeCatalog(Types()...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return types, nil
+}
@@ -0,0 +1,45 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/oplog/oplog_test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNew(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	types, err := New()
+	require.NoError(err)
+	require.Len(*types, len(Types()))
+	for _, typ := range Types() {
+		name, err := types.GetTypeName(typ.Interface)
+		require.NoError(err)
+		assert.Equal(typ.Name, name)
+		got, err := types.Get(typ.Name)
+		require.NoError(err)
+		assert.IsType(typ.Interface, got)
+	}
+}
+
+func TestReplayable(t *testing.T) {
+	for _, typ := range Types() {
+		t.Run(typ.Name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			msg := proto.Clone(typ.Interface.(proto.Message))
+			got, err := Replayable(msg)
+			require.NoError(err)
+			assert.Equal(typ.Name, got.TableName())
+
+			got.SetTableName(typ.Name + "_replay")
+			assert.Equal(typ.Name+"_replay", got.TableName())
+		})
+	}
+
+	_, err := Replayable(nil)
+	assert.Error(t, err)
+	_, err = Replayable(&oplog_test.TestUser{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,287 @@
+// Package feed provides a change feed of the oplog.  Entries are returned in
+// the order of their ids, decrypted and with their messages unmarshaled
+// using the oplog type catalog, so the id of the last change received can be
+// used as a cursor to resume the feed.
+//
+// Entry ids are allocated before the transactions writing the entries
+// commit, so an entry can be committed after an entry with a higher id.  The
+// feed stops before the first entry which a running transaction could still
+// commit an entry with a lower id than, so no entry is ever committed behind
+// the cursor of a change returned by the feed.
+package feed
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/catalog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// The metadata keys of oplog entries which changes can be filtered by.
+const (
+	ScopeIdKey          = "scope-id"
+	ResourceTypeKey     = "resource-type"
+	ResourcePublicIdKey = "resource-public-id"
+	OpTypeKey           = "op-type"
+)
+
+const (
+	// listEntriesQuery returns the entries with an id greater than $1, up to
+	// the first entry whose snapshot_xmax is greater than the id of the
+	// oldest running transaction, since that transaction could still commit
+	// an entry with a lower id.  It is formatted with additional conditions
+	// and the number of entries to return.
+	listEntriesQuery = `
+select id, create_time, aggregate_name, data
+  from oplog_entry e
+ where id > $1
+   and id < coalesce(
+         (select min(u.id)
+            from oplog_entry u
+           where u.id > $1
+             and u.snapshot_xmax > txid_snapshot_xmin(txid_current_snapshot())),
+         9223372036854775807)%s
+ order by id
+ limit %d;
+`
+	// metadataCondition restricts the entries to those with a metadata key
+	// with one of a list of values.  It is formatted with the placeholder of
+	// the key and the placeholders of the values.
+	metadataCondition = `
+   and exists (select 1 from oplog_metadata m where m.entry_id = e.id and m.key = %s and m.value in (%s))`
+	// listMetadataQuery returns the metadata of the entries with an id
+	// between $1 and $2.
+	listMetadataQuery = `
+select entry_id, key, value
+  from oplog_metadata
+ where entry_id between $1 and $2
+ order by id;
+`
+)
+
+// Change is an oplog entry with its metadata and messages.
+type Change struct {
+	EntryId       uint32
+	CreateTime    *timestamp.Timestamp
+	AggregateName string
+	Metadata      oplog.Metadata
+	Messages      []oplog.Message
+}
+
+// ScopeId returns the id of the scope the change was made in.
+func (c *Change) ScopeId() string {
+	return c.metadataValue(ScopeIdKey)
+}
+
+// ResourceType returns the type of the resource the change was made to.
+func (c *Change) ResourceType() string {
+	return c.metadataValue(ResourceTypeKey)
+}
+
+// ResourcePublicId returns the id of the resource the change was made to.
+func (c *Change) ResourcePublicId() string {
+	return c.metadataValue(ResourcePublicIdKey)
+}
+
+// OpType returns the operation performed on the resource, e.g.
+// OP_TYPE_CREATE.
+func (c *Change) OpType() string {
+	return c.metadataValue(OpTypeKey)
+}
+
+func (c *Change) metadataValue(key string) string {
+	if values := c.Metadata[key]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Feed lists the changes recorded in the oplog.
+type Feed struct {
+	reader       db.Reader
+	kms          *kms.Kms
+	types        *oplog.TypeCatalog
+	defaultLimit int
+}
+
+// NewFeed creates a new Feed.  Supported options: WithLimit, which sets the
+// default number of changes returned by ListChanges, and WithTypeCatalog.
+func NewFeed(r db.Reader, kms *kms.Kms, opt ...Option) (*Feed, error) {
+	const op = "feed.NewFeed"
+	if r == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db reader")
+	}
+	if kms == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing kms")
+	}
+	opts := getOpts(opt...)
+	types := opts.withTypeCatalog
+	if types == nil {
+		var err error
+		if types, err = catalog.New(); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	limit := opts.withLimit
+	if limit <= 0 {
+		limit = db.DefaultLimit
+	}
+	return &Feed{
+		reader:       r,
+		kms:          kms,
+		types:        types,
+		defaultLimit: limit,
+	}, nil
+}
+
+// ListChanges returns the changes recorded after the cursor, which is the
+// entry id of the last change received, in the order of their entry ids.
+// Changes committed after a change with a lower entry id which is still being
+// written are only returned once it is committed or rolled back.
+// Supported options: WithLimit, WithScopeIds and WithResourceTypes.
+func (f *Feed) ListChanges(ctx context.Context, cursor uint32, opt ...Option) ([]*Change, error) {
+	const op = "feed.(Feed).ListChanges"
+	opts := getOpts(opt...)
+	limit := f.defaultLimit
+	if opts.withLimit > 0 {
+		limit = opts.withLimit
+	}
+
+	args := []interface{}{cursor}
+	var conditions strings.Builder
+	for _, filter := range []struct {
+		key    string
+		values []string
+	}{
+		{key: ScopeIdKey, values: opts.withScopeIds},
+		{key: ResourceTypeKey, values: opts.withResourceTypes},
+	} {
+		if len(filter.values) == 0 {
+			continue
+		}
+		args = append(args, filter.key)
+		keyPlaceholder := fmt.Sprintf("$%d", len(args))
+		valuePlaceholders := make([]string, 0, len(filter.values))
+		for _, v := range filter.values {
+			args = append(args, v)
+			valuePlaceholders = append(valuePlaceholders, fmt.Sprintf("$%d", len(args)))
+		}
+		conditions.WriteString(fmt.Sprintf(metadataCondition, keyPlaceholder, strings.Join(valuePlaceholders, ", ")))
+	}
+
+	entries, err := f.listEntries(ctx, fmt.Sprintf(listEntriesQuery, conditions.String(), limit), args)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	metadata, err := f.listMetadata(ctx, entries[0].Id, entries[len(entries)-1].Id)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	changes := make([]*Change, 0, len(entries))
+	for _, e := range entries {
+		msgs, err := f.readEntry(ctx, e)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to read oplog entry %d", e.Id)))
+		}
+		changes = append(changes, &Change{
+			EntryId:       e.Id,
+			CreateTime:    e.CreateTime,
+			AggregateName: e.AggregateName,
+			Metadata:      metadata[e.Id],
+			Messages:      msgs,
+		})
+	}
+	return changes, nil
+}
+
+func (f *Feed) listEntries(ctx context.Context, query string, args []interface{}) ([]*store.Entry, error) {
+	const op = "feed.(Feed).listEntries"
+	rows, err := f.reader.Query(ctx, query, args)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var entries []*store.Entry
+	for rows.Next() {
+		e := &store.Entry{CreateTime: &timestamp.Timestamp{}}
+		if err := rows.Scan(&e.Id, e.CreateTime, &e.AggregateName, &e.CtData); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return entries, nil
+}
+
+// listMetadata returns the metadata of the entries with an id between
+// firstId and lastId, keyed by entry id.
+func (f *Feed) listMetadata(ctx context.Context, firstId, lastId uint32) (map[uint32]oplog.Metadata, error) {
+	const op = "feed.(Feed).listMetadata"
+	rows, err := f.reader.Query(ctx, listMetadataQuery, []interface{}{firstId, lastId})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	metadata := make(map[uint32]oplog.Metadata)
+	for rows.Next() {
+		var entryId uint32
+		var key string
+		var value *string
+		if err := rows.Scan(&entryId, &key, &value); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if metadata[entryId] == nil {
+			metadata[entryId] = oplog.Metadata{}
+		}
+		if value == nil {
+			// the metadata just has a key with no values
+			if _, ok := metadata[entryId][key]; !ok {
+				metadata[entryId][key] = nil
+			}
+			continue
+		}
+		metadata[entryId][key] = append(metadata[entryId][key], *value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return metadata, nil
+}
+
+// readEntry decrypts the entry with the oplog key version its data is
+// encrypted with and unmarshals its messages.
+func (f *Feed) readEntry(ctx context.Context, e *store.Entry) ([]oplog.Message, error) {
+	const op = "feed.(Feed).readEntry"
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(e.CtData, blobInfo); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithCode(errors.Decode))
+	}
+	wrapper, err := f.kms.GetWrapperForKeyVersion(ctx, blobInfo.GetKeyInfo().GetKeyID())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	entry := &oplog.Entry{Entry: e, Cipherer: wrapper}
+	if err := entry.DecryptData(ctx); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	msgs, err := entry.UnmarshalData(f.types)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return msgs, nil
+}
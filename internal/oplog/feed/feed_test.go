@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed_ListChanges(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u.Name = "updated"
+	_, _, _, err := iamRepo.UpdateUser(ctx, u, u.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	f, err := NewFeed(rw, kmsCache)
+	require.NoError(t, err)
+
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		changes, err := f.ListChanges(ctx, 0)
+		require.NoError(err)
+		require.NotEmpty(changes)
+		var last uint32
+		for _, c := range changes {
+			assert.Greater(c.EntryId, last)
+			last = c.EntryId
+			assert.NotEmpty(c.Messages)
+		}
+
+		changes, err = f.ListChanges(ctx, last)
+		require.NoError(err)
+		assert.Empty(changes)
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := f.ListChanges(ctx, 0)
+		require.NoError(err)
+		var paged []*Change
+		var cursor uint32
+		for {
+			changes, err := f.ListChanges(ctx, cursor, WithLimit(2))
+			require.NoError(err)
+			if len(changes) == 0 {
+				break
+			}
+			require.LessOrEqual(len(changes), 2)
+			paged = append(paged, changes...)
+			cursor = changes[len(changes)-1].EntryId
+		}
+		require.Len(paged, len(all))
+		for i := range all {
+			assert.Equal(all[i].EntryId, paged[i].EntryId)
+		}
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		changes, err := f.ListChanges(ctx, 0, WithScopeIds(org.GetPublicId()), WithResourceTypes("user"))
+		require.NoError(err)
+		require.Len(changes, 2)
+		for _, c := range changes {
+			assert.Equal(org.GetPublicId(), c.ScopeId())
+			assert.Equal("user", c.ResourceType())
+			assert.Equal(u.GetPublicId(), c.ResourcePublicId())
+			require.Len(c.Messages, 1)
+			assert.Equal("iam_user", c.Messages[0].TypeName)
+		}
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE.String(), changes[0].OpType())
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE.String(), changes[1].OpType())
+		updated, ok := changes[1].Messages[0].Message.(*iamStore.User)
+		require.True(ok)
+		assert.Equal("updated", updated.GetName())
+		assert.Equal([]string{"Name"}, changes[1].Messages[0].FieldMaskPaths)
+
+		changes, err = f.ListChanges(ctx, 0, WithScopeIds("o_doesntexist"))
+		require.NoError(err)
+		assert.Empty(changes)
+	})
+}
+
+func TestNewFeed(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, db.TestWrapper(t))
+
+	_, err := NewFeed(nil, kmsCache)
+	assert.Error(t, err)
+	_, err = NewFeed(rw, nil)
+	assert.Error(t, err)
+	f, err := NewFeed(rw, kmsCache, WithLimit(5))
+	require.NoError(t, err)
+	assert.Equal(t, 5, f.defaultLimit)
+}
+
+func TestFeed_ListChanges_ConcurrentCommit(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+
+	f, err := NewFeed(rw, kmsCache)
+	require.NoError(err)
+	existing, err := f.ListChanges(ctx, 0)
+	require.NoError(err)
+	require.NotEmpty(existing)
+	cursor := existing[len(existing)-1].EntryId
+
+	// The transaction writes an entry with a lower id and is left open while
+	// an entry with a higher id is committed.
+	oplogWrapper, err := kmsCache.GetWrapper(ctx, org.PublicId, kms.KeyPurposeOplog)
+	require.NoError(err)
+	tx := conn.BeginTx(ctx, nil)
+	require.NoError(tx.Error)
+	slow, err := iam.NewUser(org.PublicId)
+	require.NoError(err)
+	slow.PublicId, err = db.NewPublicId(iam.UserPrefix)
+	require.NoError(err)
+	metadata := oplog.Metadata{
+		ScopeIdKey:          []string{org.PublicId},
+		ResourceTypeKey:     []string{"user"},
+		ResourcePublicIdKey: []string{slow.PublicId},
+		OpTypeKey:           []string{oplog.OpType_OP_TYPE_CREATE.String()},
+	}
+	require.NoError(db.New(tx).Create(ctx, slow, db.WithOplog(oplogWrapper, metadata)))
+
+	fast, err := iam.NewGroup(org.PublicId)
+	require.NoError(err)
+	fast, err = iamRepo.CreateGroup(ctx, fast)
+	require.NoError(err)
+
+	changes, err := f.ListChanges(ctx, cursor)
+	require.NoError(err)
+	assert.Empty(changes)
+
+	require.NoError(tx.Commit().Error)
+	changes, err = f.ListChanges(ctx, cursor)
+	require.NoError(err)
+	require.Len(changes, 2)
+	assert.Equal(slow.PublicId, changes[0].ResourcePublicId())
+	assert.Equal(fast.PublicId, changes[1].ResourcePublicId())
+	assert.Less(changes[0].EntryId, changes[1].EntryId)
+}
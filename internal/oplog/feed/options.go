@@ -0,0 +1,59 @@
+package feed
+
+import "github.com/hashicorp/boundary/internal/oplog"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit         int
+	withScopeIds      []string
+	withResourceTypes []string
+	withTypeCatalog   *oplog.TypeCatalog
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithLimit provides an option to provide the number of changes returned.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
+
+// WithScopeIds provides an option to only return the changes made in the
+// scopes.
+func WithScopeIds(scopeIds ...string) Option {
+	return func(o *options) {
+		o.withScopeIds = scopeIds
+	}
+}
+
+// WithResourceTypes provides an option to only return the changes made to
+// resources of the types, as recorded in the resource-type metadata of the
+// entries.
+func WithResourceTypes(resourceTypes ...string) Option {
+	return func(o *options) {
+		o.withResourceTypes = resourceTypes
+	}
+}
+
+// WithTypeCatalog provides an option to provide the catalog used to
+// unmarshal the messages of the entries.
+func WithTypeCatalog(types *oplog.TypeCatalog) Option {
+	return func(o *options) {
+		o.withTypeCatalog = types
+	}
+}
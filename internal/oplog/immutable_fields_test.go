@@ -104,14 +104,13 @@ func Test_ImmutableFields(t *testing.T) {
 			fieldMask: []string{"AggregateName"},
 		},
 		{
-			name: "update data",
+			name: "update ticket_version",
 			update: func() *Entry {
 				e := testCloneEntry(new)
-				// CtData is the field sent to the db.
-				e.CtData = []byte("Lorem Ipsum")
+				e.TicketVersion = e.TicketVersion + 1
 				return e
 			}(),
-			fieldMask: []string{"CtData"},
+			fieldMask: []string{"TicketVersion"},
 		},
 	}
 	for _, tt := range tests {
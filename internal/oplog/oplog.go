@@ -150,6 +150,7 @@ func (e *Entry) WriteEntryWith(ctx context.Context, tx Writer, ticket *store.Tic
 			return errors.Wrap(err, op)
 		}
 	}
+	e.TicketVersion = ticket.Version
 	if err := tx.Create(e); err != nil {
 		return errors.Wrap(err, op, errors.WithMsg("error writing data to storage"))
 	}
@@ -177,6 +178,7 @@ func (e *Entry) Write(ctx context.Context, tx Writer, ticket *store.Ticket) erro
 			return errors.Wrap(err, op)
 		}
 	}
+	e.TicketVersion = ticket.Version
 	if err := tx.Create(e); err != nil {
 		return errors.Wrap(err, op, errors.WithMsg("error writing data to storage"))
 	}
@@ -193,6 +195,7 @@ func (e *Entry) EncryptData(ctx context.Context) error {
 	if err := structwrapping.WrapStruct(ctx, e.Cipherer, e.Entry, nil); err != nil {
 		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
 	}
+	e.KeyId = e.Cipherer.KeyID()
 	return nil
 }
 
@@ -208,12 +211,27 @@ func (e *Entry) DecryptData(ctx context.Context) error {
 
 // Replay provides the ability to replay an entry.  you must initialize any new tables ending with the tableSuffix before
 // calling Replay, otherwise you'll get "a table doesn't exist" error.
-func (e *Entry) Replay(_ context.Context, tx Writer, types *TypeCatalog, tableSuffix string) error {
+func (e *Entry) Replay(ctx context.Context, tx Writer, types *TypeCatalog, tableSuffix string) error {
 	const op = "oplog.(Entry).Replay"
 	msgs, err := e.UnmarshalData(types)
 	if err != nil {
 		return errors.Wrap(err, op)
 	}
+	if err := ReplayMessages(ctx, tx, tableSuffix, msgs...); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// ReplayMessages replays the messages of an entry, which must be
+// ReplayableMessages, into the tables ending with the tableSuffix.  It allows
+// the messages unmarshaled from an entry to be replaced with the types which
+// can be replayed, when they are not the types in the entry's TypeCatalog.
+func ReplayMessages(_ context.Context, tx Writer, tableSuffix string, msgs ...Message) error {
+	const op = "oplog.ReplayMessages"
+	if tx == nil {
+		return errors.New(errors.InvalidParameter, op, "nil writer")
+	}
 	for _, m := range msgs {
 		em, ok := m.Message.(ReplayableMessage)
 		if !ok {
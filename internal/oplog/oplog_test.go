@@ -536,6 +536,7 @@ func Test_WriteEntryWith(t *testing.T) {
 			ticketer,
 		)
 		require.NoError(err)
+		ticketVersion := ticket.Version
 		err = newLogEntry.WriteEntryWith(context.Background(), &GormWriter{db}, ticket,
 			&Message{Message: &u, TypeName: "user", OpType: OpType_OP_TYPE_CREATE},
 			&Message{Message: &u2, TypeName: "user", OpType: OpType_OP_TYPE_CREATE})
@@ -544,6 +545,7 @@ func Test_WriteEntryWith(t *testing.T) {
 		var foundEntry Entry
 		err = db.Where("id = ?", newLogEntry.Id).First(&foundEntry).Error
 		require.NoError(err)
+		assert.Equal(ticketVersion, foundEntry.TicketVersion)
 		foundEntry.Cipherer = cipherer
 		types, err := NewTypeCatalog(Type{new(oplog_test.TestUser), "user"})
 		require.NoError(err)
@@ -0,0 +1,151 @@
+// Package prune removes the oplog entries older than a retention period
+// from the oplog, either deleting them or moving them, along with their
+// metadata, to the oplog_entry_archive and oplog_metadata_archive tables.
+//
+// Entries are pruned from the oldest, in batches which each run in their own
+// transaction, and entries locked by other transactions are skipped, so the
+// oplog tables are never locked for long.  The change feed and "boundary
+// database replicate" only read the oplog, so the retention must be longer
+// than the time it takes them to catch up.
+package prune
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+const (
+	// deleteEntriesQuery deletes up to $2 entries created more than $1
+	// seconds ago.  Their metadata is deleted by cascade.
+	deleteEntriesQuery = `
+delete from oplog_entry
+ where id in (
+         select id
+           from oplog_entry
+          where create_time < now() - make_interval(secs => $1)
+          order by id
+          limit $2
+            for update skip locked
+       );
+`
+	// archiveEntriesQuery moves up to $2 entries created more than $1
+	// seconds ago, and their metadata, to the archive tables.
+	archiveEntriesQuery = `
+with
+batch as (
+  select id
+    from oplog_entry
+   where create_time < now() - make_interval(secs => $1)
+   order by id
+   limit $2
+     for update skip locked
+),
+archived_metadata as (
+  insert into oplog_metadata_archive
+    (id, create_time, update_time, entry_id, "key", value)
+  select id, create_time, update_time, entry_id, "key", value
+    from oplog_metadata
+   where entry_id in (select id from batch)
+),
+archived_entries as (
+  insert into oplog_entry_archive
+    (id, create_time, update_time, version, aggregate_name, data, key_id, ticket_version)
+  select id, create_time, update_time, version, aggregate_name, data, key_id, ticket_version
+    from oplog_entry
+   where id in (select id from batch)
+)
+delete from oplog_entry
+ where id in (select id from batch);
+`
+)
+
+// DefaultBatchSize is the number of entries pruned in a transaction unless
+// WithLimit is used.
+const DefaultBatchSize = 1000
+
+// Action is what is done with the entries pruned from the oplog.
+type Action string
+
+const (
+	// Delete deletes the entries.
+	Delete Action = "delete"
+	// Archive moves the entries to the archive tables.
+	Archive Action = "archive"
+)
+
+// Pruner prunes the oplog.
+type Pruner struct {
+	writer db.Writer
+	query  string
+	limit  int
+}
+
+// NewPruner creates a new Pruner which deletes or archives the pruned
+// entries depending on the action.  Supported options: WithLimit, which sets
+// the number of entries pruned in each transaction.
+func NewPruner(w db.Writer, action Action, opt ...Option) (*Pruner, error) {
+	const op = "prune.NewPruner"
+	if w == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	var query string
+	switch action {
+	case Delete:
+		query = deleteEntriesQuery
+	case Archive:
+		query = archiveEntriesQuery
+	default:
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("unknown action %q", action))
+	}
+	opts := getOpts(opt...)
+	limit := opts.withLimit
+	if limit <= 0 {
+		limit = DefaultBatchSize
+	}
+	return &Pruner{
+		writer: w,
+		query:  query,
+		limit:  limit,
+	}, nil
+}
+
+// Prune prunes the entries created longer ago than the retention and returns
+// the number of entries pruned.
+func (p *Pruner) Prune(ctx context.Context, retention time.Duration) (int, error) {
+	const op = "prune.(Pruner).Prune"
+	if retention <= 0 {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "retention must be greater than zero")
+	}
+	secs := int(retention.Seconds())
+	var total int
+	for {
+		var rowsAffected int
+		_, err := p.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				var err error
+				rowsAffected, err = w.Exec(ctx, p.query, []interface{}{secs, p.limit})
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return total, errors.Wrap(err, op)
+		}
+		total += rowsAffected
+		if rowsAffected < p.limit {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, errors.Wrap(err, op)
+		}
+	}
+}
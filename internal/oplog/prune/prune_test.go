@@ -0,0 +1,85 @@
+package prune
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPruner(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := NewPruner(nil, Delete)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = NewPruner(rw, Action("truncate"))
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	p, err := NewPruner(rw, Archive)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBatchSize, p.limit)
+	_, err = p.Prune(context.Background(), 0)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+}
+
+func TestPruner_Prune(t *testing.T) {
+	ctx := context.Background()
+	count := func(t *testing.T, conn *gorm.DB, table string) int {
+		var n int
+		require.NoError(t, conn.Raw("select count(*) from "+table).Row().Scan(&n))
+		return n
+	}
+	// setup writes oplog entries, waits for them to be older than 2 seconds,
+	// writes more entries and returns the number of entries written before
+	// waiting.
+	setup := func(t *testing.T) (*gorm.DB, int) {
+		conn, _ := db.TestSetup(t, "postgres")
+		wrapper := db.TestWrapper(t)
+		iamRepo := iam.TestRepo(t, conn, wrapper)
+		org, _ := iam.TestScopes(t, iamRepo)
+		old := count(t, conn, "oplog_entry")
+		require.NotZero(t, old)
+		time.Sleep(3 * time.Second)
+		iam.TestUser(t, iamRepo, org.GetPublicId())
+		require.Greater(t, count(t, conn, "oplog_entry"), old)
+		return conn, old
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		conn, old := setup(t)
+		total := count(t, conn, "oplog_entry")
+		p, err := NewPruner(db.New(conn), Delete, WithLimit(2))
+		require.NoError(err)
+		pruned, err := p.Prune(ctx, 2*time.Second)
+		require.NoError(err)
+		assert.Equal(old, pruned)
+		assert.Equal(total-old, count(t, conn, "oplog_entry"))
+		assert.Zero(count(t, conn, "oplog_entry_archive"))
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		conn, old := setup(t)
+		total := count(t, conn, "oplog_entry")
+		metadata := count(t, conn, "oplog_metadata")
+		p, err := NewPruner(db.New(conn), Archive, WithLimit(2))
+		require.NoError(err)
+		pruned, err := p.Prune(ctx, 2*time.Second)
+		require.NoError(err)
+		assert.Equal(old, pruned)
+		assert.Equal(total-old, count(t, conn, "oplog_entry"))
+		assert.Equal(old, count(t, conn, "oplog_entry_archive"))
+		assert.Equal(metadata, count(t, conn, "oplog_metadata")+count(t, conn, "oplog_metadata_archive"))
+
+		pruned, err = p.Prune(ctx, 2*time.Second)
+		require.NoError(err)
+		assert.Zero(pruned)
+	})
+}
@@ -0,0 +1,50 @@
+package replicate
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit        int
+	withTableSuffix  string
+	withStartEntryId *uint32
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithLimit provides an option to provide the number of entries replayed by
+// each call to Replicate.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
+
+// WithTableSuffix provides an option to replay the entries into the tables
+// ending with the suffix, which are created as needed, rather than into the
+// tables of the standby database.
+func WithTableSuffix(suffix string) Option {
+	return func(o *options) {
+		o.withTableSuffix = suffix
+	}
+}
+
+// WithStartEntryId provides an option to provide the id of the last entry
+// already applied to the standby database, used when the standby has not
+// replayed any entries yet.
+func WithStartEntryId(id uint32) Option {
+	return func(o *options) {
+		o.withStartEntryId = &id
+	}
+}
@@ -0,0 +1,330 @@
+// Package replicate replays the oplog entries of a primary database into a
+// standby database, so the standby holds a warm copy of the resources
+// written through the oplog.
+//
+// The entries are read from the oplog change feed, which only returns an
+// entry once no entry with a lower id can still be committed, so the last
+// entry replayed never moves past an entry which is committed later.
+//
+// Only writes recorded in the oplog are replicated: sessions, auth tokens,
+// workers and other data written without the oplog are not.  When replaying
+// into the tables of the standby, rather than into tables with a suffix, the
+// standby must start as a copy of the primary, and the kms keys created by
+// the primary are copied to the standby so the replayed values encrypted
+// with them can be decrypted.
+package replicate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/catalog"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	lastEntryIdQuery = `
+select last_entry_id
+  from oplog_replication
+ where table_suffix = ?;
+`
+	maxEntryIdQuery = `
+select coalesce(max(id), 0)
+  from oplog_entry;
+`
+	upsertLastEntryIdQuery = `
+insert into oplog_replication
+  (table_suffix, last_entry_id)
+values
+  (?, ?)
+on conflict (table_suffix) do update
+  set last_entry_id = excluded.last_entry_id;
+`
+)
+
+// keyTable is a kms table copied from the primary to the standby.  Its rows
+// are only copied once their parent row exists in the standby.
+type keyTable struct {
+	name           string
+	parentColumn   string
+	parentTable    string
+	parentIdColumn string
+	orderBy        string
+}
+
+// keyTables are the kms tables in the order their rows are copied.
+var keyTables = []keyTable{
+	{name: "kms_root_key", parentColumn: "scope_id", parentTable: "iam_scope", parentIdColumn: "public_id", orderBy: "create_time"},
+	{name: "kms_root_key_version", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "version"},
+	{name: "kms_database_key", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "create_time"},
+	{name: "kms_database_key_version", parentColumn: "database_key_id", parentTable: "kms_database_key", parentIdColumn: "private_id", orderBy: "version"},
+	{name: "kms_oplog_key", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "create_time"},
+	{name: "kms_oplog_key_version", parentColumn: "oplog_key_id", parentTable: "kms_oplog_key", parentIdColumn: "private_id", orderBy: "version"},
+	{name: "kms_session_key", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "create_time"},
+	{name: "kms_session_key_version", parentColumn: "session_key_id", parentTable: "kms_session_key", parentIdColumn: "private_id", orderBy: "version"},
+	{name: "kms_token_key", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "create_time"},
+	{name: "kms_token_key_version", parentColumn: "token_key_id", parentTable: "kms_token_key", parentIdColumn: "private_id", orderBy: "version"},
+	{name: "kms_oidc_key", parentColumn: "root_key_id", parentTable: "kms_root_key", parentIdColumn: "private_id", orderBy: "create_time"},
+	{name: "kms_oidc_key_version", parentColumn: "oidc_key_id", parentTable: "kms_oidc_key", parentIdColumn: "private_id", orderBy: "version"},
+}
+
+// Replicator replays the oplog entries of a primary database into a standby
+// database.
+type Replicator struct {
+	primary      db.Reader
+	feed         *feed.Feed
+	standby      *gorm.DB
+	tableSuffix  string
+	startEntryId *uint32
+}
+
+// NewReplicator creates a new Replicator reading the oplog of the primary
+// database, decrypted with the kms of the primary, and replaying it into the
+// standby database.  Supported options: WithLimit, WithTableSuffix and
+// WithStartEntryId.
+func NewReplicator(primary db.Reader, kms *kms.Kms, standby *gorm.DB, opt ...Option) (*Replicator, error) {
+	const op = "replicate.NewReplicator"
+	if primary == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing primary db reader")
+	}
+	if kms == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing kms")
+	}
+	if standby == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing standby db")
+	}
+	opts := getOpts(opt...)
+	limit := opts.withLimit
+	if limit <= 0 {
+		limit = db.DefaultLimit
+	}
+	f, err := feed.NewFeed(primary, kms, feed.WithLimit(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return &Replicator{
+		primary:      primary,
+		feed:         f,
+		standby:      standby,
+		tableSuffix:  opts.withTableSuffix,
+		startEntryId: opts.withStartEntryId,
+	}, nil
+}
+
+// LastEntryId returns the id of the last entry replayed into the standby.
+// Before any entry has been replayed it is the start entry id if one was
+// provided, otherwise the id of the last entry in the standby's own oplog
+// when replaying into its tables, since the standby started as a copy of the
+// primary, or zero when replaying into tables with a suffix.
+func (r *Replicator) LastEntryId(ctx context.Context) (uint32, error) {
+	const op = "replicate.(Replicator).LastEntryId"
+	var id uint32
+	err := r.standby.Raw(lastEntryIdQuery, r.tableSuffix).Row().Scan(&id)
+	switch {
+	case err == nil:
+		return id, nil
+	case err != sql.ErrNoRows:
+		return 0, errors.Wrap(err, op)
+	case r.startEntryId != nil:
+		return *r.startEntryId, nil
+	case r.tableSuffix != "":
+		return 0, nil
+	}
+	if err := r.standby.Raw(maxEntryIdQuery).Row().Scan(&id); err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	return id, nil
+}
+
+// Replicate replays the entries written to the primary after the last entry
+// replayed, up to the limit, and returns the number of entries replayed.  Each
+// entry is replayed in its own transaction, which also records it as the last
+// entry replayed.  Entries committed after an entry with a lower id which is
+// still being written are left for a later call, once it is committed or
+// rolled back.
+func (r *Replicator) Replicate(ctx context.Context) (int, error) {
+	const op = "replicate.(Replicator).Replicate"
+	lastId, err := r.LastEntryId(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	changes, err := r.feed.ListChanges(ctx, lastId)
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+	// the keys are copied before the first entry and after each change to a
+	// scope, since the keys of a scope are created with it
+	syncKeys := true
+	for i, c := range changes {
+		if err := r.replay(ctx, c, syncKeys); err != nil {
+			return i, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to replay oplog entry %d", c.EntryId)))
+		}
+		syncKeys = c.AggregateName == (&iam.Scope{}).TableName()
+	}
+	return len(changes), nil
+}
+
+// replay replays the change and records it as the last entry replayed.
+func (r *Replicator) replay(ctx context.Context, c *feed.Change, syncKeys bool) (retErr error) {
+	const op = "replicate.(Replicator).replay"
+	msgs := make([]oplog.Message, 0, len(c.Messages))
+	for _, m := range c.Messages {
+		replayable, err := catalog.Replayable(m.Message)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		m.Message = replayable.(proto.Message)
+		msgs = append(msgs, m)
+	}
+
+	tx := r.standby.BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer func() {
+		if retErr != nil {
+			tx.Rollback()
+		}
+	}()
+	// when replaying into tables with a suffix the keys are not needed, since
+	// the tables are created without foreign keys
+	if syncKeys && r.tableSuffix == "" {
+		if err := r.syncKeys(ctx, tx); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+	if err := oplog.ReplayMessages(ctx, &oplog.GormWriter{Tx: tx}, r.tableSuffix, msgs...); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err := tx.Exec(upsertLastEntryIdQuery, r.tableSuffix, c.EntryId).Error; err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
+
+// syncKeys copies the rows of the kms tables which are missing from the
+// standby.  Rows whose parent is missing are skipped, and are copied once the
+// parent has been replayed.  Rows removed from the primary, such as destroyed
+// key versions, are not removed from the standby.
+func (r *Replicator) syncKeys(ctx context.Context, tx *gorm.DB) error {
+	const op = "replicate.(Replicator).syncKeys"
+	for _, kt := range keyTables {
+		present, err := standbyIds(tx, kt.name, "private_id")
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		parents, err := standbyIds(tx, kt.parentTable, kt.parentIdColumn)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		columns, rows, err := r.primaryRows(ctx, kt)
+		if err != nil {
+			return errors.Wrap(err, op)
+		}
+		insert := fmt.Sprintf("insert into %s (%s) values (%s);",
+			kt.name, strings.Join(columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+		for _, row := range rows {
+			id, parentId := row.value("private_id"), row.value(kt.parentColumn)
+			if present[id] || !parents[parentId] {
+				continue
+			}
+			if err := tx.Exec(insert, row.values...).Error; err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to copy %s %s", kt.name, id)))
+			}
+		}
+	}
+	return nil
+}
+
+// keyRow is a row of a kms table read from the primary.
+type keyRow struct {
+	columns map[string]int
+	values  []interface{}
+}
+
+// value returns the value of a text column of the row.
+func (r keyRow) value(column string) string {
+	i, ok := r.columns[column]
+	if !ok {
+		return ""
+	}
+	s, _ := r.values[i].(string)
+	return s
+}
+
+// primaryRows returns the columns and rows of a kms table of the primary.
+func (r *Replicator) primaryRows(ctx context.Context, kt keyTable) ([]string, []keyRow, error) {
+	const op = "replicate.(Replicator).primaryRows"
+	rows, err := r.primary.Query(ctx, fmt.Sprintf("select * from %s order by %s;", kt.name, kt.orderBy), nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	columns := make([]string, 0, len(types))
+	indexes := make(map[string]int, len(types))
+	for i, t := range types {
+		columns = append(columns, t.Name())
+		indexes[t.Name()] = i
+	}
+	var keyRows []keyRow
+	for rows.Next() {
+		values := make([]interface{}, len(types))
+		dest := make([]interface{}, len(types))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, nil, errors.Wrap(err, op)
+		}
+		// text values are scanned as bytes, which would be written as
+		// bytea, so only the bytea columns keep them as bytes
+		for i, t := range types {
+			if b, ok := values[i].([]byte); ok && t.DatabaseTypeName() != "BYTEA" {
+				values[i] = string(b)
+			}
+		}
+		keyRows = append(keyRows, keyRow{columns: indexes, values: values})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	return columns, keyRows, nil
+}
+
+// standbyIds returns the ids in a column of a standby table.
+func standbyIds(tx *gorm.DB, table, column string) (map[string]bool, error) {
+	const op = "replicate.standbyIds"
+	rows, err := tx.Raw(fmt.Sprintf("select %s from %s;", column, table)).Rows()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	ids := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		ids[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return ids, nil
+}
@@ -0,0 +1,183 @@
+package replicate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicator_Replicate(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	const suffix = "_replica"
+	r, err := NewReplicator(rw, kmsCache, conn, WithTableSuffix(suffix), WithLimit(2))
+	require.NoError(t, err)
+
+	replicateAll := func(t *testing.T) int {
+		t.Helper()
+		var total int
+		for {
+			n, err := r.Replicate(ctx)
+			require.NoError(t, err)
+			if n == 0 {
+				return total
+			}
+			require.LessOrEqual(t, n, 2)
+			total += n
+		}
+	}
+	lastOplogEntryId := func(t *testing.T) uint32 {
+		t.Helper()
+		var id uint32
+		require.NoError(t, conn.Raw(maxEntryIdQuery).Row().Scan(&id))
+		return id
+	}
+	replicaUserName := func(t *testing.T) string {
+		t.Helper()
+		var name string
+		require.NoError(t, conn.Raw("select name from iam_user"+suffix+" where public_id = ?", u.GetPublicId()).Row().Scan(&name))
+		return name
+	}
+
+	t.Run("initial", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		id, err := r.LastEntryId(ctx)
+		require.NoError(err)
+		assert.Zero(id)
+
+		assert.Greater(replicateAll(t), 0)
+		id, err = r.LastEntryId(ctx)
+		require.NoError(err)
+		assert.Equal(lastOplogEntryId(t), id)
+		assert.Equal(u.GetName(), replicaUserName(t))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		u.Name = "updated"
+		_, _, _, err := iamRepo.UpdateUser(ctx, u, u.Version, []string{"Name"})
+		require.NoError(err)
+
+		assert.Equal(1, replicateAll(t))
+		assert.Equal("updated", replicaUserName(t))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := iamRepo.DeleteUser(ctx, u.GetPublicId())
+		require.NoError(err)
+
+		assert.Equal(1, replicateAll(t))
+		var count int
+		require.NoError(conn.Raw("select count(*) from iam_user"+suffix+" where public_id = ?", u.GetPublicId()).Row().Scan(&count))
+		assert.Zero(count)
+	})
+}
+
+func TestReplicator_ConcurrentCommit(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	iam.TestUser(t, iamRepo, org.GetPublicId())
+
+	const suffix = "_replica"
+	r, err := NewReplicator(rw, kmsCache, conn, WithTableSuffix(suffix))
+	require.NoError(err)
+	replicateAll := func() int {
+		var total int
+		for {
+			n, err := r.Replicate(ctx)
+			require.NoError(err)
+			if n == 0 {
+				return total
+			}
+			total += n
+		}
+	}
+	count := func(table string) int {
+		var n int
+		require.NoError(conn.Raw("select count(*) from "+table+" where scope_id = ?", org.GetPublicId()).Row().Scan(&n))
+		return n
+	}
+	replicateAll()
+	lastId, err := r.LastEntryId(ctx)
+	require.NoError(err)
+
+	// The transaction writes an entry with a lower id and is left open while
+	// an entry with a higher id is committed.
+	oplogWrapper, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(err)
+	tx := conn.BeginTx(ctx, nil)
+	require.NoError(tx.Error)
+	slow, err := iam.NewUser(org.GetPublicId())
+	require.NoError(err)
+	slow.PublicId, err = db.NewPublicId(iam.UserPrefix)
+	require.NoError(err)
+	metadata := oplog.Metadata{
+		feed.ScopeIdKey:          []string{org.GetPublicId()},
+		feed.ResourceTypeKey:     []string{"user"},
+		feed.ResourcePublicIdKey: []string{slow.PublicId},
+		feed.OpTypeKey:           []string{oplog.OpType_OP_TYPE_CREATE.String()},
+	}
+	require.NoError(db.New(tx).Create(ctx, slow, db.WithOplog(oplogWrapper, metadata)))
+	g, err := iam.NewGroup(org.GetPublicId())
+	require.NoError(err)
+	_, err = iamRepo.CreateGroup(ctx, g)
+	require.NoError(err)
+
+	assert.Zero(replicateAll())
+	id, err := r.LastEntryId(ctx)
+	require.NoError(err)
+	assert.Equal(lastId, id, "the last entry replayed does not move past an entry being written")
+
+	require.NoError(tx.Commit().Error)
+	assert.Equal(2, replicateAll())
+	assert.Equal(count("iam_user"), count("iam_user"+suffix))
+	assert.Equal(count("iam_group"), count("iam_group"+suffix))
+}
+
+func TestReplicator_LastEntryId(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	kmsCache := kms.TestKms(t, conn, db.TestWrapper(t))
+
+	var maxId uint32
+	require.NoError(t, conn.Raw(maxEntryIdQuery).Row().Scan(&maxId))
+
+	r, err := NewReplicator(rw, kmsCache, conn)
+	require.NoError(t, err)
+	id, err := r.LastEntryId(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, maxId, id, "a standby replaying into its tables starts from its own oplog")
+
+	r, err = NewReplicator(rw, kmsCache, conn, WithStartEntryId(5))
+	require.NoError(t, err)
+	id, err = r.LastEntryId(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), id)
+
+	require.NoError(t, conn.Exec(upsertLastEntryIdQuery, "", 7).Error)
+	id, err = r.LastEntryId(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), id, "the last entry replayed takes precedence")
+}
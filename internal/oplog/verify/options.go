@@ -0,0 +1,41 @@
+package verify
+
+import "github.com/hashicorp/boundary/internal/oplog"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLimit       int
+	withTypeCatalog *oplog.TypeCatalog
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithLimit provides an option to provide the number of entries read from
+// the database at a time.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
+
+// WithTypeCatalog provides an option to provide the catalog used to
+// unmarshal the messages of the entries.
+func WithTypeCatalog(types *oplog.TypeCatalog) Option {
+	return func(o *options) {
+		o.withTypeCatalog = types
+	}
+}
@@ -0,0 +1,591 @@
+// Package verify verifies the oplog against the current state of the
+// database.  Entries are decrypted with the oplog key of their scope and
+// their messages unmarshaled using the oplog type catalog.  The ticket
+// versions of the entries of each aggregate are checked for gaps and
+// reordering, and the last operation recorded for each row is checked
+// against the current contents of its table.  A row which no longer exists
+// because a parent row it references with a cascading foreign key was
+// deleted is counted as cascaded rather than reported as missing.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/catalog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// selectEntriesQuery returns a page of oplog entries with an id greater
+	// than $1, limited to $2 entries.
+	selectEntriesQuery = `
+select id, aggregate_name, data, key_id, ticket_version
+  from oplog_entry
+ where id > $1
+ order by id
+ limit $2;
+`
+	// selectTicketsQuery returns the current version of each oplog ticket.
+	selectTicketsQuery = `select name, version from oplog_ticket`
+	// rowExistsQuery returns whether a row exists.  It is formatted with the
+	// table and the where clause.
+	rowExistsQuery = `select exists(select 1 from %s where %s)`
+	// selectCascadeKeysQuery returns the column pairs of the foreign keys of
+	// table $1 whose delete cascades, in the order of the key columns.
+	selectCascadeKeysQuery = `
+select c.conname, p.relname, ca.attname, pa.attname
+  from pg_constraint c
+  join pg_class p
+    on p.oid = c.confrelid
+ cross join lateral unnest(c.conkey, c.confkey) with ordinality as k(child_attnum, parent_attnum, ord)
+  join pg_attribute ca
+    on ca.attrelid = c.conrelid and ca.attnum = k.child_attnum
+  join pg_attribute pa
+    on pa.attrelid = c.confrelid and pa.attnum = k.parent_attnum
+ where c.contype = 'f'
+   and c.confdeltype = 'c'
+   and c.conrelid = $1::regclass
+ order by c.conname, k.ord;
+`
+)
+
+// ProblemKind is the kind of a problem found by the verification.
+type ProblemKind string
+
+const (
+	// UnreadableEntry is an entry that could not be decrypted or whose
+	// messages could not be unmarshaled, which is the case when its data
+	// was modified outside of Boundary.
+	UnreadableEntry ProblemKind = "unreadable-entry"
+	// KeyIdMismatch is an entry whose key_id does not match the key version
+	// its data is encrypted with.
+	KeyIdMismatch ProblemKind = "key-id-mismatch"
+	// TicketGap is a gap in the ticket versions of the entries of an
+	// aggregate, which is the case when entries were deleted.
+	TicketGap ProblemKind = "ticket-gap"
+	// TicketOutOfOrder is an entry whose ticket version is not greater
+	// than the ticket version of the previous entry of its aggregate.
+	TicketOutOfOrder ProblemKind = "ticket-out-of-order"
+	// MissingRow is a row whose last recorded operation is a create or an
+	// update but which no longer exists while the parent rows it references
+	// still exist.
+	MissingRow ProblemKind = "missing-row"
+	// UnexpectedRow is a row whose last recorded operation is a delete but
+	// which still exists.
+	UnexpectedRow ProblemKind = "unexpected-row"
+)
+
+// Problem is a problem found by the verification.
+type Problem struct {
+	Kind ProblemKind `json:"kind"`
+	// EntryId is the id of the oplog entry the problem was found in.
+	EntryId uint32 `json:"entry_id"`
+	// AggregateName is the aggregate name of the entry.
+	AggregateName string `json:"aggregate_name"`
+	Description   string `json:"description"`
+}
+
+// Report is the result of a verification.
+type Report struct {
+	// EntriesVerified is the number of oplog entries verified.
+	EntriesVerified int `json:"entries_verified"`
+	// UnversionedEntries is the number of entries written before ticket
+	// versions were recorded, which can not be checked for gaps.
+	UnversionedEntries int `json:"unversioned_entries"`
+	// RowsVerified is the number of rows checked against the last
+	// operation recorded for them.
+	RowsVerified int `json:"rows_verified"`
+	// CascadedRows is the number of rows whose last recorded operation is a
+	// create or an update and which were removed by the cascading delete of
+	// a parent row.  They are not problems.
+	CascadedRows int        `json:"cascaded_rows"`
+	Problems     []*Problem `json:"problems,omitempty"`
+}
+
+// Verifier verifies the oplog.
+type Verifier struct {
+	reader       db.Reader
+	kms          *kms.Kms
+	types        *oplog.TypeCatalog
+	defaultLimit int
+}
+
+// NewVerifier creates a new Verifier.  Supported options: WithLimit, which
+// sets the number of entries read from the database at a time, and
+// WithTypeCatalog.
+func NewVerifier(r db.Reader, kms *kms.Kms, opt ...Option) (*Verifier, error) {
+	const op = "verify.NewVerifier"
+	if r == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db reader")
+	}
+	if kms == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing kms")
+	}
+	opts := getOpts(opt...)
+	types := opts.withTypeCatalog
+	if types == nil {
+		var err error
+		if types, err = catalog.New(); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	limit := opts.withLimit
+	if limit <= 0 {
+		limit = db.DefaultLimit
+	}
+	return &Verifier{
+		reader:       r,
+		kms:          kms,
+		types:        types,
+		defaultLimit: limit,
+	}, nil
+}
+
+// aggregateState is the last ticket version seen for an aggregate.
+type aggregateState struct {
+	ticketVersion uint32
+	entryId       uint32
+}
+
+// rowState is the last operation recorded for a row.  fields contains the
+// column values recorded for the row since it was created.
+type rowState struct {
+	table         string
+	columns       []string
+	values        []interface{}
+	fields        map[string]interface{}
+	opType        oplog.OpType
+	entryId       uint32
+	aggregateName string
+}
+
+// cascadeKey is a foreign key whose delete cascades.
+type cascadeKey struct {
+	parentTable   string
+	columns       []string
+	parentColumns []string
+}
+
+// Verify walks the oplog and returns a report of the problems found.  An
+// error is only returned if the verification could not be completed.
+func (v *Verifier) Verify(ctx context.Context) (*Report, error) {
+	const op = "verify.(Verifier).Verify"
+	report := &Report{}
+	aggregates := make(map[string]*aggregateState)
+	rows := make(map[string]*rowState)
+
+	var lastId uint32
+	for {
+		entries, err := v.listEntries(ctx, lastId)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if len(entries) == 0 {
+			break
+		}
+		for _, e := range entries {
+			lastId = e.Id
+			report.EntriesVerified++
+			v.verifyTicketVersion(report, aggregates, e)
+			msgs, problem, err := v.readEntry(ctx, e)
+			if err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+			if problem != nil {
+				report.Problems = append(report.Problems, problem)
+				continue
+			}
+			for _, m := range msgs {
+				columns, values := primaryKey(m.Message)
+				if len(columns) == 0 {
+					continue
+				}
+				key := fmt.Sprintf("%s:%v", m.TypeName, values)
+				r, ok := rows[key]
+				if !ok || m.OpType == oplog.OpType_OP_TYPE_CREATE {
+					r = &rowState{
+						table:   m.TypeName,
+						columns: columns,
+						values:  values,
+						fields:  make(map[string]interface{}),
+					}
+					rows[key] = r
+				}
+				r.opType = m.OpType
+				r.entryId = e.Id
+				r.aggregateName = e.AggregateName
+				for c, val := range columnValues(m.Message) {
+					r.fields[c] = val
+				}
+			}
+		}
+	}
+
+	if err := v.verifyTickets(ctx, report, aggregates); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := v.verifyRows(ctx, report, rows); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return report, nil
+}
+
+// listEntries returns the next page of entries with an id greater than
+// afterId.
+func (v *Verifier) listEntries(ctx context.Context, afterId uint32) ([]*store.Entry, error) {
+	const op = "verify.(Verifier).listEntries"
+	rows, err := v.reader.Query(ctx, selectEntriesQuery, []interface{}{afterId, v.defaultLimit})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var entries []*store.Entry
+	for rows.Next() {
+		var keyId *string
+		var ticketVersion *uint32
+		e := &store.Entry{}
+		if err := rows.Scan(&e.Id, &e.AggregateName, &e.CtData, &keyId, &ticketVersion); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if keyId != nil {
+			e.KeyId = *keyId
+		}
+		if ticketVersion != nil {
+			e.TicketVersion = *ticketVersion
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return entries, nil
+}
+
+// verifyTicketVersion checks the ticket version of the entry follows the
+// ticket version of the previous entry of its aggregate.
+func (v *Verifier) verifyTicketVersion(report *Report, aggregates map[string]*aggregateState, e *store.Entry) {
+	if e.TicketVersion == 0 {
+		report.UnversionedEntries++
+		return
+	}
+	prev, ok := aggregates[e.AggregateName]
+	aggregates[e.AggregateName] = &aggregateState{ticketVersion: e.TicketVersion, entryId: e.Id}
+	if !ok {
+		return
+	}
+	switch {
+	case e.TicketVersion <= prev.ticketVersion:
+		report.Problems = append(report.Problems, &Problem{
+			Kind:          TicketOutOfOrder,
+			EntryId:       e.Id,
+			AggregateName: e.AggregateName,
+			Description:   fmt.Sprintf("ticket version %d follows ticket version %d of entry %d", e.TicketVersion, prev.ticketVersion, prev.entryId),
+		})
+	case e.TicketVersion > prev.ticketVersion+1:
+		report.Problems = append(report.Problems, &Problem{
+			Kind:          TicketGap,
+			EntryId:       e.Id,
+			AggregateName: e.AggregateName,
+			Description:   fmt.Sprintf("ticket versions %d to %d are missing after entry %d", prev.ticketVersion+1, e.TicketVersion-1, prev.entryId),
+		})
+	}
+}
+
+// verifyTickets checks the last entry of each aggregate used the ticket
+// version before the current version of the aggregate's ticket.
+func (v *Verifier) verifyTickets(ctx context.Context, report *Report, aggregates map[string]*aggregateState) error {
+	const op = "verify.(Verifier).verifyTickets"
+	rows, err := v.reader.Query(ctx, selectTicketsQuery, nil)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var names []string
+	tickets := make(map[string]uint32)
+	for rows.Next() {
+		var name string
+		var version uint32
+		if err := rows.Scan(&name, &version); err != nil {
+			return errors.Wrap(err, op)
+		}
+		names = append(names, name)
+		tickets[name] = version
+	}
+	if err := rows.Err(); err != nil {
+		return errors.Wrap(err, op)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		last, ok := aggregates[name]
+		if !ok {
+			continue
+		}
+		if version := tickets[name]; last.ticketVersion+1 < version {
+			report.Problems = append(report.Problems, &Problem{
+				Kind:          TicketGap,
+				EntryId:       last.entryId,
+				AggregateName: name,
+				Description:   fmt.Sprintf("ticket versions %d to %d are missing after the last entry", last.ticketVersion+1, version-1),
+			})
+		}
+	}
+	return nil
+}
+
+// readEntry decrypts the entry and unmarshals its messages.  A problem is
+// returned if the entry can not be read.
+func (v *Verifier) readEntry(ctx context.Context, e *store.Entry) ([]oplog.Message, *Problem, error) {
+	const op = "verify.(Verifier).readEntry"
+	unreadable := func(format string, a ...interface{}) *Problem {
+		return &Problem{
+			Kind:          UnreadableEntry,
+			EntryId:       e.Id,
+			AggregateName: e.AggregateName,
+			Description:   fmt.Sprintf(format, a...),
+		}
+	}
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(e.CtData, blobInfo); err != nil {
+		return nil, unreadable("unable to unmarshal encrypted data: %v", err), nil
+	}
+	keyId := blobInfo.GetKeyInfo().GetKeyID()
+	if keyId == "" {
+		return nil, unreadable("encrypted data has no key id"), nil
+	}
+	if e.KeyId != "" && e.KeyId != keyId {
+		return nil, &Problem{
+			Kind:          KeyIdMismatch,
+			EntryId:       e.Id,
+			AggregateName: e.AggregateName,
+			Description:   fmt.Sprintf("key id %s does not match key version %s of the encrypted data", e.KeyId, keyId),
+		}, nil
+	}
+
+	wrapper, err := v.kms.GetWrapperForKeyVersion(ctx, keyId)
+	switch {
+	case errors.Match(errors.T(errors.RecordNotFound), err), errors.Match(errors.T(errors.InvalidParameter), err):
+		return nil, unreadable("oplog key version %s not found", keyId), nil
+	case err != nil:
+		return nil, nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to get wrapper for key version %s", keyId)))
+	}
+
+	entry := &oplog.Entry{Entry: e, Cipherer: wrapper}
+	if err := entry.DecryptData(ctx); err != nil {
+		return nil, unreadable("unable to decrypt data: %v", err), nil
+	}
+	msgs, err := entry.UnmarshalData(v.types)
+	if err != nil {
+		return nil, unreadable("unable to unmarshal data: %v", err), nil
+	}
+	return msgs, nil, nil
+}
+
+// verifyRows checks the rows exist, or not, according to the last operation
+// recorded for them.
+func (v *Verifier) verifyRows(ctx context.Context, report *Report, rows map[string]*rowState) error {
+	const op = "verify.(Verifier).verifyRows"
+	cascadeKeys := make(map[string][]*cascadeKey)
+	keys := make([]string, 0, len(rows))
+	for k := range rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		r := rows[k]
+		where := make([]string, 0, len(r.columns))
+		for i, c := range r.columns {
+			where = append(where, fmt.Sprintf("%s = $%d", c, i+1))
+		}
+		exists, err := v.rowExists(ctx, fmt.Sprintf(rowExistsQuery, r.table, strings.Join(where, " and ")), r.values)
+		if err != nil {
+			return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to look up %s row", r.table)))
+		}
+		report.RowsVerified++
+		switch {
+		case r.opType == oplog.OpType_OP_TYPE_DELETE && exists:
+			report.Problems = append(report.Problems, &Problem{
+				Kind:          UnexpectedRow,
+				EntryId:       r.entryId,
+				AggregateName: r.aggregateName,
+				Description:   fmt.Sprintf("%s row %v was deleted but exists", r.table, r.values),
+			})
+		case r.opType != oplog.OpType_OP_TYPE_DELETE && !exists:
+			cascaded, err := v.parentDeleted(ctx, cascadeKeys, r)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to look up parents of %s row", r.table)))
+			}
+			if cascaded {
+				report.CascadedRows++
+				continue
+			}
+			report.Problems = append(report.Problems, &Problem{
+				Kind:          MissingRow,
+				EntryId:       r.entryId,
+				AggregateName: r.aggregateName,
+				Description:   fmt.Sprintf("%s row %v was written but does not exist", r.table, r.values),
+			})
+		}
+	}
+	return nil
+}
+
+// parentDeleted returns whether a parent row the row references with a
+// cascading foreign key no longer exists, in which case the row was removed
+// by the delete of its parent.  Foreign keys whose values were not recorded
+// for the row are skipped.
+func (v *Verifier) parentDeleted(ctx context.Context, cascadeKeys map[string][]*cascadeKey, r *rowState) (bool, error) {
+	const op = "verify.(Verifier).parentDeleted"
+	fks, ok := cascadeKeys[r.table]
+	if !ok {
+		var err error
+		if fks, err = v.listCascadeKeys(ctx, r.table); err != nil {
+			return false, errors.Wrap(err, op)
+		}
+		cascadeKeys[r.table] = fks
+	}
+	for _, fk := range fks {
+		where := make([]string, 0, len(fk.columns))
+		values := make([]interface{}, 0, len(fk.columns))
+		for i, c := range fk.columns {
+			val, ok := r.fields[c]
+			if !ok {
+				break
+			}
+			where = append(where, fmt.Sprintf("%s = $%d", fk.parentColumns[i], i+1))
+			values = append(values, val)
+		}
+		if len(values) != len(fk.columns) {
+			continue
+		}
+		exists, err := v.rowExists(ctx, fmt.Sprintf(rowExistsQuery, fk.parentTable, strings.Join(where, " and ")), values)
+		if err != nil {
+			return false, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to look up %s row", fk.parentTable)))
+		}
+		if !exists {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// listCascadeKeys returns the foreign keys of the table whose delete
+// cascades.
+func (v *Verifier) listCascadeKeys(ctx context.Context, table string) ([]*cascadeKey, error) {
+	const op = "verify.(Verifier).listCascadeKeys"
+	rows, err := v.reader.Query(ctx, selectCascadeKeysQuery, []interface{}{table})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var fks []*cascadeKey
+	var lastName string
+	for rows.Next() {
+		var name, parentTable, column, parentColumn string
+		if err := rows.Scan(&name, &parentTable, &column, &parentColumn); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if len(fks) == 0 || name != lastName {
+			fks = append(fks, &cascadeKey{parentTable: parentTable})
+			lastName = name
+		}
+		fk := fks[len(fks)-1]
+		fk.columns = append(fk.columns, column)
+		fk.parentColumns = append(fk.parentColumns, parentColumn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return fks, nil
+}
+
+func (v *Verifier) rowExists(ctx context.Context, query string, values []interface{}) (bool, error) {
+	const op = "verify.(Verifier).rowExists"
+	rows, err := v.reader.Query(ctx, query, values)
+	if err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var exists bool
+	for rows.Next() {
+		if err := rows.Scan(&exists); err != nil {
+			return false, errors.Wrap(err, op)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, errors.Wrap(err, op)
+	}
+	return exists, nil
+}
+
+// primaryKey returns the columns and values of the primary key of the store
+// message, based on the gorm tags of its fields.
+func primaryKey(m proto.Message) ([]string, []interface{}) {
+	val := reflect.Indirect(reflect.ValueOf(m))
+	if val.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	var columns []string
+	var values []interface{}
+	for i := 0; i < val.NumField(); i++ {
+		column, isPrimaryKey := columnName(val.Type().Field(i))
+		if !isPrimaryKey {
+			continue
+		}
+		columns = append(columns, column)
+		values = append(values, val.Field(i).Interface())
+	}
+	return columns, values
+}
+
+// columnValues returns the values of the string and integer columns of the
+// store message which are set, keyed by column name.
+func columnValues(m proto.Message) map[string]interface{} {
+	val := reflect.Indirect(reflect.ValueOf(m))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+	values := make(map[string]interface{})
+	for i := 0; i < val.NumField(); i++ {
+		f := val.Type().Field(i)
+		if f.PkgPath != "" || val.Field(i).IsZero() {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint32, reflect.Uint64:
+		default:
+			continue
+		}
+		column, _ := columnName(f)
+		values[column] = val.Field(i).Interface()
+	}
+	return values
+}
+
+// columnName returns the column name of the field, based on its gorm tag,
+// and whether it is part of the primary key.
+func columnName(f reflect.StructField) (string, bool) {
+	var isPrimaryKey bool
+	column := gorm.ToColumnName(f.Name)
+	for _, setting := range strings.Split(f.Tag.Get("gorm"), ";") {
+		switch {
+		case strings.EqualFold(setting, "primary_key"):
+			isPrimaryKey = true
+		case strings.HasPrefix(strings.ToLower(setting), "column:"):
+			column = setting[len("column:"):]
+		}
+	}
+	return column, isPrimaryKey
+}
@@ -0,0 +1,146 @@
+package verify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamStore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	targetStore "github.com/hashicorp/boundary/internal/target/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	u1 := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u2 := iam.TestUser(t, iamRepo, org.GetPublicId())
+	u1.Name = "updated"
+	_, _, _, err := iamRepo.UpdateUser(ctx, u1, u1.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier(rw, kmsCache, WithLimit(2))
+	require.NoError(t, err)
+
+	problemsOf := func(t *testing.T, r *Report, kind ProblemKind) []*Problem {
+		t.Helper()
+		var problems []*Problem
+		for _, p := range r.Problems {
+			if p.Kind == kind {
+				problems = append(problems, p)
+			}
+		}
+		return problems
+	}
+	userEntryIds := func(t *testing.T) []uint32 {
+		t.Helper()
+		rows, err := rw.Query(ctx, "select id from oplog_entry where aggregate_name = 'iam_user' order by id", nil)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []uint32
+		for rows.Next() {
+			var id uint32
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		report, err := verifier.Verify(ctx)
+		require.NoError(err)
+		assert.Empty(report.Problems)
+		assert.Greater(report.EntriesVerified, 3)
+		assert.Zero(report.UnversionedEntries)
+		assert.Greater(report.RowsVerified, 3)
+	})
+
+	t.Run("cascaded rows", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		role, err := iam.NewRole(org.GetPublicId())
+		require.NoError(err)
+		role, err = iamRepo.CreateRole(ctx, role)
+		require.NoError(err)
+		_, err = iamRepo.AddRoleGrants(ctx, role.GetPublicId(), role.GetVersion(), []string{"id=*;type=*;actions=read"})
+		require.NoError(err)
+		_, err = iamRepo.AddPrincipalRoles(ctx, role.GetPublicId(), role.GetVersion()+1, []string{u1.GetPublicId()})
+		require.NoError(err)
+
+		// Deleting the role removes its grants and principals without oplog
+		// entries for them.
+		_, err = iamRepo.DeleteRole(ctx, role.GetPublicId())
+		require.NoError(err)
+		report, err := verifier.Verify(ctx)
+		require.NoError(err)
+		assert.Empty(problemsOf(t, report, MissingRow))
+		assert.Equal(2, report.CascadedRows)
+	})
+
+	t.Run("missing row", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, err := rw.Exec(ctx, "delete from iam_user where public_id = ?", []interface{}{u2.GetPublicId()})
+		require.NoError(err)
+		report, err := verifier.Verify(ctx)
+		require.NoError(err)
+		problems := problemsOf(t, report, MissingRow)
+		require.Len(problems, 1)
+		assert.Equal("iam_user", problems[0].AggregateName)
+		assert.Contains(problems[0].Description, u2.GetPublicId())
+	})
+
+	t.Run("deleted entry", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ids := userEntryIds(t)
+		require.Len(ids, 3)
+		_, err := rw.Exec(ctx, "delete from oplog_entry where id = ?", []interface{}{ids[1]})
+		require.NoError(err)
+		report, err := verifier.Verify(ctx)
+		require.NoError(err)
+		problems := problemsOf(t, report, TicketGap)
+		require.Len(problems, 1)
+		assert.Equal(ids[2], problems[0].EntryId)
+		assert.Equal("iam_user", problems[0].AggregateName)
+	})
+
+	t.Run("modified entry", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		ids := userEntryIds(t)
+		_, err := rw.Exec(ctx, "update oplog_entry set data = ? where id = ?", []interface{}{[]byte("tampered"), ids[0]})
+		require.NoError(err)
+		report, err := verifier.Verify(ctx)
+		require.NoError(err)
+		problems := problemsOf(t, report, UnreadableEntry)
+		require.Len(problems, 1)
+		assert.Equal(ids[0], problems[0].EntryId)
+	})
+}
+
+func Test_primaryKey(t *testing.T) {
+	assert := assert.New(t)
+	columns, values := primaryKey(&iamStore.User{PublicId: "u_1234567890", Name: "name"})
+	assert.Equal([]string{"public_id"}, columns)
+	assert.Equal([]interface{}{"u_1234567890"}, values)
+
+	columns, values = primaryKey(&targetStore.TargetClientCidr{TargetId: "ttcp_1234567890", Cidr: "10.0.0.0/8", Rule: "allow"})
+	assert.Equal([]string{"target_id", "cidr", "rule"}, columns)
+	assert.Equal([]interface{}{"ttcp_1234567890", "10.0.0.0/8", "allow"}, values)
+}
+
+func Test_columnValues(t *testing.T) {
+	assert := assert.New(t)
+	values := columnValues(&iamStore.User{PublicId: "u_1234567890", ScopeId: "o_1234567890", Version: 2})
+	assert.Equal(map[string]interface{}{
+		"public_id": "u_1234567890",
+		"scope_id":  "o_1234567890",
+		"version":   uint32(2),
+	}, values)
+}
@@ -24,6 +24,10 @@ import (
 // action is allowed on a resource based on a principal's (user or group) grants.
 type ACL struct {
 	scopeMap map[string][]Grant
+
+	// restrictions contains ACLs which must also allow an action for the
+	// action to be allowed by this ACL.
+	restrictions []ACL
 }
 
 // ACLResults provides a type for the permission's engine results so that we can
@@ -67,8 +71,36 @@ func NewACL(grants ...Grant) ACL {
 	return ret
 }
 
+// Restrict returns a copy of the ACL which only allows an action for a
+// resource if the action is also allowed by an ACL created from the grants
+// provided.  The result is the intersection of the two sets of grants, which
+// is useful to limit a principal to a subset of its grants.  Restrict may be
+// called more than once; every restriction must allow an action.
+func (a ACL) Restrict(grants ...Grant) ACL {
+	ret := ACL{
+		scopeMap:     a.scopeMap,
+		restrictions: make([]ACL, 0, len(a.restrictions)+1),
+	}
+	ret.restrictions = append(ret.restrictions, a.restrictions...)
+	ret.restrictions = append(ret.restrictions, NewACL(grants...))
+	return ret
+}
+
 // Allowed determines if the grants for an ACL allow an action for a resource.
 func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
+	results = a.allowed(r, aType)
+	for _, restriction := range a.restrictions {
+		if !results.Authorized {
+			break
+		}
+		results.Authorized = restriction.allowed(r, aType).Authorized
+	}
+	return
+}
+
+// allowed determines if the grants for an ACL, without taking its restrictions
+// into account, allow an action for a resource.
+func (a ACL) allowed(r Resource, aType action.Type) (results ACLResults) {
 	// First, get the grants within the specified scope
 	grants := a.scopeMap[r.ScopeId]
 	results.scopeMap = a.scopeMap
@@ -147,7 +179,8 @@ func (a ACL) Allowed(r Resource, aType action.Type) (results ACLResults) {
 
 func topLevelType(typ resource.Type) bool {
 	switch typ {
-	case resource.AuthMethod,
+	case resource.ApiToken,
+		resource.AuthMethod,
 		resource.AuthToken,
 		resource.Group,
 		resource.HostCatalog,
@@ -307,3 +307,109 @@ func Test_ACLAllowed(t *testing.T) {
 		})
 	}
 }
+
+func Test_ACLRestrict(t *testing.T) {
+	t.Parallel()
+
+	type actionAuthorized struct {
+		action     action.Type
+		authorized bool
+	}
+	type input struct {
+		name              string
+		grants            []string
+		restrictions      [][]string
+		resource          Resource
+		actionsAuthorized []actionAuthorized
+	}
+
+	grants := []string{
+		"id=*;type=target;actions=read,update,authorize-session",
+		"id=*;type=host;actions=*",
+		"type=target;actions=list",
+	}
+
+	tests := []input{
+		{
+			name:     "no restrictions",
+			grants:   grants,
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.Update, authorized: true},
+				{action: action.AuthorizeSession, authorized: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:         "restricted to subset",
+			grants:       grants,
+			restrictions: [][]string{{"id=*;type=target;actions=authorize-session"}},
+			resource:     Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.Update},
+				{action: action.AuthorizeSession, authorized: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:         "restriction does not add to grants",
+			grants:       grants,
+			restrictions: [][]string{{"id=*;type=*;actions=*"}},
+			resource:     Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.Delete},
+			},
+		},
+		{
+			name:         "restriction to other type",
+			grants:       grants,
+			restrictions: [][]string{{"id=*;type=host;actions=read"}},
+			resource:     Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read},
+				{action: action.AuthorizeSession},
+			},
+		},
+		{
+			name:   "multiple restrictions",
+			grants: grants,
+			restrictions: [][]string{
+				{"id=*;type=target;actions=read,authorize-session"},
+				{"id=*;type=target;actions=read,update"},
+			},
+			resource: Resource{ScopeId: "p_a", Id: "ttcp_1", Type: resource.Target},
+			actionsAuthorized: []actionAuthorized{
+				{action: action.Read, authorized: true},
+				{action: action.Update},
+				{action: action.AuthorizeSession},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var parsed []Grant
+			for _, g := range test.grants {
+				grant, err := Parse("p_a", g)
+				require.NoError(t, err)
+				parsed = append(parsed, grant)
+			}
+			acl := NewACL(parsed...)
+			for _, r := range test.restrictions {
+				var restriction []Grant
+				for _, g := range r {
+					grant, err := Parse("p_a", g)
+					require.NoError(t, err)
+					restriction = append(restriction, grant)
+				}
+				acl = acl.Restrict(restriction...)
+			}
+			for _, aa := range test.actionsAuthorized {
+				assert.Equal(t, aa.authorized, acl.Allowed(test.resource, aa.action).Authorized, "action: %s", aa.action)
+			}
+		})
+	}
+}
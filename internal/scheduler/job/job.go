@@ -0,0 +1,84 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+)
+
+const (
+	jobTableName = "job"
+	runTableName = "job_run"
+)
+
+// Job is a job registered with the scheduler.
+type Job struct {
+	// Name is the unique name of the job
+	Name string `gorm:"primary_key"`
+	// Description of the job
+	Description string
+	// NextScheduledRun is the time the job is next due to run
+	NextScheduledRun *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// UpdateTime from the RDBMS
+	UpdateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+}
+
+// TableName returns the table name of the job.
+func (j *Job) TableName() string {
+	return jobTableName
+}
+
+// RunStatus is the status of a run of a job.
+type RunStatus string
+
+const (
+	// Running is the status of a run which has not ended yet.
+	Running RunStatus = "running"
+	// Completed is the status of a run which ended without an error.
+	Completed RunStatus = "completed"
+	// Failed is the status of a run which ended with an error.
+	Failed RunStatus = "failed"
+	// Interrupted is the status of a run whose controller stopped before the
+	// run ended.
+	Interrupted RunStatus = "interrupted"
+)
+
+// Run is a run of a job.
+type Run struct {
+	// Id of the run
+	Id uint32 `gorm:"primary_key"`
+	// JobName is the name of the job run
+	JobName string
+	// ServerId is the name of the controller running the job
+	ServerId string
+	// Status of the run
+	Status string
+	// Error returned by the job if the run failed
+	Error string `gorm:"default:null"`
+	// CreateTime from the RDBMS, which is when the run started
+	CreateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// UpdateTime from the RDBMS
+	UpdateTime *timestamp.Timestamp `gorm:"default:current_timestamp"`
+	// EndTime is when the run ended, or nil if it is still running
+	EndTime *timestamp.Timestamp `gorm:"default:null"`
+}
+
+// TableName returns the table name of the run.
+func (r *Run) TableName() string {
+	return runTableName
+}
+
+// Duration returns how long the run took, or has taken so far if it is still
+// running.
+func (r *Run) Duration() time.Duration {
+	if r.CreateTime == nil {
+		return 0
+	}
+	end := time.Now()
+	if r.EndTime != nil {
+		end = r.EndTime.GetTimestamp().AsTime()
+	}
+	return end.Sub(r.CreateTime.GetTimestamp().AsTime())
+}
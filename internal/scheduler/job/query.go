@@ -0,0 +1,77 @@
+package job
+
+const (
+	upsertJobQuery = `
+insert into job
+  (name, description)
+values
+  ($1, $2)
+on conflict (name) do update
+  set description = excluded.description;
+`
+	runJobNowQuery = `
+update job
+   set next_scheduled_run = now()
+ where name = $1;
+`
+	// startRunQuery starts a run of the job ($1) by the server ($2) if the
+	// job is due and is not already running.
+	startRunQuery = `
+insert into job_run
+  (job_name, server_id)
+select name, $2
+  from job
+ where name = $1
+   and next_scheduled_run <= now()
+on conflict (job_name) where status = 'running' do nothing;
+`
+	// endRunQuery ends the running run ($1) with the status ($2) and error
+	// ($3).
+	endRunQuery = `
+update job_run
+   set status = $2,
+       error = $3,
+       end_time = now()
+ where id = $1
+   and status = 'running';
+`
+	// scheduleNextRunQuery schedules the next run of the job of the run ($1)
+	// in $2 seconds, unless the job was asked to run again since the run
+	// started.
+	scheduleNextRunQuery = `
+update job
+   set next_scheduled_run = now() + make_interval(secs => $2)
+  from job_run r
+ where r.id = $1
+   and job.name = r.job_name
+   and job.next_scheduled_run <= r.create_time;
+`
+	// interruptRunsQuery interrupts the runs of servers which have not
+	// reported their status in the last $1 seconds.
+	interruptRunsQuery = `
+update job_run
+   set status = 'interrupted',
+       end_time = now()
+ where status = 'running'
+   and server_id not in (
+         select private_id
+           from server
+          where type = 'controller'
+            and update_time > now() - make_interval(secs => $1)
+       );
+`
+	// interruptServerRunsQuery interrupts the runs of the server ($1).
+	interruptServerRunsQuery = `
+update job_run
+   set status = 'interrupted',
+       end_time = now()
+ where status = 'running'
+   and server_id = $1;
+`
+	// deleteRunsQuery deletes the runs which ended more than $1 seconds ago.
+	deleteRunsQuery = `
+delete from job_run
+ where status != 'running'
+   and end_time < now() - make_interval(secs => $1);
+`
+)
@@ -0,0 +1,282 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// Repository is the job database repository
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+
+	// defaultLimit provides a default for limiting the number of results
+	// returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new job Repository. Supports the options: WithLimit
+// which sets a default limit on results returned by repo operations.
+func NewRepository(r db.Reader, w db.Writer, opt ...Option) (*Repository, error) {
+	const op = "job.NewRepository"
+	if r == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db reader")
+	}
+	if w == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing db writer")
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// UpsertJob registers the job with the name and description, updating the
+// description of a job already registered, and returns the job.  A new job
+// is due to run immediately.
+func (r *Repository) UpsertJob(ctx context.Context, name, description string) (*Job, error) {
+	const op = "job.(Repository).UpsertJob"
+	if name == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing name")
+	}
+	if description == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing description")
+	}
+	j := &Job{}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			if _, err := w.Exec(ctx, upsertJobQuery, []interface{}{name, description}); err != nil {
+				return errors.Wrap(err, op)
+			}
+			if err := reader.LookupWhere(ctx, j, "name = $1", name); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", name)))
+	}
+	return j, nil
+}
+
+// LookupJob returns the job with the name.  If it is not found, it returns
+// nil, nil.
+func (r *Repository) LookupJob(ctx context.Context, name string) (*Job, error) {
+	const op = "job.(Repository).LookupJob"
+	if name == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing name")
+	}
+	j := &Job{}
+	if err := r.reader.LookupWhere(ctx, j, "name = $1", name); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", name)))
+	}
+	return j, nil
+}
+
+// ListJobs returns the jobs ordered by name.  Supports the WithLimit option.
+func (r *Repository) ListJobs(ctx context.Context, opt ...Option) ([]*Job, error) {
+	const op = "job.(Repository).ListJobs"
+	limit := r.defaultLimit
+	if opts := getOpts(opt...); opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var jobs []*Job
+	if err := r.reader.SearchWhere(ctx, &jobs, "true", nil, db.WithLimit(limit), db.WithOrder("name")); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return jobs, nil
+}
+
+// RunJobNow makes the job with the name due to run, so it is run by the next
+// controller looking for due jobs, and returns the job.  If the job is
+// already running it is run again once the current run ends.
+func (r *Repository) RunJobNow(ctx context.Context, name string) (*Job, error) {
+	const op = "job.(Repository).RunJobNow"
+	if name == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing name")
+	}
+	j := &Job{}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Exec(ctx, runJobNowQuery, []interface{}{name})
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated == 0 {
+				return errors.New(errors.RecordNotFound, op, "job not found")
+			}
+			if err := reader.LookupWhere(ctx, j, "name = $1", name); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", name)))
+	}
+	return j, nil
+}
+
+// StartRun starts a run of the job with the name by the server and returns
+// the run.  If the job is not due or is already running, it returns nil, nil.
+func (r *Repository) StartRun(ctx context.Context, name, serverId string) (*Run, error) {
+	const op = "job.(Repository).StartRun"
+	if name == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing name")
+	}
+	if serverId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing server id")
+	}
+	var run *Run
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			run = nil
+			rowsInserted, err := w.Exec(ctx, startRunQuery, []interface{}{name, serverId})
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsInserted == 0 {
+				return nil
+			}
+			run = &Run{}
+			if err := reader.LookupWhere(ctx, run, "job_name = $1 and status = $2", name, string(Running)); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", name)))
+	}
+	return run, nil
+}
+
+// EndRun ends the running run with the id, as failed if runErr is not nil or
+// as completed otherwise, schedules the next run of its job in nextRunIn and
+// returns the run.
+func (r *Repository) EndRun(ctx context.Context, runId uint32, runErr error, nextRunIn time.Duration) (*Run, error) {
+	const op = "job.(Repository).EndRun"
+	if runId == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing run id")
+	}
+	if nextRunIn < 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "negative next run")
+	}
+	status, errMsg := Completed, interface{}(nil)
+	if runErr != nil {
+		status, errMsg = Failed, runErr.Error()
+	}
+	run := &Run{}
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rowsUpdated, err := w.Exec(ctx, endRunQuery, []interface{}{runId, string(status), errMsg})
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			if rowsUpdated == 0 {
+				return errors.New(errors.RecordNotFound, op, "running run not found")
+			}
+			if _, err := w.Exec(ctx, scheduleNextRunQuery, []interface{}{runId, nextRunIn.Seconds()}); err != nil {
+				return errors.Wrap(err, op)
+			}
+			if err := reader.LookupWhere(ctx, run, "id = $1", runId); err != nil {
+				return errors.Wrap(err, op)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for run %d", runId)))
+	}
+	return run, nil
+}
+
+// ListRuns returns the runs of the job with the name, from the most recent
+// to the oldest.  Supports the WithLimit option.
+func (r *Repository) ListRuns(ctx context.Context, name string, opt ...Option) ([]*Run, error) {
+	const op = "job.(Repository).ListRuns"
+	if name == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing name")
+	}
+	limit := r.defaultLimit
+	if opts := getOpts(opt...); opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var runs []*Run
+	if err := r.reader.SearchWhere(ctx, &runs, "job_name = $1", []interface{}{name}, db.WithLimit(limit), db.WithOrder("id desc")); err != nil {
+		return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", name)))
+	}
+	return runs, nil
+}
+
+// InterruptRuns interrupts the runs of the controllers which have not
+// reported their status within liveness, and returns the number of runs
+// interrupted.
+func (r *Repository) InterruptRuns(ctx context.Context, liveness time.Duration) (int, error) {
+	const op = "job.(Repository).InterruptRuns"
+	if liveness <= 0 {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "liveness must be greater than zero")
+	}
+	rowsUpdated, err := r.writer.Exec(ctx, interruptRunsQuery, []interface{}{liveness.Seconds()})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return rowsUpdated, nil
+}
+
+// InterruptServerRuns interrupts the runs of the controller with the server
+// id, which is done when it starts since it can not have any runs yet, and
+// returns the number of runs interrupted.
+func (r *Repository) InterruptServerRuns(ctx context.Context, serverId string) (int, error) {
+	const op = "job.(Repository).InterruptServerRuns"
+	if serverId == "" {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing server id")
+	}
+	rowsUpdated, err := r.writer.Exec(ctx, interruptServerRunsQuery, []interface{}{serverId})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return rowsUpdated, nil
+}
+
+// DeleteRuns deletes the runs which ended longer ago than olderThan, and
+// returns the number of runs deleted.
+func (r *Repository) DeleteRuns(ctx context.Context, olderThan time.Duration) (int, error) {
+	const op = "job.(Repository).DeleteRuns"
+	if olderThan <= 0 {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "older than must be greater than zero")
+	}
+	rowsDeleted, err := r.writer.Exec(ctx, deleteRunsQuery, []interface{}{olderThan.Seconds()})
+	if err != nil {
+		return db.NoRowsAffected, errors.Wrap(err, op)
+	}
+	return rowsDeleted, nil
+}
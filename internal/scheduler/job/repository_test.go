@@ -0,0 +1,246 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRepo(t *testing.T) (*gorm.DB, *Repository) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+	return conn, repo
+}
+
+// testController records a status update of the controller with the name.
+func testController(t *testing.T, conn *gorm.DB, name string) {
+	t.Helper()
+	require.NoError(t, conn.Exec(`
+insert into server
+  (private_id, type, update_time)
+values
+  (?, 'controller', now())
+on conflict (private_id) do update
+  set update_time = now();
+`, name).Error)
+}
+
+func TestNewRepository(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+
+	_, err := NewRepository(nil, rw)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = NewRepository(rw, nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	repo, err := NewRepository(rw, rw)
+	require.NoError(t, err)
+	assert.Equal(t, db.DefaultLimit, repo.defaultLimit)
+	repo, err = NewRepository(rw, rw, WithLimit(5))
+	require.NoError(t, err)
+	assert.Equal(t, 5, repo.defaultLimit)
+}
+
+func TestRepository_Jobs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	_, repo := testRepo(t)
+
+	_, err := repo.UpsertJob(ctx, "", "description")
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = repo.UpsertJob(ctx, "name", "")
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	j, err := repo.UpsertJob(ctx, "b_job", "first description")
+	require.NoError(err)
+	assert.Equal("b_job", j.Name)
+	assert.Equal("first description", j.Description)
+	assert.NotNil(j.NextScheduledRun)
+
+	j, err = repo.UpsertJob(ctx, "b_job", "second description")
+	require.NoError(err)
+	assert.Equal("second description", j.Description)
+
+	_, err = repo.UpsertJob(ctx, "a_job", "description")
+	require.NoError(err)
+
+	jobs, err := repo.ListJobs(ctx)
+	require.NoError(err)
+	require.Len(jobs, 2)
+	assert.Equal("a_job", jobs[0].Name)
+	assert.Equal("b_job", jobs[1].Name)
+	jobs, err = repo.ListJobs(ctx, WithLimit(1))
+	require.NoError(err)
+	assert.Len(jobs, 1)
+
+	got, err := repo.LookupJob(ctx, "b_job")
+	require.NoError(err)
+	assert.Equal("second description", got.Description)
+	got, err = repo.LookupJob(ctx, "unknown")
+	require.NoError(err)
+	assert.Nil(got)
+
+	_, err = repo.RunJobNow(ctx, "unknown")
+	assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+	_, err = repo.RunJobNow(ctx, "b_job")
+	require.NoError(err)
+}
+
+func TestRepository_Runs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("one-run-at-a-time", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, repo := testRepo(t)
+		_, err := repo.UpsertJob(ctx, "job", "description")
+		require.NoError(err)
+
+		run, err := repo.StartRun(ctx, "job", "controller1")
+		require.NoError(err)
+		require.NotNil(run)
+		assert.Equal("job", run.JobName)
+		assert.Equal("controller1", run.ServerId)
+		assert.Equal(string(Running), run.Status)
+		assert.Nil(run.EndTime)
+
+		// The job is already running.
+		other, err := repo.StartRun(ctx, "job", "controller2")
+		require.NoError(err)
+		assert.Nil(other)
+
+		run, err = repo.EndRun(ctx, run.Id, nil, time.Hour)
+		require.NoError(err)
+		assert.Equal(string(Completed), run.Status)
+		assert.NotNil(run.EndTime)
+		assert.GreaterOrEqual(int64(run.Duration()), int64(0))
+
+		// The job is not due until its next run.
+		other, err = repo.StartRun(ctx, "job", "controller2")
+		require.NoError(err)
+		assert.Nil(other)
+
+		_, err = repo.RunJobNow(ctx, "job")
+		require.NoError(err)
+		other, err = repo.StartRun(ctx, "job", "controller2")
+		require.NoError(err)
+		require.NotNil(other)
+		assert.Equal("controller2", other.ServerId)
+
+		other, err = repo.EndRun(ctx, other.Id, stderrors.New("job error"), time.Hour)
+		require.NoError(err)
+		assert.Equal(string(Failed), other.Status)
+		assert.Equal("job error", other.Error)
+
+		_, err = repo.EndRun(ctx, other.Id, nil, time.Hour)
+		assert.True(errors.Match(errors.T(errors.RecordNotFound), err))
+
+		runs, err := repo.ListRuns(ctx, "job")
+		require.NoError(err)
+		require.Len(runs, 2)
+		assert.Equal(other.Id, runs[0].Id)
+		assert.Equal(run.Id, runs[1].Id)
+	})
+
+	t.Run("run-now-while-running", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, repo := testRepo(t)
+		_, err := repo.UpsertJob(ctx, "job", "description")
+		require.NoError(err)
+
+		run, err := repo.StartRun(ctx, "job", "controller1")
+		require.NoError(err)
+		require.NotNil(run)
+		_, err = repo.RunJobNow(ctx, "job")
+		require.NoError(err)
+		_, err = repo.EndRun(ctx, run.Id, nil, time.Hour)
+		require.NoError(err)
+
+		// The job runs again since it was asked to while it was running.
+		run, err = repo.StartRun(ctx, "job", "controller1")
+		require.NoError(err)
+		assert.NotNil(run)
+	})
+
+	t.Run("interrupt", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		conn, repo := testRepo(t)
+		testController(t, conn, "live")
+		for _, name := range []string{"job1", "job2", "job3"} {
+			_, err := repo.UpsertJob(ctx, name, "description")
+			require.NoError(err)
+		}
+		live, err := repo.StartRun(ctx, "job1", "live")
+		require.NoError(err)
+		dead, err := repo.StartRun(ctx, "job2", "dead")
+		require.NoError(err)
+		restarted, err := repo.StartRun(ctx, "job3", "restarted")
+		require.NoError(err)
+
+		_, err = repo.InterruptRuns(ctx, 0)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		_, err = repo.InterruptServerRuns(ctx, "")
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+		count, err := repo.InterruptServerRuns(ctx, "restarted")
+		require.NoError(err)
+		assert.Equal(1, count)
+		testController(t, conn, "restarted")
+		count, err = repo.InterruptRuns(ctx, time.Minute)
+		require.NoError(err)
+		assert.Equal(1, count)
+
+		for _, want := range []struct {
+			run    *Run
+			status RunStatus
+		}{
+			{live, Running},
+			{dead, Interrupted},
+			{restarted, Interrupted},
+		} {
+			runs, err := repo.ListRuns(ctx, want.run.JobName)
+			require.NoError(err)
+			require.Len(runs, 1)
+			assert.Equal(string(want.status), runs[0].Status, want.run.JobName)
+		}
+
+		// An interrupted job is still due, so it is run again.
+		run, err := repo.StartRun(ctx, "job2", "live")
+		require.NoError(err)
+		assert.NotNil(run)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		_, repo := testRepo(t)
+		_, err := repo.UpsertJob(ctx, "job", "description")
+		require.NoError(err)
+		run, err := repo.StartRun(ctx, "job", "controller1")
+		require.NoError(err)
+		_, err = repo.EndRun(ctx, run.Id, nil, 0)
+		require.NoError(err)
+		time.Sleep(2 * time.Second)
+		running, err := repo.StartRun(ctx, "job", "controller1")
+		require.NoError(err)
+		require.NotNil(running)
+
+		_, err = repo.DeleteRuns(ctx, 0)
+		assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+		count, err := repo.DeleteRuns(ctx, time.Second)
+		require.NoError(err)
+		assert.Equal(1, count)
+		runs, err := repo.ListRuns(ctx, "job")
+		require.NoError(err)
+		require.Len(runs, 1)
+		assert.Equal(running.Id, runs[0].Id)
+	})
+}
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withLogger             hclog.Logger
+	withRunJobsInterval    time.Duration
+	withInterruptThreshold time.Duration
+	withRunRetention       time.Duration
+}
+
+func getDefaultOptions() options {
+	return options{
+		withLogger:             hclog.NewNullLogger(),
+		withRunJobsInterval:    DefaultRunJobsInterval,
+		withInterruptThreshold: DefaultInterruptThreshold,
+		withRunRetention:       DefaultRunRetention,
+	}
+}
+
+// WithLogger provides an option to provide a logger for the scheduler.
+func WithLogger(l hclog.Logger) Option {
+	return func(o *options) {
+		o.withLogger = l
+	}
+}
+
+// WithRunJobsInterval provides an option to provide how often the scheduler
+// looks for due jobs to run.
+func WithRunJobsInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.withRunJobsInterval = interval
+	}
+}
+
+// WithInterruptThreshold provides an option to provide how long a controller
+// can go without reporting its status before the runs of its jobs are
+// interrupted, so the jobs can be run by another controller.
+func WithInterruptThreshold(threshold time.Duration) Option {
+	return func(o *options) {
+		o.withInterruptThreshold = threshold
+	}
+}
+
+// WithRunRetention provides an option to provide how long the history of the
+// runs is kept once they end.
+func WithRunRetention(retention time.Duration) Option {
+	return func(o *options) {
+		o.withRunRetention = retention
+	}
+}
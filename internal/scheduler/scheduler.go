@@ -0,0 +1,218 @@
+// Package scheduler runs the jobs registered by the controllers.  The jobs
+// and the history of their runs are kept in the database, and every
+// controller regularly looks for jobs which are due, but only one controller
+// starts a run of a job at a time: the database allows a single running run
+// per job.
+//
+// Once a run ends, the next run of its job is scheduled after the interval
+// returned by the job.  The runs of a controller which stops reporting its
+// status are interrupted so its jobs are run by another controller.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/hashicorp/go-hclog"
+	ua "go.uber.org/atomic"
+)
+
+const (
+	// DefaultRunJobsInterval is how often the scheduler looks for due jobs
+	// unless WithRunJobsInterval is used.
+	DefaultRunJobsInterval = 10 * time.Second
+	// DefaultInterruptThreshold is how long a controller can go without
+	// reporting its status before its runs are interrupted unless
+	// WithInterruptThreshold is used.
+	DefaultInterruptThreshold = time.Minute
+	// DefaultRunRetention is how long the runs are kept once they end unless
+	// WithRunRetention is used.
+	DefaultRunRetention = 7 * 24 * time.Hour
+)
+
+// Job is a job run by the scheduler.
+type Job interface {
+	// Name is the unique name of the job.
+	Name() string
+	// Description of the job.
+	Description() string
+	// Run runs the job.  The context is canceled when the scheduler stops.
+	Run(ctx context.Context) error
+	// NextRunIn returns how long after a run ends the next run is due.
+	NextRunIn() time.Duration
+}
+
+// Scheduler runs the jobs registered with it when they are due.
+type Scheduler struct {
+	serverId  string
+	jobRepoFn func() (*job.Repository, error)
+	logger    hclog.Logger
+
+	runJobsInterval    time.Duration
+	interruptThreshold time.Duration
+	runRetention       time.Duration
+
+	jobsLock sync.RWMutex
+	jobs     map[string]Job
+
+	started *ua.Bool
+}
+
+// New creates a new Scheduler which runs jobs on behalf of the controller
+// with the server id.  Supported options: WithLogger, WithRunJobsInterval,
+// WithInterruptThreshold and WithRunRetention.
+func New(serverId string, jobRepoFn func() (*job.Repository, error), opt ...Option) (*Scheduler, error) {
+	const op = "scheduler.New"
+	if serverId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing server id")
+	}
+	if jobRepoFn == nil {
+		return nil, errors.New(errors.InvalidParameter, op, "missing job repo function")
+	}
+	opts := getOpts(opt...)
+	if opts.withRunJobsInterval <= 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "run jobs interval must be greater than zero")
+	}
+	if opts.withInterruptThreshold <= 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "interrupt threshold must be greater than zero")
+	}
+	if opts.withRunRetention <= 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "run retention must be greater than zero")
+	}
+	return &Scheduler{
+		serverId:           serverId,
+		jobRepoFn:          jobRepoFn,
+		logger:             opts.withLogger,
+		runJobsInterval:    opts.withRunJobsInterval,
+		interruptThreshold: opts.withInterruptThreshold,
+		runRetention:       opts.withRunRetention,
+		jobs:               make(map[string]Job),
+		started:            ua.NewBool(false),
+	}, nil
+}
+
+// RegisterJob registers the job with the scheduler, which records it in the
+// database so it is listed and can be run by any controller which registered
+// it too.  A job can only be registered once.
+func (s *Scheduler) RegisterJob(ctx context.Context, j Job) error {
+	const op = "scheduler.(Scheduler).RegisterJob"
+	if j == nil {
+		return errors.New(errors.InvalidParameter, op, "missing job")
+	}
+	name := j.Name()
+	if name == "" {
+		return errors.New(errors.InvalidParameter, op, "missing job name")
+	}
+	s.jobsLock.Lock()
+	defer s.jobsLock.Unlock()
+	if _, ok := s.jobs[name]; ok {
+		return errors.New(errors.InvalidParameter, op, fmt.Sprintf("job %q already registered", name))
+	}
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if _, err := repo.UpsertJob(ctx, name, j.Description()); err != nil {
+		return errors.Wrap(err, op)
+	}
+	s.jobs[name] = j
+	return nil
+}
+
+// Start interrupts the runs left over by a previous start of the controller
+// and starts looking for due jobs to run until the context is canceled.
+func (s *Scheduler) Start(ctx context.Context) error {
+	const op = "scheduler.(Scheduler).Start"
+	if s.started.Load() {
+		s.logger.Info("scheduler already started, skipping")
+		return nil
+	}
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	interruptedCount, err := repo.InterruptServerRuns(ctx, s.serverId)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if interruptedCount > 0 {
+		s.logger.Info("interrupted runs left over by a previous start", "runs_interrupted", interruptedCount)
+	}
+	s.started.Store(true)
+
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-ctx.Done():
+				s.logger.Info("scheduler shutting down")
+				s.started.Store(false)
+				return
+
+			case <-timer.C:
+				s.runJobs(ctx)
+				timer.Reset(s.runJobsInterval)
+			}
+		}
+	}()
+	return nil
+}
+
+// runJobs interrupts the runs of controllers which stopped, deletes the runs
+// past their retention and starts a run of the registered jobs which are
+// due.
+func (s *Scheduler) runJobs(ctx context.Context) {
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		s.logger.Error("error fetching repository for running jobs", "error", err)
+		return
+	}
+	interruptedCount, err := repo.InterruptRuns(ctx, s.interruptThreshold)
+	if err != nil {
+		s.logger.Error("error interrupting runs of stopped controllers", "error", err)
+	} else if interruptedCount > 0 {
+		s.logger.Info("interrupting runs of stopped controllers successful", "runs_interrupted", interruptedCount)
+	}
+	if _, err := repo.DeleteRuns(ctx, s.runRetention); err != nil {
+		s.logger.Error("error deleting runs past their retention", "error", err)
+	}
+
+	s.jobsLock.RLock()
+	defer s.jobsLock.RUnlock()
+	for name, j := range s.jobs {
+		run, err := repo.StartRun(ctx, name, s.serverId)
+		if err != nil {
+			s.logger.Error("error starting job run", "job", name, "error", err)
+			continue
+		}
+		if run == nil {
+			// The job is not due or another controller is running it.
+			continue
+		}
+		go s.runJob(ctx, j, run)
+	}
+}
+
+// runJob runs the job and records the end of the run.
+func (s *Scheduler) runJob(ctx context.Context, j Job, run *job.Run) {
+	s.logger.Trace("job run started", "job", run.JobName, "run_id", run.Id)
+	runErr := j.Run(ctx)
+	if runErr != nil {
+		s.logger.Error("error running job", "job", run.JobName, "run_id", run.Id, "error", runErr)
+	}
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		s.logger.Error("error fetching repository for ending job run", "job", run.JobName, "run_id", run.Id, "error", err)
+		return
+	}
+	run, err = repo.EndRun(ctx, run.Id, runErr, j.NextRunIn())
+	if err != nil {
+		s.logger.Error("error ending job run", "job", j.Name(), "error", err)
+		return
+	}
+	s.logger.Trace("job run ended", "job", run.JobName, "run_id", run.Id, "status", run.Status, "duration", run.Duration())
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testJob struct {
+	name      string
+	runs      chan struct{}
+	err       error
+	nextRunIn time.Duration
+}
+
+func (j *testJob) Name() string             { return j.name }
+func (j *testJob) Description() string      { return "test job " + j.name }
+func (j *testJob) NextRunIn() time.Duration { return j.nextRunIn }
+func (j *testJob) Run(ctx context.Context) error {
+	j.runs <- struct{}{}
+	return j.err
+}
+
+func testJobRepoFn(t *testing.T) func() (*job.Repository, error) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	require.NoError(t, conn.Exec(`insert into server (private_id, type, update_time) values ('controller1', 'controller', now())`).Error)
+	return func() (*job.Repository, error) {
+		return job.NewRepository(rw, rw)
+	}
+}
+
+func TestNew(t *testing.T) {
+	repoFn := func() (*job.Repository, error) { return nil, nil }
+
+	_, err := New("", repoFn)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = New("controller1", nil)
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	_, err = New("controller1", repoFn, WithRunJobsInterval(0))
+	assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	s, err := New("controller1", repoFn)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRunJobsInterval, s.runJobsInterval)
+	assert.Equal(t, DefaultInterruptThreshold, s.interruptThreshold)
+	assert.Equal(t, DefaultRunRetention, s.runRetention)
+}
+
+func TestScheduler_RegisterJob(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	repoFn := testJobRepoFn(t)
+	s, err := New("controller1", repoFn)
+	require.NoError(err)
+
+	err = s.RegisterJob(ctx, nil)
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+	err = s.RegisterJob(ctx, &testJob{})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	require.NoError(s.RegisterJob(ctx, &testJob{name: "job"}))
+	err = s.RegisterJob(ctx, &testJob{name: "job"})
+	assert.True(errors.Match(errors.T(errors.InvalidParameter), err))
+
+	repo, err := repoFn()
+	require.NoError(err)
+	j, err := repo.LookupJob(ctx, "job")
+	require.NoError(err)
+	require.NotNil(j)
+	assert.Equal("test job job", j.Description)
+}
+
+func TestScheduler_Start(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repoFn := testJobRepoFn(t)
+	s, err := New("controller1", repoFn, WithRunJobsInterval(100*time.Millisecond))
+	require.NoError(err)
+
+	succeeding := &testJob{name: "succeeding", runs: make(chan struct{}), nextRunIn: time.Hour}
+	failing := &testJob{name: "failing", runs: make(chan struct{}), err: stderrors.New("failure"), nextRunIn: time.Hour}
+	require.NoError(s.RegisterJob(ctx, succeeding))
+	require.NoError(s.RegisterJob(ctx, failing))
+	require.NoError(s.Start(ctx))
+
+	for _, j := range []*testJob{succeeding, failing} {
+		select {
+		case <-j.runs:
+		case <-time.After(5 * time.Second):
+			require.FailNow("job not run", j.name)
+		}
+	}
+
+	repo, err := repoFn()
+	require.NoError(err)
+	waitForEnd := func(name string) *job.Run {
+		for i := 0; i < 50; i++ {
+			runs, err := repo.ListRuns(ctx, name)
+			require.NoError(err)
+			require.Len(runs, 1)
+			if runs[0].Status != string(job.Running) {
+				return runs[0]
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		require.FailNow("run not ended", name)
+		return nil
+	}
+	assert.Equal(string(job.Completed), waitForEnd("succeeding").Status)
+	run := waitForEnd("failing")
+	assert.Equal(string(job.Failed), run.Status)
+	assert.Equal("failure", run.Error)
+
+	// The jobs are not due for another hour, unless they are asked to run.
+	_, err = repo.RunJobNow(ctx, "succeeding")
+	require.NoError(err)
+	select {
+	case <-succeeding.runs:
+	case <-time.After(5 * time.Second):
+		require.FailNow("job not run again")
+	}
+	select {
+	case <-failing.runs:
+		require.FailNow("job run before it was due")
+	case <-time.After(time.Second):
+	}
+}
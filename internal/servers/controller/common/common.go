@@ -5,6 +5,9 @@ import (
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/hashicorp/boundary/internal/oplog/prune"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
@@ -13,6 +16,9 @@ import (
 type (
 	AuthTokenRepoFactory    func() (*authtoken.Repository, error)
 	IamRepoFactory          func() (*iam.Repository, error)
+	JobRepoFactory          func() (*job.Repository, error)
+	OplogFeedFactory        func() (*feed.Feed, error)
+	OplogPrunerFactory      func() (*prune.Pruner, error)
 	PasswordAuthRepoFactory func() (*password.Repository, error)
 	ServersRepoFactory      func() (*servers.Repository, error)
 	StaticRepoFactory       func() (*static.Repository, error)
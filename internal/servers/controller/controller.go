@@ -13,6 +13,10 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/hashicorp/boundary/internal/oplog/prune"
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
@@ -40,13 +44,17 @@ type Controller struct {
 	// Repo factory methods
 	AuthTokenRepoFn    common.AuthTokenRepoFactory
 	IamRepoFn          common.IamRepoFactory
+	JobRepoFn          common.JobRepoFactory
+	OplogFeedFn        common.OplogFeedFactory
+	OplogPrunerFn      common.OplogPrunerFactory
 	PasswordAuthRepoFn common.PasswordAuthRepoFactory
 	ServersRepoFn      common.ServersRepoFactory
 	SessionRepoFn      common.SessionRepoFactory
 	StaticHostRepoFn   common.StaticRepoFactory
 	TargetRepoFn       common.TargetRepoFactory
 
-	kms *kms.Kms
+	kms       *kms.Kms
+	scheduler *scheduler.Scheduler
 }
 
 func New(conf *Config) (*Controller, error) {
@@ -95,7 +103,7 @@ func New(conf *Config) (*Controller, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms repository: %w", err)
 	}
-	c.kms, err = kms.NewKms(kmsRepo, kms.WithLogger(c.logger.Named("kms")))
+	c.kms, err = kms.NewKms(kmsRepo, kms.WithLogger(c.logger.Named("kms")), kms.WithRandomReader(c.conf.SecureRandomReader))
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms cache: %w", err)
 	}
@@ -126,8 +134,34 @@ func New(conf *Config) (*Controller, error) {
 	c.TargetRepoFn = func() (*target.Repository, error) {
 		return target.NewRepository(dbase, dbase, c.kms)
 	}
+	c.JobRepoFn = func() (*job.Repository, error) {
+		return job.NewRepository(dbase, dbase)
+	}
+	c.OplogFeedFn = func() (*feed.Feed, error) {
+		return feed.NewFeed(dbase, c.kms)
+	}
+	c.OplogPrunerFn = func() (*prune.Pruner, error) {
+		action := prune.Action(c.conf.RawConfig.Controller.OplogRetentionAction)
+		if action == "" {
+			action = prune.Archive
+		}
+		return prune.NewPruner(dbase, action)
+	}
 	c.SessionRepoFn = func() (*session.Repository, error) {
-		return session.NewRepository(dbase, dbase, c.kms)
+		return session.NewRepository(dbase, dbase, c.kms,
+			session.WithSessionQuotas(session.SessionQuotas{
+				MaxPerUserPerTarget: c.conf.RawConfig.Controller.MaxSessionsPerUserPerTarget,
+				MaxPerUserPerScope:  c.conf.RawConfig.Controller.MaxSessionsPerUserPerScope,
+				MaxPerTarget:        c.conf.RawConfig.Controller.MaxSessionsPerTarget,
+			}))
+	}
+
+	c.scheduler, err = scheduler.New(c.conf.RawConfig.Controller.Name, c.JobRepoFn, scheduler.WithLogger(c.logger.Named("scheduler")))
+	if err != nil {
+		return nil, fmt.Errorf("error creating scheduler: %w", err)
+	}
+	if err := c.registerJobs(context.Background()); err != nil {
+		return nil, err
 	}
 
 	c.workerAuthCache = cache.New(0, 0)
@@ -147,8 +181,10 @@ func (c *Controller) Start() error {
 	}
 
 	c.startStatusTicking(c.baseContext)
-	c.startRecoveryNonceCleanupTicking(c.baseContext)
-	c.startTerminateCompletedSessionsTicking(c.baseContext)
+	c.startKmsCacheRefreshTicking(c.baseContext)
+	if err := c.scheduler.Start(c.baseContext); err != nil {
+		return fmt.Errorf("error starting scheduler: %w", err)
+	}
 	c.started.Store(true)
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_sets"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/invites"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/sessions"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/targets"
 	"github.com/hashicorp/boundary/sdk/strutil"
@@ -25,6 +27,7 @@ import (
 	"google.golang.org/grpc/codes"
 
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/apitokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/host_catalogs"
@@ -110,6 +113,13 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAccountServiceHandlerServer(ctx, mux, accts); err != nil {
 		return nil, fmt.Errorf("failed to register account service handler: %w", err)
 	}
+	invs, err := invites.NewService(c.PasswordAuthRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite handler service: %w", err)
+	}
+	if err := services.RegisterInviteServiceHandlerServer(ctx, mux, invs); err != nil {
+		return nil, fmt.Errorf("failed to register invite service handler: %w", err)
+	}
 	authMethods, err := authmethods.NewService(c.kms, c.PasswordAuthRepoFn, c.IamRepoFn, c.AuthTokenRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth method handler service: %w", err)
@@ -117,14 +127,21 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAuthMethodServiceHandlerServer(ctx, mux, authMethods); err != nil {
 		return nil, fmt.Errorf("failed to register auth method service handler: %w", err)
 	}
-	authtoks, err := authtokens.NewService(c.AuthTokenRepoFn, c.IamRepoFn)
+	authtoks, err := authtokens.NewService(c.kms, c.AuthTokenRepoFn, c.IamRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create auth token handler service: %w", err)
 	}
 	if err := services.RegisterAuthTokenServiceHandlerServer(ctx, mux, authtoks); err != nil {
 		return nil, fmt.Errorf("failed to register auth token service handler: %w", err)
 	}
-	os, err := scopes.NewService(c.IamRepoFn)
+	apitoks, err := apitokens.NewService(c.kms, c.AuthTokenRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api token handler service: %w", err)
+	}
+	if err := services.RegisterApiTokenServiceHandlerServer(ctx, mux, apitoks); err != nil {
+		return nil, fmt.Errorf("failed to register api token service handler: %w", err)
+	}
+	os, err := scopes.NewService(c.IamRepoFn, c.OplogFeedFn, c.JobRepoFn, c.kms)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 	}
@@ -232,6 +249,9 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 			DisableAuthzFailures: disableAuthzFailures,
 		}
 
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			requestInfo.ClientIp = host
+		}
 		requestInfo.PublicId, requestInfo.EncryptedToken, requestInfo.TokenFormat = auth.GetTokenFromRequest(c.logger, c.kms, r)
 		ctx = auth.NewVerifierContext(ctx, c.logger, c.IamRepoFn, c.AuthTokenRepoFn, c.ServersRepoFn, c.kms, requestInfo)
 
@@ -2,7 +2,9 @@ package accounts
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/auth/password"
@@ -30,6 +32,13 @@ var (
 		action.Delete,
 		action.SetPassword,
 		action.ChangePassword,
+		action.Unlock,
+		action.EnrollTotp,
+		action.ConfirmTotp,
+		action.RemoveTotp,
+		action.CreateResetToken,
+		action.Disable,
+		action.Enable,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -206,6 +215,149 @@ func (s Service) SetPassword(ctx context.Context, req *pbs.SetPasswordRequest) (
 	return &pbs.SetPasswordResponse{Item: u}, nil
 }
 
+// UnlockAccount implements the interface pbs.AccountServiceServer.
+func (s Service) UnlockAccount(ctx context.Context, req *pbs.UnlockAccountRequest) (*pbs.UnlockAccountResponse, error) {
+	if err := validateUnlockRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Unlock)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.unlockInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.UnlockAccountResponse{Item: u}, nil
+}
+
+// DisableAccount implements the interface pbs.AccountServiceServer.
+func (s Service) DisableAccount(ctx context.Context, req *pbs.DisableAccountRequest) (*pbs.DisableAccountResponse, error) {
+	if err := validateDisableRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Disable)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setActiveInRepo(ctx, authResults.Scope.GetId(), req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.DisableAccountResponse{Item: u}, nil
+}
+
+// EnableAccount implements the interface pbs.AccountServiceServer.
+func (s Service) EnableAccount(ctx context.Context, req *pbs.EnableAccountRequest) (*pbs.EnableAccountResponse, error) {
+	if err := validateEnableRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Enable)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setActiveInRepo(ctx, authResults.Scope.GetId(), req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.EnableAccountResponse{Item: u}, nil
+}
+
+// EnrollTotp implements the interface pbs.AccountServiceServer.
+func (s Service) EnrollTotp(ctx context.Context, req *pbs.EnrollTotpRequest) (*pbs.EnrollTotpResponse, error) {
+	if err := validateEnrollTotpRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.EnrollTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	enrollment, err := s.enrollTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.EnrollTotpResponse{
+		Item:          u,
+		TotpSecret:    enrollment.Secret,
+		TotpUrl:       enrollment.Url,
+		RecoveryCodes: enrollment.RecoveryCodes,
+	}, nil
+}
+
+// ConfirmTotp implements the interface pbs.AccountServiceServer.
+func (s Service) ConfirmTotp(ctx context.Context, req *pbs.ConfirmTotpRequest) (*pbs.ConfirmTotpResponse, error) {
+	if err := validateConfirmTotpRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.ConfirmTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.confirmTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.ConfirmTotpResponse{Item: u}, nil
+}
+
+// RemoveTotp implements the interface pbs.AccountServiceServer.
+func (s Service) RemoveTotp(ctx context.Context, req *pbs.RemoveTotpRequest) (*pbs.RemoveTotpResponse, error) {
+	if err := validateRemoveTotpRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.RemoveTotp)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.removeTotpInRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.RemoveTotpResponse{Item: u}, nil
+}
+
+// CreateResetToken implements the interface pbs.AccountServiceServer.
+func (s Service) CreateResetToken(ctx context.Context, req *pbs.CreateResetTokenRequest) (*pbs.CreateResetTokenResponse, error) {
+	if err := validateCreateResetTokenRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.CreateResetToken)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	rt, err := s.createResetTokenInRepo(ctx, req.GetId(), time.Duration(req.GetTtlSeconds())*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.CreateResetTokenResponse{
+		Item:           u,
+		ResetToken:     rt.Token,
+		ExpirationTime: rt.GetExpirationTime().GetTimestamp(),
+	}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -221,7 +373,15 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Account, error
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
 	}
-	return toProto(u)
+	l, err := repo.LookupAccountLockout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	t, err := repo.LookupAccountTotp(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toProto(u, l, t)
 }
 
 func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string, item *pb.Account) (*pb.Account, error) {
@@ -250,14 +410,24 @@ func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string,
 	if pwAttrs.GetPassword() != nil {
 		createOpts = append(createOpts, password.WithPassword(pwAttrs.GetPassword().GetValue()))
 	}
+	if pwAttrs.GetPasswordHash() != nil {
+		createOpts = append(createOpts, password.WithPasswordHash(pwAttrs.GetPasswordHash().GetValue()))
+	}
 	out, err := repo.CreateAccount(ctx, scopeId, a, createOpts...)
 	if err != nil {
+		switch {
+		case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+			return nil, passwordPolicyError("attributes.password", err)
+		case errors.Match(errors.T(errors.PasswordUnsupportedHash), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"attributes.password_hash": "Unsupported or malformed password hash."})
+		}
 		return nil, fmt.Errorf("unable to create user: %w", err)
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create user but no error returned from repository.")
 	}
-	return toProto(out)
+	return toProto(out, nil, nil)
 }
 
 func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id string, mask []string, item *pb.Account) (*pb.Account, error) {
@@ -303,7 +473,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, authMethId, id strin
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Account %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out)
+	return toProto(out, nil, nil)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
@@ -332,7 +502,7 @@ func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]*pb.A
 	}
 	var outUl []*pb.Account
 	for _, u := range ul {
-		ou, err := toProto(u)
+		ou, err := toProto(u, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -357,13 +527,15 @@ func (s Service) changePasswordInRepo(ctx context.Context, scopeId, id string, v
 		case errors.Match(errors.T(errors.PasswordsEqual), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"new_password": "New password equal to current password."})
+		case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+			return nil, passwordPolicyError("new_password", err)
 		}
 		return nil, fmt.Errorf("unable to change password: %w", err)
 	}
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Failed to change password.")
 	}
-	return toProto(out)
+	return toProto(out, nil, nil)
 }
 
 func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, version uint32, pw string) (*pb.Account, error) {
@@ -379,10 +551,107 @@ func (s Service) setPasswordInRepo(ctx context.Context, scopeId, id string, vers
 		case errors.Match(errors.T(errors.PasswordTooShort), err):
 			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 				map[string]string{"password": "Password is too short."})
+		case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+			return nil, passwordPolicyError("password", err)
 		}
 		return nil, fmt.Errorf("unable to set password: %w", err)
 	}
-	return toProto(out)
+	return toProto(out, nil, nil)
+}
+
+// passwordPolicyError converts an error with code PasswordPolicyViolation
+// into an invalid argument error for field listing the violated rules.
+func passwordPolicyError(field string, err error) error {
+	desc := "Password does not meet the password policy."
+	var pve *password.PolicyViolationError
+	if stderrors.As(err, &pve) {
+		desc = fmt.Sprintf("Password does not meet the password policy: %s.", pve.Error())
+	}
+	return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{field: desc})
+}
+
+func (s Service) unlockInRepo(ctx context.Context, id string) (*pb.Account, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.UnlockAccount(ctx, id); err != nil {
+		return nil, fmt.Errorf("unable to unlock account: %w", err)
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) setActiveInRepo(ctx context.Context, scopeId, id string, active bool) (*pb.Account, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.SetAccountActive(ctx, scopeId, id, active); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account %q doesn't exist.", id)
+		}
+		return nil, fmt.Errorf("unable to set account active state: %w", err)
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) enrollTotpInRepo(ctx context.Context, scopeId, id string) (*password.TotpEnrollment, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.EnrollTotp(ctx, scopeId, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
+		}
+		return nil, fmt.Errorf("unable to enroll account in totp: %w", err)
+	}
+	return out, nil
+}
+
+func (s Service) confirmTotpInRepo(ctx context.Context, scopeId, id, code string) (*pb.Account, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if err := repo.ConfirmTotp(ctx, scopeId, id, code); err != nil {
+		switch {
+		case errors.IsNotFoundError(err):
+			return nil, handlers.NotFoundErrorf("Account does not have a pending TOTP enrollment.")
+		case errors.Match(errors.T(errors.TotpInvalidCode), err):
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+				map[string]string{"code": "Invalid TOTP code."})
+		}
+		return nil, fmt.Errorf("unable to confirm totp: %w", err)
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) removeTotpInRepo(ctx context.Context, scopeId, id string) (*pb.Account, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.DeleteTotp(ctx, scopeId, id); err != nil {
+		return nil, fmt.Errorf("unable to remove totp: %w", err)
+	}
+	return s.getFromRepo(ctx, id)
+}
+
+func (s Service) createResetTokenInRepo(ctx context.Context, id string, ttl time.Duration) (*password.ResetToken, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateResetToken(ctx, id, password.WithResetTokenTtl(ttl))
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Account not found.")
+		}
+		return nil, fmt.Errorf("unable to create reset token: %w", err)
+	}
+	return out, nil
 }
 
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*password.AuthMethod, auth.VerifyResults) {
@@ -426,7 +695,7 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return authMeth, auth.Verify(ctx, opts...)
 }
 
-func toProto(in *password.Account) (*pb.Account, error) {
+func toProto(in *password.Account, lockout *password.AccountLockout, totp *password.AccountTotp) (*pb.Account, error) {
 	out := pb.Account{
 		Id:           in.GetPublicId(),
 		CreatedTime:  in.GetCreateTime().GetTimestamp(),
@@ -434,6 +703,7 @@ func toProto(in *password.Account) (*pb.Account, error) {
 		AuthMethodId: in.GetAuthMethodId(),
 		Version:      in.GetVersion(),
 		Type:         auth.PasswordSubtype.String(),
+		Active:       in.GetActive(),
 	}
 	if in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
@@ -441,7 +711,17 @@ func toProto(in *password.Account) (*pb.Account, error) {
 	if in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
-	if st, err := handlers.ProtoToStruct(&pb.PasswordAccountAttributes{LoginName: in.GetLoginName()}); err == nil {
+	attrs := &pb.PasswordAccountAttributes{LoginName: in.GetLoginName()}
+	if lockout != nil {
+		attrs.FailedAttemptCount = lockout.GetFailedAttemptCount()
+		attrs.LockoutCount = lockout.GetLockoutCount()
+		attrs.LastFailedAttemptTime = lockout.GetLastFailedAttemptTime().GetTimestamp()
+		attrs.LockedUntilTime = lockout.GetLockedUntilTime().GetTimestamp()
+	}
+	if totp != nil && totp.GetConfirmTime() != nil {
+		attrs.TotpEnabled = true
+	}
+	if st, err := handlers.ProtoToStruct(attrs); err == nil {
 		out.Attributes = st
 	} else {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
@@ -476,6 +756,9 @@ func validateCreateRequest(req *pbs.CreateAccountRequest) error {
 			if pwAttrs.GetLoginName() == "" {
 				badFields["login_name"] = "This is a required field for this type."
 			}
+			if pwAttrs.GetPassword() != nil && pwAttrs.GetPasswordHash() != nil {
+				badFields["attributes.password_hash"] = "Only one of password and password_hash can be set."
+			}
 		default:
 			badFields["auth_method_id"] = "Unknown auth method type from ID."
 		}
@@ -551,3 +834,86 @@ func validateSetPasswordRequest(req *pbs.SetPasswordRequest) error {
 	}
 	return nil
 }
+
+func validateUnlockRequest(req *pbs.UnlockAccountRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateDisableRequest(req *pbs.DisableAccountRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateEnableRequest(req *pbs.EnableAccountRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateEnrollTotpRequest(req *pbs.EnrollTotpRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateConfirmTotpRequest(req *pbs.ConfirmTotpRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if req.GetCode() == "" {
+		badFields["code"] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRemoveTotpRequest(req *pbs.RemoveTotpRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateCreateResetTokenRequest(req *pbs.CreateResetTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AccountPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if time.Duration(req.GetTtlSeconds())*time.Second > password.MaxResetTokenTtl {
+		badFields["ttl_seconds"] = fmt.Sprintf("Must not be greater than %d.", int(password.MaxResetTokenTtl.Seconds()))
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
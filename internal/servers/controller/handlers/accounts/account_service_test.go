@@ -1,10 +1,12 @@
 package accounts_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
@@ -57,7 +59,8 @@ func TestGet(t *testing.T) {
 		Version:           1,
 		Type:              "password",
 		Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
-		AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+		AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+		Active:            true,
 	}
 
 	cases := []struct {
@@ -130,7 +133,8 @@ func TestList(t *testing.T) {
 			Version:           1,
 			Type:              "password",
 			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
-			AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+			AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+			Active:            true,
 		})
 	}
 
@@ -145,7 +149,8 @@ func TestList(t *testing.T) {
 			Version:           1,
 			Type:              "password",
 			Attributes:        &structpb.Struct{Fields: map[string]*structpb.Value{"login_name": structpb.NewStringValue(aa.GetLoginName())}},
-			AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+			AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+			Active:            true,
 		})
 	}
 
@@ -363,7 +368,8 @@ func TestCreate(t *testing.T) {
 					Version:           1,
 					Type:              "password",
 					Attributes:        createAttr("validaccount", ""),
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -383,7 +389,8 @@ func TestCreate(t *testing.T) {
 					Version:           1,
 					Type:              "password",
 					Attributes:        createAttr("notypedefined", ""),
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -407,10 +414,64 @@ func TestCreate(t *testing.T) {
 					Version:           1,
 					Type:              "password",
 					Attributes:        createAttr("haspassword", ""),
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
+		{
+			name: "Create a valid Account with password hash defined",
+			req: &pbs.CreateAccountRequest{
+				Item: &pb.Account{
+					AuthMethodId: defaultAccount.GetAuthMethodId(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"login_name":    structpb.NewStringValue("haspasswordhash"),
+						"password_hash": structpb.NewStringValue("$pbkdf2-sha256$i=1000$c2FsdHNhbHQ$Kp/vyk5ELNeBAOkvw7l4+iQWFPj5OmRhprFmpEIfn0w"),
+					}},
+				},
+			},
+			res: &pbs.CreateAccountResponse{
+				Uri: fmt.Sprintf("accounts/%s_", password.AccountPrefix),
+				Item: &pb.Account{
+					AuthMethodId:      defaultAccount.GetAuthMethodId(),
+					Scope:             &scopepb.ScopeInfo{Id: o.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Version:           1,
+					Type:              "password",
+					Attributes:        createAttr("haspasswordhash", ""),
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
+				},
+			},
+		},
+		{
+			name: "Cant specify password and password hash",
+			req: &pbs.CreateAccountRequest{
+				Item: &pb.Account{
+					AuthMethodId: defaultAccount.GetAuthMethodId(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"login_name":    structpb.NewStringValue("passwordandhash"),
+						"password":      structpb.NewStringValue("somepassword"),
+						"password_hash": structpb.NewStringValue("$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"),
+					}},
+				},
+			},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Cant specify unsupported password hash",
+			req: &pbs.CreateAccountRequest{
+				Item: &pb.Account{
+					AuthMethodId: defaultAccount.GetAuthMethodId(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"login_name":    structpb.NewStringValue("badhash"),
+						"password_hash": structpb.NewStringValue("$md5$salt$hash"),
+					}},
+				},
+			},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Cant specify mismatching type",
 			req: &pbs.CreateAccountRequest{
@@ -578,7 +639,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        defaultAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -602,7 +664,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        defaultAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -672,7 +735,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        defaultAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -696,7 +760,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        defaultAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -720,7 +785,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        defaultAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -744,7 +810,8 @@ func TestUpdate(t *testing.T) {
 					Type:              "password",
 					Attributes:        modifiedAttributes,
 					Scope:             defaultScopeInfo,
-					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password"},
+					AuthorizedActions: []string{"read", "update", "delete", "set-password", "change-password", "unlock", "enroll-totp", "confirm-totp", "remove-totp", "create-reset-token", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -1129,3 +1196,179 @@ func TestChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUnlockAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+	repo, err := repoFn()
+	require.NoError(t, err)
+	_, err = repo.SetPassword(context.Background(), o.GetPublicId(), acct.GetPublicId(), "originalpassword", acct.GetVersion())
+	require.NoError(t, err)
+	got, err := repo.Authenticate(context.Background(), o.GetPublicId(), am.GetPublicId(), acct.GetLoginName(), "wrong password")
+	require.NoError(t, err)
+	require.Nil(t, got)
+
+	getResp, err := tested.GetAccount(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.GetAccountRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	attrs := getResp.GetItem().GetAttributes().GetFields()
+	assert.Equal(t, float64(1), attrs["failed_attempt_count"].GetNumberValue())
+	assert.NotEmpty(t, attrs["last_failed_attempt_time"].GetStringValue())
+
+	unlockResp, err := tested.UnlockAccount(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.UnlockAccountRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	attrs = unlockResp.GetItem().GetAttributes().GetFields()
+	assert.Nil(t, attrs["failed_attempt_count"])
+	assert.Nil(t, attrs["locked_until_time"])
+
+	_, err = tested.UnlockAccount(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.UnlockAccountRequest{Id: "bad_id"})
+	assert.Error(t, err)
+}
+
+func TestDisableEnableAccount(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	at := authtoken.TestAuthToken(t, conn, kms, o.GetPublicId())
+	repo, err := repoFn()
+	require.NoError(t, err)
+	_, err = repo.SetPassword(context.Background(), o.GetPublicId(), at.GetAuthAccountId(), "originalpassword", 1)
+	require.NoError(t, err)
+	acct, err := repo.LookupAccount(context.Background(), at.GetAuthAccountId())
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId())
+
+	disableResp, err := tested.DisableAccount(ctx, &pbs.DisableAccountRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	assert.False(t, disableResp.GetItem().GetActive())
+
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	gotAt, err := atRepo.LookupAuthToken(context.Background(), at.GetPublicId())
+	require.NoError(t, err)
+	assert.Nil(t, gotAt, "the auth token of a disabled account should be deleted")
+
+	got, err := repo.Authenticate(context.Background(), o.GetPublicId(), acct.GetAuthMethodId(), acct.GetLoginName(), "originalpassword")
+	require.NoError(t, err)
+	assert.Nil(t, got, "a disabled account should not authenticate")
+
+	enableResp, err := tested.EnableAccount(ctx, &pbs.EnableAccountRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	assert.True(t, enableResp.GetItem().GetActive())
+
+	got, err = repo.Authenticate(context.Background(), o.GetPublicId(), acct.GetAuthMethodId(), acct.GetLoginName(), "originalpassword")
+	require.NoError(t, err)
+	assert.NotNil(t, got)
+
+	_, err = tested.DisableAccount(ctx, &pbs.DisableAccountRequest{Id: "bad_id"})
+	assert.Error(t, err)
+	_, err = tested.EnableAccount(ctx, &pbs.EnableAccountRequest{Id: "bad_id"})
+	assert.Error(t, err)
+}
+
+func TestTotp(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+
+	enrollResp, err := tested.EnrollTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.EnrollTotpRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollResp.GetTotpSecret())
+	assert.Contains(t, enrollResp.GetTotpUrl(), "otpauth://totp/")
+	assert.NotEmpty(t, enrollResp.GetRecoveryCodes())
+	assert.Nil(t, enrollResp.GetItem().GetAttributes().GetFields()["totp_enabled"])
+
+	_, err = tested.ConfirmTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.ConfirmTotpRequest{Id: acct.GetPublicId(), Code: "000000"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v, wanted InvalidArgument", err)
+
+	code := password.TestTotpCode(t, enrollResp.GetTotpSecret(), time.Now())
+	confirmResp, err := tested.ConfirmTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.ConfirmTotpRequest{Id: acct.GetPublicId(), Code: code})
+	require.NoError(t, err)
+	assert.True(t, confirmResp.GetItem().GetAttributes().GetFields()["totp_enabled"].GetBoolValue())
+
+	removeResp, err := tested.RemoveTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.RemoveTotpRequest{Id: acct.GetPublicId()})
+	require.NoError(t, err)
+	assert.Nil(t, removeResp.GetItem().GetAttributes().GetFields()["totp_enabled"])
+
+	_, err = tested.ConfirmTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.ConfirmTotpRequest{Id: "bad_id"})
+	assert.Error(t, err)
+}
+
+func TestCreateResetToken(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.NewRepository(rw, rw, kms)
+	}
+
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	tested, err := accounts.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new auth_method service.")
+
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+
+	resp, err := tested.CreateResetToken(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.CreateResetTokenRequest{Id: acct.GetPublicId(), TtlSeconds: 600})
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetResetToken())
+	assert.Equal(t, acct.GetPublicId(), resp.GetItem().GetId())
+	exp, err := ptypes.Timestamp(resp.GetExpirationTime())
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), exp, time.Minute)
+
+	_, err = tested.CreateResetToken(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.CreateResetTokenRequest{Id: acct.GetPublicId(), TtlSeconds: 30 * 24 * 60 * 60})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v, wanted InvalidArgument", err)
+
+	_, err = tested.CreateResetToken(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.CreateResetTokenRequest{Id: "bad_id"})
+	assert.Error(t, err)
+}
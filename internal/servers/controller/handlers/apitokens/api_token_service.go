@@ -0,0 +1,524 @@
+package apitokens
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/apitokens"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Delete,
+		action.Rotate,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.ApiTokenServiceServer interface.
+type Service struct {
+	pbs.UnimplementedApiTokenServiceServer
+
+	kms       *kms.Kms
+	repoFn    common.AuthTokenRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns an api token service which handles api token related requests to boundary.
+func NewService(kms *kms.Kms, repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if kms == nil {
+		return Service{}, stderrors.New("nil kms provided")
+	}
+	if repo == nil {
+		return Service{}, fmt.Errorf("nil auth token repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{kms: kms, repoFn: repo, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.ApiTokenServiceServer = Service{}
+
+// ListApiTokens implements the interface pbs.ApiTokenServiceServer.
+func (s Service) ListApiTokens(ctx context.Context, req *pbs.ListApiTokensRequest) (*pbs.ListApiTokensResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		// If it's forbidden, and it's a recursive request, and they're
+		// successfully authenticated but just not authorized, keep going as we
+		// may have authorization on downstream scopes.
+		if authResults.Error == handlers.ForbiddenError() &&
+			req.GetRecursive() &&
+			authResults.Authenticated {
+		} else {
+			return nil, authResults.Error
+		}
+	}
+
+	scopeIds, scopeInfoMap, err := scopeids.GetListingScopeIds(
+		ctx, s.iamRepoFn, authResults, req.GetScopeId(), resource.ApiToken, req.GetRecursive(), false)
+	if err != nil {
+		return nil, err
+	}
+	// If no scopes match, return an empty response
+	if len(scopeIds) == 0 {
+		return &pbs.ListApiTokensResponse{}, nil
+	}
+
+	ul, err := s.listFromRepo(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.ApiToken, 0, len(ul))
+	res := &perms.Resource{
+		Type: resource.ApiToken,
+	}
+	for _, item := range ul {
+		item.Scope = scopeInfoMap[item.GetScopeId()]
+		res.ScopeId = item.Scope.Id
+		item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithResource(res)).Strings()
+		if len(item.AuthorizedActions) == 0 {
+			continue
+		}
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListApiTokensResponse{Items: finalItems}, nil
+}
+
+// GetApiToken implements the interface pbs.ApiTokenServiceServer.
+func (s Service) GetApiToken(ctx context.Context, req *pbs.GetApiTokenRequest) (*pbs.GetApiTokenResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.GetApiTokenResponse{Item: u}, nil
+}
+
+// CreateApiToken implements the interface pbs.ApiTokenServiceServer.
+func (s Service) CreateApiToken(ctx context.Context, req *pbs.CreateApiTokenRequest) (*pbs.CreateApiTokenResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.validateServiceAccount(ctx, authResults.Scope.GetId(), req.GetItem().GetUserId()); err != nil {
+		return nil, err
+	}
+	u, err := s.createInRepo(ctx, req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.CreateApiTokenResponse{Item: u, Uri: fmt.Sprintf("api-tokens/%s", u.GetId())}, nil
+}
+
+// DeleteApiToken implements the interface pbs.ApiTokenServiceServer.
+func (s Service) DeleteApiToken(ctx context.Context, req *pbs.DeleteApiTokenRequest) (*pbs.DeleteApiTokenResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteApiTokenResponse{}, nil
+}
+
+// RotateApiToken implements the interface pbs.ApiTokenServiceServer.
+func (s Service) RotateApiToken(ctx context.Context, req *pbs.RotateApiTokenRequest) (*pbs.RotateApiTokenResponse, error) {
+	if err := validateRotateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Rotate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.rotateInRepo(ctx, req.GetId(), req.GetExpirationTime())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.RotateApiTokenResponse{Item: u}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.ApiToken, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	u, grants, err := repo.LookupApiToken(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("ApiToken %q doesn't exist.", id)
+		}
+		return nil, fmt.Errorf("unable to lookup api token: %w", err)
+	}
+	if u == nil {
+		return nil, handlers.NotFoundErrorf("ApiToken %q doesn't exist.", id)
+	}
+	return toProto(u, grants), nil
+}
+
+func (s Service) createInRepo(ctx context.Context, item *pb.ApiToken) (*pb.ApiToken, error) {
+	var opts []authtoken.Option
+	if item.GetName() != nil {
+		opts = append(opts, authtoken.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, authtoken.WithDescription(item.GetDescription().GetValue()))
+	}
+	if item.GetGrantScopeId() != "" {
+		opts = append(opts, authtoken.WithGrantScopeId(item.GetGrantScopeId()))
+	}
+	at, err := authtoken.NewApiToken(item.GetUserId(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build api token for creation: %v.", err)
+	}
+	var createOpts []authtoken.Option
+	if item.GetExpirationTime() != nil {
+		exp, err := ptypes.Timestamp(item.GetExpirationTime())
+		if err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"expiration_time": "Invalid timestamp."})
+		}
+		createOpts = append(createOpts, authtoken.WithExpirationTime(exp))
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, grants, err := repo.CreateApiToken(ctx, at, item.GetGrantStrings(), createOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create api token: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create api token but no error returned from repository.")
+	}
+	if err := s.encryptToken(ctx, out); err != nil {
+		return nil, err
+	}
+	return toProto(out, grants), nil
+}
+
+func (s Service) rotateInRepo(ctx context.Context, id string, expiration *timestamppb.Timestamp) (*pb.ApiToken, error) {
+	var opts []authtoken.Option
+	if expiration != nil {
+		exp, err := ptypes.Timestamp(expiration)
+		if err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"expiration_time": "Invalid timestamp."})
+		}
+		opts = append(opts, authtoken.WithExpirationTime(exp))
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.RotateApiToken(ctx, id, opts...)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("ApiToken %q doesn't exist.", id)
+		}
+		return nil, fmt.Errorf("unable to rotate api token: %w", err)
+	}
+	if err := s.encryptToken(ctx, out); err != nil {
+		return nil, err
+	}
+	_, grants, err := repo.LookupApiToken(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to lookup api token: %w", err)
+	}
+	return toProto(out, grants), nil
+}
+
+// encryptToken replaces the value of the api token with the value a client
+// presents as a bearer token, in the same format as auth tokens.
+func (s Service) encryptToken(ctx context.Context, at *authtoken.ApiToken) error {
+	token, err := authtoken.EncryptToken(ctx, s.kms, at.GetScopeId(), at.GetPublicId(), at.GetToken())
+	if err != nil {
+		return err
+	}
+	at.Token = at.GetPublicId() + "_" + token
+	return nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteApiToken(ctx, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to delete api token: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*pb.ApiToken, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ul, err := repo.ListApiTokens(ctx, scopeIds)
+	if err != nil {
+		return nil, err
+	}
+	var outUl []*pb.ApiToken
+	for _, u := range ul {
+		outUl = append(outUl, toProto(u, nil))
+	}
+	return outUl, nil
+}
+
+// validateServiceAccount ensures the user an api token is being issued to is
+// a service account in the scope of the request.
+func (s Service) validateServiceAccount(ctx context.Context, scopeId, userId string) error {
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return err
+	}
+	u, _, err := iamRepo.LookupUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	switch {
+	case u == nil, u.GetScopeId() != scopeId:
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"user_id": "Must be the ID of a service account in this scope."})
+	case !u.GetServiceAccount():
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"user_id": "API Tokens can only be issued to service accounts."})
+	}
+	return nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.ApiToken), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	default:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		apiTok, _, err := repo.LookupApiToken(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if apiTok == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = apiTok.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *authtoken.ApiToken, grants []*authtoken.ApiTokenGrant) *pb.ApiToken {
+	out := pb.ApiToken{
+		Id:                      in.GetPublicId(),
+		ScopeId:                 in.GetScopeId(),
+		UserId:                  in.GetIamUserId(),
+		GrantScopeId:            in.GetGrantScopeId(),
+		Token:                   in.GetToken(),
+		CreatedTime:             in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:             in.GetUpdateTime().GetTimestamp(),
+		ApproximateLastUsedTime: in.GetApproximateLastAccessTime().GetTimestamp(),
+		ExpirationTime:          in.GetExpirationTime().GetTimestamp(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	for _, g := range grants {
+		out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetApiTokenRequest) error {
+	return handlers.ValidateGetRequest(authtoken.ApiTokenPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateApiTokenRequest) error {
+	badFields := map[string]string{}
+	item := req.GetItem()
+	if item.GetId() != "" {
+		badFields["id"] = "This is a read only field."
+	}
+	if !handlers.ValidId(scope.Org.Prefix(), item.GetScopeId()) &&
+		scope.Global.String() != item.GetScopeId() {
+		badFields["scope_id"] = "Must be 'global' or a valid org scope id."
+	}
+	if !handlers.ValidId(iam.UserPrefix, item.GetUserId()) {
+		badFields["user_id"] = "This field is missing or improperly formatted."
+	}
+	if item.GetName() != nil && !handlers.ValidNameDescription(item.GetName().GetValue()) {
+		badFields["name"] = "Name contains unprintable characters"
+	}
+	if item.GetDescription() != nil && !handlers.ValidNameDescription(item.GetDescription().GetValue()) {
+		badFields["description"] = "Description contains unprintable characters"
+	}
+	if gsId := item.GetGrantScopeId(); gsId != "" &&
+		!handlers.ValidId(scope.Org.Prefix(), gsId) &&
+		!handlers.ValidId(scope.Project.Prefix(), gsId) &&
+		gsId != scope.Global.String() {
+		badFields["grant_scope_id"] = "Improperly formatted identifier."
+	}
+	for _, g := range item.GetGrantStrings() {
+		if _, err := perms.Parse("o_abcd1234", g); err != nil {
+			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q: %v.", g, err)
+			break
+		}
+	}
+	if item.GetToken() != "" {
+		badFields["token"] = "This is a read only field."
+	}
+	if item.GetCreatedTime() != nil {
+		badFields["created_time"] = "This is a read only field."
+	}
+	if item.GetUpdatedTime() != nil {
+		badFields["updated_time"] = "This is a read only field."
+	}
+	if item.GetApproximateLastUsedTime() != nil {
+		badFields["approximate_last_used_time"] = "This is a read only field."
+	}
+	if msg := validateExpirationTime(item.GetExpirationTime()); msg != "" {
+		badFields["expiration_time"] = msg
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateDeleteRequest(req *pbs.DeleteApiTokenRequest) error {
+	return handlers.ValidateDeleteRequest(authtoken.ApiTokenPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateRotateRequest(req *pbs.RotateApiTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(authtoken.ApiTokenPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if msg := validateExpirationTime(req.GetExpirationTime()); msg != "" {
+		badFields["expiration_time"] = msg
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListRequest(req *pbs.ListApiTokensRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "This field must be 'global' or a valid org scope id."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateExpirationTime(in *timestamppb.Timestamp) string {
+	if in == nil {
+		return ""
+	}
+	exp, err := ptypes.Timestamp(in)
+	if err != nil {
+		return "Invalid timestamp."
+	}
+	if !exp.After(time.Now()) {
+		return "Must be in the future."
+	}
+	return ""
+}
@@ -0,0 +1,339 @@
+package apitokens_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/apitokens"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/apitokens"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	s, err := apitokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new api token service.")
+
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	at := authtoken.TestApiToken(t, conn, kms, org.GetPublicId(), "id=*;type=target;actions=authorize-session")
+
+	wireApiToken := pb.ApiToken{
+		Id:                      at.GetPublicId(),
+		ScopeId:                 at.GetScopeId(),
+		UserId:                  at.GetIamUserId(),
+		GrantScopeId:            at.GetGrantScopeId(),
+		GrantStrings:            []string{"id=*;type=target;actions=authorize-session"},
+		CreatedTime:             at.GetCreateTime().GetTimestamp(),
+		UpdatedTime:             at.GetUpdateTime().GetTimestamp(),
+		ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
+		ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
+		Scope:                   &scopes.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+		AuthorizedActions:       []string{"read", "delete", "rotate"},
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.GetApiTokenRequest
+		res  *pbs.GetApiTokenResponse
+		err  error
+	}{
+		{
+			name: "Get an existing api token",
+			req:  &pbs.GetApiTokenRequest{Id: wireApiToken.GetId()},
+			res:  &pbs.GetApiTokenResponse{Item: &wireApiToken},
+		},
+		{
+			name: "Get a non existing api token",
+			req:  &pbs.GetApiTokenRequest{Id: authtoken.ApiTokenPrefix + "_DoesntExis"},
+			res:  nil,
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Wrong id prefix",
+			req:  &pbs.GetApiTokenRequest{Id: authtoken.AuthTokenPrefix + "_1234567890"},
+			res:  nil,
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.GetApiToken(auth.DisabledAuthTestContext(iamRepoFn, org.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "GetApiToken(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.Empty(cmp.Diff(got, tc.res, protocmp.Transform()), "GetApiToken(%q) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	s, err := apitokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new api token service.")
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	sa := iam.TestUser(t, iamRepo, org.GetPublicId(), iam.WithServiceAccount(true))
+	u := iam.TestUser(t, iamRepo, org.GetPublicId())
+	otherOrg, _ := iam.TestScopes(t, iamRepo)
+	otherSa := iam.TestUser(t, iamRepo, otherOrg.GetPublicId(), iam.WithServiceAccount(true))
+
+	cases := []struct {
+		name             string
+		item             *pb.ApiToken
+		wantGrantScopeId string
+		err              error
+	}{
+		{
+			name: "Create a valid api token",
+			item: &pb.ApiToken{
+				ScopeId: org.GetPublicId(),
+				UserId:  sa.GetPublicId(),
+				Name:    &wrapperspb.StringValue{Value: "ci"},
+			},
+			wantGrantScopeId: org.GetPublicId(),
+		},
+		{
+			name: "Create a restricted api token",
+			item: &pb.ApiToken{
+				ScopeId:        org.GetPublicId(),
+				UserId:         sa.GetPublicId(),
+				GrantScopeId:   proj.GetPublicId(),
+				GrantStrings:   []string{"id=*;type=target;actions=authorize-session"},
+				ExpirationTime: timestampIn(t, time.Hour),
+			},
+			wantGrantScopeId: proj.GetPublicId(),
+		},
+		{
+			name: "Not a service account",
+			item: &pb.ApiToken{ScopeId: org.GetPublicId(), UserId: u.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing user id",
+			item: &pb.ApiToken{ScopeId: org.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Service account in another scope",
+			item: &pb.ApiToken{ScopeId: org.GetPublicId(), UserId: otherSa.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad grant",
+			item: &pb.ApiToken{
+				ScopeId:      org.GetPublicId(),
+				UserId:       sa.GetPublicId(),
+				GrantStrings: []string{"id=*;actions=read"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Expiration in the past",
+			item: &pb.ApiToken{
+				ScopeId:        org.GetPublicId(),
+				UserId:         sa.GetPublicId(),
+				ExpirationTime: timestampIn(t, -time.Hour),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Can't specify token",
+			item: &pb.ApiToken{
+				ScopeId: org.GetPublicId(),
+				UserId:  sa.GetPublicId(),
+				Token:   "token",
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.CreateApiToken(auth.DisabledAuthTestContext(iamRepoFn, org.GetPublicId()), &pbs.CreateApiTokenRequest{Item: tc.item})
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "CreateApiToken(%+v) got error %v, wanted %v", tc.item, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.True(strings.HasPrefix(item.GetId(), authtoken.ApiTokenPrefix+"_"))
+			assert.Equal("api-tokens/"+item.GetId(), got.GetUri())
+			assert.True(strings.HasPrefix(item.GetToken(), item.GetId()+"_"))
+			assert.Equal(sa.GetPublicId(), item.GetUserId())
+			assert.Equal(org.GetPublicId(), item.GetScopeId())
+			assert.Equal(tc.wantGrantScopeId, item.GetGrantScopeId())
+			assert.Equal(tc.item.GetGrantStrings(), item.GetGrantStrings())
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	repo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+	repoFn := func() (*authtoken.Repository, error) {
+		return repo, nil
+	}
+
+	s, err := apitokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new api token service.")
+
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	at := authtoken.TestApiToken(t, conn, kms, org.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.RotateApiTokenRequest
+		err  error
+	}{
+		{
+			name: "Rotate an existing api token",
+			req:  &pbs.RotateApiTokenRequest{Id: at.GetPublicId()},
+		},
+		{
+			name: "Rotate with a new expiration time",
+			req:  &pbs.RotateApiTokenRequest{Id: at.GetPublicId(), ExpirationTime: timestampIn(t, 24*time.Hour)},
+		},
+		{
+			name: "Expiration in the past",
+			req:  &pbs.RotateApiTokenRequest{Id: at.GetPublicId(), ExpirationTime: timestampIn(t, -time.Hour)},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Rotate a non existing api token",
+			req:  &pbs.RotateApiTokenRequest{Id: authtoken.ApiTokenPrefix + "_DoesntExis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.RotateApiToken(auth.DisabledAuthTestContext(iamRepoFn, org.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RotateApiToken(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.True(strings.HasPrefix(got.GetItem().GetToken(), at.GetPublicId()+"_"))
+			if tc.req.GetExpirationTime() != nil {
+				assert.Equal(tc.req.GetExpirationTime().GetSeconds(), got.GetItem().GetExpirationTime().GetSeconds())
+			}
+
+			// the original value of the api token is no longer valid
+			old, _, err := repo.ValidateApiToken(context.Background(), at.GetPublicId(), at.GetToken())
+			require.NoError(err)
+			assert.Nil(old)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrap), nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+
+	s, err := apitokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new api token service.")
+
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
+	at := authtoken.TestApiToken(t, conn, kms, org.GetPublicId())
+
+	cases := []struct {
+		name string
+		req  *pbs.DeleteApiTokenRequest
+		res  *pbs.DeleteApiTokenResponse
+		err  error
+	}{
+		{
+			name: "Delete an existing api token",
+			req:  &pbs.DeleteApiTokenRequest{Id: at.GetPublicId()},
+			res:  &pbs.DeleteApiTokenResponse{},
+		},
+		{
+			name: "Delete a non existing api token",
+			req:  &pbs.DeleteApiTokenRequest{Id: authtoken.ApiTokenPrefix + "_doesntexis"},
+			err:  handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name: "Bad id formatting",
+			req:  &pbs.DeleteApiTokenRequest{Id: "bad_format"},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.DeleteApiToken(auth.DisabledAuthTestContext(iamRepoFn, org.GetPublicId()), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeleteApiToken(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			}
+			assert.EqualValuesf(tc.res, got, "DeleteApiToken(%+v) got response %q, wanted %q", tc.req, got, tc.res)
+		})
+	}
+}
+
+func timestampIn(t *testing.T, d time.Duration) *timestamppb.Timestamp {
+	t.Helper()
+	ts, err := ptypes.TimestampProto(time.Now().Add(d))
+	require.NoError(t, err)
+	return ts
+}
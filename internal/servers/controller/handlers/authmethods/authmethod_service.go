@@ -17,11 +17,13 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/accounts"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/invites"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
@@ -33,8 +35,14 @@ import (
 )
 
 const (
-	loginNameKey = "login_name"
-	pwKey        = "password"
+	loginNameKey    = "login_name"
+	pwKey           = "password"
+	totpCodeKey     = "totp_code"
+	recoveryCodeKey = "recovery_code"
+	newPasswordKey  = "new_password"
+
+	mfaPolicyOptional = "optional"
+	mfaPolicyRequired = "required"
 )
 
 var (
@@ -58,6 +66,7 @@ var (
 
 	collectionTypeMap = map[resource.Type]action.ActionSet{
 		resource.Account: accounts.CollectionActions,
+		resource.Invite:  invites.CollectionActions,
 	}
 )
 
@@ -103,6 +112,7 @@ type Service struct {
 	pwRepoFn  common.PasswordAuthRepoFactory
 	iamRepoFn common.IamRepoFactory
 	atRepoFn  common.AuthTokenRepoFactory
+	throttle  *authThrottle
 }
 
 // NewService returns a auth method service which handles auth method related requests to boundary.
@@ -116,7 +126,13 @@ func NewService(kms *kms.Kms, pwRepoFn common.PasswordAuthRepoFactory, iamRepoFn
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{kms: kms, pwRepoFn: pwRepoFn, iamRepoFn: iamRepoFn, atRepoFn: atRepoFn}, nil
+	return Service{
+		kms:       kms,
+		pwRepoFn:  pwRepoFn,
+		iamRepoFn: iamRepoFn,
+		atRepoFn:  atRepoFn,
+		throttle:  newAuthThrottle(maxFailedAuthentications, failedAuthenticationWindow),
+	}, nil
 }
 
 var _ pbs.AuthMethodServiceServer = Service{}
@@ -267,7 +283,9 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 		return nil, authResults.Error
 	}
 	attrs := req.GetAttributes().GetFields()
-	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), attrs[loginNameKey].GetStringValue(), attrs[pwKey].GetStringValue())
+	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), attrs[loginNameKey].GetStringValue(), attrs[pwKey].GetStringValue(),
+		password.WithTotpCode(attrs[totpCodeKey].GetStringValue()), password.WithRecoveryCode(attrs[recoveryCodeKey].GetStringValue()),
+		password.WithNewPassword(attrs[newPasswordKey].GetStringValue()))
 	if err != nil {
 		return nil, err
 	}
@@ -289,7 +307,9 @@ func (s Service) AuthenticateLogin(ctx context.Context, req *pbs.AuthenticateLog
 		return nil, authResults.Error
 	}
 	creds := req.GetCredentials().GetFields()
-	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue(),
+		password.WithTotpCode(creds[totpCodeKey].GetStringValue()), password.WithRecoveryCode(creds[recoveryCodeKey].GetStringValue()),
+		password.WithNewPassword(creds[newPasswordKey].GetStringValue()))
 	if err != nil {
 		return nil, err
 	}
@@ -301,6 +321,60 @@ func (s Service) AuthenticateLogin(ctx context.Context, req *pbs.AuthenticateLog
 	return &pbs.AuthenticateLoginResponse{Item: tok, TokenType: req.GetTokenType()}, nil
 }
 
+// ResetPassword implements the interface pbs.AuthMethodServiceServer. It
+// does not require an authenticated user and is authorized with the
+// authenticate action.
+func (s Service) ResetPassword(ctx context.Context, req *pbs.ResetPasswordRequest) (*pbs.ResetPasswordResponse, error) {
+	if err := validateResetPasswordRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.resetPasswordInRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), req.GetToken(), req.GetNewPassword()); err != nil {
+		return nil, err
+	}
+	return &pbs.ResetPasswordResponse{}, nil
+}
+
+// RedeemInvite implements the interface pbs.AuthMethodServiceServer. It
+// does not require an authenticated user and is authorized with the
+// authenticate action.
+func (s Service) RedeemInvite(ctx context.Context, req *pbs.RedeemInviteRequest) (*pbs.RedeemInviteResponse, error) {
+	if err := validateRedeemInviteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	return s.redeemInviteInRepo(ctx, authResults.Scope.GetId(), req)
+}
+
+// EnrollAccountTotp implements the interface pbs.AuthMethodServiceServer. It
+// does not require an authenticated user and is authorized with the
+// authenticate action. The TOTP secret is only returned to the holder of the
+// password of the account.
+func (s Service) EnrollAccountTotp(ctx context.Context, req *pbs.EnrollAccountTotpRequest) (*pbs.EnrollAccountTotpResponse, error) {
+	if err := validateEnrollAccountTotpRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetAuthMethodId(), action.Authenticate)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	out, err := s.enrollTotpInRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), req.GetLoginName(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.EnrollAccountTotpResponse{
+		TotpSecret:    out.Secret,
+		TotpUrl:       out.Url,
+		RecoveryCodes: out.RecoveryCodes,
+	}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, error) {
 	repo, err := s.pwRepoFn()
 	if err != nil {
@@ -351,6 +425,25 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Auth
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build auth method for creation: %v.", err)
 	}
+	pwAttrs := &pb.PasswordAuthMethodAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), pwAttrs); err != nil {
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes": "Attribute fields do not match the expected format."})
+	}
+	u.AuthTokenTimeToLiveSeconds = pwAttrs.GetAuthTokenTimeToLiveSeconds()
+	u.AuthTokenTimeToStaleSeconds = pwAttrs.GetAuthTokenTimeToStaleSeconds()
+	u.LockoutThreshold = pwAttrs.GetLockoutThreshold()
+	u.LockoutDurationSeconds = pwAttrs.GetLockoutDurationSeconds()
+	u.MfaPolicy = pwAttrs.GetMfaPolicy()
+	u.PasswordRequireUppercase = pwAttrs.GetPasswordRequireUppercase()
+	u.PasswordRequireLowercase = pwAttrs.GetPasswordRequireLowercase()
+	u.PasswordRequireDigit = pwAttrs.GetPasswordRequireDigit()
+	u.PasswordRequireSymbol = pwAttrs.GetPasswordRequireSymbol()
+	u.PasswordHistoryCount = pwAttrs.GetPasswordHistoryCount()
+	u.MaxPasswordAgeSeconds = pwAttrs.GetMaxPasswordAgeSeconds()
+	u.BannedPasswords = pwAttrs.GetBannedPasswords()
+	u.AllowedClientCidrs = pwAttrs.GetAllowedClientCidrs()
+	u.DeniedClientCidrs = pwAttrs.GetDeniedClientCidrs()
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
@@ -389,6 +482,20 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if pwAttrs.GetMinPasswordLength() != 0 {
 		u.MinPasswordLength = pwAttrs.GetMinPasswordLength()
 	}
+	u.AuthTokenTimeToLiveSeconds = pwAttrs.GetAuthTokenTimeToLiveSeconds()
+	u.AuthTokenTimeToStaleSeconds = pwAttrs.GetAuthTokenTimeToStaleSeconds()
+	u.LockoutThreshold = pwAttrs.GetLockoutThreshold()
+	u.LockoutDurationSeconds = pwAttrs.GetLockoutDurationSeconds()
+	u.MfaPolicy = pwAttrs.GetMfaPolicy()
+	u.PasswordRequireUppercase = pwAttrs.GetPasswordRequireUppercase()
+	u.PasswordRequireLowercase = pwAttrs.GetPasswordRequireLowercase()
+	u.PasswordRequireDigit = pwAttrs.GetPasswordRequireDigit()
+	u.PasswordRequireSymbol = pwAttrs.GetPasswordRequireSymbol()
+	u.PasswordHistoryCount = pwAttrs.GetPasswordHistoryCount()
+	u.MaxPasswordAgeSeconds = pwAttrs.GetMaxPasswordAgeSeconds()
+	u.BannedPasswords = pwAttrs.GetBannedPasswords()
+	u.AllowedClientCidrs = pwAttrs.GetAllowedClientCidrs()
+	u.DeniedClientCidrs = pwAttrs.GetDeniedClientCidrs()
 	version := item.GetVersion()
 
 	u.PublicId = id
@@ -425,7 +532,7 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*pba.AuthToken, error) {
+func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string, opt ...password.Option) (*pba.AuthToken, error) {
 	iamRepo, err := s.iamRepoFn()
 	if err != nil {
 		return nil, err
@@ -439,11 +546,48 @@ func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId
 		return nil, err
 	}
 
-	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw)
+	clientIp := auth.ClientIpFromContext(ctx)
+	if !s.throttle.allowed(clientIp) {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many failed authentication attempts, try again later.")
+	}
+	// The client address respects the trusted X-Forwarded-For settings of the
+	// listener the request was received on.
+	am, err := pwRepo.LookupAuthMethod(ctx, authMethodId)
 	if err != nil {
 		return nil, err
 	}
+	if am != nil && !am.ClientAllowed(clientIp) {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Authentication is not allowed from this address.")
+	}
+	acct, err := pwRepo.Authenticate(ctx, scopeId, authMethodId, loginName, pw, opt...)
+	switch {
+	case errors.Match(errors.T(errors.TotpCodeRequired), err):
+		return nil, handlers.InvalidArgumentErrorf("A second authentication factor is required.",
+			map[string]string{"attributes." + totpCodeKey: "A TOTP code or a recovery code is required for this account."})
+	case errors.Match(errors.T(errors.TotpEnrollmentRequired), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "This account must enroll in TOTP before it can authenticate, using the enroll-totp action of the auth method.")
+	case errors.Match(errors.T(errors.PasswordExpired), err):
+		return nil, handlers.InvalidArgumentErrorf("The password has expired and must be changed.",
+			map[string]string{"attributes." + newPasswordKey: "A new password is required for this account."})
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes." + newPasswordKey: "Password is too short."})
+	case errors.Match(errors.T(errors.PasswordsEqual), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes." + newPasswordKey: "New password equal to current password."})
+	case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+		desc := "Password does not meet the password policy."
+		var pve *password.PolicyViolationError
+		if stderrors.As(err, &pve) {
+			desc = fmt.Sprintf("Password does not meet the password policy: %s.", pve.Error())
+		}
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{"attributes." + newPasswordKey: desc})
+	case err != nil:
+		return nil, err
+	}
 	if acct == nil {
+		s.throttle.failed(clientIp)
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
 	}
 
@@ -451,6 +595,10 @@ func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId
 	if err != nil {
 		return nil, err
 	}
+	if !u.GetActive() {
+		s.throttle.failed(clientIp)
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
 	tok, err := atRepo.CreateAuthToken(ctx, u, acct.GetPublicId())
 	if err != nil {
 		return nil, err
@@ -480,6 +628,109 @@ func (s Service) authenticateWithRepo(ctx context.Context, scopeId, authMethodId
 	return prot, nil
 }
 
+func (s Service) resetPasswordInRepo(ctx context.Context, scopeId, authMethodId, token, newPassword string) error {
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return err
+	}
+	clientIp := auth.ClientIpFromContext(ctx)
+	if !s.throttle.allowed(clientIp) {
+		return handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many failed authentication attempts, try again later.")
+	}
+	_, err = repo.ResetPassword(ctx, scopeId, authMethodId, token, newPassword)
+	switch {
+	case errors.Match(errors.T(errors.PasswordResetTokenInvalid), err):
+		s.throttle.failed(clientIp)
+		return handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Invalid or expired reset token.")
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{newPasswordKey: "Password is too short."})
+	case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+		desc := "Password does not meet the password policy."
+		var pve *password.PolicyViolationError
+		if stderrors.As(err, &pve) {
+			desc = fmt.Sprintf("Password does not meet the password policy: %s.", pve.Error())
+		}
+		return handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{newPasswordKey: desc})
+	case err != nil:
+		return fmt.Errorf("unable to reset password: %w", err)
+	}
+	return nil
+}
+
+func (s Service) redeemInviteInRepo(ctx context.Context, scopeId string, req *pbs.RedeemInviteRequest) (*pbs.RedeemInviteResponse, error) {
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	clientIp := auth.ClientIpFromContext(ctx)
+	if !s.throttle.allowed(clientIp) {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many failed authentication attempts, try again later.")
+	}
+	acct, user, err := repo.RedeemInvite(ctx, scopeId, req.GetAuthMethodId(), req.GetToken(), req.GetLoginName(), req.GetPassword(), password.WithName(req.GetName()))
+	switch {
+	case errors.Match(errors.T(errors.PasswordInviteInvalid), err):
+		s.throttle.failed(clientIp)
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Invalid, expired or already redeemed invite.")
+	case errors.Match(errors.T(errors.NotUnique), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{loginNameKey: "Login name or user name is already in use."})
+	case errors.Match(errors.T(errors.TooShort), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{loginNameKey: "Login name is too short."})
+	case errors.Match(errors.T(errors.InvalidParameter), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{loginNameKey: "Login name must be all-lowercase alphanumeric, period or hyphen."})
+	case errors.Match(errors.T(errors.PasswordTooShort), err):
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{pwKey: "Password is too short."})
+	case errors.Match(errors.T(errors.PasswordPolicyViolation), err):
+		desc := "Password does not meet the password policy."
+		var pve *password.PolicyViolationError
+		if stderrors.As(err, &pve) {
+			desc = fmt.Sprintf("Password does not meet the password policy: %s.", pve.Error())
+		}
+		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
+			map[string]string{pwKey: desc})
+	case err != nil:
+		return nil, fmt.Errorf("unable to redeem invite: %w", err)
+	}
+	return &pbs.RedeemInviteResponse{AccountId: acct.GetPublicId(), UserId: user.GetPublicId()}, nil
+}
+
+func (s Service) enrollTotpInRepo(ctx context.Context, scopeId, authMethodId, loginName, pw string) (*password.TotpEnrollment, error) {
+	repo, err := s.pwRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	clientIp := auth.ClientIpFromContext(ctx)
+	if !s.throttle.allowed(clientIp) {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "Too many failed authentication attempts, try again later.")
+	}
+	am, err := repo.LookupAuthMethod(ctx, authMethodId)
+	if err != nil {
+		return nil, err
+	}
+	if am != nil && !am.ClientAllowed(clientIp) {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Authentication is not allowed from this address.")
+	}
+	out, err := repo.EnrollTotpWithPassword(ctx, scopeId, authMethodId, loginName, pw)
+	switch {
+	case errors.Match(errors.T(errors.TotpCodeRequired), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "This account has already enrolled in TOTP.")
+	case errors.Match(errors.T(errors.InvalidParameter), err):
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "This auth method does not require TOTP.")
+	case err != nil:
+		return nil, fmt.Errorf("unable to enroll account in totp: %w", err)
+	}
+	if out == nil {
+		s.throttle.failed(clientIp)
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Unauthenticated, "Unable to authenticate.")
+	}
+	return out, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
@@ -540,8 +791,22 @@ func toAuthMethodProto(in *password.AuthMethod) (*pb.AuthMethod, error) {
 		out.Name = wrapperspb.String(in.GetName())
 	}
 	st, err := handlers.ProtoToStruct(&pb.PasswordAuthMethodAttributes{
-		MinLoginNameLength: in.GetMinLoginNameLength(),
-		MinPasswordLength:  in.GetMinPasswordLength(),
+		MinLoginNameLength:          in.GetMinLoginNameLength(),
+		MinPasswordLength:           in.GetMinPasswordLength(),
+		AuthTokenTimeToLiveSeconds:  in.GetAuthTokenTimeToLiveSeconds(),
+		AuthTokenTimeToStaleSeconds: in.GetAuthTokenTimeToStaleSeconds(),
+		LockoutThreshold:            in.GetLockoutThreshold(),
+		LockoutDurationSeconds:      in.GetLockoutDurationSeconds(),
+		MfaPolicy:                   in.GetMfaPolicy(),
+		PasswordRequireUppercase:    in.GetPasswordRequireUppercase(),
+		PasswordRequireLowercase:    in.GetPasswordRequireLowercase(),
+		PasswordRequireDigit:        in.GetPasswordRequireDigit(),
+		PasswordRequireSymbol:       in.GetPasswordRequireSymbol(),
+		PasswordHistoryCount:        in.GetPasswordHistoryCount(),
+		MaxPasswordAgeSeconds:       in.GetMaxPasswordAgeSeconds(),
+		BannedPasswords:             in.GetBannedPasswords(),
+		AllowedClientCidrs:          in.GetAllowedClientCidrs(),
+		DeniedClientCidrs:           in.GetDeniedClientCidrs(),
 	})
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "failed building password attribute struct: %v", err)
@@ -586,6 +851,8 @@ func validateCreateRequest(req *pbs.CreateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+			validateMfaPolicy(pwAttrs.GetMfaPolicy(), badFields)
+			validateClientCidrs(pwAttrs, badFields)
 		default:
 			badFields["type"] = fmt.Sprintf("This is a required field and must be %q.", auth.PasswordSubtype.String())
 		}
@@ -605,6 +872,8 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 			if err := handlers.StructToProto(req.GetItem().GetAttributes(), pwAttrs); err != nil {
 				badFields["attributes"] = "Attribute fields do not match the expected format."
 			}
+			validateMfaPolicy(pwAttrs.GetMfaPolicy(), badFields)
+			validateClientCidrs(pwAttrs, badFields)
 		default:
 			badFields["id"] = "Incorrectly formatted identifier."
 		}
@@ -612,6 +881,23 @@ func validateUpdateRequest(req *pbs.UpdateAuthMethodRequest) error {
 	})
 }
 
+func validateMfaPolicy(policy string, badFields map[string]string) {
+	switch policy {
+	case "", mfaPolicyOptional, mfaPolicyRequired:
+	default:
+		badFields["attributes.mfa_policy"] = fmt.Sprintf("Must be %q or %q.", mfaPolicyOptional, mfaPolicyRequired)
+	}
+}
+
+func validateClientCidrs(pwAttrs *pb.PasswordAuthMethodAttributes, badFields map[string]string) {
+	if _, err := cidr.Normalize(pwAttrs.GetAllowedClientCidrs()); err != nil {
+		badFields["attributes.allowed_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+	}
+	if _, err := cidr.Normalize(pwAttrs.GetDeniedClientCidrs()); err != nil {
+		badFields["attributes.denied_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteAuthMethodRequest) error {
 	return handlers.ValidateDeleteRequest(password.AuthMethodPrefix, req, handlers.NoopValidatorFn)
 }
@@ -693,3 +979,66 @@ func validateAuthenticateLoginRequest(req *pbs.AuthenticateLoginRequest) error {
 	}
 	return nil
 }
+
+func validateResetPasswordRequest(req *pbs.ResetPasswordRequest) error {
+	badFields := make(map[string]string)
+	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
+		badFields["auth_method_id"] = "This is a required field."
+	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
+		badFields["auth_method_id"] = "Invalid formatted identifier."
+	}
+	if req.GetToken() == "" {
+		badFields["token"] = "This is a required field."
+	}
+	if req.GetNewPassword() == "" {
+		badFields[newPasswordKey] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateRedeemInviteRequest(req *pbs.RedeemInviteRequest) error {
+	badFields := make(map[string]string)
+	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
+		badFields["auth_method_id"] = "This is a required field."
+	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
+		badFields["auth_method_id"] = "Invalid formatted identifier."
+	}
+	if req.GetToken() == "" {
+		badFields["token"] = "This is a required field."
+	}
+	if req.GetLoginName() == "" {
+		badFields[loginNameKey] = "This is a required field."
+	}
+	if req.GetPassword() == "" {
+		badFields[pwKey] = "This is a required field."
+	}
+	if req.GetName() != "" && !handlers.ValidNameDescription(req.GetName()) {
+		badFields["name"] = "Name contains unprintable characters."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
+
+func validateEnrollAccountTotpRequest(req *pbs.EnrollAccountTotpRequest) error {
+	badFields := make(map[string]string)
+	if strings.TrimSpace(req.GetAuthMethodId()) == "" {
+		badFields["auth_method_id"] = "This is a required field."
+	} else if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
+		badFields["auth_method_id"] = "Invalid formatted identifier."
+	}
+	if req.GetLoginName() == "" {
+		badFields[loginNameKey] = "This is a required field."
+	}
+	if req.GetPassword() == "" {
+		badFields[pwKey] = "This is a required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Invalid fields provided in request.", badFields)
+	}
+	return nil
+}
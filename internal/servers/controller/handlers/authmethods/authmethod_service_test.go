@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
@@ -43,6 +44,12 @@ var authorizedCollectionActions = map[string]*structpb.ListValue{
 			structpb.NewStringValue("list"),
 		},
 	},
+	"invites": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 }
 
 func TestGet(t *testing.T) {
@@ -508,6 +515,21 @@ func TestCreate(t *testing.T) {
 			res: nil,
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Client cidrs must be valid",
+			req: &pbs.CreateAuthMethodRequest{Item: &pb.AuthMethod{
+				ScopeId: o.GetPublicId(),
+				Name:    &wrapperspb.StringValue{Value: "Client cidrs must be valid"},
+				Type:    "password",
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"allowed_client_cidrs": structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{
+						structpb.NewStringValue("10.0.0.0/33"),
+					}}),
+				}},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -898,6 +920,146 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Update auth token durations",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.auth_token_time_to_live_seconds", "attributes.auth_token_time_to_stale_seconds"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"auth_token_time_to_live_seconds":  structpb.NewNumberValue(3600),
+						"auth_token_time_to_stale_seconds": structpb.NewNumberValue(600),
+					}},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"min_password_length":              structpb.NewNumberValue(8),
+						"min_login_name_length":            structpb.NewNumberValue(3),
+						"auth_token_time_to_live_seconds":  structpb.NewNumberValue(3600),
+						"auth_token_time_to_stale_seconds": structpb.NewNumberValue(600),
+					}},
+					Scope:                       defaultScopeInfo,
+					AuthorizedActions:           []string{"read", "update", "delete", "authenticate"},
+					AuthorizedCollectionActions: authorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Update lockout settings",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.lockout_threshold", "attributes.lockout_duration_seconds"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"lockout_threshold":        structpb.NewNumberValue(5),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+					}},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"min_password_length":      structpb.NewNumberValue(8),
+						"min_login_name_length":    structpb.NewNumberValue(3),
+						"lockout_threshold":        structpb.NewNumberValue(5),
+						"lockout_duration_seconds": structpb.NewNumberValue(900),
+					}},
+					Scope:                       defaultScopeInfo,
+					AuthorizedActions:           []string{"read", "update", "delete", "authenticate"},
+					AuthorizedCollectionActions: authorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Update mfa policy",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.mfa_policy"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"mfa_policy": structpb.NewStringValue("required"),
+					}},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"min_password_length":   structpb.NewNumberValue(8),
+						"min_login_name_length": structpb.NewNumberValue(3),
+						"mfa_policy":            structpb.NewStringValue("required"),
+					}},
+					Scope:                       defaultScopeInfo,
+					AuthorizedActions:           []string{"read", "update", "delete", "authenticate"},
+					AuthorizedCollectionActions: authorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Update password policy",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.password_require_digit", "attributes.password_history_count", "attributes.max_password_age_seconds", "attributes.banned_passwords"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"password_require_digit":   structpb.NewBoolValue(true),
+						"password_history_count":   structpb.NewNumberValue(3),
+						"max_password_age_seconds": structpb.NewNumberValue(86400),
+						"banned_passwords":         structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("Password1")}}),
+					}},
+				},
+			},
+			res: &pbs.UpdateAuthMethodResponse{
+				Item: &pb.AuthMethod{
+					ScopeId:     o.GetPublicId(),
+					Name:        &wrapperspb.StringValue{Value: "default"},
+					Description: &wrapperspb.StringValue{Value: "default"},
+					Type:        "password",
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"min_password_length":      structpb.NewNumberValue(8),
+						"min_login_name_length":    structpb.NewNumberValue(3),
+						"password_require_digit":   structpb.NewBoolValue(true),
+						"password_history_count":   structpb.NewNumberValue(3),
+						"max_password_age_seconds": structpb.NewNumberValue(86400),
+						"banned_passwords":         structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{structpb.NewStringValue("password1")}}),
+					}},
+					Scope:                       defaultScopeInfo,
+					AuthorizedActions:           []string{"read", "update", "delete", "authenticate"},
+					AuthorizedCollectionActions: authorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name: "Update invalid mfa policy",
+			req: &pbs.UpdateAuthMethodRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"attributes.mfa_policy"},
+				},
+				Item: &pb.AuthMethod{
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"mfa_policy": structpb.NewStringValue("sometimes"),
+					}},
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1164,3 +1326,258 @@ func TestAuthenticate_AuthAccountConnectedToIamUser(t *testing.T) {
 	assert.NotEmpty(aToken.GetToken())
 	assert.True(strings.HasPrefix(aToken.GetToken(), aToken.GetId()))
 }
+
+func TestResetPassword(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	acct := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+
+	pwRepo, err := pwRepoFn()
+	require.NoError(t, err)
+	rt, err := pwRepo.CreateResetToken(context.Background(), acct.GetPublicId())
+	require.NoError(t, err)
+
+	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		request *pbs.ResetPasswordRequest
+		wantErr error
+	}{
+		{
+			name:    "missing-token",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), NewPassword: "resetpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "missing-new-password",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), Token: rt.Token},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "wrong-token",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), Token: "wrong", NewPassword: "resetpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name:    "too-short",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), Token: rt.Token, NewPassword: "a"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "valid",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), Token: rt.Token, NewPassword: "resetpassword"},
+		},
+		{
+			name:    "already-used",
+			request: &pbs.ResetPasswordRequest{AuthMethodId: am.GetPublicId(), Token: rt.Token, NewPassword: "resetpassword2"},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := s.ResetPassword(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.request)
+			if tc.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tc.wantErr), "Got %#v, wanted %#v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	authed, err := pwRepo.Authenticate(context.Background(), o.GetPublicId(), am.GetPublicId(), acct.GetLoginName(), "resetpassword")
+	require.NoError(t, err)
+	assert.NotNil(t, authed)
+}
+
+func TestRedeemInvite(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	existing := password.TestAccounts(t, conn, am.GetPublicId(), 1)[0]
+
+	pwRepo, err := pwRepoFn()
+	require.NoError(t, err)
+	in, err := password.NewInvite(am.GetPublicId())
+	require.NoError(t, err)
+	in, err = pwRepo.CreateInvite(context.Background(), o.GetPublicId(), in)
+	require.NoError(t, err)
+
+	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		request *pbs.RedeemInviteRequest
+		wantErr error
+	}{
+		{
+			name:    "missing-token",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), LoginName: "invited", Password: "invitedpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "missing-login-name",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: in.Token, Password: "invitedpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "wrong-token",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: "wrong", LoginName: "invited", Password: "invitedpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+		{
+			name:    "login-name-in-use",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: in.Token, LoginName: existing.GetLoginName(), Password: "invitedpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "too-short",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: in.Token, LoginName: "invited", Password: "a"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "valid",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: in.Token, LoginName: "invited", Password: "invitedpassword", Name: "invited"},
+		},
+		{
+			name:    "already-redeemed",
+			request: &pbs.RedeemInviteRequest{AuthMethodId: am.GetPublicId(), Token: in.Token, LoginName: "invited2", Password: "invitedpassword"},
+			wantErr: handlers.ApiErrorWithCode(codes.Unauthenticated),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := s.RedeemInvite(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), tc.request)
+			if tc.wantErr != nil {
+				assert.Error(t, err)
+				assert.Truef(t, errors.Is(err, tc.wantErr), "Got %#v, wanted %#v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, got.GetAccountId())
+			assert.NotEmpty(t, got.GetUserId())
+		})
+	}
+
+	authed, err := pwRepo.Authenticate(context.Background(), o.GetPublicId(), am.GetPublicId(), "invited", "invitedpassword")
+	require.NoError(t, err)
+	assert.NotNil(t, authed)
+}
+
+func TestEnrollAccountTotp(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+	ctx := context.Background()
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	pwRepo, err := pwRepoFn()
+	require.NoError(t, err)
+	ams := password.TestAuthMethods(t, conn, o.GetPublicId(), 2)
+	am, optionalAm := ams[0], ams[1]
+	am.MfaPolicy = "required"
+	am, _, err = pwRepo.UpdateAuthMethod(ctx, am, am.Version, []string{"MfaPolicy"})
+	require.NoError(t, err)
+	for _, m := range ams {
+		acct, err := password.NewAccount(m.GetPublicId(), password.WithLoginName(testLoginName))
+		require.NoError(t, err)
+		_, err = pwRepo.CreateAccount(ctx, o.GetPublicId(), acct, password.WithPassword(testPassword))
+		require.NoError(t, err)
+	}
+
+	s, err := authmethods.NewService(kms, pwRepoFn, iamRepoFn, atRepoFn)
+	require.NoError(t, err)
+	authenticate := func(totpCode string) (*pbs.AuthenticateResponse, error) {
+		attrs := map[string]*structpb.Value{
+			"login_name": structpb.NewStringValue(testLoginName),
+			"password":   structpb.NewStringValue(testPassword),
+		}
+		if totpCode != "" {
+			attrs["totp_code"] = structpb.NewStringValue(totpCode)
+		}
+		return s.Authenticate(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.AuthenticateRequest{
+			AuthMethodId: am.GetPublicId(),
+			Attributes:   &structpb.Struct{Fields: attrs},
+		})
+	}
+	enroll := func(amId, pw string) (*pbs.EnrollAccountTotpResponse, error) {
+		return s.EnrollAccountTotp(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.EnrollAccountTotpRequest{
+			AuthMethodId: amId,
+			LoginName:    testLoginName,
+			Password:     pw,
+		})
+	}
+
+	_, err = authenticate("")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "Got %#v", err)
+
+	_, err = enroll(am.GetPublicId(), "")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	_, err = enroll(am.GetPublicId(), "wrongpassword")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), "Got %#v", err)
+	_, err = enroll(optionalAm.GetPublicId(), testPassword)
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Got %#v", err)
+
+	enrollment, err := enroll(am.GetPublicId(), testPassword)
+	require.NoError(t, err)
+	assert.NotEmpty(t, enrollment.GetTotpSecret())
+	assert.Contains(t, enrollment.GetTotpUrl(), enrollment.GetTotpSecret())
+	assert.NotEmpty(t, enrollment.GetRecoveryCodes())
+
+	// the pending enrollment does not let the account authenticate without
+	// a code for it
+	_, err = authenticate("")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "Got %#v", err)
+	_, err = authenticate("000000")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.Unauthenticated)), "Got %#v", err)
+
+	// authenticating with a code for the pending enrollment confirms it
+	resp, err := authenticate(password.TestTotpCode(t, enrollment.GetTotpSecret(), time.Now()))
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetItem().GetToken())
+
+	_, err = authenticate("")
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+	_, err = enroll(am.GetPublicId(), testPassword)
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Got %#v", err)
+}
@@ -0,0 +1,71 @@
+package authmethods
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxFailedAuthentications is the number of failed authentication attempts
+	// a client IP address is allowed within failedAuthenticationWindow before
+	// its authentication requests are rejected.
+	maxFailedAuthentications = 20
+
+	// failedAuthenticationWindow is the period within which failed
+	// authentication attempts are counted.
+	failedAuthenticationWindow = time.Minute
+)
+
+// authThrottle counts failed authentication attempts per client IP address
+// in fixed windows of time. All counts are reset when a window ends, which
+// bounds the memory used by the throttle to the number of distinct
+// addresses seen within a single window.
+type authThrottle struct {
+	mu          sync.Mutex
+	limit       int
+	window      time.Duration
+	windowStart time.Time
+	failures    map[string]int
+	now         func() time.Time
+}
+
+func newAuthThrottle(limit int, window time.Duration) *authThrottle {
+	return &authThrottle{
+		limit:    limit,
+		window:   window,
+		failures: make(map[string]int),
+		now:      time.Now,
+	}
+}
+
+// rotate resets the failure counts if the current window has ended. It must
+// be called with the lock held.
+func (t *authThrottle) rotate() {
+	now := t.now()
+	if now.Sub(t.windowStart) >= t.window {
+		t.windowStart = now
+		t.failures = make(map[string]int)
+	}
+}
+
+// allowed reports whether an authentication attempt from ip is allowed.
+func (t *authThrottle) allowed(ip string) bool {
+	if ip == "" {
+		return true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate()
+	return t.failures[ip] < t.limit
+}
+
+// failed records a failed authentication attempt from ip.
+func (t *authThrottle) failed(ip string) {
+	if ip == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rotate()
+	t.failures[ip]++
+}
@@ -0,0 +1,31 @@
+package authmethods
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthThrottle(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	th := newAuthThrottle(2, time.Minute)
+	th.now = func() time.Time { return now }
+
+	assert.True(th.allowed("10.0.0.1"))
+	th.failed("10.0.0.1")
+	assert.True(th.allowed("10.0.0.1"))
+	th.failed("10.0.0.1")
+	assert.False(th.allowed("10.0.0.1"))
+
+	// other addresses and requests without an address are not throttled
+	assert.True(th.allowed("10.0.0.2"))
+	th.failed("")
+	th.failed("")
+	assert.True(th.allowed(""))
+
+	// the failures are forgotten once the window ends
+	now = now.Add(time.Minute)
+	assert.True(th.allowed("10.0.0.1"))
+}
@@ -2,13 +2,17 @@ package authtokens
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
@@ -16,6 +20,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -24,6 +29,7 @@ var (
 	IdActions = action.ActionSet{
 		action.Read,
 		action.Delete,
+		action.Derive,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -37,19 +43,23 @@ var (
 type Service struct {
 	pbs.UnimplementedAuthTokenServiceServer
 
+	kms       *kms.Kms
 	repoFn    common.AuthTokenRepoFactory
 	iamRepoFn common.IamRepoFactory
 }
 
 // NewService returns a user service which handles user related requests to boundary.
-func NewService(repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+func NewService(kms *kms.Kms, repo common.AuthTokenRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if kms == nil {
+		return Service{}, stderrors.New("nil kms provided")
+	}
 	if repo == nil {
 		return Service{}, fmt.Errorf("nil auth token repository provided")
 	}
 	if iamRepoFn == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repo, iamRepoFn: iamRepoFn}, nil
+	return Service{kms: kms, repoFn: repo, iamRepoFn: iamRepoFn}, nil
 }
 
 var _ pbs.AuthTokenServiceServer = Service{}
@@ -142,6 +152,29 @@ func (s Service) DeleteAuthToken(ctx context.Context, req *pbs.DeleteAuthTokenRe
 	return &pbs.DeleteAuthTokenResponse{}, nil
 }
 
+// DeriveAuthToken implements the interface pbs.AuthTokenServiceServer.
+func (s Service) DeriveAuthToken(ctx context.Context, req *pbs.DeriveAuthTokenRequest) (*pbs.DeriveAuthTokenResponse, error) {
+	if err := validateDeriveRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Derive)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	// Deriving an auth token from an auth token other than the one used to
+	// make this request would allow the requester to act as another user.
+	if authResults.AuthTokenId != req.GetId() {
+		return nil, handlers.ForbiddenError()
+	}
+	u, err := s.deriveInRepo(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.DeriveAuthTokenResponse{Item: u}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthToken, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -157,7 +190,54 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthToken, err
 	if u == nil {
 		return nil, handlers.NotFoundErrorf("AuthToken %q doesn't exist.", id)
 	}
-	return toProto(u), nil
+	var grants []*authtoken.AuthTokenGrant
+	if u.IsDerived() {
+		grants, err = repo.ListAuthTokenGrants(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lookup auth token grants: %w", err)
+		}
+	}
+	return toProto(u, grants), nil
+}
+
+func (s Service) deriveInRepo(ctx context.Context, req *pbs.DeriveAuthTokenRequest) (*pb.AuthToken, error) {
+	var opts []authtoken.Option
+	if req.GetGrantScopeId() != "" {
+		opts = append(opts, authtoken.WithGrantScopeId(req.GetGrantScopeId()))
+	}
+	if req.GetExpirationTime() != nil {
+		exp, err := ptypes.Timestamp(req.GetExpirationTime())
+		if err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"expiration_time": "Invalid timestamp."})
+		}
+		opts = append(opts, authtoken.WithExpirationTime(exp))
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, grants, err := repo.DeriveAuthToken(ctx, req.GetId(), req.GetGrantStrings(), opts...)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("AuthToken %q doesn't exist.", req.GetId())
+		}
+		if errors.Match(errors.T(errors.DerivedAuthToken), err) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"id": "Auth tokens cannot be derived from derived auth tokens."})
+		}
+		if errors.Match(errors.T(errors.InvalidParameter), err) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to derive auth token: %v.", err)
+		}
+		return nil, fmt.Errorf("unable to derive auth token: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to derive auth token but no error returned from repository.")
+	}
+	token, err := authtoken.EncryptToken(ctx, s.kms, out.GetScopeId(), out.GetPublicId(), out.GetToken())
+	if err != nil {
+		return nil, err
+	}
+	out.Token = out.GetPublicId() + "_" + token
+	return toProto(out, grants), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -187,7 +267,7 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*pb.Aut
 	}
 	var outUl []*pb.AuthToken
 	for _, u := range ul {
-		outUl = append(outUl, toProto(u))
+		outUl = append(outUl, toProto(u, nil))
 	}
 	return outUl, nil
 }
@@ -236,10 +316,11 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *authtoken.AuthToken) *pb.AuthToken {
+func toProto(in *authtoken.AuthToken, grants []*authtoken.AuthTokenGrant) *pb.AuthToken {
 	out := pb.AuthToken{
 		Id:                      in.GetPublicId(),
 		ScopeId:                 in.GetScopeId(),
+		Token:                   in.GetToken(),
 		CreatedTime:             in.GetCreateTime().GetTimestamp(),
 		UpdatedTime:             in.GetUpdateTime().GetTimestamp(),
 		ApproximateLastUsedTime: in.GetApproximateLastAccessTime().GetTimestamp(),
@@ -247,6 +328,11 @@ func toProto(in *authtoken.AuthToken) *pb.AuthToken {
 		UserId:                  in.GetIamUserId(),
 		AuthMethodId:            in.GetAuthMethodId(),
 		AccountId:               in.GetAuthAccountId(),
+		ParentId:                in.GetParentId(),
+		GrantScopeId:            in.GetGrantScopeId(),
+	}
+	for _, g := range grants {
+		out.GrantStrings = append(out.GrantStrings, g.GetRawGrant())
 	}
 	return &out
 }
@@ -264,6 +350,41 @@ func validateDeleteRequest(req *pbs.DeleteAuthTokenRequest) error {
 	return handlers.ValidateDeleteRequest(authtoken.AuthTokenPrefix, req, handlers.NoopValidatorFn)
 }
 
+func validateDeriveRequest(req *pbs.DeriveAuthTokenRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(authtoken.AuthTokenPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if gsId := req.GetGrantScopeId(); gsId != "" &&
+		!handlers.ValidId(scope.Org.Prefix(), gsId) &&
+		!handlers.ValidId(scope.Project.Prefix(), gsId) &&
+		gsId != scope.Global.String() {
+		badFields["grant_scope_id"] = "Improperly formatted identifier."
+	}
+	if len(req.GetGrantStrings()) == 0 {
+		badFields["grant_strings"] = "At least one grant must be provided."
+	}
+	for _, g := range req.GetGrantStrings() {
+		if _, err := perms.Parse("o_abcd1234", g); err != nil {
+			badFields["grant_strings"] = fmt.Sprintf("Improperly formatted grant %q: %v.", g, err)
+			break
+		}
+	}
+	if req.GetExpirationTime() != nil {
+		exp, err := ptypes.Timestamp(req.GetExpirationTime())
+		switch {
+		case err != nil:
+			badFields["expiration_time"] = "Invalid timestamp."
+		case !exp.After(time.Now()):
+			badFields["expiration_time"] = "Must be in the future."
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListAuthTokensRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
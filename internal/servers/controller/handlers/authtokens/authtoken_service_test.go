@@ -1,10 +1,14 @@
 package authtokens_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -14,9 +18,11 @@ import (
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/testing/protocmp"
 
@@ -36,7 +42,7 @@ func TestGet(t *testing.T) {
 		return authtoken.NewRepository(rw, rw, kms)
 	}
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(t, err, "Couldn't create new auth token service.")
 
 	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrap))
@@ -53,7 +59,7 @@ func TestGet(t *testing.T) {
 		ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 		ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 		Scope:                   &scopes.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
-		AuthorizedActions:       []string{"read", "delete"},
+		AuthorizedActions:       []string{"read", "delete", "derive"},
 	}
 
 	cases := []struct {
@@ -128,7 +134,7 @@ func TestList(t *testing.T) {
 			ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 			ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 			Scope:                   &scopes.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
-			AuthorizedActions:       []string{"read", "delete"},
+			AuthorizedActions:       []string{"read", "delete", "derive"},
 		})
 	}
 
@@ -147,7 +153,7 @@ func TestList(t *testing.T) {
 			ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 			ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 			Scope:                   &scopes.ScopeInfo{Id: orgWithSomeTokens.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
-			AuthorizedActions:       []string{"read", "delete"},
+			AuthorizedActions:       []string{"read", "delete", "derive"},
 		})
 	}
 
@@ -166,7 +172,7 @@ func TestList(t *testing.T) {
 			ApproximateLastUsedTime: at.GetApproximateLastAccessTime().GetTimestamp(),
 			ExpirationTime:          at.GetExpirationTime().GetTimestamp(),
 			Scope:                   &scopes.ScopeInfo{Id: orgWithOtherTokens.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
-			AuthorizedActions:       []string{"read", "delete"},
+			AuthorizedActions:       []string{"read", "delete", "derive"},
 		})
 	}
 
@@ -226,7 +232,7 @@ func TestList(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			s, err := authtokens.NewService(repoFn, iamRepoFn)
+			s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 			require.NoError(t, err, "Couldn't create new user service.")
 
 			got, gErr := s.ListAuthTokens(auth.DisabledAuthTestContext(iamRepoFn, tc.req.GetScopeId()), tc.req)
@@ -257,7 +263,7 @@ func TestDelete(t *testing.T) {
 	org, _ := iam.TestScopes(t, iamRepo)
 	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(t, err, "Error when getting new user service.")
 
 	cases := []struct {
@@ -322,7 +328,7 @@ func TestDelete_twice(t *testing.T) {
 	org, _ := iam.TestScopes(t, iamRepo)
 	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
 
-	s, err := authtokens.NewService(repoFn, iamRepoFn)
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
 	require.NoError(err, "Error when getting new user service")
 	req := &pbs.DeleteAuthTokenRequest{
 		Id: at.GetPublicId(),
@@ -333,3 +339,211 @@ func TestDelete_twice(t *testing.T) {
 	assert.Error(gErr, "Second attempt")
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected permission denied for the second delete.")
 }
+
+func TestDerive(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	logger := hclog.New(nil)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	otherAt := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	derived := authtoken.TestDerivedAuthToken(t, conn, kms, at.GetPublicId(), "id=*;type=auth-token;actions=derive")
+	role := iam.TestRole(t, conn, org.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=auth-token;actions=derive")
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+	iam.TestUserRole(t, conn, role.GetPublicId(), otherAt.GetIamUserId())
+
+	parentExp, err := ptypes.Timestamp(at.GetExpirationTime().GetTimestamp())
+	require.NoError(t, err)
+	laterThanParent, err := ptypes.TimestampProto(parentExp.Add(time.Hour))
+	require.NoError(t, err)
+	past, err := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create new auth token service.")
+
+	cases := []struct {
+		name             string
+		requester        *authtoken.AuthToken
+		req              *pbs.DeriveAuthTokenRequest
+		wantGrantScopeId string
+		wantExpiration   time.Time
+		err              error
+	}{
+		{
+			name:      "Derive own token",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:           at.GetPublicId(),
+				GrantStrings: []string{"id=ttcp_1234567890;actions=authorize-session"},
+			},
+			wantGrantScopeId: org.GetPublicId(),
+			wantExpiration:   time.Now().Add(10 * time.Minute),
+		},
+		{
+			name:      "Derive own token with options",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:             at.GetPublicId(),
+				GrantScopeId:   proj.GetPublicId(),
+				GrantStrings:   []string{"id=ttcp_1234567890;actions=authorize-session"},
+				ExpirationTime: laterThanParent,
+			},
+			wantGrantScopeId: proj.GetPublicId(),
+			wantExpiration:   parentExp,
+		},
+		{
+			name:      "Derive other token",
+			requester: otherAt,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:           at.GetPublicId(),
+				GrantStrings: []string{"id=*;type=*;actions=read"},
+			},
+			err: handlers.ForbiddenError(),
+		},
+		{
+			name:      "Derive derived token",
+			requester: derived,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:           derived.GetPublicId(),
+				GrantStrings: []string{"id=*;type=*;actions=read"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:      "Missing grants",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id: at.GetPublicId(),
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:      "Bad grant",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:           at.GetPublicId(),
+				GrantStrings: []string{"id=*;actions=read"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:      "Expiration in the past",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:             at.GetPublicId(),
+				GrantStrings:   []string{"id=*;type=*;actions=read"},
+				ExpirationTime: past,
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:      "Bad token id formatting",
+			requester: at,
+			req: &pbs.DeriveAuthTokenRequest{
+				Id:           "bad_format",
+				GrantStrings: []string{"id=*;type=*;actions=read"},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			req := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/auth-tokens/%s:derive", tc.req.GetId()), nil)
+			requestInfo := auth.RequestInfo{
+				Path:        req.URL.Path,
+				Method:      req.Method,
+				TokenFormat: auth.AuthTokenTypeBearer,
+				PublicId:    tc.requester.GetPublicId(),
+				Token:       tc.requester.GetToken(),
+			}
+			ctx := auth.NewVerifierContext(context.Background(), logger, iamRepoFn, repoFn, serversRepoFn, kms, requestInfo)
+
+			got, gErr := s.DeriveAuthToken(ctx, tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "DeriveAuthToken(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			item := got.GetItem()
+			assert.NotEqual(at.GetPublicId(), item.GetId())
+			assert.Equal(at.GetPublicId(), item.GetParentId())
+			assert.Equal(at.GetIamUserId(), item.GetUserId())
+			assert.Equal(at.GetAuthAccountId(), item.GetAccountId())
+			assert.Equal(tc.wantGrantScopeId, item.GetGrantScopeId())
+			assert.Equal(tc.req.GetGrantStrings(), item.GetGrantStrings())
+			assert.Contains(item.GetToken(), item.GetId()+"_")
+			exp, err := ptypes.Timestamp(item.GetExpirationTime())
+			require.NoError(err)
+			assert.WithinDuration(tc.wantExpiration, exp, 5*time.Second)
+		})
+	}
+}
+
+func TestDerive_invalidParameter(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrap := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrap)
+	logger := hclog.New(nil)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	repoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	org, _ := iam.TestScopes(t, iamRepo)
+	at := authtoken.TestAuthToken(t, conn, kms, org.GetPublicId())
+	role := iam.TestRole(t, conn, org.GetPublicId())
+	iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=auth-token;actions=derive")
+	iam.TestUserRole(t, conn, role.GetPublicId(), at.GetIamUserId())
+
+	s, err := authtokens.NewService(kms, repoFn, iamRepoFn)
+	require.NoError(err, "Couldn't create new auth token service.")
+
+	// The account is disabled without deleting its auth token, as happens
+	// when it is disabled while the request is in flight.
+	_, err = rw.Exec(context.Background(), "update auth_password_account set active = false where public_id = ?", []interface{}{at.GetAuthAccountId()})
+	require.NoError(err)
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/auth-tokens/%s:derive", at.GetPublicId()), nil)
+	requestInfo := auth.RequestInfo{
+		Path:        req.URL.Path,
+		Method:      req.Method,
+		TokenFormat: auth.AuthTokenTypeBearer,
+		PublicId:    at.GetPublicId(),
+		Token:       at.GetToken(),
+	}
+	ctx := auth.NewVerifierContext(context.Background(), logger, iamRepoFn, repoFn, serversRepoFn, kms, requestInfo)
+
+	_, err = s.DeriveAuthToken(ctx, &pbs.DeriveAuthTokenRequest{
+		Id:           at.GetPublicId(),
+		GrantStrings: []string{"id=*;type=*;actions=read"},
+	})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	assert.Contains(err.Error(), "is not active")
+	assert.NotContains(err.Error(), "derived auth tokens")
+}
@@ -161,6 +161,10 @@ func backendErrorToApiError(inErr error) error {
 		return NotFoundErrorf(genericNotFoundMsg)
 	case errors.Match(errors.T(errors.AccountAlreadyAssociated), inErr):
 		return InvalidArgumentErrorf(inErr.Error(), nil)
+	case errors.Match(errors.T(errors.SessionQuotaExceeded), inErr):
+		return ApiErrorWithCodeAndMessage(codes.ResourceExhausted, "%s", inErr.Error())
+	case errors.Match(errors.T(errors.KeyVersionInUse), inErr):
+		return ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "%s", inErr.Error())
 	case errors.Match(errors.T(errors.InvalidFieldMask), inErr), errors.Match(errors.T(errors.EmptyFieldMask), inErr):
 		return InvalidArgumentErrorf("Error in provided request", map[string]string{"update_mask": "Invalid update mask provided."})
 	case errors.IsUniqueError(inErr):
@@ -195,6 +195,17 @@ func TestApiErrorHandler(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Domain error session quota exceeded",
+			err:  errors.E(errors.WithCode(errors.SessionQuotaExceeded)),
+			expected: apiError{
+				status: http.StatusTooManyRequests,
+				inner: &pb.Error{
+					Kind:    "ResourceExhausted",
+					Message: "session quota exceeded, integrity violation: error #115",
+				},
+			},
+		},
 		{
 			name: "Wrapped domain error",
 			err:  errors.E(errors.WithCode(errors.InvalidAddress), errors.WithMsg("test msg"), errors.WithWrap(errors.E(errors.WithCode(errors.NotNull), errors.WithMsg("inner msg")))),
@@ -261,7 +261,11 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Group, error)
 	if g == nil {
 		return nil, handlers.NotFoundErrorf("Group %q doesn't exist.", id)
 	}
-	return toProto(g, m), nil
+	em, err := repo.ListEffectiveGroupMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get group effective members: %w", err)
+	}
+	return toProto(g, m, em), nil
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Group) (*pb.Group, error) {
@@ -287,7 +291,7 @@ func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Grou
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create group but no error returned from repository.")
 	}
-	return toProto(out, nil), nil
+	return toProto(out, nil, nil), nil
 }
 
 func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []string, item *pb.Group) (*pb.Group, error) {
@@ -319,7 +323,11 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Group %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, m), nil
+	em, err := repo.ListEffectiveGroupMembers(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get group effective members: %w", err)
+	}
+	return toProto(out, m, em), nil
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
@@ -348,18 +356,21 @@ func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*pb.Gro
 	}
 	var outGl []*pb.Group
 	for _, g := range gl {
-		outGl = append(outGl, toProto(g, nil))
+		outGl = append(outGl, toProto(g, nil, nil))
 	}
 	return outGl, nil
 }
 
-func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) addMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	_, err = repo.AddGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, err = repo.AddGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
+		if errors.IsCheckConstraintError(err) {
+			return nil, handlers.InvalidArgumentErrorf("Unable to add members to group.", map[string]string{"member_ids": "Adding these members would create a group membership cycle."})
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to add members to group: %v.", err)
 	}
@@ -370,16 +381,23 @@ func (s Service) addMembersInRepo(ctx context.Context, groupId string, userIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after adding member to it.")
 	}
-	return toProto(out, m), nil
+	em, err := repo.ListEffectiveGroupMembers(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up group effective members after adding members: %w", err)
+	}
+	return toProto(out, m, em), nil
 }
 
-func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) setMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	_, _, err = repo.SetGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, _, err = repo.SetGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
+		if errors.IsCheckConstraintError(err) {
+			return nil, handlers.InvalidArgumentErrorf("Unable to set members on group.", map[string]string{"member_ids": "Setting these members would create a group membership cycle."})
+		}
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to set members on group: %v.", err)
 	}
@@ -390,15 +408,19 @@ func (s Service) setMembersInRepo(ctx context.Context, groupId string, userIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after setting members for it.")
 	}
-	return toProto(out, m), nil
+	em, err := repo.ListEffectiveGroupMembers(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up group effective members after setting members: %w", err)
+	}
+	return toProto(out, m, em), nil
 }
 
-func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userIds []string, version uint32) (*pb.Group, error) {
+func (s Service) removeMembersInRepo(ctx context.Context, groupId string, memberIds []string, version uint32) (*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	_, err = repo.DeleteGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(userIds, false))
+	_, err = repo.DeleteGroupMembers(ctx, groupId, version, strutil.RemoveDuplicates(memberIds, false))
 	if err != nil {
 		// TODO: Figure out a way to surface more helpful error info beyond the Internal error.
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to remove members from group: %v.", err)
@@ -410,7 +432,11 @@ func (s Service) removeMembersInRepo(ctx context.Context, groupId string, userId
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup group after removing members from it.")
 	}
-	return toProto(out, m), nil
+	em, err := repo.ListEffectiveGroupMembers(ctx, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up group effective members after removing members: %w", err)
+	}
+	return toProto(out, m, em), nil
 }
 
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
@@ -452,7 +478,7 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 	return auth.Verify(ctx, opts...)
 }
 
-func toProto(in *iam.Group, members []*iam.GroupMember) *pb.Group {
+func toProto(in *iam.Group, members []*iam.GroupMember, effectiveMembers []*iam.EffectiveGroupMember) *pb.Group {
 	out := pb.Group{
 		Id:          in.GetPublicId(),
 		ScopeId:     in.GetScopeId(),
@@ -473,6 +499,13 @@ func toProto(in *iam.Group, members []*iam.GroupMember) *pb.Group {
 			ScopeId: m.GetMemberScopeId(),
 		})
 	}
+	for _, m := range effectiveMembers {
+		out.EffectiveMemberIds = append(out.EffectiveMemberIds, m.GetMemberId())
+		out.EffectiveMembers = append(out.EffectiveMembers, &pb.Member{
+			Id:      m.GetMemberId(),
+			ScopeId: m.GetMemberScopeId(),
+		})
+	}
 	return &out
 }
 
@@ -533,14 +566,18 @@ func validateAddGroupMembersRequest(req *pbs.AddGroupMembersRequest) error {
 		badFields["member_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(iam.UserPrefix, id) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(iam.UserPrefix, id) && !handlers.ValidId(iam.GroupPrefix, id) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
 			break
 		}
 		if id == "u_recovery" {
 			badFields["member_ids"] = "u_recovery cannot be assigned to a group."
 			break
 		}
+		if id == req.GetId() {
+			badFields["member_ids"] = "A group cannot be a member of itself."
+			break
+		}
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
@@ -557,14 +594,18 @@ func validateSetGroupMembersRequest(req *pbs.SetGroupMembersRequest) error {
 		badFields["version"] = "Required field."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(iam.UserPrefix, id) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(iam.UserPrefix, id) && !handlers.ValidId(iam.GroupPrefix, id) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
 			break
 		}
 		if id == "u_recovery" {
 			badFields["member_ids"] = "u_recovery cannot be assigned to a group."
 			break
 		}
+		if id == req.GetId() {
+			badFields["member_ids"] = "A group cannot be a member of itself."
+			break
+		}
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
@@ -584,8 +625,8 @@ func validateRemoveGroupMembersRequest(req *pbs.RemoveGroupMembersRequest) error
 		badFields["member_ids"] = "Must be non-empty."
 	}
 	for _, id := range req.GetMemberIds() {
-		if !handlers.ValidId(iam.UserPrefix, id) {
-			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user ids but found %q.", id)
+		if !handlers.ValidId(iam.UserPrefix, id) && !handlers.ValidId(iam.GroupPrefix, id) {
+			badFields["member_ids"] = fmt.Sprintf("Must only contain valid user or group ids but found %q.", id)
 			break
 		}
 	}
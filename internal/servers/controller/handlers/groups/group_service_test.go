@@ -106,6 +106,13 @@ func TestGet(t *testing.T) {
 				ScopeId: u.GetScopeId(),
 			},
 		},
+		EffectiveMemberIds: []string{u.GetPublicId()},
+		EffectiveMembers: []*pb.Member{
+			{
+				Id:      u.GetPublicId(),
+				ScopeId: u.GetScopeId(),
+			},
+		},
 		AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 	}
 
@@ -125,6 +132,13 @@ func TestGet(t *testing.T) {
 				ScopeId: u.GetScopeId(),
 			},
 		},
+		EffectiveMemberIds: []string{u.GetPublicId()},
+		EffectiveMembers: []*pb.Member{
+			{
+				Id:      u.GetPublicId(),
+				ScopeId: u.GetScopeId(),
+			},
+		},
 		AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 	}
 
@@ -632,6 +646,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -663,6 +684,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -695,6 +723,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -727,6 +762,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -790,6 +832,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -821,6 +870,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -852,6 +908,13 @@ func TestUpdate(t *testing.T) {
 							ScopeId: u.GetScopeId(),
 						},
 					},
+					EffectiveMemberIds: []string{u.GetPublicId()},
+					EffectiveMembers: []*pb.Member{
+						{
+							Id:      u.GetPublicId(),
+							ScopeId: u.GetScopeId(),
+						},
+					},
 					AuthorizedActions: []string{"read", "update", "delete", "add-members", "set-members", "remove-members"},
 				},
 			},
@@ -978,6 +1041,8 @@ func TestAddMember(t *testing.T) {
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 	}
+	nested := iam.TestGroup(t, conn, o.GetPublicId())
+	iam.TestGroupMember(t, conn, nested.GetPublicId(), users[2].GetPublicId())
 
 	addCases := []struct {
 		name         string
@@ -1024,6 +1089,22 @@ func TestAddMember(t *testing.T) {
 			addUsers: []string{"u_recovery"},
 			wantErr:  true,
 		},
+		{
+			name:         "Add group on empty group",
+			setup:        func(g *iam.Group) {},
+			addGroups:    []string{nested.GetPublicId()},
+			resultGroups: []string{nested.GetPublicId()},
+		},
+		{
+			name: "Add user and group on populated group",
+			setup: func(g *iam.Group) {
+				iam.TestGroupMember(t, conn, g.GetPublicId(), users[0].GetPublicId())
+			},
+			addUsers:     []string{users[1].GetPublicId()},
+			addGroups:    []string{nested.GetPublicId()},
+			resultUsers:  []string{users[0].GetPublicId(), users[1].GetPublicId()},
+			resultGroups: []string{nested.GetPublicId()},
+		},
 	}
 
 	for _, tc := range addCases {
@@ -1034,7 +1115,7 @@ func TestAddMember(t *testing.T) {
 				req := &pbs.AddGroupMembersRequest{
 					Id:        grp.GetPublicId(),
 					Version:   grp.GetVersion(),
-					MemberIds: tc.addUsers,
+					MemberIds: append(tc.addUsers, tc.addGroups...),
 				}
 
 				got, err := s.AddGroupMembers(auth.DisabledAuthTestContext(repoFn, scp.GetPublicId()), req)
@@ -1046,12 +1127,17 @@ func TestAddMember(t *testing.T) {
 				require.True(t, ok)
 				require.NoError(t, err, "Got error: %v", s)
 
-				assert.True(t, equalMembers(got.GetItem(), tc.resultUsers))
+				assert.True(t, equalMembers(got.GetItem(), append(tc.resultUsers, tc.resultGroups...)))
+				if len(tc.resultGroups) > 0 {
+					assert.Contains(t, got.GetItem().GetEffectiveMemberIds(), users[2].GetPublicId())
+				}
 			})
 		}
 	}
 
 	grp := iam.TestGroup(t, conn, p.GetPublicId())
+	parent := iam.TestGroup(t, conn, p.GetPublicId())
+	iam.TestGroupMemberGroup(t, conn, parent.GetPublicId(), grp.GetPublicId())
 
 	failCases := []struct {
 		name string
@@ -1084,6 +1170,24 @@ func TestAddMember(t *testing.T) {
 			},
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Group as member of itself",
+			req: &pbs.AddGroupMembersRequest{
+				Id:        grp.GetPublicId(),
+				Version:   grp.GetVersion(),
+				MemberIds: []string{grp.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Membership cycle",
+			req: &pbs.AddGroupMembersRequest{
+				Id:        grp.GetPublicId(),
+				Version:   grp.GetVersion(),
+				MemberIds: []string{parent.GetPublicId()},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range failCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1113,6 +1217,7 @@ func TestSetMember(t *testing.T) {
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 	}
+	nested := iam.TestGroup(t, conn, o.GetPublicId())
 
 	setCases := []struct {
 		name         string
@@ -1154,6 +1259,16 @@ func TestSetMember(t *testing.T) {
 			setUsers:    []string{},
 			resultUsers: nil,
 		},
+		{
+			name: "Set user and group on populated group",
+			setup: func(r *iam.Group) {
+				iam.TestGroupMember(t, conn, r.GetPublicId(), users[0].GetPublicId())
+			},
+			setUsers:     []string{users[1].GetPublicId()},
+			setGroups:    []string{nested.GetPublicId()},
+			resultUsers:  []string{users[1].GetPublicId()},
+			resultGroups: []string{nested.GetPublicId()},
+		},
 	}
 
 	for _, tc := range setCases {
@@ -1164,7 +1279,7 @@ func TestSetMember(t *testing.T) {
 				req := &pbs.SetGroupMembersRequest{
 					Id:        grp.GetPublicId(),
 					Version:   grp.GetVersion(),
-					MemberIds: tc.setUsers,
+					MemberIds: append(tc.setUsers, tc.setGroups...),
 				}
 
 				got, err := s.SetGroupMembers(auth.DisabledAuthTestContext(repoFn, scp.GetPublicId()), req)
@@ -1243,6 +1358,8 @@ func TestRemoveMember(t *testing.T) {
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 		iam.TestUser(t, iamRepo, o.GetPublicId()),
 	}
+	nested := iam.TestGroup(t, conn, o.GetPublicId())
+	iam.TestGroupMember(t, conn, nested.GetPublicId(), users[2].GetPublicId())
 
 	addCases := []struct {
 		name         string
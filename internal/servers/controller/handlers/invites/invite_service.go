@@ -0,0 +1,400 @@
+package invites
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/errors"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/invites"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	// IdActions contains the set of actions that can be performed on
+	// individual resources
+	IdActions = action.ActionSet{
+		action.Read,
+		action.Delete,
+	}
+
+	// CollectionActions contains the set of actions that can be performed on
+	// this collection
+	CollectionActions = action.ActionSet{
+		action.Create,
+		action.List,
+	}
+)
+
+// Service handles request as described by the pbs.InviteServiceServer interface.
+type Service struct {
+	pbs.UnimplementedInviteServiceServer
+
+	repoFn    common.PasswordAuthRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns an invite service which handles invite related requests to boundary.
+func NewService(repo common.PasswordAuthRepoFactory, iamRepo common.IamRepoFactory) (Service, error) {
+	if repo == nil {
+		return Service{}, fmt.Errorf("nil password repository provided")
+	}
+	if iamRepo == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{repoFn: repo, iamRepoFn: iamRepo}, nil
+}
+
+var _ pbs.InviteServiceServer = Service{}
+
+// ListInvites implements the interface pbs.InviteServiceServer.
+func (s Service) ListInvites(ctx context.Context, req *pbs.ListInvitesRequest) (*pbs.ListInvitesResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetAuthMethodId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
+	if err != nil {
+		return nil, err
+	}
+	filter, err := handlers.NewFilter(req.GetFilter())
+	if err != nil {
+		return nil, err
+	}
+	finalItems := make([]*pb.Invite, 0, len(ul))
+	res := &perms.Resource{
+		ScopeId: authResults.Scope.Id,
+		Type:    resource.Invite,
+		Pin:     req.GetAuthMethodId(),
+	}
+	for _, item := range ul {
+		item.Scope = authResults.Scope
+		item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithResource(res)).Strings()
+		if len(item.AuthorizedActions) == 0 {
+			continue
+		}
+		if filter.Match(item) {
+			finalItems = append(finalItems, item)
+		}
+	}
+	return &pbs.ListInvitesResponse{Items: finalItems}, nil
+}
+
+// GetInvite implements the interface pbs.InviteServiceServer.
+func (s Service) GetInvite(ctx context.Context, req *pbs.GetInviteRequest) (*pbs.GetInviteResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.GetInviteResponse{Item: u}, nil
+}
+
+// CreateInvite implements the interface pbs.InviteServiceServer. The caller
+// must be allowed to add principals to every role of the invite since the
+// invitee is added to them when the invite is redeemed.
+func (s Service) CreateInvite(ctx context.Context, req *pbs.CreateInviteRequest) (*pbs.CreateInviteResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authMeth, authResults := s.parentAndAuthResult(ctx, req.GetItem().GetAuthMethodId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.authorizeRoles(ctx, &authResults, req.GetItem().GetRoleIds()); err != nil {
+		return nil, err
+	}
+	u, err := s.createInRepo(ctx, authMeth.GetPublicId(), authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.CreateInviteResponse{Item: u, Uri: fmt.Sprintf("invites/%s", u.GetId())}, nil
+}
+
+// DeleteInvite implements the interface pbs.InviteServiceServer.
+func (s Service) DeleteInvite(ctx context.Context, req *pbs.DeleteInviteRequest) (*pbs.DeleteInviteResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	_, authResults := s.parentAndAuthResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, authResults.Scope.GetId(), req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteInviteResponse{}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Invite, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	in, err := repo.LookupInvite(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if in == nil {
+		return nil, handlers.NotFoundErrorf("Invite %q doesn't exist.", id)
+	}
+	return toProto(in), nil
+}
+
+func (s Service) createInRepo(ctx context.Context, authMethodId, scopeId string, item *pb.Invite) (*pb.Invite, error) {
+	var opts []password.Option
+	if item.GetName() != nil {
+		opts = append(opts, password.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, password.WithDescription(item.GetDescription().GetValue()))
+	}
+	in, err := password.NewInvite(authMethodId, opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build invite for creation: %v.", err)
+	}
+	in.RoleIds = item.GetRoleIds()
+
+	var createOpts []password.Option
+	if item.GetExpirationTime() != nil {
+		exp, err := ptypes.Timestamp(item.GetExpirationTime())
+		if err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"expiration_time": "Invalid timestamp."})
+		}
+		createOpts = append(createOpts, password.WithInviteTtl(time.Until(exp)))
+	}
+
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateInvite(ctx, scopeId, in, createOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create invite: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create invite but no error returned from repository.")
+	}
+	ret := toProto(out)
+	ret.Token = out.Token
+	return ret, nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteInvite(ctx, scopeId, id)
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to delete invite: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]*pb.Invite, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	ul, err := repo.ListInvites(ctx, authMethodId)
+	if err != nil {
+		return nil, err
+	}
+	var outUl []*pb.Invite
+	for _, u := range ul {
+		outUl = append(outUl, toProto(u))
+	}
+	return outUl, nil
+}
+
+// authorizeRoles returns an error if the caller is not allowed to add
+// principals to every role in roleIds.
+func (s Service) authorizeRoles(ctx context.Context, authResults *auth.VerifyResults, roleIds []string) error {
+	if len(roleIds) == 0 {
+		return nil
+	}
+	iamRepo, err := s.iamRepoFn()
+	if err != nil {
+		return err
+	}
+	badFields := map[string]string{}
+	for _, roleId := range roleIds {
+		role, _, _, err := iamRepo.LookupRole(ctx, roleId)
+		if err != nil {
+			return err
+		}
+		if role == nil {
+			badFields["role_ids"] = fmt.Sprintf("Role %q doesn't exist.", roleId)
+			continue
+		}
+		res := &perms.Resource{
+			ScopeId: role.GetScopeId(),
+			Type:    resource.Role,
+		}
+		if len(authResults.FetchActionSetForId(ctx, roleId, action.ActionSet{action.AddPrincipals}, auth.WithResource(res))) == 0 {
+			return handlers.ForbiddenError()
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*password.AuthMethod, auth.VerifyResults) {
+	res := auth.VerifyResults{}
+	repo, err := s.repoFn()
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Invite), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+	default:
+		in, err := repo.LookupInvite(ctx, id)
+		if err != nil {
+			res.Error = err
+			return nil, res
+		}
+		if in == nil {
+			res.Error = handlers.NotFoundError()
+			return nil, res
+		}
+		parentId = in.GetAuthMethodId()
+		opts = append(opts, auth.WithId(id))
+	}
+
+	authMeth, err := repo.LookupAuthMethod(ctx, parentId)
+	if err != nil {
+		res.Error = err
+		return nil, res
+	}
+	if authMeth == nil {
+		res.Error = handlers.NotFoundError()
+		return nil, res
+	}
+	opts = append(opts, auth.WithScopeId(authMeth.GetScopeId()), auth.WithPin(parentId))
+	return authMeth, auth.Verify(ctx, opts...)
+}
+
+func toProto(in *password.Invite) *pb.Invite {
+	out := pb.Invite{
+		Id:             in.GetPublicId(),
+		CreatedTime:    in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:    in.GetUpdateTime().GetTimestamp(),
+		Version:        in.GetVersion(),
+		AuthMethodId:   in.GetAuthMethodId(),
+		RoleIds:        in.RoleIds,
+		ExpirationTime: in.GetExpirationTime().GetTimestamp(),
+		RedeemedTime:   in.GetRedeemTime().GetTimestamp(),
+		AccountId:      in.GetAccountId(),
+		UserId:         in.GetIamUserId(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	return &out
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetInviteRequest) error {
+	return handlers.ValidateGetRequest(password.InvitePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateInviteRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(password.AuthMethodPrefix, item.GetAuthMethodId()) {
+			badFields["auth_method_id"] = "This field is required to be a valid password auth method id."
+		}
+		for _, roleId := range item.GetRoleIds() {
+			if !handlers.ValidId(iam.RolePrefix, roleId) {
+				badFields["role_ids"] = fmt.Sprintf("Improperly formatted role id %q.", roleId)
+				break
+			}
+		}
+		if item.GetExpirationTime() != nil {
+			exp, err := ptypes.Timestamp(item.GetExpirationTime())
+			switch {
+			case err != nil:
+				badFields["expiration_time"] = "Invalid timestamp."
+			case !exp.After(time.Now()):
+				badFields["expiration_time"] = "Must be in the future."
+			case exp.After(time.Now().Add(password.MaxInviteTtl)):
+				badFields["expiration_time"] = fmt.Sprintf("Must be no more than %s in the future.", password.MaxInviteTtl)
+			}
+		}
+		if item.GetToken() != "" {
+			badFields["token"] = "This is a read only field."
+		}
+		if item.GetRedeemedTime() != nil {
+			badFields["redeemed_time"] = "This is a read only field."
+		}
+		if item.GetAccountId() != "" {
+			badFields["account_id"] = "This is a read only field."
+		}
+		if item.GetUserId() != "" {
+			badFields["user_id"] = "This is a read only field."
+		}
+		return badFields
+	})
+}
+
+func validateDeleteRequest(req *pbs.DeleteInviteRequest) error {
+	return handlers.ValidateDeleteRequest(password.InvitePrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListInvitesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(password.AuthMethodPrefix, req.GetAuthMethodId()) {
+		badFields["auth_method_id"] = "Invalid formatted identifier."
+	}
+	if _, err := handlers.NewFilter(req.GetFilter()); err != nil {
+		badFields["filter"] = fmt.Sprintf("This field could not be parsed. %v", err)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package invites_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/invites"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/invites"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestCreate(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	am := password.TestAuthMethods(t, conn, o.GetPublicId(), 1)[0]
+	role := iam.TestRole(t, conn, o.GetPublicId())
+
+	s, err := invites.NewService(pwRepoFn, iamRepoFn)
+	require.NoError(t, err)
+
+	tooLate, err := ptypes.TimestampProto(time.Now().Add(password.MaxInviteTtl + time.Hour))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		item    *pb.Invite
+		wantErr error
+	}{
+		{
+			name:    "missing-auth-method",
+			item:    &pb.Invite{},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "bad-role-id",
+			item:    &pb.Invite{AuthMethodId: am.GetPublicId(), RoleIds: []string{"bad"}},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "unknown-role",
+			item:    &pb.Invite{AuthMethodId: am.GetPublicId(), RoleIds: []string{"r_1234567890"}},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "expiration-too-late",
+			item:    &pb.Invite{AuthMethodId: am.GetPublicId(), ExpirationTime: tooLate},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "read-only-token",
+			item:    &pb.Invite{AuthMethodId: am.GetPublicId(), Token: "token"},
+			wantErr: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "valid",
+			item: &pb.Invite{
+				AuthMethodId: am.GetPublicId(),
+				Name:         wrapperspb.String("invite"),
+				RoleIds:      []string{role.GetPublicId()},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := s.CreateInvite(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.CreateInviteRequest{Item: tc.item})
+			if tc.wantErr != nil {
+				assert.Error(err)
+				assert.Truef(errors.Is(err, tc.wantErr), "Got %#v, wanted %#v", err, tc.wantErr)
+				return
+			}
+			require.NoError(err)
+			item := got.GetItem()
+			assert.Equal("invites/"+item.GetId(), got.GetUri())
+			assert.NotEmpty(item.GetToken())
+			assert.Equal([]string{role.GetPublicId()}, item.GetRoleIds())
+			assert.NotNil(item.GetExpirationTime())
+			assert.Nil(item.GetRedeemedTime())
+			assert.Equal(o.GetPublicId(), item.GetScope().GetId())
+
+			// the token is only returned on creation
+			read, err := s.GetInvite(auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId()), &pbs.GetInviteRequest{Id: item.GetId()})
+			require.NoError(err)
+			assert.Empty(read.GetItem().GetToken())
+			assert.Equal(item.GetName().GetValue(), read.GetItem().GetName().GetValue())
+		})
+	}
+}
+
+func TestListAndDelete(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	o, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iam.TestRepo(t, conn, wrapper), nil
+	}
+	pwRepoFn := func() (*password.Repository, error) {
+		return password.NewRepository(rw, rw, kms)
+	}
+	ams := password.TestAuthMethods(t, conn, o.GetPublicId(), 2)
+	am, emptyAm := ams[0], ams[1]
+
+	s, err := invites.NewService(pwRepoFn, iamRepoFn)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(iamRepoFn, o.GetPublicId())
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		got, err := s.CreateInvite(ctx, &pbs.CreateInviteRequest{Item: &pb.Invite{AuthMethodId: am.GetPublicId()}})
+		require.NoError(t, err)
+		ids = append(ids, got.GetItem().GetId())
+	}
+
+	list, err := s.ListInvites(ctx, &pbs.ListInvitesRequest{AuthMethodId: am.GetPublicId()})
+	require.NoError(t, err)
+	assert.Len(t, list.GetItems(), 3)
+	for _, item := range list.GetItems() {
+		assert.Empty(t, item.GetToken())
+		assert.Equal(t, invites.IdActions.Strings(), item.GetAuthorizedActions())
+	}
+
+	list, err = s.ListInvites(ctx, &pbs.ListInvitesRequest{AuthMethodId: emptyAm.GetPublicId()})
+	require.NoError(t, err)
+	assert.Empty(t, list.GetItems())
+
+	_, err = s.ListInvites(ctx, &pbs.ListInvitesRequest{AuthMethodId: "bad"})
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Got %#v", err)
+
+	_, err = s.DeleteInvite(ctx, &pbs.DeleteInviteRequest{Id: ids[0]})
+	require.NoError(t, err)
+	_, err = s.DeleteInvite(ctx, &pbs.DeleteInviteRequest{Id: ids[0]})
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Got %#v", err)
+	_, err = s.GetInvite(ctx, &pbs.GetInviteRequest{Id: ids[0]})
+	assert.Truef(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "Got %#v", err)
+
+	list, err = s.ListInvites(ctx, &pbs.ListInvitesRequest{AuthMethodId: am.GetPublicId()})
+	require.NoError(t, err)
+	assert.Len(t, list.GetItems(), 2)
+}
@@ -0,0 +1,61 @@
+package scopes
+
+import (
+	"strings"
+
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func toChangeProto(in *feed.Change) (*pb.Change, error) {
+	out := pb.Change{
+		Id:            in.EntryId,
+		CreatedTime:   in.CreateTime.GetTimestamp(),
+		AggregateName: in.AggregateName,
+		ScopeId:       in.ScopeId(),
+		ResourceType:  in.ResourceType(),
+		ResourceId:    in.ResourcePublicId(),
+		Operation:     toOperation(in.OpType()),
+	}
+	for _, m := range in.Messages {
+		msg := proto.Clone(m.Message)
+		redactBytes(msg.ProtoReflect())
+		value, err := handlers.ProtoToStruct(msg)
+		if err != nil {
+			return nil, err
+		}
+		out.Messages = append(out.Messages, &pb.ChangeMessage{
+			Type:           m.TypeName,
+			Operation:      toOperation(m.OpType.String()),
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+			Value:          value,
+		})
+	}
+	return &out, nil
+}
+
+// toOperation converts an oplog op type, e.g. OP_TYPE_CREATE, to the
+// operation returned in changes, e.g. create.
+func toOperation(opType string) string {
+	if opType == "" || opType == oplog.OpType_OP_TYPE_UNSPECIFIED.String() {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(opType, "OP_TYPE_"))
+}
+
+// redactBytes clears the bytes fields of a message, which hold the
+// encrypted values, hashes, salts and keys of the stores, so they are never
+// returned in changes.
+func redactBytes(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.Kind() == protoreflect.BytesKind {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
@@ -0,0 +1,70 @@
+package scopes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth/password/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToChangeProto(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	cred := &store.Argon2Credential{
+		PrivateId:  "arg2cred_1234567890",
+		CtSalt:     []byte("encrypted salt"),
+		Salt:       []byte("salt"),
+		DerivedKey: []byte("derived key"),
+		KeyId:      "kdkv_1234567890",
+	}
+	in := &feed.Change{
+		EntryId:       5,
+		AggregateName: "auth_password_argon2_cred",
+		Metadata: oplog.Metadata{
+			feed.ScopeIdKey:          []string{"o_1234567890"},
+			feed.ResourceTypeKey:     []string{"password argon2 credential"},
+			feed.ResourcePublicIdKey: []string{"arg2cred_1234567890"},
+			feed.OpTypeKey:           []string{oplog.OpType_OP_TYPE_UPDATE.String()},
+		},
+		Messages: []oplog.Message{
+			{
+				Message:        cred,
+				TypeName:       "auth_password_argon2_cred",
+				OpType:         oplog.OpType_OP_TYPE_UPDATE,
+				FieldMaskPaths: []string{"CtSalt", "DerivedKey"},
+			},
+		},
+	}
+	got, err := toChangeProto(in)
+	require.NoError(err)
+	assert.Equal(uint32(5), got.GetId())
+	assert.Equal("o_1234567890", got.GetScopeId())
+	assert.Equal("password argon2 credential", got.GetResourceType())
+	assert.Equal("arg2cred_1234567890", got.GetResourceId())
+	assert.Equal("update", got.GetOperation())
+	require.Len(got.GetMessages(), 1)
+
+	m := got.GetMessages()[0]
+	assert.Equal("update", m.GetOperation())
+	assert.Equal([]string{"CtSalt", "DerivedKey"}, m.GetFieldMaskPaths())
+	fields := m.GetValue().GetFields()
+	assert.Equal("arg2cred_1234567890", fields["private_id"].GetStringValue())
+	assert.Equal("kdkv_1234567890", fields["key_id"].GetStringValue())
+	for _, f := range []string{"ct_salt", "salt", "derived_key"} {
+		assert.NotContains(fields, f)
+	}
+
+	// the messages of the change are not modified
+	assert.Equal([]byte("derived key"), cred.DerivedKey)
+}
+
+func TestToOperation(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("create", toOperation(oplog.OpType_OP_TYPE_CREATE.String()))
+	assert.Equal("update", toOperation(oplog.OpType_OP_TYPE_UPDATE.String()))
+	assert.Equal("delete", toOperation(oplog.OpType_OP_TYPE_DELETE.String()))
+	assert.Equal("", toOperation(oplog.OpType_OP_TYPE_UNSPECIFIED.String()))
+	assert.Equal("", toOperation(""))
+}
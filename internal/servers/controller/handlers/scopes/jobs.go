@@ -0,0 +1,35 @@
+package scopes
+
+import (
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// defaultJobRunsLimit is the number of runs listed for each job unless the
+// request provides a limit.
+const defaultJobRunsLimit = 5
+
+func toJobProto(in *job.Job, runs []*job.Run) *pb.Job {
+	out := pb.Job{
+		Name:             in.Name,
+		Description:      in.Description,
+		NextScheduledRun: in.NextScheduledRun.GetTimestamp(),
+	}
+	for _, r := range runs {
+		out.Runs = append(out.Runs, toJobRunProto(r))
+	}
+	return &out
+}
+
+func toJobRunProto(in *job.Run) *pb.JobRun {
+	return &pb.JobRun{
+		Id:         in.Id,
+		Controller: in.ServerId,
+		Status:     in.Status,
+		Error:      in.Error,
+		StartTime:  in.CreateTime.GetTimestamp(),
+		EndTime:    in.EndTime.GetTimestamp(),
+		Duration:   durationpb.New(in.Duration()),
+	}
+}
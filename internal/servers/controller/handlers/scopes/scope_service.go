@@ -7,14 +7,19 @@ import (
 	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
 	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/common/scopeids"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/apitokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authmethods"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/authtokens"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/groups"
@@ -41,6 +46,13 @@ var (
 		action.Read,
 		action.Update,
 		action.Delete,
+		action.RotateKeys,
+		action.ReencryptKeyVersion,
+		action.DestroyKeyVersion,
+		action.ListKeys,
+		action.ListChanges,
+		action.ListJobs,
+		action.RunJob,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -51,6 +63,7 @@ var (
 	}
 
 	orgCollectionTypeMap = map[resource.Type]action.ActionSet{
+		resource.ApiToken:   apitokens.CollectionActions,
 		resource.AuthMethod: authmethods.CollectionActions,
 		resource.AuthToken:  authtokens.CollectionActions,
 		resource.Group:      groups.CollectionActions,
@@ -79,15 +92,27 @@ func init() {
 type Service struct {
 	pbs.UnimplementedScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn    common.IamRepoFactory
+	feedFn    common.OplogFeedFactory
+	jobRepoFn common.JobRepoFactory
+	kms       *kms.Kms
 }
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(repo common.IamRepoFactory, feed common.OplogFeedFactory, jobRepo common.JobRepoFactory, kms *kms.Kms) (Service, error) {
 	if repo == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repo}, nil
+	if feed == nil {
+		return Service{}, fmt.Errorf("nil oplog feed provided")
+	}
+	if jobRepo == nil {
+		return Service{}, fmt.Errorf("nil job repository provided")
+	}
+	if kms == nil {
+		return Service{}, fmt.Errorf("nil kms provided")
+	}
+	return Service{repoFn: repo, feedFn: feed, jobRepoFn: jobRepo, kms: kms}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -169,7 +194,7 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 	for _, item := range pl {
 		item.Scope = scopeInfoMap[item.GetScopeId()]
 		res.ScopeId = item.Scope.Id
-		item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, IdActions, auth.WithResource(res)).Strings()
+		item.AuthorizedActions = authResults.FetchActionSetForId(ctx, item.Id, idActions(item.Id), auth.WithResource(res)).Strings()
 		if len(item.AuthorizedActions) == 0 {
 			continue
 		}
@@ -198,12 +223,7 @@ func (s Service) GetScope(ctx context.Context, req *pbs.GetScopeRequest) (*pbs.G
 		return nil, err
 	}
 	p.Scope = authResults.Scope
-	act := IdActions
-	// Can't delete global so elide it
-	if p.Id == "global" {
-		act = act[0:2]
-	}
-	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, act).Strings()
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, idActions(p.Id)).Strings()
 	if err := populateCollectionAuthorizedActions(ctx, authResults, p); err != nil {
 		return nil, err
 	}
@@ -224,7 +244,7 @@ func (s Service) CreateScope(ctx context.Context, req *pbs.CreateScopeRequest) (
 		return nil, err
 	}
 	p.Scope = authResults.Scope
-	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, IdActions).Strings()
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, idActions(p.Id)).Strings()
 	if err := populateCollectionAuthorizedActions(ctx, authResults, p); err != nil {
 		return nil, err
 	}
@@ -245,7 +265,7 @@ func (s Service) UpdateScope(ctx context.Context, req *pbs.UpdateScopeRequest) (
 		return nil, err
 	}
 	p.Scope = authResults.Scope
-	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, IdActions).Strings()
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, idActions(p.Id)).Strings()
 	if err := populateCollectionAuthorizedActions(ctx, authResults, p); err != nil {
 		return nil, err
 	}
@@ -268,6 +288,209 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return &pbs.DeleteScopeResponse{}, nil
 }
 
+// RotateKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateKeys(ctx context.Context, req *pbs.RotateKeysRequest) (*pbs.RotateKeysResponse, error) {
+	if err := validateRotateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if _, err := s.kms.RotateKeys(ctx, req.GetId()); err != nil {
+		return nil, fmt.Errorf("unable to rotate keys: %w", err)
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p.Scope = authResults.Scope
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, idActions(p.Id)).Strings()
+	if err := populateCollectionAuthorizedActions(ctx, authResults, p); err != nil {
+		return nil, err
+	}
+	return &pbs.RotateKeysResponse{Item: p}, nil
+}
+
+// ReencryptKeyVersion implements the interface pbs.ScopeServiceServer.
+func (s Service) ReencryptKeyVersion(ctx context.Context, req *pbs.ReencryptKeyVersionRequest) (*pbs.ReencryptKeyVersionResponse, error) {
+	if err := validateKeyVersionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReencryptKeyVersion)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	ke, err := s.kms.CreateKeyVersionReencryption(ctx, req.GetId(), req.GetKeyVersionId())
+	if err != nil {
+		if errors.Match(errors.T(errors.InvalidParameter), err) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"key_version_id": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to re-encrypt key version: %w", err)
+	}
+	return &pbs.ReencryptKeyVersionResponse{Item: toKeyVersionReencryptionProto(ke)}, nil
+}
+
+// DestroyKeyVersion implements the interface pbs.ScopeServiceServer.
+func (s Service) DestroyKeyVersion(ctx context.Context, req *pbs.DestroyKeyVersionRequest) (*pbs.DestroyKeyVersionResponse, error) {
+	if err := validateKeyVersionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DestroyKeyVersion)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.kms.DestroyKeyVersion(ctx, req.GetId(), req.GetKeyVersionId()); err != nil {
+		if errors.Match(errors.T(errors.InvalidParameter), err) {
+			return nil, handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"key_version_id": err.Error()})
+		}
+		return nil, fmt.Errorf("unable to destroy key version: %w", err)
+	}
+	p, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	p.Scope = authResults.Scope
+	p.AuthorizedActions = authResults.FetchActionSetForId(ctx, p.Id, idActions(p.Id)).Strings()
+	if err := populateCollectionAuthorizedActions(ctx, authResults, p); err != nil {
+		return nil, err
+	}
+	return &pbs.DestroyKeyVersionResponse{Item: p}, nil
+}
+
+// ListKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) ListKeys(ctx context.Context, req *pbs.ListKeysRequest) (*pbs.ListKeysResponse, error) {
+	if err := validateRotateKeysRequest(&pbs.RotateKeysRequest{Id: req.GetId()}); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	keys, err := s.kms.ListKeys(ctx, req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("unable to list keys: %w", err)
+	}
+	items := make([]*pb.Key, 0, len(keys))
+	for _, k := range keys {
+		items = append(items, toKeyProto(k))
+	}
+	return &pbs.ListKeysResponse{Items: items}, nil
+}
+
+// ListChanges implements the interface pbs.ScopeServiceServer.
+func (s Service) ListChanges(ctx context.Context, req *pbs.ListChangesRequest) (*pbs.ListChangesResponse, error) {
+	if err := validateListChangesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListChanges)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	f, err := s.feedFn()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := f.ListChanges(ctx, req.GetCursor(),
+		feed.WithLimit(int(req.GetLimit())),
+		feed.WithScopeIds(req.GetScopeIds()...),
+		feed.WithResourceTypes(req.GetResourceTypes()...))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list changes: %w", err)
+	}
+	resp := &pbs.ListChangesResponse{Cursor: req.GetCursor()}
+	for _, c := range changes {
+		item, err := toChangeProto(c)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert change %d: %w", c.EntryId, err)
+		}
+		resp.Items = append(resp.Items, item)
+		resp.Cursor = c.EntryId
+	}
+	return resp, nil
+}
+
+// ListJobs implements the interface pbs.ScopeServiceServer.
+func (s Service) ListJobs(ctx context.Context, req *pbs.ListJobsRequest) (*pbs.ListJobsResponse, error) {
+	if err := validateJobsRequest(req.GetId(), nil); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListJobs)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := repo.ListJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list jobs: %w", err)
+	}
+	runsLimit := int(req.GetRunsLimit())
+	if runsLimit == 0 {
+		runsLimit = defaultJobRunsLimit
+	}
+	items := make([]*pb.Job, 0, len(jobs))
+	for _, j := range jobs {
+		runs, err := repo.ListRuns(ctx, j.Name, job.WithLimit(runsLimit))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list runs of job %q: %w", j.Name, err)
+		}
+		items = append(items, toJobProto(j, runs))
+	}
+	return &pbs.ListJobsResponse{Items: items}, nil
+}
+
+// RunJob implements the interface pbs.ScopeServiceServer.
+func (s Service) RunJob(ctx context.Context, req *pbs.RunJobRequest) (*pbs.RunJobResponse, error) {
+	if err := validateJobsRequest(req.GetId(), func(badFields map[string]string) {
+		if req.GetName() == "" {
+			badFields["name"] = "This is a required field."
+		}
+	}); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RunJob)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.jobRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	j, err := repo.RunJobNow(ctx, req.GetName())
+	if err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("Job %q doesn't exist.", req.GetName())
+		}
+		return nil, fmt.Errorf("unable to run job: %w", err)
+	}
+	runs, err := repo.ListRuns(ctx, j.Name, job.WithLimit(defaultJobRunsLimit))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list runs of job %q: %w", j.Name, err)
+	}
+	return &pbs.RunJobResponse{Item: toJobProto(j, runs)}, nil
+}
+
+// idActions returns the actions that can be performed on the scope with the
+// given id.
+func idActions(id string) action.ActionSet {
+	// Can't delete global so elide it, and changes and jobs can only be
+	// listed in global so elide them elsewhere
+	elided := map[action.Type]bool{action.ListChanges: true, action.ListJobs: true, action.RunJob: true}
+	if id == scope.Global.String() {
+		elided = map[action.Type]bool{action.Delete: true}
+	}
+	act := make(action.ActionSet, 0, len(IdActions))
+	for _, a := range IdActions {
+		if !elided[a] {
+			act = append(act, a)
+		}
+	}
+	return act
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -292,6 +515,15 @@ func (s Service) createInRepo(ctx context.Context, authResults auth.VerifyResult
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if ttl := item.GetAuthTokenTimeToLiveSeconds(); ttl != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToLiveSeconds(ttl.GetValue()))
+	}
+	if tts := item.GetAuthTokenTimeToStaleSeconds(); tts != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToStaleSeconds(tts.GetValue()))
+	}
+	if sr := item.GetSessionRetentionSeconds(); sr != nil {
+		opts = append(opts, iam.WithSessionRetentionSeconds(sr.GetValue()))
+	}
 	opts = append(opts, iam.WithSkipAdminRoleCreation(req.GetSkipAdminRoleCreation()))
 	opts = append(opts, iam.WithSkipDefaultRoleCreation(req.GetSkipDefaultRoleCreation()))
 
@@ -329,6 +561,15 @@ func (s Service) updateInRepo(ctx context.Context, parentScope *pb.ScopeInfo, sc
 	if name := item.GetName(); name != nil {
 		opts = append(opts, iam.WithName(name.GetValue()))
 	}
+	if ttl := item.GetAuthTokenTimeToLiveSeconds(); ttl != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToLiveSeconds(ttl.GetValue()))
+	}
+	if tts := item.GetAuthTokenTimeToStaleSeconds(); tts != nil {
+		opts = append(opts, iam.WithAuthTokenTimeToStaleSeconds(tts.GetValue()))
+	}
+	if sr := item.GetSessionRetentionSeconds(); sr != nil {
+		opts = append(opts, iam.WithSessionRetentionSeconds(sr.GetValue()))
+	}
 	version := item.GetVersion()
 
 	var iamScope *iam.Scope
@@ -453,6 +694,58 @@ func ToProto(in *iam.Scope) *pb.Scope {
 	if in.GetName() != "" {
 		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
 	}
+	if in.GetAuthTokenTimeToLiveSeconds() != 0 {
+		out.AuthTokenTimeToLiveSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToLiveSeconds())
+	}
+	if in.GetAuthTokenTimeToStaleSeconds() != 0 {
+		out.AuthTokenTimeToStaleSeconds = wrapperspb.UInt32(in.GetAuthTokenTimeToStaleSeconds())
+	}
+	if in.GetSessionRetentionSeconds() != 0 {
+		out.SessionRetentionSeconds = wrapperspb.UInt32(in.GetSessionRetentionSeconds())
+	}
+	return &out
+}
+
+func toKeyProto(in *kms.KeyInfo) *pb.Key {
+	out := pb.Key{
+		Id:          in.Id,
+		ScopeId:     in.ScopeId,
+		Type:        "data",
+		CreatedTime: in.CreateTime.GetTimestamp(),
+	}
+	if in.Type == kms.KeyTypeRootKey {
+		out.Type = "root"
+	} else {
+		out.Purpose = in.Purpose.String()
+	}
+	for _, v := range in.Versions {
+		out.Versions = append(out.Versions, &pb.KeyVersion{
+			Id:              v.Id,
+			Version:         v.Version,
+			CreatedTime:     v.CreateTime.GetTimestamp(),
+			Current:         v.Current,
+			ReferenceCounts: v.References,
+		})
+	}
+	return &out
+}
+
+func toKeyVersionReencryptionProto(in *kms.KeyVersionReencryption) *pb.KeyVersionReencryption {
+	out := pb.KeyVersionReencryption{
+		KeyVersionId: in.KeyVersionId,
+		ScopeId:      in.ScopeId,
+		Purpose:      in.Purpose().String(),
+		CreatedTime:  in.CreateTime.GetTimestamp(),
+		UpdatedTime:  in.UpdateTime.GetTimestamp(),
+		Completed:    in.Completed(),
+	}
+	for _, t := range in.Tables {
+		out.Tables = append(out.Tables, &pb.KeyVersionReencryptionTable{
+			Name:           t.Name,
+			TotalCount:     t.TotalCount,
+			RemainingCount: t.RemainingCount,
+		})
+	}
 	return &out
 }
 
@@ -483,6 +776,94 @@ func validateGetRequest(req *pbs.GetScopeRequest) error {
 	return nil
 }
 
+// keyVersionRequest is implemented by the requests of the actions on a key
+// version of a scope.
+type keyVersionRequest interface {
+	GetId() string
+	GetKeyVersionId() string
+}
+
+func validateKeyVersionRequest(req keyVersionRequest) error {
+	badFields := map[string]string{}
+	id := req.GetId()
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(scope.Org.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(scope.Project.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if req.GetKeyVersionId() == "" {
+		badFields["key_version_id"] = "Missing value for key_version_id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateListChangesRequest(req *pbs.ListChangesRequest) error {
+	badFields := map[string]string{}
+	if req.GetId() != scope.Global.String() {
+		badFields["id"] = "Changes can only be listed in the global scope."
+	}
+	for _, id := range req.GetScopeIds() {
+		if id != scope.Global.String() && !handlers.ValidId(scope.Org.Prefix(), id) && !handlers.ValidId(scope.Project.Prefix(), id) {
+			badFields["scope_ids"] = fmt.Sprintf("Invalidly formatted scope id %q.", id)
+			break
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+// validateJobsRequest validates the requests on jobs, which are only
+// supported in the global scope.  The validate function, if provided, adds
+// the bad fields of the rest of the request.
+func validateJobsRequest(id string, validate func(badFields map[string]string)) error {
+	badFields := map[string]string{}
+	if id != scope.Global.String() {
+		badFields["id"] = "Jobs can only be listed and run in the global scope."
+	}
+	if validate != nil {
+		validate(badFields)
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateRotateKeysRequest(req *pbs.RotateKeysRequest) error {
+	badFields := map[string]string{}
+	id := req.GetId()
+	switch {
+	case id == scope.Global.String():
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		if !handlers.ValidId(scope.Org.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	case strings.HasPrefix(id, scope.Project.Prefix()):
+		if !handlers.ValidId(scope.Project.Prefix(), id) {
+			badFields["id"] = "Invalidly formatted scope id."
+		}
+	default:
+		badFields["id"] = "Invalidly formatted scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateCreateRequest(req *pbs.CreateScopeRequest) error {
 	badFields := map[string]string{}
 	item := req.GetItem()
@@ -513,6 +894,10 @@ func validateCreateRequest(req *pbs.CreateScopeRequest) error {
 	if item.GetVersion() != 0 {
 		badFields["version"] = "This cannot be specified at create time."
 	}
+	validateAuthTokenDurations(item, strings.HasPrefix(item.GetScopeId(), scope.Org.Prefix()), badFields)
+	if sr := item.GetSessionRetentionSeconds(); sr != nil && sr.GetValue() == 0 {
+		badFields["session_retention_seconds"] = "This must be greater than zero."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
 	}
@@ -566,6 +951,10 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	if item.GetUpdatedTime() != nil {
 		badFields["updated_time"] = "This is a read only field and cannot be specified in an update request."
 	}
+	validateAuthTokenDurations(item, strings.HasPrefix(id, scope.Project.Prefix()), badFields)
+	if sr := item.GetSessionRetentionSeconds(); sr != nil && sr.GetValue() == 0 {
+		badFields["session_retention_seconds"] = "This must be greater than zero."
+	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
 	}
@@ -573,6 +962,23 @@ func validateUpdateRequest(req *pbs.UpdateScopeRequest) error {
 	return nil
 }
 
+// validateAuthTokenDurations ensures the auth token durations are only set on
+// scopes which can contain auth methods and are greater than zero.
+func validateAuthTokenDurations(item *pb.Scope, isProject bool, badFields map[string]string) {
+	for field, val := range map[string]*wrapperspb.UInt32Value{
+		"auth_token_time_to_live_seconds":  item.GetAuthTokenTimeToLiveSeconds(),
+		"auth_token_time_to_stale_seconds": item.GetAuthTokenTimeToStaleSeconds(),
+	} {
+		switch {
+		case val == nil:
+		case isProject:
+			badFields[field] = "This field cannot be set on project scopes."
+		case val.GetValue() == 0:
+			badFields[field] = "This must be greater than zero."
+		}
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteScopeRequest) error {
 	badFields := map[string]string{}
 	id := req.GetId()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/wrappers"
@@ -15,6 +16,9 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/feed"
+	"github.com/hashicorp/boundary/internal/scheduler/job"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/types/scope"
@@ -29,14 +33,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), func() (*feed.Feed, error), func() (*job.Repository, error), *kms.Kms) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
 	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	feedFn := func() (*feed.Feed, error) {
+		return feed.NewFeed(db.New(conn), kmsCache)
+	}
+	jobRepoFn := func() (*job.Repository, error) {
+		return job.NewRepository(db.New(conn), db.New(conn))
+	}
 
 	oRes, pRes := iam.TestScopes(t, iamRepo)
 
@@ -53,10 +64,16 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	require.NoError(t, err)
 	pRes, _, err = repo.UpdateScope(context.Background(), pRes, 1, []string{"Name", "Description"})
 	require.NoError(t, err)
-	return oRes, pRes, repoFn
+	return oRes, pRes, repoFn, feedFn, jobRepoFn, kmsCache
 }
 
 var orgAuthorizedCollectionActions = map[string]*structpb.ListValue{
+	"api-tokens": {
+		Values: []*structpb.Value{
+			structpb.NewStringValue("create"),
+			structpb.NewStringValue("list"),
+		},
+	},
 	"auth-methods": {
 		Values: []*structpb.Value{
 			structpb.NewStringValue("create"),
@@ -127,7 +144,7 @@ var projectAuthorizedCollectionActions = map[string]*structpb.ListValue{
 }
 
 func TestGet(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
 	toMerge := &pbs.GetScopeRequest{
 		Id: proj.GetPublicId(),
 	}
@@ -142,7 +159,7 @@ func TestGet(t *testing.T) {
 		UpdatedTime:                 org.UpdateTime.GetTimestamp(),
 		Version:                     2,
 		Type:                        scope.Org.String(),
-		AuthorizedActions:           []string{"read", "update", "delete"},
+		AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 		AuthorizedCollectionActions: orgAuthorizedCollectionActions,
 	}
 
@@ -156,7 +173,7 @@ func TestGet(t *testing.T) {
 		UpdatedTime:                 proj.UpdateTime.GetTimestamp(),
 		Version:                     2,
 		Type:                        scope.Project.String(),
-		AuthorizedActions:           []string{"read", "update", "delete"},
+		AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 		AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 	}
 
@@ -215,7 +232,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetScopeRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 			require.NoError(err, "Couldn't create new project service.")
 
 			got, gErr := s.GetScope(auth.DisabledAuthTestContext(repoFn, tc.scopeId), req)
@@ -232,9 +249,16 @@ func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
 	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	feedFn := func() (*feed.Feed, error) {
+		return feed.NewFeed(db.New(conn), kmsCache)
+	}
+	jobRepoFn := func() (*job.Repository, error) {
+		return job.NewRepository(db.New(conn), db.New(conn))
+	}
 	repo, err := repoFn()
 	require.NoError(t, err)
 
@@ -249,11 +273,11 @@ func TestList(t *testing.T) {
 	globalScope := &pb.ScopeInfo{Id: "global", Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"}
 	oNoProjectsProto := scopes.ToProto(oNoProjects)
 	oNoProjectsProto.Scope = globalScope
-	oNoProjectsProto.AuthorizedActions = []string{"read", "update", "delete"}
+	oNoProjectsProto.AuthorizedActions = []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"}
 	oNoProjectsProto.AuthorizedCollectionActions = orgAuthorizedCollectionActions
 	oWithProjectsProto := scopes.ToProto(oWithProjects)
 	oWithProjectsProto.Scope = globalScope
-	oWithProjectsProto.AuthorizedActions = []string{"read", "update", "delete"}
+	oWithProjectsProto.AuthorizedActions = []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"}
 	oWithProjectsProto.AuthorizedCollectionActions = orgAuthorizedCollectionActions
 	initialOrgs = append(initialOrgs, oNoProjectsProto, oWithProjectsProto)
 	scopes.SortScopes(initialOrgs)
@@ -293,7 +317,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
@@ -319,7 +343,7 @@ func TestList(t *testing.T) {
 			UpdatedTime:                 o.GetUpdateTime().GetTimestamp(),
 			Version:                     1,
 			Type:                        scope.Org.String(),
-			AuthorizedActions:           []string{"read", "update", "delete"},
+			AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 			AuthorizedCollectionActions: orgAuthorizedCollectionActions,
 		})
 	}
@@ -340,7 +364,7 @@ func TestList(t *testing.T) {
 			UpdatedTime:                 p.GetUpdateTime().GetTimestamp(),
 			Version:                     1,
 			Type:                        scope.Project.String(),
-			AuthorizedActions:           []string{"read", "update", "delete"},
+			AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 			AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 		})
 	}
@@ -395,7 +419,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
@@ -409,9 +433,9 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	cases := []struct {
@@ -485,9 +509,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repoFn)
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 	require.NoError(err, "Error when getting new scopes service")
 	ctx := auth.DisabledAuthTestContext(repoFn, org.GetPublicId())
 	req := &pbs.DeleteScopeRequest{
@@ -510,9 +534,369 @@ func TestDelete_twice(t *testing.T) {
 	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found for the second delete.")
 }
 
+func TestRotateKeys(t *testing.T) {
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.RotateKeysRequest
+		err     error
+	}{
+		{
+			name:    "Global",
+			scopeId: scope.Global.String(),
+			req:     &pbs.RotateKeysRequest{Id: scope.Global.String()},
+		},
+		{
+			name:    "Org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.RotateKeysRequest{Id: org.GetPublicId()},
+		},
+		{
+			name:    "Project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateKeysRequest{Id: proj.GetPublicId()},
+		},
+		{
+			name:    "Nonexistent project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateKeysRequest{Id: "p_doesntexis"},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Bad id formatting",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.RotateKeysRequest{Id: "bad_format"},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.RotateKeys(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "RotateKeys(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			assert.Equal(tc.req.GetId(), got.GetItem().GetId())
+			assert.Contains(got.GetItem().GetAuthorizedActions(), "rotate-keys")
+		})
+	}
+}
+
+func TestKeyVersions(t *testing.T) {
+	org, _, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+	ctx := auth.DisabledAuthTestContext(repoFn, scope.Global.String())
+
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	w, err := kmsCache.GetWrapper(context.Background(), org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(t, err)
+	oldKeyId := w.KeyID()
+	_, err = s.RotateKeys(ctx, &pbs.RotateKeysRequest{Id: org.GetPublicId()})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name         string
+		id           string
+		keyVersionId string
+		err          error
+	}{
+		{
+			name:         "Bad id formatting",
+			id:           "bad_format",
+			keyVersionId: oldKeyId,
+			err:          handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing key version id",
+			id:   org.GetPublicId(),
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:         "Not a key version id",
+			id:           org.GetPublicId(),
+			keyVersionId: org.GetPublicId(),
+			err:          handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.ReencryptKeyVersion(ctx, &pbs.ReencryptKeyVersionRequest{Id: tc.id, KeyVersionId: tc.keyVersionId})
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "ReencryptKeyVersion got error %v, wanted %v", gErr, tc.err)
+			_, gErr = s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: tc.id, KeyVersionId: tc.keyVersionId})
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "DestroyKeyVersion got error %v, wanted %v", gErr, tc.err)
+		})
+	}
+
+	t.Run("Reencrypt and destroy", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ReencryptKeyVersion(ctx, &pbs.ReencryptKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: oldKeyId})
+		require.NoError(err)
+		assert.Equal(oldKeyId, got.GetItem().GetKeyVersionId())
+		assert.Equal(org.GetPublicId(), got.GetItem().GetScopeId())
+		assert.Equal(kms.KeyPurposeOplog.String(), got.GetItem().GetPurpose())
+
+		require.NoError(kmsCache.RunKeyVersionReencryptions(context.Background()))
+		got, err = s.ReencryptKeyVersion(ctx, &pbs.ReencryptKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: oldKeyId})
+		require.NoError(err)
+		assert.True(got.GetItem().GetCompleted())
+
+		destroyed, err := s.DestroyKeyVersion(ctx, &pbs.DestroyKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: oldKeyId})
+		require.NoError(err)
+		assert.Equal(org.GetPublicId(), destroyed.GetItem().GetId())
+		assert.Contains(destroyed.GetItem().GetAuthorizedActions(), "destroy-key-version")
+	})
+}
+
+func TestListKeys(t *testing.T) {
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
+	require.NoError(t, err, "Error when getting new scopes service")
+
+	cases := []struct {
+		name    string
+		scopeId string
+		req     *pbs.ListKeysRequest
+		err     error
+	}{
+		{
+			name:    "Global",
+			scopeId: scope.Global.String(),
+			req:     &pbs.ListKeysRequest{Id: scope.Global.String()},
+		},
+		{
+			name:    "Org",
+			scopeId: scope.Global.String(),
+			req:     &pbs.ListKeysRequest{Id: org.GetPublicId()},
+		},
+		{
+			name:    "Project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: proj.GetPublicId()},
+		},
+		{
+			name:    "Nonexistent project",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: "p_doesntexis"},
+			err:     handlers.ApiErrorWithCode(codes.NotFound),
+		},
+		{
+			name:    "Bad id formatting",
+			scopeId: org.GetPublicId(),
+			req:     &pbs.ListKeysRequest{Id: "bad_format"},
+			err:     handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ListKeys(auth.DisabledAuthTestContext(repoFn, tc.scopeId), tc.req)
+			if tc.err != nil {
+				require.Error(gErr)
+				assert.True(errors.Is(gErr, tc.err), "ListKeys(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+				return
+			}
+			require.NoError(gErr)
+			require.Len(got.GetItems(), 6)
+			assert.Equal("root", got.GetItems()[0].GetType())
+			for _, k := range got.GetItems() {
+				assert.Equal(tc.req.GetId(), k.GetScopeId())
+				require.NotEmpty(k.GetVersions())
+				assert.True(k.GetVersions()[0].GetCurrent())
+			}
+		})
+	}
+}
+
+func TestListChanges(t *testing.T) {
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
+	require.NoError(t, err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(repoFn, scope.Global.String())
+
+	t.Run("all", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListChanges(ctx, &pbs.ListChangesRequest{Id: scope.Global.String()})
+		require.NoError(err)
+		require.NotEmpty(got.GetItems())
+		var last uint32
+		for _, c := range got.GetItems() {
+			assert.Greater(c.GetId(), last)
+			last = c.GetId()
+			require.NotEmpty(c.GetMessages())
+		}
+		assert.Equal(last, got.GetCursor())
+
+		got, err = s.ListChanges(ctx, &pbs.ListChangesRequest{Id: scope.Global.String(), Cursor: last})
+		require.NoError(err)
+		assert.Empty(got.GetItems())
+		assert.Equal(last, got.GetCursor())
+	})
+	t.Run("paged", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		all, err := s.ListChanges(ctx, &pbs.ListChangesRequest{Id: scope.Global.String()})
+		require.NoError(err)
+		var paged []*pb.Change
+		var cursor uint32
+		for {
+			got, err := s.ListChanges(ctx, &pbs.ListChangesRequest{Id: scope.Global.String(), Cursor: cursor, Limit: 1})
+			require.NoError(err)
+			if len(got.GetItems()) == 0 {
+				break
+			}
+			require.Len(got.GetItems(), 1)
+			paged = append(paged, got.GetItems()...)
+			cursor = got.GetCursor()
+		}
+		assert.Empty(cmp.Diff(all.GetItems(), paged, protocmp.Transform()))
+	})
+	t.Run("filtered", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListChanges(ctx, &pbs.ListChangesRequest{
+			Id:            scope.Global.String(),
+			ScopeIds:      []string{org.GetPublicId()},
+			ResourceTypes: []string{"scope"},
+		})
+		require.NoError(err)
+		require.NotEmpty(got.GetItems())
+		var updated bool
+		for _, c := range got.GetItems() {
+			assert.Equal(org.GetPublicId(), c.GetScopeId())
+			assert.Equal("scope", c.GetResourceType())
+			if c.GetOperation() == "update" && c.GetResourceId() == proj.GetPublicId() {
+				updated = true
+				require.Len(c.GetMessages(), 1)
+				m := c.GetMessages()[0]
+				assert.Equal("iam_scope", m.GetType())
+				assert.ElementsMatch([]string{"Name", "Description"}, m.GetFieldMaskPaths())
+				assert.Equal("defaultProj", m.GetValue().GetFields()["name"].GetStringValue())
+			}
+		}
+		assert.True(updated, "the update of the default project was not listed")
+	})
+	errCases := []struct {
+		name string
+		req  *pbs.ListChangesRequest
+	}{
+		{
+			name: "Org",
+			req:  &pbs.ListChangesRequest{Id: org.GetPublicId()},
+		},
+		{
+			name: "Project",
+			req:  &pbs.ListChangesRequest{Id: proj.GetPublicId()},
+		},
+		{
+			name: "Bad scope id filter",
+			req:  &pbs.ListChangesRequest{Id: scope.Global.String(), ScopeIds: []string{"bad_format"}},
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			_, gErr := s.ListChanges(ctx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "ListChanges(%+v) got error %v, wanted invalid argument", tc.req, gErr)
+		})
+	}
+}
+
+func TestJobs(t *testing.T) {
+	org, _, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
+	require.NoError(t, err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(repoFn, scope.Global.String())
+
+	jobRepo, err := jobRepoFn()
+	require.NoError(t, err)
+	_, err = jobRepo.UpsertJob(context.Background(), "test_job", "Test job.")
+	require.NoError(t, err)
+	run, err := jobRepo.StartRun(context.Background(), "test_job", "controller1")
+	require.NoError(t, err)
+	_, err = jobRepo.EndRun(context.Background(), run.Id, fmt.Errorf("test failure"), time.Hour)
+	require.NoError(t, err)
+
+	t.Run("list", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ListJobs(ctx, &pbs.ListJobsRequest{Id: scope.Global.String()})
+		require.NoError(err)
+		require.Len(got.GetItems(), 1)
+		j := got.GetItems()[0]
+		assert.Equal("test_job", j.GetName())
+		assert.Equal("Test job.", j.GetDescription())
+		assert.True(j.GetNextScheduledRun().AsTime().After(time.Now()))
+		require.Len(j.GetRuns(), 1)
+		r := j.GetRuns()[0]
+		assert.Equal("controller1", r.GetController())
+		assert.Equal("failed", r.GetStatus())
+		assert.Equal("test failure", r.GetError())
+		assert.NotNil(r.GetEndTime())
+		assert.NotNil(r.GetDuration())
+	})
+	t.Run("run", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.RunJob(ctx, &pbs.RunJobRequest{Id: scope.Global.String(), Name: "test_job"})
+		require.NoError(err)
+		assert.Equal("test_job", got.GetItem().GetName())
+		assert.False(got.GetItem().GetNextScheduledRun().AsTime().After(time.Now()))
+
+		_, err = s.RunJob(ctx, &pbs.RunJobRequest{Id: scope.Global.String(), Name: "unknown"})
+		require.Error(err)
+		assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "RunJob got error %v, wanted not found", err)
+	})
+	errCases := []struct {
+		name string
+		fn   func() error
+	}{
+		{
+			name: "List in org",
+			fn: func() error {
+				_, err := s.ListJobs(ctx, &pbs.ListJobsRequest{Id: org.GetPublicId()})
+				return err
+			},
+		},
+		{
+			name: "Run in org",
+			fn: func() error {
+				_, err := s.RunJob(ctx, &pbs.RunJobRequest{Id: org.GetPublicId(), Name: "test_job"})
+				return err
+			},
+		},
+		{
+			name: "Run without name",
+			fn: func() error {
+				_, err := s.RunJob(ctx, &pbs.RunJobRequest{Id: scope.Global.String()})
+				return err
+			},
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			gErr := tc.fn()
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", gErr)
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
+	defaultOrg, defaultProj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
 	defaultProjCreated, err := ptypes.Timestamp(defaultProj.GetCreateTime().GetTimestamp())
 	require.NoError(t, err, "Error converting proto to timestamp.")
 	toMerge := &pbs.CreateScopeRequest{}
@@ -554,7 +938,7 @@ func TestCreate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					Version:                     1,
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -578,7 +962,7 @@ func TestCreate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					Version:                     1,
 					Type:                        scope.Org.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: orgAuthorizedCollectionActions,
 				},
 			},
@@ -601,7 +985,7 @@ func TestCreate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					Version:                     1,
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -624,7 +1008,7 @@ func TestCreate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					Version:                     1,
 					Type:                        scope.Org.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: orgAuthorizedCollectionActions,
 				},
 			},
@@ -696,7 +1080,7 @@ func TestCreate(t *testing.T) {
 				req := proto.Clone(toMerge).(*pbs.CreateScopeRequest)
 				proto.Merge(req, tc.req)
 
-				s, err := scopes.NewService(repoFn)
+				s, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 				require.NoError(err, "Error when getting new project service.")
 
 				if name != "" {
@@ -772,8 +1156,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
-	tested, err := scopes.NewService(repoFn)
+	org, proj, repoFn, feedFn, jobRepoFn, kmsCache := createDefaultScopesAndRepo(t)
+	tested, err := scopes.NewService(repoFn, feedFn, jobRepoFn, kmsCache)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	var orgVersion uint32 = 2
@@ -836,7 +1220,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -863,7 +1247,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:                 org.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Org.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: orgAuthorizedCollectionActions,
 				},
 			},
@@ -889,7 +1273,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -962,7 +1346,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "defaultProj"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -986,7 +1370,7 @@ func TestUpdate(t *testing.T) {
 					Name:                        &wrappers.StringValue{Value: "defaultProj"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -1012,7 +1396,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "defaultProj"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -1038,7 +1422,7 @@ func TestUpdate(t *testing.T) {
 					Description:                 &wrapperspb.StringValue{Value: "notignored"},
 					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
 					Type:                        scope.Project.String(),
-					AuthorizedActions:           []string{"read", "update", "delete"},
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
 					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
 				},
 			},
@@ -1104,6 +1488,99 @@ func TestUpdate(t *testing.T) {
 			res: nil,
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name:    "Cant set Auth Token Durations on a Project",
+			scopeId: org.GetPublicId(),
+			req: &pbs.UpdateScopeRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"auth_token_time_to_live_seconds"},
+				},
+				Item: &pb.Scope{
+					AuthTokenTimeToLiveSeconds: wrapperspb.UInt32(3600),
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Cant set Auth Token Duration to zero",
+			scopeId: scope.Global.String(),
+			req: &pbs.UpdateScopeRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"auth_token_time_to_stale_seconds"},
+				},
+				Item: &pb.Scope{
+					AuthTokenTimeToStaleSeconds: wrapperspb.UInt32(0),
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Update Org Auth Token Durations",
+			scopeId: scope.Global.String(),
+			req: &pbs.UpdateScopeRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"auth_token_time_to_live_seconds", "auth_token_time_to_stale_seconds"},
+				},
+				Item: &pb.Scope{
+					AuthTokenTimeToLiveSeconds:  wrapperspb.UInt32(3600),
+					AuthTokenTimeToStaleSeconds: wrapperspb.UInt32(600),
+				},
+			},
+			res: &pbs.UpdateScopeResponse{
+				Item: &pb.Scope{
+					Id:                          org.GetPublicId(),
+					ScopeId:                     scope.Global.String(),
+					Scope:                       &pb.ScopeInfo{Id: scope.Global.String(), Type: scope.Global.String(), Name: scope.Global.String(), Description: "Global Scope"},
+					Name:                        &wrapperspb.StringValue{Value: "defaultOrg"},
+					Description:                 &wrapperspb.StringValue{Value: "defaultOrg"},
+					CreatedTime:                 org.GetCreateTime().GetTimestamp(),
+					Type:                        scope.Org.String(),
+					AuthTokenTimeToLiveSeconds:  wrapperspb.UInt32(3600),
+					AuthTokenTimeToStaleSeconds: wrapperspb.UInt32(600),
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
+					AuthorizedCollectionActions: orgAuthorizedCollectionActions,
+				},
+			},
+		},
+		{
+			name:    "Cant set Session Retention to zero",
+			scopeId: org.GetPublicId(),
+			req: &pbs.UpdateScopeRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"session_retention_seconds"},
+				},
+				Item: &pb.Scope{
+					SessionRetentionSeconds: wrapperspb.UInt32(0),
+				},
+			},
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name:    "Update Project Session Retention",
+			scopeId: org.GetPublicId(),
+			req: &pbs.UpdateScopeRequest{
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"session_retention_seconds"},
+				},
+				Item: &pb.Scope{
+					SessionRetentionSeconds: wrapperspb.UInt32(86400),
+				},
+			},
+			res: &pbs.UpdateScopeResponse{
+				Item: &pb.Scope{
+					Id:                          proj.GetPublicId(),
+					ScopeId:                     org.GetPublicId(),
+					Scope:                       &pb.ScopeInfo{Id: org.GetPublicId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String(), Name: "defaultOrg", Description: "defaultOrg"},
+					Name:                        &wrapperspb.StringValue{Value: "defaultProj"},
+					Description:                 &wrapperspb.StringValue{Value: "defaultProj"},
+					CreatedTime:                 proj.GetCreateTime().GetTimestamp(),
+					Type:                        scope.Project.String(),
+					SessionRetentionSeconds:     wrapperspb.UInt32(86400),
+					AuthorizedActions:           []string{"read", "update", "delete", "rotate-keys", "reencrypt-key-version", "destroy-key-version", "list-keys"},
+					AuthorizedCollectionActions: projectAuthorizedCollectionActions,
+				},
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
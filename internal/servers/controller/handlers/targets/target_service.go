@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/hashicorp/boundary/internal/auth"
@@ -17,6 +18,8 @@ import (
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
+	"github.com/hashicorp/boundary/internal/libs/cron"
 	"github.com/hashicorp/boundary/internal/perms"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
@@ -335,6 +338,12 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 	if authResults.AuthTokenId == "" {
 		return nil, handlers.ForbiddenError()
 	}
+	// A session references the auth token it was authorized with and is
+	// canceled when that auth token is deleted. Api tokens are not auth
+	// tokens, so they can't be used to authorize sessions.
+	if authResults.ApiToken {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.PermissionDenied, "Sessions cannot be authorized with an API token.")
+	}
 
 	// Get the target information
 	repo, err := s.repoFn()
@@ -352,6 +361,24 @@ func (s Service) AuthorizeSession(ctx context.Context, req *pbs.AuthorizeSession
 		return nil, handlers.NotFoundErrorf("Target %q not found.", t.GetPublicId())
 	}
 
+	// The client address respects the trusted X-Forwarded-For settings of the
+	// listener the request was received on. Workers check the address of
+	// each connection again when the client connects.
+	if !target.ClientAllowed(t, auth.ClientIpFromContext(ctx)) {
+		return nil, handlers.ForbiddenError()
+	}
+
+	// Sessions can only be authorized while the access window of the target,
+	// if any, is open and they expire no later than when it closes.
+	now := time.Now()
+	windowCloses, windowOpen, err := target.AccessWindowCloses(t, now)
+	if err != nil {
+		return nil, err
+	}
+	if !windowOpen {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Target %q is outside of its access window.", t.GetPublicId())
+	}
+
 	// Instantiate some repos
 	sessionRepo, err := s.sessionRepoFn()
 	if err != nil {
@@ -509,8 +536,10 @@ HostSetIterationLoop:
 		endpointUrl.Host = endpointHost
 	}
 
-	expTime := timestamppb.Now()
-	expTime.Seconds += int64(t.GetSessionMaxSeconds())
+	expTime := timestamppb.New(now.Add(time.Duration(t.GetSessionMaxSeconds()) * time.Second))
+	if !windowCloses.IsZero() && windowCloses.Before(expTime.AsTime()) {
+		expTime = timestamppb.New(windowCloses)
+	}
 	sessionComposition := session.ComposedOf{
 		UserId:          authResults.UserId,
 		HostId:          chosenId.hostId,
@@ -522,6 +551,9 @@ HostSetIterationLoop:
 		ExpirationTime:  &timestamp.Timestamp{Timestamp: expTime},
 		ConnectionLimit: t.GetSessionConnectionLimit(),
 		WorkerFilter:    t.GetWorkerFilter(),
+
+		AllowedClientCidrs: t.GetAllowedClientCidrs(),
+		DeniedClientCidrs:  t.GetDeniedClientCidrs(),
 	}
 
 	sess, err := session.New(sessionComposition)
@@ -603,6 +635,21 @@ func (s Service) createInRepo(ctx context.Context, item *pb.Target) (*pb.Target,
 	if item.GetWorkerFilter() != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
+	if len(item.GetAllowedClientCidrs()) > 0 {
+		opts = append(opts, target.WithAllowedClientCidrs(item.GetAllowedClientCidrs()))
+	}
+	if len(item.GetDeniedClientCidrs()) > 0 {
+		opts = append(opts, target.WithDeniedClientCidrs(item.GetDeniedClientCidrs()))
+	}
+	if item.GetAccessWindowSchedule() != nil {
+		opts = append(opts, target.WithAccessWindowSchedule(item.GetAccessWindowSchedule().GetValue()))
+	}
+	if item.GetAccessWindowDurationSeconds() != nil {
+		opts = append(opts, target.WithAccessWindowDurationSeconds(item.GetAccessWindowDurationSeconds().GetValue()))
+	}
+	if item.GetAccessWindowTimeZone() != nil {
+		opts = append(opts, target.WithAccessWindowTimeZone(item.GetAccessWindowTimeZone().GetValue()))
+	}
 	tcpAttrs := &pb.TcpTargetAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
@@ -645,6 +692,19 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, id string, mask []st
 	if filter := item.GetWorkerFilter(); filter != nil {
 		opts = append(opts, target.WithWorkerFilter(item.GetWorkerFilter().GetValue()))
 	}
+	opts = append(opts,
+		target.WithAllowedClientCidrs(item.GetAllowedClientCidrs()),
+		target.WithDeniedClientCidrs(item.GetDeniedClientCidrs()),
+	)
+	if schedule := item.GetAccessWindowSchedule(); schedule != nil {
+		opts = append(opts, target.WithAccessWindowSchedule(schedule.GetValue()))
+	}
+	if duration := item.GetAccessWindowDurationSeconds(); duration != nil {
+		opts = append(opts, target.WithAccessWindowDurationSeconds(duration.GetValue()))
+	}
+	if tz := item.GetAccessWindowTimeZone(); tz != nil {
+		opts = append(opts, target.WithAccessWindowTimeZone(tz.GetValue()))
+	}
 	tcpAttrs := &pb.TcpTargetAttributes{}
 	if err := handlers.StructToProto(item.GetAttributes(), tcpAttrs); err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Provided attributes don't match expected format.")
@@ -841,6 +901,15 @@ func toProto(in target.Target, m []*target.TargetSet) (*pb.Target, error) {
 	if in.GetWorkerFilter() != "" {
 		out.WorkerFilter = wrapperspb.String(in.GetWorkerFilter())
 	}
+	out.AllowedClientCidrs = in.GetAllowedClientCidrs()
+	out.DeniedClientCidrs = in.GetDeniedClientCidrs()
+	if in.GetAccessWindowSchedule() != "" {
+		out.AccessWindowSchedule = wrapperspb.String(in.GetAccessWindowSchedule())
+		out.AccessWindowDurationSeconds = wrapperspb.UInt32(in.GetAccessWindowDurationSeconds())
+	}
+	if in.GetAccessWindowTimeZone() != "" {
+		out.AccessWindowTimeZone = wrapperspb.String(in.GetAccessWindowTimeZone())
+	}
 	attrs := &pb.TcpTargetAttributes{}
 	if in.GetDefaultPort() > 0 {
 		attrs.DefaultPort = &wrappers.UInt32Value{Value: in.GetDefaultPort()}
@@ -912,6 +981,23 @@ func validateCreateRequest(req *pbs.CreateTargetRequest) error {
 				badFields["worker_filter"] = "Unable to successfully parse filter expression."
 			}
 		}
+		if _, err := cidr.Normalize(req.GetItem().GetAllowedClientCidrs()); err != nil {
+			badFields["allowed_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+		}
+		if _, err := cidr.Normalize(req.GetItem().GetDeniedClientCidrs()); err != nil {
+			badFields["denied_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+		}
+		validateAccessWindow(req.GetItem(), badFields)
+		if req.GetItem().GetAccessWindowSchedule().GetValue() == "" {
+			if req.GetItem().GetAccessWindowDurationSeconds() != nil {
+				badFields["access_window_duration_seconds"] = "This field requires an access window schedule."
+			}
+			if req.GetItem().GetAccessWindowTimeZone() != nil {
+				badFields["access_window_time_zone"] = "This field requires an access window schedule."
+			}
+		} else if req.GetItem().GetAccessWindowDurationSeconds() == nil {
+			badFields["access_window_duration_seconds"] = "This field is required when an access window schedule is set."
+		}
 		return badFields
 	})
 }
@@ -952,10 +1038,35 @@ func validateUpdateRequest(req *pbs.UpdateTargetRequest) error {
 				badFields["worker_filter"] = "Unable to successfully parse filter expression."
 			}
 		}
+		if _, err := cidr.Normalize(req.GetItem().GetAllowedClientCidrs()); err != nil {
+			badFields["allowed_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+		}
+		if _, err := cidr.Normalize(req.GetItem().GetDeniedClientCidrs()); err != nil {
+			badFields["denied_client_cidrs"] = "This field must only contain valid CIDR blocks or IP addresses."
+		}
+		validateAccessWindow(req.GetItem(), badFields)
 		return badFields
 	})
 }
 
+// validateAccessWindow adds the invalid access window fields of item to
+// badFields.
+func validateAccessWindow(item *pb.Target, badFields map[string]string) {
+	if schedule := item.GetAccessWindowSchedule().GetValue(); schedule != "" {
+		if _, err := cron.Parse(schedule); err != nil {
+			badFields["access_window_schedule"] = fmt.Sprintf("This field must be a valid cron expression: %v.", err)
+		}
+	}
+	if d := item.GetAccessWindowDurationSeconds(); d != nil && d.GetValue() == 0 {
+		badFields["access_window_duration_seconds"] = "This must be greater than zero."
+	}
+	if tz := item.GetAccessWindowTimeZone().GetValue(); tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			badFields["access_window_time_zone"] = "This field must be a valid IANA time zone name."
+		}
+	}
+}
+
 func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
 	return handlers.ValidateDeleteRequest(target.TcpTargetPrefix, req, handlers.NoopValidatorFn)
 }
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
@@ -23,6 +25,7 @@ import (
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 	"github.com/jinzhu/gorm"
 	"github.com/stretchr/testify/assert"
@@ -393,6 +396,36 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create with client cidrs",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				ScopeId: proj.GetPublicId(),
+				Name:    wrapperspb.String("cidrs"),
+				Type:    target.TcpTargetType.String(),
+				Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+					"default_port": structpb.NewNumberValue(2),
+				}},
+				AllowedClientCidrs: []string{"192.168.1.1", "10.0.0.0/8"},
+				DeniedClientCidrs:  []string{"10.1.0.0/16"},
+			}},
+			res: &pbs.CreateTargetResponse{
+				Uri: fmt.Sprintf("targets/%s_", target.TcpTargetPrefix),
+				Item: &pb.Target{
+					ScopeId: proj.GetPublicId(),
+					Scope:   &scopes.ScopeInfo{Id: proj.GetPublicId(), Type: scope.Project.String(), ParentScopeId: org.GetPublicId()},
+					Name:    wrapperspb.String("cidrs"),
+					Type:    target.TcpTargetType.String(),
+					Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+						"default_port": structpb.NewNumberValue(2),
+					}},
+					SessionMaxSeconds:      wrapperspb.UInt32(28800),
+					SessionConnectionLimit: wrapperspb.Int32(1),
+					AuthorizedActions:      targets.IdActions.Strings(),
+					AllowedClientCidrs:     []string{"10.0.0.0/8", "192.168.1.1/32"},
+					DeniedClientCidrs:      []string{"10.1.0.0/16"},
+				},
+			},
+		},
 		{
 			name: "Create with default port 0",
 			req: &pbs.CreateTargetRequest{Item: &pb.Target{
@@ -454,6 +487,31 @@ func TestCreate(t *testing.T) {
 			res: nil,
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Invalid access window schedule",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				AccessWindowSchedule:        wrapperspb.String("every day"),
+				AccessWindowDurationSeconds: wrapperspb.UInt32(3600),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Access window schedule without duration",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				AccessWindowSchedule: wrapperspb.String("0 9 * * *"),
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Invalid client cidr",
+			req: &pbs.CreateTargetRequest{Item: &pb.Target{
+				AllowedClientCidrs: []string{"10.0.0.0/33"},
+			}},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1250,3 +1308,51 @@ func TestRemoveTargetHostSets(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizeSession_apiToken(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return servers.NewRepository(rw, rw, kms)
+	}
+
+	org, proj := iam.TestScopes(t, iamRepo)
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	h := static.TestHosts(t, conn, hc.GetPublicId(), 1)[0]
+	hs := static.TestSets(t, conn, hc.GetPublicId(), 1)[0]
+	_ = static.TestSetMembers(t, conn, hs.GetPublicId(), []*static.Host{h})
+	tar := target.TestTcpTarget(t, conn, proj.GetPublicId(), "test", target.WithHostSets([]string{hs.GetPublicId()}))
+
+	apt := authtoken.TestApiToken(t, conn, kms, org.GetPublicId())
+	role := iam.TestRole(t, conn, proj.GetPublicId())
+	_ = iam.TestRoleGrant(t, conn, role.GetPublicId(), "id=*;type=target;actions=authorize-session")
+	_ = iam.TestUserRole(t, conn, role.GetPublicId(), apt.GetIamUserId())
+
+	s, err := testService(t, conn, kms, wrapper)
+	require.NoError(err)
+
+	req := httptest.NewRequest("POST", fmt.Sprintf("http://127.0.0.1/v1/targets/%s:authorize-session", tar.GetPublicId()), nil)
+	requestInfo := auth.RequestInfo{
+		Path:        req.URL.Path,
+		Method:      req.Method,
+		TokenFormat: auth.AuthTokenTypeBearer,
+		PublicId:    apt.GetPublicId(),
+		Token:       apt.GetToken(),
+	}
+	ctx := auth.NewVerifierContext(context.Background(), hclog.New(nil), iamRepoFn, atRepoFn, serversRepoFn, kms, requestInfo)
+
+	_, err = s.AuthorizeSession(ctx, &pbs.AuthorizeSessionRequest{Id: tar.GetPublicId()})
+	require.Error(err)
+	assert.True(errors.Is(err, handlers.ApiErrorWithCode(codes.PermissionDenied)), "got error %v", err)
+	assert.Contains(err.Error(), "API token")
+}
@@ -35,6 +35,8 @@ var (
 		action.AddAccounts,
 		action.SetAccounts,
 		action.RemoveAccounts,
+		action.Disable,
+		action.Enable,
 	}
 
 	// CollectionActions contains the set of actions that can be performed on
@@ -247,6 +249,42 @@ func (s Service) RemoveUserAccounts(ctx context.Context, req *pbs.RemoveUserAcco
 	return &pbs.RemoveUserAccountsResponse{Item: u}, nil
 }
 
+// DisableUser implements the interface pbs.UserServiceServer.
+func (s Service) DisableUser(ctx context.Context, req *pbs.DisableUserRequest) (*pbs.DisableUserResponse, error) {
+	if err := validateDisableRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Disable)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setActiveInRepo(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.DisableUserResponse{Item: u}, nil
+}
+
+// EnableUser implements the interface pbs.UserServiceServer.
+func (s Service) EnableUser(ctx context.Context, req *pbs.EnableUserRequest) (*pbs.EnableUserResponse, error) {
+	if err := validateEnableRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Enable)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	u, err := s.setActiveInRepo(ctx, req.GetId(), true)
+	if err != nil {
+		return nil, err
+	}
+	u.Scope = authResults.Scope
+	u.AuthorizedActions = authResults.FetchActionSetForId(ctx, u.Id, IdActions).Strings()
+	return &pbs.EnableUserResponse{Item: u}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -273,6 +311,9 @@ func (s Service) createInRepo(ctx context.Context, orgId string, item *pb.User)
 	if item.GetDescription() != nil {
 		opts = append(opts, iam.WithDescription(item.GetDescription().GetValue()))
 	}
+	if item.GetServiceAccount() {
+		opts = append(opts, iam.WithServiceAccount(true))
+	}
 	u, err := iam.NewUser(orgId, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build user for creation: %v.", err)
@@ -338,6 +379,20 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
+func (s Service) setActiveInRepo(ctx context.Context, id string, active bool) (*pb.User, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.SetUserActive(ctx, id, active); err != nil {
+		if errors.IsNotFoundError(err) {
+			return nil, handlers.NotFoundErrorf("User %q doesn't exist.", id)
+		}
+		return nil, fmt.Errorf("unable to set user active state: %w", err)
+	}
+	return s.getFromRepo(ctx, id)
+}
+
 func (s Service) listFromRepo(ctx context.Context, scopeIds []string) ([]*pb.User, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -452,12 +507,14 @@ func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.
 
 func toProto(in *iam.User, accts []string) *pb.User {
 	out := pb.User{
-		Id:          in.GetPublicId(),
-		ScopeId:     in.GetScopeId(),
-		CreatedTime: in.GetCreateTime().GetTimestamp(),
-		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
-		Version:     in.GetVersion(),
-		AccountIds:  accts,
+		Id:             in.GetPublicId(),
+		ScopeId:        in.GetScopeId(),
+		CreatedTime:    in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:    in.GetUpdateTime().GetTimestamp(),
+		Version:        in.GetVersion(),
+		AccountIds:     accts,
+		ServiceAccount: in.GetServiceAccount(),
+		Active:         in.GetActive(),
 	}
 	if in.GetDescription() != "" {
 		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
@@ -496,13 +553,44 @@ func validateCreateRequest(req *pbs.CreateUserRequest) error {
 }
 
 func validateUpdateRequest(req *pbs.UpdateUserRequest) error {
-	return handlers.ValidateUpdateRequest(iam.UserPrefix, req, req.GetItem(), handlers.NoopValidatorFn)
+	return handlers.ValidateUpdateRequest(iam.UserPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetServiceAccount() {
+			badFields["service_account"] = "This field can only be set on creation."
+		}
+		if req.GetItem().GetActive() {
+			badFields["active"] = "This is a read only field. Use the disable and enable actions."
+		}
+		return badFields
+	})
 }
 
 func validateDeleteRequest(req *pbs.DeleteUserRequest) error {
 	return handlers.ValidateDeleteRequest(iam.UserPrefix, req, handlers.NoopValidatorFn)
 }
 
+func validateDisableRequest(req *pbs.DisableUserRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.UserPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateEnableRequest(req *pbs.EnableUserRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(iam.UserPrefix, req.GetId()) {
+		badFields["id"] = "Improperly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
 func validateListRequest(req *pbs.ListUsersRequest) error {
 	badFields := map[string]string{}
 	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
@@ -11,13 +11,16 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/users"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
@@ -57,7 +60,8 @@ func TestGet(t *testing.T) {
 		CreatedTime:       u.CreateTime.GetTimestamp(),
 		UpdatedTime:       u.UpdateTime.GetTimestamp(),
 		Version:           1,
-		AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+		AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+		Active:            true,
 	}
 
 	cases := []struct {
@@ -152,7 +156,8 @@ func TestList(t *testing.T) {
 			CreatedTime:       u.GetCreateTime().GetTimestamp(),
 			UpdatedTime:       u.GetUpdateTime().GetTimestamp(),
 			Version:           1,
-			AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+			AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+			Active:            true,
 		})
 	}
 
@@ -307,7 +312,8 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					Version:           1,
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -326,7 +332,28 @@ func TestCreate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "name"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					Version:           1,
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
+				},
+			},
+		},
+		{
+			name: "Create a valid Service Account",
+			req: &pbs.CreateUserRequest{Item: &pb.User{
+				ScopeId:        defaultUser.GetScopeId(),
+				Name:           &wrapperspb.StringValue{Value: "service account"},
+				ServiceAccount: true,
+			}},
+			res: &pbs.CreateUserResponse{
+				Uri: fmt.Sprintf("users/%s_", iam.UserPrefix),
+				Item: &pb.User{
+					ScopeId:           defaultUser.GetScopeId(),
+					Scope:             &scopes.ScopeInfo{Id: defaultUser.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
+					Name:              &wrapperspb.StringValue{Value: "service account"},
+					Version:           1,
+					ServiceAccount:    true,
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -437,7 +464,8 @@ func TestUpdate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "new"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       u.GetCreateTime().GetTimestamp(),
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -460,7 +488,8 @@ func TestUpdate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "new"},
 					Description:       &wrapperspb.StringValue{Value: "desc"},
 					CreatedTime:       u.GetCreateTime().GetTimestamp(),
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -513,7 +542,8 @@ func TestUpdate(t *testing.T) {
 					Scope:             &scopes.ScopeInfo{Id: u.GetScopeId(), Type: scope.Org.String(), ParentScopeId: scope.Global.String()},
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       u.GetCreateTime().GetTimestamp(),
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -536,7 +566,8 @@ func TestUpdate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "updated"},
 					Description:       &wrapperspb.StringValue{Value: "default"},
 					CreatedTime:       u.GetCreateTime().GetTimestamp(),
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -559,7 +590,8 @@ func TestUpdate(t *testing.T) {
 					Name:              &wrapperspb.StringValue{Value: "default"},
 					Description:       &wrapperspb.StringValue{Value: "notignored"},
 					CreatedTime:       u.GetCreateTime().GetTimestamp(),
-					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts"},
+					AuthorizedActions: []string{"read", "update", "delete", "add-accounts", "set-accounts", "remove-accounts", "disable", "enable"},
+					Active:            true,
 				},
 			},
 		},
@@ -593,6 +625,20 @@ func TestUpdate(t *testing.T) {
 			res: nil,
 			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
 		},
+		{
+			name: "Cant change Service Account",
+			req: &pbs.UpdateUserRequest{
+				Id: u.GetPublicId(),
+				UpdateMask: &field_mask.FieldMask{
+					Paths: []string{"service_account"},
+				},
+				Item: &pb.User{
+					ServiceAccount: true,
+				},
+			},
+			res: nil,
+			err: handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
 		{
 			name: "Cant specify Created Time",
 			req: &pbs.UpdateUserRequest{
@@ -1039,3 +1085,57 @@ func TestRemoveAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestDisableEnable(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	kms := kms.TestKms(t, conn, wrap)
+	rw := db.New(conn)
+	atRepoFn := func() (*authtoken.Repository, error) {
+		return authtoken.NewRepository(rw, rw, kms)
+	}
+	sessRepoFn := func() (*session.Repository, error) {
+		return session.NewRepository(rw, rw, kms)
+	}
+	s, err := users.NewService(repoFn)
+	require.NoError(t, err, "Error when getting new user service.")
+
+	sess := session.TestDefaultSession(t, conn, wrap, iamRepo)
+	usr, _, err := iamRepo.LookupUser(context.Background(), sess.UserId)
+	require.NoError(t, err)
+	ctx := auth.DisabledAuthTestContext(repoFn, usr.GetScopeId())
+
+	disabled, err := s.DisableUser(ctx, &pbs.DisableUserRequest{Id: usr.GetPublicId()})
+	require.NoError(t, err)
+	assert.False(t, disabled.GetItem().GetActive())
+
+	atRepo, err := atRepoFn()
+	require.NoError(t, err)
+	at, err := atRepo.LookupAuthToken(context.Background(), sess.AuthTokenId)
+	require.NoError(t, err)
+	assert.Nil(t, at, "the auth token of a disabled user should be deleted")
+
+	sessRepo, err := sessRepoFn()
+	require.NoError(t, err)
+	got, _, err := sessRepo.LookupSession(context.Background(), sess.PublicId)
+	require.NoError(t, err)
+	require.NotEmpty(t, got.States)
+	assert.Equal(t, session.StatusCanceling, got.States[0].Status)
+
+	// Disabling a disabled user is not an error.
+	_, err = s.DisableUser(ctx, &pbs.DisableUserRequest{Id: usr.GetPublicId()})
+	require.NoError(t, err)
+
+	enabled, err := s.EnableUser(ctx, &pbs.EnableUserRequest{Id: usr.GetPublicId()})
+	require.NoError(t, err)
+	assert.True(t, enabled.GetItem().GetActive())
+
+	_, err = s.DisableUser(ctx, &pbs.DisableUserRequest{Id: "bad id"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v", err)
+	_, err = s.EnableUser(ctx, &pbs.EnableUserRequest{Id: iam.UserPrefix + "_DoesntExis"})
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.NotFound)), "got error %v", err)
+}
@@ -214,6 +214,9 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		HostSetId:       sessionInfo.HostSetId,
 		TargetId:        sessionInfo.TargetId,
 		UserId:          sessionInfo.UserId,
+
+		AllowedClientCidrs: sessionInfo.AllowedClientCidrs,
+		DeniedClientCidrs:  sessionInfo.DeniedClientCidrs,
 	}
 	if resp.ConnectionsLeft != -1 {
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/scheduler"
+	"github.com/hashicorp/go-multierror"
+)
+
+// These are exported so they can be tweaked in tests
+var (
+	RecoveryNonceCleanupInterval   = 2 * time.Minute
+	SessionTerminationInterval     = 1 * time.Minute
+	SessionRevocationInterval      = 1 * time.Minute
+	KeyVersionReencryptionInterval = 1 * time.Minute
+	RetentionInterval              = 10 * time.Minute
+)
+
+// controllerJob is a job run by the scheduler on one of the controllers at a
+// time.  Unlike the tickers, which run on every controller, jobs only need
+// to be performed once per interval for the whole cluster.
+type controllerJob struct {
+	name        string
+	description string
+	interval    *time.Duration
+	run         func(ctx context.Context) error
+}
+
+var _ scheduler.Job = (*controllerJob)(nil)
+
+func (j *controllerJob) Name() string                  { return j.name }
+func (j *controllerJob) Description() string           { return j.description }
+func (j *controllerJob) NextRunIn() time.Duration      { return *j.interval }
+func (j *controllerJob) Run(ctx context.Context) error { return j.run(ctx) }
+
+// registerJobs registers the controller's jobs with the scheduler.
+func (c *Controller) registerJobs(ctx context.Context) error {
+	jobs := []*controllerJob{
+		{
+			name:        "recovery_nonce_cleanup",
+			description: "Deletes the recovery nonces which are past the validity period of recovery tokens.",
+			interval:    &RecoveryNonceCleanupInterval,
+			run:         c.cleanupRecoveryNonces,
+		},
+		{
+			name:        "terminate_completed_sessions",
+			description: "Cancels the sessions outside the access windows of their targets and terminates the sessions which are completed.",
+			interval:    &SessionTerminationInterval,
+			run:         c.terminateCompletedSessions,
+		},
+		{
+			name:        "revoke_unauthorized_sessions",
+			description: "Cancels the sessions whose users are no longer authorized to connect to their targets.",
+			interval:    &SessionRevocationInterval,
+			run:         c.revokeUnauthorizedSessions,
+		},
+		{
+			name:        "key_version_reencryption",
+			description: "Re-encrypts the data encrypted with the key versions being re-encrypted.",
+			interval:    &KeyVersionReencryptionInterval,
+			run: func(ctx context.Context) error {
+				return c.kms.RunKeyVersionReencryptions(ctx)
+			},
+		},
+		{
+			name:        "retention",
+			description: "Deletes the terminated sessions and prunes the oplog entries which are older than their retention.",
+			interval:    &RetentionInterval,
+			run:         c.pruneRetained,
+		},
+	}
+	for _, j := range jobs {
+		if err := c.scheduler.RegisterJob(ctx, j); err != nil {
+			return fmt.Errorf("error registering job %q: %w", j.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Controller) cleanupRecoveryNonces(ctx context.Context) error {
+	repo, err := c.ServersRepoFn()
+	if err != nil {
+		return fmt.Errorf("error fetching repository for recovery nonce cleanup: %w", err)
+	}
+	nonceCount, err := repo.CleanupNonces(ctx)
+	if err != nil {
+		return fmt.Errorf("error performing recovery nonce cleanup: %w", err)
+	}
+	if nonceCount > 0 {
+		c.logger.Info("recovery nonce cleanup successful", "nonces_cleaned", nonceCount)
+	}
+	return nil
+}
+
+func (c *Controller) terminateCompletedSessions(ctx context.Context) error {
+	repo, err := c.SessionRepoFn()
+	if err != nil {
+		return fmt.Errorf("error fetching repository for terminating completed sessions: %w", err)
+	}
+	var result *multierror.Error
+	// Sessions outside the access window of their target are canceled first
+	// so they are terminated as soon as their connections are closed.
+	if targetRepo, err := c.TargetRepoFn(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("error fetching target repository for canceling sessions outside access windows: %w", err))
+	} else {
+		canceledCount, err := cancelSessionsOutsideAccessWindows(ctx, targetRepo, repo, time.Now())
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error canceling sessions outside access windows: %w", err))
+		}
+		if canceledCount > 0 {
+			c.logger.Info("canceling sessions outside access windows successful", "sessions_canceled", canceledCount)
+		}
+	}
+	terminationCount, err := repo.TerminateCompletedSessions(ctx)
+	if err != nil {
+		result = multierror.Append(result, fmt.Errorf("error performing termination of completed sessions: %w", err))
+	} else if terminationCount > 0 {
+		c.logger.Info("terminating completed sessions successful", "sessions_terminated", terminationCount)
+	}
+	return result.ErrorOrNil()
+}
+
+func (c *Controller) revokeUnauthorizedSessions(ctx context.Context) error {
+	iamRepo, err := c.IamRepoFn()
+	if err != nil {
+		return fmt.Errorf("error fetching iam repository for revoking unauthorized sessions: %w", err)
+	}
+	atRepo, err := c.AuthTokenRepoFn()
+	if err != nil {
+		return fmt.Errorf("error fetching auth token repository for revoking unauthorized sessions: %w", err)
+	}
+	sessRepo, err := c.SessionRepoFn()
+	if err != nil {
+		return fmt.Errorf("error fetching session repository for revoking unauthorized sessions: %w", err)
+	}
+	revokedCount, err := revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	if revokedCount > 0 {
+		c.logger.Info("revoking unauthorized sessions successful", "sessions_revoked", revokedCount)
+	}
+	if err != nil {
+		return fmt.Errorf("error performing revocation of unauthorized sessions: %w", err)
+	}
+	return nil
+}
+
+// pruneRetained deletes the terminated sessions and prunes the oplog entries
+// which are older than their retention.
+func (c *Controller) pruneRetained(ctx context.Context) error {
+	var result *multierror.Error
+	if repo, err := c.SessionRepoFn(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("error fetching repository for deleting retained sessions: %w", err))
+	} else {
+		deletedCount, err := repo.DeleteRetainedSessions(ctx, c.conf.RawConfig.Controller.SessionRetentionDuration)
+		if err != nil {
+			result = multierror.Append(result, fmt.Errorf("error deleting retained sessions: %w", err))
+		}
+		if deletedCount > 0 {
+			c.logger.Info("deleting retained sessions successful", "sessions_deleted", deletedCount)
+		}
+	}
+	if retention := c.conf.RawConfig.Controller.OplogRetentionDuration; retention > 0 {
+		if pruner, err := c.OplogPrunerFn(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("error fetching pruner for pruning the oplog: %w", err))
+		} else {
+			prunedCount, err := pruner.Prune(ctx, retention)
+			if err != nil {
+				result = multierror.Append(result, fmt.Errorf("error pruning the oplog: %w", err))
+			}
+			if prunedCount > 0 {
+				c.logger.Info("pruning the oplog successful", "entries_pruned", prunedCount)
+			}
+		}
+	}
+	return result.ErrorOrNil()
+}
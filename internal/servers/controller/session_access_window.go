@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/go-multierror"
+)
+
+// cancelSessionsOutsideAccessWindows cancels the pending and active sessions
+// whose target has an access window which is closed at now.  Sessions expire
+// when the access window they were authorized in closes, this also ends the
+// sessions of targets whose access window was changed after the sessions were
+// authorized.  It returns the number of sessions canceled.
+func cancelSessionsOutsideAccessWindows(ctx context.Context, targetRepo *target.Repository, sessRepo *session.Repository, now time.Time) (int, error) {
+	const op = "controller.cancelSessionsOutsideAccessWindows"
+	sessions, err := sessRepo.ListSessions(ctx, session.WithStates(session.StatusPending, session.StatusActive), session.WithLimit(-1))
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	// whether the access window of each target is open is only computed once
+	// per run.
+	open := make(map[string]bool)
+	var canceled int
+	var cancelErrs *multierror.Error
+	for _, s := range sessions {
+		if s.TargetId == "" {
+			// sessions of deleted targets are revoked
+			continue
+		}
+		isOpen, ok := open[s.TargetId]
+		if !ok {
+			t, _, err := targetRepo.LookupTarget(ctx, s.TargetId)
+			if err != nil && !errors.IsNotFoundError(err) {
+				return canceled, errors.Wrap(err, op)
+			}
+			isOpen = true
+			if t != nil {
+				if _, isOpen, err = target.AccessWindowCloses(t, now); err != nil {
+					return canceled, errors.Wrap(err, op)
+				}
+			}
+			open[s.TargetId] = isOpen
+		}
+		if isOpen {
+			continue
+		}
+		if _, err := sessRepo.CancelSession(ctx, s.PublicId, s.Version); err != nil {
+			// The session may have changed since it was listed.  The other
+			// sessions are still canceled and this one is checked again on
+			// the next run.
+			cancelErrs = multierror.Append(cancelErrs, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to cancel session %s", s.PublicId))))
+			continue
+		}
+		canceled++
+	}
+	return canceled, cancelErrs.ErrorOrNil()
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelSessionsOutsideAccessWindows(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	targetRepo, err := target.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	sess := session.TestSession(t, conn, wrapper, composedOf)
+
+	// targets without an access window can always be accessed
+	canceled, err := cancelSessionsOutsideAccessWindows(ctx, targetRepo, sessRepo, time.Now())
+	require.NoError(err)
+	assert.Equal(0, canceled)
+
+	tar, _, err := targetRepo.LookupTarget(ctx, composedOf.TargetId)
+	require.NoError(err)
+	update := tar.(*target.TcpTarget).Clone().(*target.TcpTarget)
+	update.AccessWindowSchedule = "0 9 * * *"
+	update.AccessWindowDurationSeconds = 3600
+	_, _, _, err = targetRepo.UpdateTcpTarget(ctx, update, tar.GetVersion(), []string{"AccessWindowSchedule", "AccessWindowDurationSeconds"})
+	require.NoError(err)
+
+	canceled, err = cancelSessionsOutsideAccessWindows(ctx, targetRepo, sessRepo, time.Date(2021, 3, 1, 9, 30, 0, 0, time.UTC))
+	require.NoError(err)
+	assert.Equal(0, canceled)
+
+	canceled, err = cancelSessionsOutsideAccessWindows(ctx, targetRepo, sessRepo, time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC))
+	require.NoError(err)
+	assert.Equal(1, canceled)
+
+	got, _, err := sessRepo.LookupSession(ctx, sess.PublicId)
+	require.NoError(err)
+	assert.Equal(session.StatusCanceling, got.States[0].Status)
+}
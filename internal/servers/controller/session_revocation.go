@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/go-multierror"
+)
+
+// revokeUnauthorizedSessions revokes the pending and active sessions whose
+// access was removed after they were authorized: the auth token or the target
+// of the session was deleted, or the user of the session no longer holds the
+// authorize-session grant on the target.  Revoked sessions are canceled and
+// terminated with the session.AccessRevoked reason once their connections are
+// closed.  It returns the number of sessions revoked.
+func revokeUnauthorizedSessions(ctx context.Context, iamRepo *iam.Repository, atRepo *authtoken.Repository, sessRepo *session.Repository) (int, error) {
+	const op = "controller.revokeUnauthorizedSessions"
+	sessions, err := sessRepo.ListSessions(ctx, session.WithStates(session.StatusPending, session.StatusActive), session.WithLimit(-1))
+	if err != nil {
+		return 0, errors.Wrap(err, op)
+	}
+
+	// sessions are usually authorized with a handful of auth tokens, so the
+	// acl of each auth token is only computed once per run.
+	acls := make(map[string]*perms.ACL)
+	aclFailed := make(map[string]bool)
+	var revoked int
+	var revokeErrs *multierror.Error
+	for _, s := range sessions {
+		allowed := s.AuthTokenId != "" && s.TargetId != "" && s.UserId != ""
+		if allowed {
+			if aclFailed[s.AuthTokenId] {
+				continue
+			}
+			acl, ok := acls[s.AuthTokenId]
+			if !ok {
+				acl, err = authTokenAcl(ctx, iamRepo, atRepo, s.AuthTokenId)
+				if err != nil {
+					// The sessions of this auth token are checked again on
+					// the next run, the other sessions are still revoked.
+					aclFailed[s.AuthTokenId] = true
+					revokeErrs = multierror.Append(revokeErrs, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to check session %s", s.PublicId))))
+					continue
+				}
+				acls[s.AuthTokenId] = acl
+			}
+			allowed = acl != nil && acl.Allowed(perms.Resource{
+				ScopeId: s.ScopeId,
+				Id:      s.TargetId,
+				Type:    resource.Target,
+			}, action.AuthorizeSession).Authorized
+		}
+		if allowed {
+			continue
+		}
+		if _, err := sessRepo.RevokeSession(ctx, s.PublicId, s.Version); err != nil {
+			// The session may have changed since it was listed.  The other
+			// sessions are still revoked and this one is checked again on
+			// the next run.
+			revokeErrs = multierror.Append(revokeErrs, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to revoke session %s", s.PublicId))))
+			continue
+		}
+		revoked++
+	}
+	return revoked, revokeErrs.ErrorOrNil()
+}
+
+// authTokenAcl returns the acl of the user of the auth token with the
+// provided id, restricted by the grants of the auth token if it is derived.
+// It returns nil if the auth token no longer exists.
+func authTokenAcl(ctx context.Context, iamRepo *iam.Repository, atRepo *authtoken.Repository, authTokenId string) (*perms.ACL, error) {
+	const op = "controller.authTokenAcl"
+	at, err := atRepo.LookupAuthToken(ctx, authTokenId)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if at == nil || at.GetIamUserId() == "" {
+		return nil, nil
+	}
+	parseOpts := []perms.Option{
+		perms.WithUserId(at.GetIamUserId()),
+		perms.WithAccountId(at.GetAuthAccountId()),
+		perms.WithSkipFinalValidation(true),
+	}
+
+	grantPairs, err := iamRepo.GrantsForUser(ctx, at.GetIamUserId())
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	grants := make([]perms.Grant, 0, len(grantPairs))
+	for _, pair := range grantPairs {
+		parsed, err := perms.Parse(pair.ScopeId, pair.Grant, parseOpts...)
+		if err != nil {
+			return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse grant %#v", pair.Grant)))
+		}
+		grants = append(grants, parsed)
+	}
+	acl := perms.NewACL(grants...)
+
+	if at.IsDerived() {
+		atGrants, err := atRepo.ListAuthTokenGrants(ctx, at.GetPublicId())
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		restrictions := make([]perms.Grant, 0, len(atGrants))
+		for _, g := range atGrants {
+			parsed, err := perms.Parse(at.GetGrantScopeId(), g.GetCanonicalGrant(), parseOpts...)
+			if err != nil {
+				return nil, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed to parse derived auth token grant %#v", g.GetCanonicalGrant())))
+			}
+			restrictions = append(restrictions, parsed)
+		}
+		acl = acl.Restrict(restrictions...)
+	}
+	return &acl, nil
+}
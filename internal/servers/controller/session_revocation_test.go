@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeUnauthorizedSessions(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	atRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	role := iam.TestRole(t, conn, composedOf.ScopeId)
+	_ = iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=target;actions=authorize-session")
+	_ = iam.TestUserRole(t, conn, role.PublicId, composedOf.UserId)
+	sess := session.TestSession(t, conn, wrapper, composedOf)
+
+	// the user still has access to the target
+	revoked, err := revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	require.NoError(err)
+	assert.Equal(0, revoked)
+
+	_, err = iamRepo.DeleteRole(ctx, role.PublicId)
+	require.NoError(err)
+	revoked, err = revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	require.NoError(err)
+	assert.Equal(1, revoked)
+
+	got, _, err := sessRepo.LookupSession(ctx, sess.PublicId)
+	require.NoError(err)
+	assert.Equal(session.StatusCanceling, got.States[0].Status)
+
+	// revoked sessions are no longer pending or active
+	revoked, err = revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	require.NoError(err)
+	assert.Equal(0, revoked)
+
+	_, err = sessRepo.TerminateCompletedSessions(ctx)
+	require.NoError(err)
+	got, _, err = sessRepo.LookupSession(ctx, sess.PublicId)
+	require.NoError(err)
+	assert.Equal(session.StatusTerminated, got.States[0].Status)
+	assert.Equal(session.AccessRevoked.String(), got.TerminationReason)
+}
+
+func TestRevokeUnauthorizedSessions_deletedAuthToken(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	atRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	role := iam.TestRole(t, conn, composedOf.ScopeId)
+	_ = iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=target;actions=authorize-session")
+	_ = iam.TestUserRole(t, conn, role.PublicId, composedOf.UserId)
+	sess := session.TestSession(t, conn, wrapper, composedOf)
+
+	// deleting the auth token cancels the session in the database, it is
+	// terminated with the access revoked reason.
+	_, err = atRepo.DeleteAuthToken(ctx, composedOf.AuthTokenId)
+	require.NoError(err)
+	revoked, err := revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	require.NoError(err)
+	assert.Equal(0, revoked)
+
+	_, err = sessRepo.TerminateCompletedSessions(ctx)
+	require.NoError(err)
+	got, _, err := sessRepo.LookupSession(ctx, sess.PublicId)
+	require.NoError(err)
+	assert.Equal(session.StatusTerminated, got.States[0].Status)
+	assert.Equal(session.AccessRevoked.String(), got.TerminationReason)
+}
+
+func TestRevokeUnauthorizedSessions_aclError(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	atRepo, err := authtoken.NewRepository(rw, rw, kms)
+	require.NoError(err)
+	sessRepo, err := session.NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	// the grants of the first user can't be parsed
+	brokenOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	brokenRole := iam.TestRole(t, conn, brokenOf.ScopeId)
+	_ = iam.TestUserRole(t, conn, brokenRole.PublicId, brokenOf.UserId)
+	_, err = rw.Exec(ctx, "insert into iam_role_grant (role_id, canonical_grant, raw_grant) values (?, ?, ?)",
+		[]interface{}{brokenRole.PublicId, "id=*;type=bogus;actions=read", "id=*;type=bogus;actions=read"})
+	require.NoError(err)
+	brokenSess := session.TestSession(t, conn, wrapper, brokenOf)
+
+	// the second user has no access to the target
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	sess := session.TestSession(t, conn, wrapper, composedOf)
+
+	revoked, err := revokeUnauthorizedSessions(ctx, iamRepo, atRepo, sessRepo)
+	require.Error(err)
+	assert.Equal(1, revoked)
+
+	got, _, err := sessRepo.LookupSession(ctx, sess.PublicId)
+	require.NoError(err)
+	assert.Equal(session.StatusCanceling, got.States[0].Status)
+	got, _, err = sessRepo.LookupSession(ctx, brokenSess.PublicId)
+	require.NoError(err)
+	assert.NotEqual(session.StatusCanceling, got.States[0].Status)
+}
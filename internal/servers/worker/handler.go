@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/boundary/globals"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
 	"github.com/hashicorp/boundary/internal/proxy"
 	"github.com/hashicorp/shared-secure-libs/configutil"
 	"nhooyr.io/websocket"
@@ -71,12 +72,22 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		tofuToken := si.lookupSessionResponse.GetTofuToken()
 		version := si.lookupSessionResponse.GetVersion()
 		endpoint := si.lookupSessionResponse.GetEndpoint()
+		allowedClientCidrs := si.lookupSessionResponse.GetAllowedClientCidrs()
+		deniedClientCidrs := si.lookupSessionResponse.GetDeniedClientCidrs()
 		// userId := si.lookupSessionResponse.GetAuthorization()
 		sessStatus := si.status
 		si.RUnlock()
 
 		w.logger.Trace("found session in session info map")
 
+		// The client cidrs of the target are checked against the address of
+		// the TCP connection, regardless of any forwarding headers.
+		if !cidr.Allowed(clientIp, allowedClientCidrs, deniedClientCidrs) {
+			w.logger.Error("client address not allowed to connect to session", "session_id", sessionId, "client_ip", clientIp)
+			wr.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		opts := &websocket.AcceptOptions{
 			Subprotocols: []string{globals.TcpProxyV1},
 		}
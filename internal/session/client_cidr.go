@@ -0,0 +1,94 @@
+package session
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/db/timestamp"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
+)
+
+const (
+	defaultClientCidrTableName = "session_client_cidr"
+
+	allowClientCidrRule = "allow"
+	denyClientCidrRule  = "deny"
+)
+
+// ClientCidr is a CIDR block which clients of a session must, or must not,
+// connect from.  They are copied from the target of the session when the
+// session is created.
+type ClientCidr struct {
+	// SessionId references the session public id
+	SessionId string `json:"session_id,omitempty" gorm:"primary_key"`
+	// Cidr is the CIDR block
+	Cidr string `json:"cidr,omitempty" gorm:"primary_key"`
+	// Rule is either allow or deny
+	Rule string `json:"rule,omitempty" gorm:"primary_key"`
+	// CreateTime from the RDBMS
+	CreateTime *timestamp.Timestamp `json:"create_time,omitempty" gorm:"default:current_timestamp"`
+
+	tableName string `gorm:"-"`
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (c *ClientCidr) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return defaultClientCidrTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (c *ClientCidr) SetTableName(n string) {
+	c.tableName = n
+}
+
+// newClientCidrs returns the client cidrs of the session with the provided id.
+func newClientCidrs(sessionId string, allowed, denied []string) ([]interface{}, error) {
+	const op = "session.newClientCidrs"
+	allowed, err := cidr.Normalize(allowed)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	denied, err = cidr.Normalize(denied)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	items := make([]interface{}, 0, len(allowed)+len(denied))
+	for _, c := range allowed {
+		items = append(items, &ClientCidr{SessionId: sessionId, Cidr: c, Rule: allowClientCidrRule})
+	}
+	for _, c := range denied {
+		items = append(items, &ClientCidr{SessionId: sessionId, Cidr: c, Rule: denyClientCidrRule})
+	}
+	return items, nil
+}
+
+// fetchClientCidrs returns the allowed and denied client cidrs of the session
+// with the provided id.
+func fetchClientCidrs(ctx context.Context, r db.Reader, sessionId string) (allowed, denied []string, err error) {
+	const op = "session.fetchClientCidrs"
+	var cidrs []*ClientCidr
+	if err := r.SearchWhere(ctx, &cidrs, "session_id = ?", []interface{}{sessionId}, db.WithLimit(-1), db.WithOrder("cidr asc")); err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	for _, c := range cidrs {
+		switch c.Rule {
+		case allowClientCidrRule:
+			allowed = append(allowed, c.Cidr)
+		case denyClientCidrRule:
+			denied = append(denied, c.Cidr)
+		}
+	}
+	return allowed, denied, nil
+}
+
+// ClientAllowed reports whether a client with the provided IP address is
+// allowed to connect to the session.
+func (s *Session) ClientAllowed(clientIp string) bool {
+	return cidr.Allowed(clientIp, s.AllowedClientCidrs, s.DeniedClientCidrs)
+}
@@ -27,6 +27,9 @@ type options struct {
 	withTestTofu          []byte
 	withListingConvert    bool
 	withSessionIds        []string
+	withStates            []Status
+	withAccessRevoked     bool
+	withSessionQuotas     SessionQuotas
 }
 
 func getDefaultOptions() options {
@@ -86,6 +89,28 @@ func WithSessionIds(ids ...string) Option {
 	}
 }
 
+// WithStates allows specifying the current states of the sessions returned by
+// the function.
+func WithStates(states ...Status) Option {
+	return func(o *options) {
+		o.withStates = states
+	}
+}
+
+// WithSessionQuotas allows specifying the maximum number of active sessions
+// a repository allows to be created.
+func WithSessionQuotas(quotas SessionQuotas) Option {
+	return func(o *options) {
+		o.withSessionQuotas = quotas
+	}
+}
+
+func withAccessRevoked(withAccessRevoked bool) Option {
+	return func(o *options) {
+		o.withAccessRevoked = withAccessRevoked
+	}
+}
+
 func withListingConvert(withListingConvert bool) Option {
 	return func(o *options) {
 		o.withListingConvert = withListingConvert
@@ -16,6 +16,15 @@ with not_active as (
 		s.public_id not in(select session_id from session_state where session_id = $1 and state = 'active') 
 )
 select * from not_active;
+`
+
+	// insertSessionAccessRevoked records that access to a session was
+	// revoked, so it is terminated with the 'access revoked' reason.
+	insertSessionAccessRevoked = `
+insert into session_access_revoked (session_id)
+values
+	($1)
+on conflict do nothing;
 `
 
 	// updateSessionState checks that we don't already have a row for the new
@@ -160,6 +169,8 @@ where
 	//	* sessions that are expired and all their connections are closed.
 	// 	* sessions that are canceling and all their connections are closed
 	//  * sessions that have exhausted their connection limit and all their connections are closed.
+	// Canceling sessions whose access was revoked are terminated with the
+	// 'access revoked' reason.
 	termSessionsUpdate = `
 with canceling_session(session_id) as
 (
@@ -174,6 +185,15 @@ with canceling_session(session_id) as
 update session us
 	set termination_reason = 
 	case 
+		-- sessions canceled because access to them was revoked
+		when us.public_id in (
+			select
+				session_id
+			from
+				session_access_revoked sar
+			where
+				us.public_id = sar.session_id
+			) then 'access revoked'
 		-- timed out sessions
 		when now() > us.expiration_time then 'timed out'
 		-- canceling sessions
@@ -231,5 +251,70 @@ where
                	end_time is null
     )
 )
+`
+
+	// lockSessionQuota serializes the creation of sessions for a target and
+	// user, so concurrent requests can't exceed the session quotas.  The
+	// target is always locked first to avoid deadlocks.
+	lockSessionQuota = `
+select
+	pg_advisory_xact_lock(hashtext('session_quota_target_' || $1::text)),
+	pg_advisory_xact_lock(hashtext('session_quota_user_' || $2::text));
+`
+
+	// activeSessionCounts counts the sessions which are not terminated or
+	// expired for a user and target.
+	activeSessionCounts = `
+select
+	count(*) filter (where s.user_id = $1 and s.target_id = $2) as user_target_count,
+	count(*) filter (where s.user_id = $1 and s.scope_id = $3) as user_scope_count,
+	count(*) filter (where s.target_id = $2) as target_count
+from
+	session s,
+	session_state ss
+where
+	s.public_id = ss.session_id and
+	ss.end_time is null and
+	ss.state != 'terminated' and
+	s.expiration_time > now() and
+	(s.user_id = $1 or s.target_id = $2);
+`
+
+	// deleteRetainedSessions deletes up to $2 sessions which were terminated
+	// before their retention period, resolved from the session_retention_policy
+	// of their scope or $1 seconds if it is not set, and whose warehouse facts
+	// are final.  Sessions locked by another transaction are skipped.  The
+	// states and connections of the sessions are deleted by cascade.
+	deleteRetainedSessions = `
+delete from session
+where
+	public_id in (
+		select
+			s.public_id
+		from
+			session s
+			join session_state ss
+				on ss.session_id = s.public_id and
+				ss.state = 'terminated'
+			join wh_session_accumulating_fact f
+				on f.session_id = s.public_id
+			left join session_retention_policy p
+				on p.scope_id = coalesce(s.scope_id, 'global')
+		where
+			ss.start_time < now() - make_interval(secs => coalesce(p.retention_seconds, $1::int)) and
+			f.session_terminated_time != 'infinity' and
+			not exists (
+				select 1
+				from
+					wh_session_connection_accumulating_fact c
+				where
+					c.session_id = s.public_id and
+					c.connection_closed_time = 'infinity'
+			)
+		order by
+			ss.start_time
+		limit $2
+		for update of s skip locked
+	);
 `
 )
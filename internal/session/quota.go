@@ -0,0 +1,68 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// SessionQuotas are the maximum numbers of active sessions a repository
+// allows to be created.  A session is active until it is terminated or
+// expires.  A zero value means there is no maximum.
+type SessionQuotas struct {
+	// MaxPerUserPerTarget is the maximum number of active sessions a user can
+	// have for a single target.
+	MaxPerUserPerTarget uint32
+	// MaxPerUserPerScope is the maximum number of active sessions a user can
+	// have for all of the targets in a single scope.
+	MaxPerUserPerScope uint32
+	// MaxPerTarget is the maximum number of active sessions all users can
+	// have for a single target.
+	MaxPerTarget uint32
+}
+
+// enabled reports whether any of the quotas are set.
+func (q SessionQuotas) enabled() bool {
+	return q.MaxPerUserPerTarget > 0 || q.MaxPerUserPerScope > 0 || q.MaxPerTarget > 0
+}
+
+type activeSessionCount struct {
+	UserTargetCount uint32
+	UserScopeCount  uint32
+	TargetCount     uint32
+}
+
+// checkSessionQuotas returns an error with the SessionQuotaExceeded code if
+// creating the session s would exceed any of the quotas q.  It must be called
+// within the transaction creating the session.
+func checkSessionQuotas(ctx context.Context, r db.Reader, w db.Writer, q SessionQuotas, s *Session) error {
+	const op = "session.checkSessionQuotas"
+	if !q.enabled() {
+		return nil
+	}
+	if _, err := w.Exec(ctx, lockSessionQuota, []interface{}{s.TargetId, s.UserId}); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to lock session quotas"))
+	}
+	rows, err := r.Query(ctx, activeSessionCounts, []interface{}{s.UserId, s.TargetId, s.ScopeId})
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	defer rows.Close()
+	var count activeSessionCount
+	for rows.Next() {
+		if err := r.ScanRows(rows, &count); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("scan row failed"))
+		}
+	}
+	switch {
+	case q.MaxPerUserPerTarget > 0 && count.UserTargetCount >= q.MaxPerUserPerTarget:
+		return errors.New(errors.SessionQuotaExceeded, op, fmt.Sprintf("user %s has reached the maximum of %d active sessions for target %s", s.UserId, q.MaxPerUserPerTarget, s.TargetId))
+	case q.MaxPerUserPerScope > 0 && count.UserScopeCount >= q.MaxPerUserPerScope:
+		return errors.New(errors.SessionQuotaExceeded, op, fmt.Sprintf("user %s has reached the maximum of %d active sessions in scope %s", s.UserId, q.MaxPerUserPerScope, s.ScopeId))
+	case q.MaxPerTarget > 0 && count.TargetCount >= q.MaxPerTarget:
+		return errors.New(errors.SessionQuotaExceeded, op, fmt.Sprintf("target %s has reached the maximum of %d active sessions", s.TargetId, q.MaxPerTarget))
+	}
+	return nil
+}
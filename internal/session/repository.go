@@ -22,10 +22,14 @@ type Repository struct {
 
 	// defaultLimit provides a default for limiting the number of results returned from the repo
 	defaultLimit int
+
+	// quotas limit the number of active sessions CreateSession allows
+	quotas SessionQuotas
 }
 
 // NewRepository creates a new session Repository. Supports the options: WithLimit
-// which sets a default limit on results returned by repo operations.
+// which sets a default limit on results returned by repo operations and
+// WithSessionQuotas which limits the number of active sessions.
 func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
 	const op = "session.NewRepository"
 	if r == nil {
@@ -47,6 +51,7 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 		writer:       w,
 		kms:          kms,
 		defaultLimit: opts.withLimit,
+		quotas:       opts.withSessionQuotas,
 	}, nil
 }
 
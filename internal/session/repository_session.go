@@ -6,6 +6,7 @@ import (
 	"crypto/subtle"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
@@ -18,8 +19,9 @@ import (
 
 // CreateSession inserts into the repository and returns the new Session with
 // its State of "Pending".  The following fields must be empty when creating a
-// session: ServerId, ServerType, and PublicId.  No options are
-// currently supported.
+// session: ServerId, ServerType, and PublicId.  If creating the session would
+// exceed the session quotas of the repository, an error with the
+// SessionQuotaExceeded code is returned.  No options are currently supported.
 func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.Wrapper, newSession *Session, _ ...Option) (*Session, ed25519.PrivateKey, error) {
 	const op = "session.(Repository).CreateSession"
 	if newSession == nil {
@@ -78,16 +80,32 @@ func (r *Repository) CreateSession(ctx context.Context, sessionWrapper wrapping.
 	newSession.PublicId = id
 	newSession.KeyId = sessionWrapper.KeyID()
 
+	clientCidrs, err := newClientCidrs(id, newSession.AllowedClientCidrs, newSession.DeniedClientCidrs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+
 	var returnedSession *Session
 	_, err = r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
 		func(read db.Reader, w db.Writer) error {
+			if err = checkSessionQuotas(ctx, read, w, r.quotas, newSession); err != nil {
+				return errors.Wrap(err, op)
+			}
 			returnedSession = newSession.Clone().(*Session)
 			if err = w.Create(ctx, returnedSession); err != nil {
 				return errors.Wrap(err, op)
 			}
+			if len(clientCidrs) > 0 {
+				if err = w.CreateItems(ctx, clientCidrs); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to create client cidrs"))
+				}
+				if returnedSession.AllowedClientCidrs, returnedSession.DeniedClientCidrs, err = fetchClientCidrs(ctx, read, returnedSession.PublicId); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
 			var foundStates []*State
 			// trigger will create new "Pending" state
 			if foundStates, err = fetchStates(ctx, read, returnedSession.PublicId); err != nil {
@@ -136,6 +154,9 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 				return errors.Wrap(err, op)
 			}
 			session.States = states
+			if session.AllowedClientCidrs, session.DeniedClientCidrs, err = fetchClientCidrs(ctx, read, sessionId); err != nil {
+				return errors.Wrap(err, op)
+			}
 			return nil
 		},
 	)
@@ -146,7 +167,7 @@ func (r *Repository) LookupSession(ctx context.Context, sessionId string, _ ...O
 		return nil, nil, errors.Wrap(err, op)
 	}
 	if len(session.CtTofuToken) > 0 {
-		databaseWrapper, err := r.kms.GetWrapper(ctx, session.ScopeId, kms.KeyPurposeDatabase)
+		databaseWrapper, err := r.kms.GetWrapper(ctx, session.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(session.TofuTokenKeyId))
 		if err != nil {
 			return nil, nil, errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
 		}
@@ -199,6 +220,14 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		}
 		where = append(where, fmt.Sprintf("s.public_id in (%s)", strings.Join(idsInClause, ",")))
 	}
+	if len(opts.withStates) > 0 {
+		statesInClause := make([]string, 0, len(opts.withStates))
+		for _, st := range opts.withStates {
+			inClauseCnt += 1
+			statesInClause, args = append(statesInClause, fmt.Sprintf("$%d", inClauseCnt)), append(args, st.String())
+		}
+		where = append(where, fmt.Sprintf("s.public_id in (select session_id from session_state where end_time is null and state in (%s))", strings.Join(statesInClause, ",")))
+	}
 
 	var limit string
 	switch {
@@ -307,6 +336,26 @@ func (r *Repository) CancelSession(ctx context.Context, sessionId string, sessio
 	return s, nil
 }
 
+// RevokeSession sets a session's state to "canceling" because its user no
+// longer has access to it.  Once all its connections are closed, the session
+// is terminated with the AccessRevoked termination reason instead of
+// SessionCanceled.  RevokeSession is idempotent.
+func (r *Repository) RevokeSession(ctx context.Context, sessionId string, sessionVersion uint32) (*Session, error) {
+	const op = "session.(Repository).RevokeSession"
+	if sessionId == "" {
+		return nil, errors.New(errors.InvalidParameter, op, "missing session id")
+	}
+	if sessionVersion == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing session version")
+	}
+	s, ss, err := r.updateState(ctx, sessionId, sessionVersion, StatusCanceling, withAccessRevoked(true))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	s.States = ss
+	return s, nil
+}
+
 // TerminateSession sets a session's termination reason and it's state to
 // "terminated" Sessions cannot be terminated which still have connections that
 // are not closed.
@@ -383,6 +432,65 @@ func (r *Repository) TerminateCompletedSessions(ctx context.Context) (int, error
 	return rowsAffected, nil
 }
 
+// DefaultRetentionBatchSize is the number of sessions DeleteRetainedSessions
+// deletes in a transaction unless WithLimit is used.
+const DefaultRetentionBatchSize = 1000
+
+// DeleteRetainedSessions deletes the sessions, along with their states and
+// connections, which were terminated longer ago than their retention period
+// and whose warehouse facts are final, so no data is lost from the warehouse.
+// The retention period of a session is resolved from its scope, its scope's
+// parent and the global scope; defaultRetention applies when none of them set
+// it, and a zero defaultRetention means those sessions are retained forever.
+//
+// Sessions are deleted in batches, each in its own transaction, so that the
+// session tables are not locked for long.  WithLimit sets the size of the
+// batches.  The number of sessions deleted is returned.  This function should
+// be called on a periodic basis by Controllers via their "ticker" pattern.
+func (r *Repository) DeleteRetainedSessions(ctx context.Context, defaultRetention time.Duration, opt ...Option) (int, error) {
+	const op = "session.(Repository).DeleteRetainedSessions"
+	if defaultRetention < 0 {
+		return db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "negative default retention")
+	}
+	opts := getOpts(opt...)
+	limit := opts.withLimit
+	if limit <= 0 {
+		limit = DefaultRetentionBatchSize
+	}
+	var defaultSeconds interface{}
+	if defaultRetention > 0 {
+		defaultSeconds = int(defaultRetention.Seconds())
+	}
+
+	var total int
+	for {
+		var rowsAffected int
+		_, err := r.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(_ db.Reader, w db.Writer) error {
+				var err error
+				rowsAffected, err = w.Exec(ctx, deleteRetainedSessions, []interface{}{defaultSeconds, limit})
+				if err != nil {
+					return errors.Wrap(err, op)
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return total, errors.Wrap(err, op)
+		}
+		total += rowsAffected
+		if rowsAffected < limit {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, errors.Wrap(err, op)
+		}
+	}
+}
+
 // AuthorizeConnection will check to see if a connection is allowed.  Currently,
 // that authorization checks:
 // * the hasn't expired based on the session.Expiration
@@ -638,7 +746,7 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 			if err := updatedSession.encrypt(ctx, databaseWrapper); err != nil {
 				return errors.Wrap(err, op)
 			}
-			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"CtTofuToken"}, nil)
+			rowsUpdated, err := w.Update(ctx, &updatedSession, []string{"CtTofuToken", "TofuTokenKeyId"}, nil)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
@@ -663,7 +771,7 @@ func (r *Repository) ActivateSession(ctx context.Context, sessionId string, sess
 // updateState will update the session's state using the session id and its
 // version. updateState is idempotent. States are ordered by start time
 // descending. No options are currently supported.
-func (r *Repository) updateState(ctx context.Context, sessionId string, sessionVersion uint32, s Status, _ ...Option) (*Session, []*State, error) {
+func (r *Repository) updateState(ctx context.Context, sessionId string, sessionVersion uint32, s Status, opt ...Option) (*Session, []*State, error) {
 	const op = "session.(Repository).updateState"
 	if sessionId == "" {
 		return nil, nil, errors.New(errors.InvalidParameter, op, "missing session id")
@@ -678,6 +786,8 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 		return nil, nil, errors.New(errors.InvalidParameter, op, "you must call ActivateSession to update a session's state to active")
 	}
 
+	opts := getOpts(opt...)
+
 	var rowsAffected int
 	updatedSession := AllocSession()
 	var returnedStates []*State
@@ -698,7 +808,7 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 				return errors.New(errors.MultipleRecords, op, fmt.Sprintf("updated session and %d rows updated", rowsUpdated))
 			}
 			if len(updatedSession.CtTofuToken) > 0 {
-				databaseWrapper, err := r.kms.GetWrapper(ctx, updatedSession.ScopeId, kms.KeyPurposeDatabase)
+				databaseWrapper, err := r.kms.GetWrapper(ctx, updatedSession.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(updatedSession.TofuTokenKeyId))
 				if err != nil {
 					return errors.Wrap(err, op, errors.WithMsg("unable to get database wrapper"))
 				}
@@ -709,6 +819,11 @@ func (r *Repository) updateState(ctx context.Context, sessionId string, sessionV
 				updatedSession.CtTofuToken = nil
 			}
 
+			if opts.withAccessRevoked {
+				if _, err := w.Exec(ctx, insertSessionAccessRevoked, []interface{}{sessionId}); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to revoke session %s", sessionId)))
+				}
+			}
 			rowsAffected, err = w.Exec(ctx, updateSessionState, []interface{}{sessionId, s.String()})
 			if err != nil {
 				return errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("unable to update session %s state to %s", sessionId, s.String())))
@@ -14,6 +14,7 @@ import (
 	staticStore "github.com/hashicorp/boundary/internal/host/static/store"
 	"github.com/hashicorp/boundary/internal/target"
 	targetStore "github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/lib/pq"
 
 	"github.com/hashicorp/boundary/internal/errors"
@@ -243,6 +244,18 @@ func TestRepository_CreateSession(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with-client-cidrs",
+			args: args{
+				composedOf: func() ComposedOf {
+					c := TestSessionParams(t, conn, wrapper, iamRepo)
+					c.AllowedClientCidrs = []string{"10.0.0.0/8"}
+					c.DeniedClientCidrs = []string{"10.1.0.0/16"}
+					return c
+				}(),
+			},
+			wantErr: false,
+		},
 		{
 			name: "empty-userId",
 			args: args{
@@ -329,6 +342,9 @@ func TestRepository_CreateSession(t *testing.T) {
 				Endpoint:        "tcp://127.0.0.1:22",
 				ExpirationTime:  tt.args.composedOf.ExpirationTime,
 				ConnectionLimit: tt.args.composedOf.ConnectionLimit,
+
+				AllowedClientCidrs: tt.args.composedOf.AllowedClientCidrs,
+				DeniedClientCidrs:  tt.args.composedOf.DeniedClientCidrs,
 			}
 			ses, privKey, err := repo.CreateSession(context.Background(), wrapper, s)
 			if tt.wantErr {
@@ -354,6 +370,8 @@ func TestRepository_CreateSession(t *testing.T) {
 			ses.ExpirationTime = foundSession.ExpirationTime
 
 			assert.Equal(foundSession, ses)
+			assert.Equal(tt.args.composedOf.AllowedClientCidrs, foundSession.AllowedClientCidrs)
+			assert.Equal(tt.args.composedOf.DeniedClientCidrs, foundSession.DeniedClientCidrs)
 
 			err = db.TestVerifyOplog(t, rw, ses.PublicId, db.WithOperation(oplog.OpType_OP_TYPE_CREATE), db.WithCreateNotBefore(10*time.Second))
 			assert.Error(err)
@@ -364,6 +382,84 @@ func TestRepository_CreateSession(t *testing.T) {
 	}
 }
 
+func TestRepository_CreateSession_Quotas(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	newSession := func(c ComposedOf) *Session {
+		s, err := New(c)
+		require.NoError(t, err)
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		quotas SessionQuotas
+		// other returns the composition of a session which shares the quota
+		// with a session composed of c
+		other func(t *testing.T, c ComposedOf) ComposedOf
+	}{
+		{
+			name:   "per-user-per-target",
+			quotas: SessionQuotas{MaxPerUserPerTarget: 1},
+			other: func(t *testing.T, c ComposedOf) ComposedOf {
+				return c
+			},
+		},
+		{
+			name:   "per-user-per-scope",
+			quotas: SessionQuotas{MaxPerUserPerScope: 1},
+			other: func(t *testing.T, c ComposedOf) ComposedOf {
+				tar := target.TestTcpTarget(t, conn, c.ScopeId, "other target")
+				c.TargetId = tar.GetPublicId()
+				return c
+			},
+		},
+		{
+			name:   "per-target",
+			quotas: SessionQuotas{MaxPerTarget: 1},
+			other: func(t *testing.T, c ComposedOf) ComposedOf {
+				c.UserId = iam.TestUser(t, iamRepo, scope.Global.String()).GetPublicId()
+				return c
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			repo, err := NewRepository(rw, rw, kms, WithSessionQuotas(tt.quotas))
+			require.NoError(err)
+
+			c := TestSessionParams(t, conn, wrapper, iamRepo)
+			first, _, err := repo.CreateSession(ctx, wrapper, newSession(c))
+			require.NoError(err)
+
+			other := tt.other(t, c)
+			got, _, err := repo.CreateSession(ctx, wrapper, newSession(other))
+			require.Error(err)
+			assert.Nil(got)
+			assert.True(errors.Match(errors.T(errors.SessionQuotaExceeded), err))
+
+			// A session which does not share the quota is not limited by it.
+			_, _, err = repo.CreateSession(ctx, wrapper, newSession(TestSessionParams(t, conn, wrapper, iamRepo)))
+			require.NoError(err)
+
+			// Terminated sessions no longer count towards the quota.
+			_, err = repo.CancelSession(ctx, first.PublicId, first.Version)
+			require.NoError(err)
+			_ = TestState(t, conn, first.PublicId, StatusTerminated)
+			got, _, err = repo.CreateSession(ctx, wrapper, newSession(other))
+			require.NoError(err)
+			assert.NotNil(got)
+		})
+	}
+}
+
 func TestRepository_updateState(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1211,6 +1307,55 @@ func TestRepository_CancelSession(t *testing.T) {
 	}
 }
 
+func TestRepository_RevokeSession(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		c := TestConnection(t, conn, s.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+
+		live, err := repo.ListSessions(context.Background(), WithSessionIds(s.PublicId), WithStates(StatusPending, StatusActive))
+		require.NoError(err)
+		assert.Len(live, 1)
+
+		got, err := repo.RevokeSession(context.Background(), s.PublicId, s.Version)
+		require.NoError(err)
+		assert.Equal(StatusCanceling, got.States[0].Status)
+
+		live, err = repo.ListSessions(context.Background(), WithSessionIds(s.PublicId), WithStates(StatusPending, StatusActive))
+		require.NoError(err)
+		assert.Empty(live)
+
+		// revoking is idempotent
+		_, err = repo.RevokeSession(context.Background(), s.PublicId, got.Version)
+		require.NoError(err)
+
+		_, err = repo.CloseConnections(context.Background(), []CloseWith{{ConnectionId: c.PublicId, ClosedReason: ConnectionCanceled}})
+		require.NoError(err)
+		found, _, err := repo.LookupSession(context.Background(), s.PublicId)
+		require.NoError(err)
+		assert.Equal(StatusTerminated, found.States[0].Status)
+		assert.Equal(AccessRevoked.String(), found.TerminationReason)
+	})
+	t.Run("missing-id", func(t *testing.T) {
+		_, err := repo.RevokeSession(context.Background(), "", 1)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+	t.Run("missing-version", func(t *testing.T) {
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		_, err := repo.RevokeSession(context.Background(), s.PublicId, 0)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+}
+
 func TestRepository_CancelSessionViaFKNull(t *testing.T) {
 	t.Parallel()
 	conn, _ := db.TestSetup(t, "postgres")
@@ -1595,3 +1740,56 @@ func TestRepository_DeleteSession(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_DeleteRetainedSessions(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	terminateFn := func() *Session {
+		s := TestDefaultSession(t, conn, wrapper, iamRepo)
+		s, err := repo.TerminateSession(ctx, s.PublicId, s.Version, ClosedByUser)
+		require.NoError(t, err)
+		return s
+	}
+	existsFn := func(s *Session) bool {
+		found, _, err := repo.LookupSession(ctx, s.PublicId)
+		require.NoError(t, err)
+		return found != nil
+	}
+
+	t.Run("negative-retention", func(t *testing.T) {
+		_, err := repo.DeleteRetainedSessions(ctx, -time.Second)
+		require.Error(t, err)
+		assert.True(t, errors.Match(errors.T(errors.InvalidParameter), err))
+	})
+
+	old := terminateFn()
+	overridden := terminateFn()
+	_, err = rw.Exec(ctx, "update iam_scope set session_retention_seconds = $1 where public_id = $2", []interface{}{3600, overridden.ScopeId})
+	require.NoError(t, err)
+	connected := TestDefaultSession(t, conn, wrapper, iamRepo)
+	_ = TestConnection(t, conn, connected.PublicId, "127.0.0.1", 22, "127.0.0.1", 222)
+	time.Sleep(3 * time.Second)
+	recent := terminateFn()
+
+	// Without a default retention, sessions are only deleted when their
+	// scopes set one.
+	deleted, err := repo.DeleteRetainedSessions(ctx, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+
+	deleted, err = repo.DeleteRetainedSessions(ctx, 2*time.Second, WithLimit(1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	assert.False(t, existsFn(old))
+	assert.True(t, existsFn(overridden))
+	assert.True(t, existsFn(connected))
+	assert.True(t, existsFn(recent))
+}
@@ -49,6 +49,12 @@ type ComposedOf struct {
 	// existed at creation time. Round tripping it through here saves a lookup
 	// in the DB. It is not stored in the warehouse.
 	WorkerFilter string
+	// Allowed client cidrs. Active client cidr restrictions when the session
+	// was created, used by workers to validate the address of each client
+	// connecting to the session. They are not stored in the warehouse.
+	AllowedClientCidrs []string
+	// Denied client cidrs. See AllowedClientCidrs.
+	DeniedClientCidrs []string
 }
 
 // Session contains information about a user's session with a target
@@ -95,6 +101,12 @@ type Session struct {
 	ConnectionLimit int32 `json:"connection_limit,omitempty" gorm:"default:null"`
 	// Worker filter
 	WorkerFilter string `json:"-" gorm:"default:null"`
+	// AllowedClientCidrs are the CIDR blocks clients must connect from.  They
+	// are stored in the session_client_cidr table.
+	AllowedClientCidrs []string `json:"-" gorm:"-"`
+	// DeniedClientCidrs are the CIDR blocks clients can not connect from.
+	// They are stored in the session_client_cidr table.
+	DeniedClientCidrs []string `json:"-" gorm:"-"`
 
 	// key_id is the key ID that was used for the encryption operation. It can be
 	// used to identify a specific version of the key needed to decrypt the value,
@@ -102,6 +114,10 @@ type Session struct {
 	// @inject_tag: `gorm:"not_null"`
 	KeyId string `json:"key_id,omitempty" gorm:"not_null"`
 
+	// TofuTokenKeyId is the ID of the database key version used to encrypt
+	// the tofu token.
+	TofuTokenKeyId string `json:"tofu_token_key_id,omitempty" gorm:"default:null"`
+
 	// States for the session which are for read only and are ignored during
 	// write operations
 	States    []*State `gorm:"-"`
@@ -132,6 +148,12 @@ func New(c ComposedOf, _ ...Option) (*Session, error) {
 		ConnectionLimit: c.ConnectionLimit,
 		WorkerFilter:    c.WorkerFilter,
 	}
+	if len(c.AllowedClientCidrs) > 0 {
+		s.AllowedClientCidrs = append([]string(nil), c.AllowedClientCidrs...)
+	}
+	if len(c.DeniedClientCidrs) > 0 {
+		s.DeniedClientCidrs = append([]string(nil), c.DeniedClientCidrs...)
+	}
 	if err := s.validateNewSession(); err != nil {
 		return nil, errors.Wrap(err, op)
 	}
@@ -161,6 +183,7 @@ func (s *Session) Clone() interface{} {
 		ConnectionLimit:   s.ConnectionLimit,
 		WorkerFilter:      s.WorkerFilter,
 		KeyId:             s.KeyId,
+		TofuTokenKeyId:    s.TofuTokenKeyId,
 	}
 	if len(s.States) > 0 {
 		clone.States = make([]*State, 0, len(s.States))
@@ -169,6 +192,12 @@ func (s *Session) Clone() interface{} {
 			clone.States = append(clone.States, cp)
 		}
 	}
+	if len(s.AllowedClientCidrs) > 0 {
+		clone.AllowedClientCidrs = append([]string(nil), s.AllowedClientCidrs...)
+	}
+	if len(s.DeniedClientCidrs) > 0 {
+		clone.DeniedClientCidrs = append([]string(nil), s.DeniedClientCidrs...)
+	}
 	if s.TofuToken != nil {
 		clone.TofuToken = make([]byte, len(s.TofuToken))
 		copy(clone.TofuToken, s.TofuToken)
@@ -372,7 +401,7 @@ func (s *Session) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
 	if err := structwrapping.WrapStruct(ctx, cipher, s, nil); err != nil {
 		return errors.Wrap(err, op, errors.WithCode(errors.Encrypt))
 	}
-	s.KeyId = cipher.KeyID()
+	s.TofuTokenKeyId = cipher.KeyID()
 	return nil
 }
 
@@ -18,6 +18,7 @@ const (
 	SystemError        TerminationReason = "system error"
 	ConnectionLimit    TerminationReason = "connection limit"
 	SessionCanceled    TerminationReason = "canceled"
+	AccessRevoked      TerminationReason = "access revoked"
 )
 
 // String representation of the termination reason
@@ -42,6 +43,8 @@ func convertToReason(s string) (TerminationReason, error) {
 		return SystemError, nil
 	case ConnectionLimit.String():
 		return ConnectionLimit, nil
+	case AccessRevoked.String():
+		return AccessRevoked, nil
 	default:
 		return "", errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is not a valid reason", s))
 	}
@@ -0,0 +1,97 @@
+package target
+
+import (
+	"fmt"
+	"time"
+
+	// The access window time zones are loaded from the embedded database
+	// when the host does not provide one.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/cron"
+)
+
+// maxAccessWindowChain bounds how far past now consecutive, overlapping access
+// windows are merged when computing when an access window closes.
+const maxAccessWindowChain = 7 * 24 * time.Hour
+
+// accessWindow is the parsed access window of a target.
+type accessWindow struct {
+	schedule *cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+// parseAccessWindow parses the access window of a target. It returns nil if
+// the target has no access window schedule.
+func parseAccessWindow(schedule string, durationSeconds uint32, timeZone string) (*accessWindow, error) {
+	const op = "target.parseAccessWindow"
+	if schedule == "" {
+		if durationSeconds != 0 || timeZone != "" {
+			return nil, errors.New(errors.InvalidParameter, op, "access window duration and time zone require a schedule")
+		}
+		return nil, nil
+	}
+	if durationSeconds == 0 {
+		return nil, errors.New(errors.InvalidParameter, op, "missing access window duration")
+	}
+	s, err := cron.Parse(schedule)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid access window schedule: %s", err))
+	}
+	loc := time.UTC
+	if timeZone != "" {
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid access window time zone %q", timeZone))
+		}
+	}
+	return &accessWindow{
+		schedule: s,
+		duration: time.Duration(durationSeconds) * time.Second,
+		location: loc,
+	}, nil
+}
+
+// closes returns when the access window open at now closes, and false if no
+// access window is open at now. Overlapping windows are treated as a single
+// window.
+func (w *accessWindow) closes(now time.Time) (time.Time, bool) {
+	now = now.In(w.location)
+	// The latest window which opened at or before now is the one closing
+	// last.
+	start := w.schedule.Next(now.Add(-w.duration))
+	if start.IsZero() || start.After(now) {
+		return time.Time{}, false
+	}
+	for next := w.schedule.Next(start); !next.IsZero() && !next.After(now); next = w.schedule.Next(next) {
+		start = next
+	}
+	end := start.Add(w.duration)
+	if !end.After(now) {
+		return time.Time{}, false
+	}
+	horizon := now.Add(maxAccessWindowChain)
+	for next := w.schedule.Next(start); !next.IsZero() && next.Before(end) && end.Before(horizon); next = w.schedule.Next(next) {
+		if e := next.Add(w.duration); e.After(end) {
+			end = e
+		}
+	}
+	return end.UTC(), true
+}
+
+// AccessWindowCloses reports whether sessions of the target t can be
+// authorized and used at now and, if they can, when that stops being the
+// case. The returned time is the zero time if t has no access window.
+func AccessWindowCloses(t Target, now time.Time) (time.Time, bool, error) {
+	const op = "target.AccessWindowCloses"
+	w, err := parseAccessWindow(t.GetAccessWindowSchedule(), t.GetAccessWindowDurationSeconds(), t.GetAccessWindowTimeZone())
+	if err != nil {
+		return time.Time{}, false, errors.Wrap(err, op)
+	}
+	if w == nil {
+		return time.Time{}, true, nil
+	}
+	end, open := w.closes(now)
+	return end, open, nil
+}
@@ -0,0 +1,116 @@
+package target
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessWindowCloses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name            string
+		schedule        string
+		durationSeconds uint32
+		timeZone        string
+		now             time.Time
+		wantOpen        bool
+		wantCloses      time.Time
+		wantIsErr       errors.Code
+	}{
+		{
+			name:     "no-window",
+			now:      time.Date(2021, 3, 1, 3, 0, 0, 0, time.UTC),
+			wantOpen: true,
+		},
+		{
+			name:            "open",
+			schedule:        "0 9 * * mon-fri",
+			durationSeconds: 8 * 3600,
+			now:             time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC),
+			wantOpen:        true,
+			wantCloses:      time.Date(2021, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "opening",
+			schedule:        "0 9 * * mon-fri",
+			durationSeconds: 8 * 3600,
+			now:             time.Date(2021, 3, 1, 9, 0, 0, 0, time.UTC),
+			wantOpen:        true,
+			wantCloses:      time.Date(2021, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "closed",
+			schedule:        "0 9 * * mon-fri",
+			durationSeconds: 8 * 3600,
+			now:             time.Date(2021, 3, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "closed-on-weekend",
+			schedule:        "0 9 * * mon-fri",
+			durationSeconds: 8 * 3600,
+			now:             time.Date(2021, 3, 6, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "overlapping-windows",
+			schedule:        "0 * * * *",
+			durationSeconds: 2 * 3600,
+			now:             time.Date(2021, 3, 1, 12, 30, 0, 0, time.UTC),
+			wantOpen:        true,
+			wantCloses:      time.Date(2021, 3, 8, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "time-zone",
+			schedule:        "0 9 * * *",
+			durationSeconds: 3600,
+			timeZone:        "America/New_York",
+			now:             time.Date(2021, 3, 1, 14, 30, 0, 0, time.UTC),
+			wantOpen:        true,
+			wantCloses:      time.Date(2021, 3, 1, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:            "invalid-schedule",
+			schedule:        "0 25 * * *",
+			durationSeconds: 3600,
+			wantIsErr:       errors.InvalidParameter,
+		},
+		{
+			name:      "missing-duration",
+			schedule:  "0 9 * * *",
+			wantIsErr: errors.InvalidParameter,
+		},
+		{
+			name:            "missing-schedule",
+			durationSeconds: 3600,
+			wantIsErr:       errors.InvalidParameter,
+		},
+		{
+			name:            "invalid-time-zone",
+			schedule:        "0 9 * * *",
+			durationSeconds: 3600,
+			timeZone:        "Mars/Olympus_Mons",
+			wantIsErr:       errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			tar, err := NewTcpTarget("p_1234567890",
+				WithAccessWindowSchedule(tt.schedule),
+				WithAccessWindowDurationSeconds(tt.durationSeconds),
+				WithAccessWindowTimeZone(tt.timeZone))
+			require.NoError(err)
+			closes, open, err := AccessWindowCloses(tar, tt.now)
+			if tt.wantIsErr != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsErr), err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.wantOpen, open)
+			assert.Truef(tt.wantCloses.Equal(closes), "got %s, want %s", closes, tt.wantCloses)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package target
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/libs/cidr"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultTargetClientCidrTableName = "target_client_cidr"
+
+	allowClientCidrRule = "allow"
+	denyClientCidrRule  = "deny"
+)
+
+// TargetClientCidr is a CIDR block which clients of a target must, or must
+// not, connect from.
+type TargetClientCidr struct {
+	*store.TargetClientCidr
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*TargetClientCidr)(nil)
+
+// allocTargetClientCidr will allocate a target client cidr
+func allocTargetClientCidr() TargetClientCidr {
+	return TargetClientCidr{
+		TargetClientCidr: &store.TargetClientCidr{},
+	}
+}
+
+// Clone creates a clone of the target client cidr
+func (c *TargetClientCidr) Clone() interface{} {
+	cp := proto.Clone(c.TargetClientCidr)
+	return &TargetClientCidr{
+		TargetClientCidr: cp.(*store.TargetClientCidr),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the target
+// client cidr before it's written.
+func (c *TargetClientCidr) VetForWrite(_ context.Context, _ db.Reader, opType db.OpType, _ ...db.Option) error {
+	const op = "target.(TargetClientCidr).VetForWrite"
+	if opType == db.CreateOp {
+		if c.TargetId == "" {
+			return errors.New(errors.InvalidParameter, op, "missing target id")
+		}
+		if c.Cidr == "" {
+			return errors.New(errors.InvalidParameter, op, "missing cidr")
+		}
+		if c.Rule != allowClientCidrRule && c.Rule != denyClientCidrRule {
+			return errors.New(errors.InvalidParameter, op, fmt.Sprintf("invalid rule %q", c.Rule))
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (c *TargetClientCidr) TableName() string {
+	if c.tableName != "" {
+		return c.tableName
+	}
+	return DefaultTargetClientCidrTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (c *TargetClientCidr) SetTableName(n string) {
+	c.tableName = n
+}
+
+// newClientCidrs returns the target client cidrs for the allowed and denied
+// cidrs of the target with the provided id.  The cidrs must be valid CIDR
+// blocks or IP addresses, they are stored in their canonical form.
+func newClientCidrs(targetId string, allowed, denied []string) ([]interface{}, error) {
+	const op = "target.newClientCidrs"
+	allowed, err := cidr.Normalize(allowed)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	denied, err = cidr.Normalize(denied)
+	if err != nil {
+		return nil, errors.New(errors.InvalidParameter, op, err.Error())
+	}
+	items := make([]interface{}, 0, len(allowed)+len(denied))
+	for _, c := range allowed {
+		tc := allocTargetClientCidr()
+		tc.TargetId, tc.Cidr, tc.Rule = targetId, c, allowClientCidrRule
+		items = append(items, &tc)
+	}
+	for _, c := range denied {
+		tc := allocTargetClientCidr()
+		tc.TargetId, tc.Cidr, tc.Rule = targetId, c, denyClientCidrRule
+		items = append(items, &tc)
+	}
+	return items, nil
+}
+
+// fetchClientCidrs returns the allowed and denied client cidrs of the targets
+// with the provided ids keyed by target id.
+func fetchClientCidrs(ctx context.Context, r db.Reader, targetIds ...string) (allowed, denied map[string][]string, err error) {
+	const op = "target.fetchClientCidrs"
+	if len(targetIds) == 0 {
+		return nil, nil, nil
+	}
+	var cidrs []*TargetClientCidr
+	if err := r.SearchWhere(ctx, &cidrs, "target_id in (?)", []interface{}{targetIds}, db.WithLimit(-1), db.WithOrder("cidr asc")); err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
+	allowed = make(map[string][]string, len(targetIds))
+	denied = make(map[string][]string, len(targetIds))
+	for _, c := range cidrs {
+		switch c.Rule {
+		case allowClientCidrRule:
+			allowed[c.TargetId] = append(allowed[c.TargetId], c.Cidr)
+		case denyClientCidrRule:
+			denied[c.TargetId] = append(denied[c.TargetId], c.Cidr)
+		}
+	}
+	return allowed, denied, nil
+}
+
+// setClientCidrs replaces the client cidrs of the target t.
+func setClientCidrs(ctx context.Context, r db.Reader, w db.Writer, oplogWrapper wrapping.Wrapper, t Target, allowed, denied []string) error {
+	const op = "target.setClientCidrs"
+	items, err := newClientCidrs(t.GetPublicId(), allowed, denied)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	var existing []*TargetClientCidr
+	if err := r.SearchWhere(ctx, &existing, "target_id = ?", []interface{}{t.GetPublicId()}, db.WithLimit(-1)); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if len(existing) > 0 {
+		deleteItems := make([]interface{}, 0, len(existing))
+		for _, c := range existing {
+			deleteItems = append(deleteItems, c)
+		}
+		if _, err := w.DeleteItems(ctx, deleteItems, db.WithOplog(oplogWrapper, t.oplog(oplog.OpType_OP_TYPE_DELETE))); err != nil {
+			return errors.Wrap(err, op, errors.WithMsg("unable to delete target client cidrs"))
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	if err := w.CreateItems(ctx, items, db.WithOplog(oplogWrapper, t.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+		return errors.Wrap(err, op, errors.WithMsg("unable to create target client cidrs"))
+	}
+	return nil
+}
+
+// assignClientCidrs sets the allowed and denied client cidrs of the target t.
+func assignClientCidrs(t interface{}, allowed, denied []string) {
+	switch tt := t.(type) {
+	case *TcpTarget:
+		tt.AllowedClientCidrs, tt.DeniedClientCidrs = allowed, denied
+	}
+}
+
+// ClientAllowed reports whether a client with the provided IP address is
+// allowed to connect to the target t.
+func ClientAllowed(t Target, clientIp string) bool {
+	return cidr.Allowed(clientIp, t.GetAllowedClientCidrs(), t.GetDeniedClientCidrs())
+}
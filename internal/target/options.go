@@ -30,6 +30,12 @@ type options struct {
 	withSessionConnectionLimit int32
 	withPublicId               string
 	withWorkerFilter           string
+	withAllowedClientCidrs     []string
+	withDeniedClientCidrs      []string
+	withSetClientCidrs         bool
+	withAccessWindowSchedule   string
+	withAccessWindowDuration   uint32
+	withAccessWindowTimeZone   string
 }
 
 func getDefaultOptions() options {
@@ -48,6 +54,12 @@ func getDefaultOptions() options {
 		withSessionConnectionLimit: 1,
 		withPublicId:               "",
 		withWorkerFilter:           "",
+		withAllowedClientCidrs:     nil,
+		withDeniedClientCidrs:      nil,
+		withSetClientCidrs:         false,
+		withAccessWindowSchedule:   "",
+		withAccessWindowDuration:   0,
+		withAccessWindowTimeZone:   "",
 	}
 }
 
@@ -148,3 +160,52 @@ func WithWorkerFilter(filter string) Option {
 		o.withWorkerFilter = filter
 	}
 }
+
+// WithAllowedClientCidrs provides an optional list of CIDR blocks clients
+// must connect from
+func WithAllowedClientCidrs(cidrs []string) Option {
+	return func(o *options) {
+		o.withAllowedClientCidrs = cidrs
+	}
+}
+
+// WithDeniedClientCidrs provides an optional list of CIDR blocks clients can
+// not connect from
+func WithDeniedClientCidrs(cidrs []string) Option {
+	return func(o *options) {
+		o.withDeniedClientCidrs = cidrs
+	}
+}
+
+// WithAccessWindowSchedule provides an optional cron expression for the start
+// of the access windows of a target
+func WithAccessWindowSchedule(schedule string) Option {
+	return func(o *options) {
+		o.withAccessWindowSchedule = schedule
+	}
+}
+
+// WithAccessWindowDurationSeconds provides an optional duration, in seconds,
+// of the access windows of a target
+func WithAccessWindowDurationSeconds(seconds uint32) Option {
+	return func(o *options) {
+		o.withAccessWindowDuration = seconds
+	}
+}
+
+// WithAccessWindowTimeZone provides an optional IANA time zone the access
+// window schedule of a target is evaluated in
+func WithAccessWindowTimeZone(tz string) Option {
+	return func(o *options) {
+		o.withAccessWindowTimeZone = tz
+	}
+}
+
+// withSetClientCidrs is used by update to replace the client cidrs of a
+// target with the ones provided by WithAllowedClientCidrs and
+// WithDeniedClientCidrs.
+func withSetClientCidrs(b bool) Option {
+	return func(o *options) {
+		o.withSetClientCidrs = b
+	}
+}
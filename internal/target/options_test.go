@@ -104,4 +104,31 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withWorkerFilter = `"/foo" == "bar"`
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAllowedClientCidrs", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAllowedClientCidrs([]string{"10.0.0.0/8"}))
+		testOpts := getDefaultOptions()
+		testOpts.withAllowedClientCidrs = []string{"10.0.0.0/8"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithDeniedClientCidrs", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithDeniedClientCidrs([]string{"10.0.0.0/8"}))
+		testOpts := getDefaultOptions()
+		testOpts.withDeniedClientCidrs = []string{"10.0.0.0/8"}
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAccessWindow", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(
+			WithAccessWindowSchedule("0 9 * * mon-fri"),
+			WithAccessWindowDurationSeconds(3600),
+			WithAccessWindowTimeZone("Europe/Berlin"),
+		)
+		testOpts := getDefaultOptions()
+		testOpts.withAccessWindowSchedule = "0 9 * * mon-fri"
+		testOpts.withAccessWindowDuration = 3600
+		testOpts.withAccessWindowTimeZone = "Europe/Berlin"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -52,8 +52,9 @@ func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repo
 	}, nil
 }
 
-// LookupTarget will look up a target in the repository and return the target
-// with its host set ids.  If the target is not found, it will return nil, nil, nil.
+// LookupTarget will look up a target in the repository and return the target,
+// including its client cidrs, with its host set ids.  If the target is not
+// found, it will return nil, nil, nil.
 // No options are currently supported.
 func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, opt ...Option) (Target, []*TargetSet, error) {
 	const op = "target.(Repository).LookupTarget"
@@ -97,6 +98,7 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	target := allocTargetView()
 	target.PublicId = publicIdOrName
 	var hostSets []*TargetSet
+	var allowedCidrs, deniedCidrs map[string][]string
 	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
@@ -117,6 +119,9 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 			if hostSets, err = fetchSets(ctx, read, target.PublicId); err != nil {
 				return errors.Wrap(err, op)
 			}
+			if allowedCidrs, deniedCidrs, err = fetchClientCidrs(ctx, read, target.PublicId); err != nil {
+				return errors.Wrap(err, op)
+			}
 			return nil
 		},
 	)
@@ -130,6 +135,7 @@ func (r *Repository) LookupTarget(ctx context.Context, publicIdOrName string, op
 	if err != nil {
 		return nil, nil, errors.Wrap(err, op)
 	}
+	assignClientCidrs(subType, allowedCidrs[target.PublicId], deniedCidrs[target.PublicId])
 	return subType, hostSets, nil
 }
 
@@ -168,6 +174,15 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		return nil, errors.Wrap(err, op)
 	}
 
+	targetIds := make([]string, 0, len(foundTargets))
+	for _, t := range foundTargets {
+		targetIds = append(targetIds, t.PublicId)
+	}
+	allowedCidrs, deniedCidrs, err := fetchClientCidrs(ctx, r.reader, targetIds...)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
 	targets := make([]Target, 0, len(foundTargets))
 
 	for _, t := range foundTargets {
@@ -175,6 +190,7 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 		if err != nil {
 			return nil, errors.Wrap(err, op)
 		}
+		assignClientCidrs(subType, allowedCidrs[t.PublicId], deniedCidrs[t.PublicId])
 		targets = append(targets, subType)
 	}
 	return targets, nil
@@ -255,10 +271,13 @@ func (r *Repository) DeleteTarget(ctx context.Context, publicId string, _ ...Opt
 	return rowsDeleted, nil
 }
 
-// update a target in the db repository with an oplog entry.
-// It currently supports no options.
-func (r *Repository) update(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, setToNullPaths []string, _ ...Option) (Target, []*TargetSet, int, error) {
+// update a target in the db repository with an oplog entry.  If the
+// withSetClientCidrs option is set, the client cidrs of the target are
+// replaced with the ones provided by the WithAllowedClientCidrs and
+// WithDeniedClientCidrs options.
+func (r *Repository) update(ctx context.Context, target Target, version uint32, fieldMaskPaths []string, setToNullPaths []string, opt ...Option) (Target, []*TargetSet, int, error) {
 	const op = "target.(Repository).update"
+	opts := getOpts(opt...)
 	if version == 0 {
 		return nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing version")
 	}
@@ -312,6 +331,17 @@ func (r *Repository) update(ctx context.Context, target Target, version uint32,
 				return errors.New(errors.MultipleRecords, op, "more than 1 resource would have been updated")
 			}
 
+			if opts.withSetClientCidrs && rowsUpdated == 1 {
+				if err := setClientCidrs(ctx, reader, w, oplogWrapper, target, opts.withAllowedClientCidrs, opts.withDeniedClientCidrs); err != nil {
+					return errors.Wrap(err, op)
+				}
+			}
+			allowedCidrs, deniedCidrs, err := fetchClientCidrs(ctx, reader, target.GetPublicId())
+			if err != nil {
+				return errors.Wrap(err, op)
+			}
+			assignClientCidrs(returnedTarget, allowedCidrs[target.GetPublicId()], deniedCidrs[target.GetPublicId()])
+
 			if hostSets, err = fetchSets(ctx, reader, target.GetPublicId()); err != nil {
 				return errors.Wrap(err, op)
 			}
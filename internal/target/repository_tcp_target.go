@@ -14,6 +14,10 @@ import (
 
 // CreateTcpTarget inserts into the repository and returns the new Target with
 // its list of host sets.  WithHostSets is currently the only supported option.
+// The target's AllowedClientCidrs and DeniedClientCidrs must be valid CIDR
+// blocks or IP addresses and are stored in their canonical form.  If the
+// target has an AccessWindowSchedule, it must be a valid cron expression and
+// AccessWindowDurationSeconds must be set.
 func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt ...Option) (Target, []*TargetSet, error) {
 	const op = "target.(Repository).CreateTcpTarget"
 	opts := getOpts(opt...)
@@ -32,6 +36,9 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 	if target.PublicId != "" {
 		return nil, nil, errors.New(errors.InvalidParameter, op, "public id not empty")
 	}
+	if _, err := parseAccessWindow(target.AccessWindowSchedule, target.AccessWindowDurationSeconds, target.AccessWindowTimeZone); err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
 
 	t := target.Clone().(*TcpTarget)
 
@@ -62,6 +69,10 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 		}
 		newHostSets = append(newHostSets, hostSet)
 	}
+	clientCidrs, err := newClientCidrs(t.PublicId, t.AllowedClientCidrs, t.DeniedClientCidrs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, op)
+	}
 
 	metadata := t.oplog(oplog.OpType_OP_TYPE_CREATE)
 	var returnedTarget interface{}
@@ -92,6 +103,18 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 				}
 				msgs = append(msgs, hostSetOplogMsgs...)
 			}
+			if len(clientCidrs) > 0 {
+				clientCidrOplogMsgs := make([]*oplog.Message, 0, len(clientCidrs))
+				if err := w.CreateItems(ctx, clientCidrs, db.NewOplogMsgs(&clientCidrOplogMsgs)); err != nil {
+					return errors.Wrap(err, op, errors.WithMsg("unable to add client cidrs"))
+				}
+				msgs = append(msgs, clientCidrOplogMsgs...)
+			}
+			allowedCidrs, deniedCidrs, err := fetchClientCidrs(ctx, read, t.PublicId)
+			if err != nil {
+				return errors.Wrap(err, op, errors.WithMsg("unable to read client cidrs"))
+			}
+			assignClientCidrs(returnedTarget, allowedCidrs[t.PublicId], deniedCidrs[t.PublicId])
 			if err := w.WriteOplogEntryWith(ctx, oplogWrapper, targetTicket, metadata, msgs); err != nil {
 				return errors.Wrap(err, op, errors.WithMsg("unable to write oplog"))
 			}
@@ -108,9 +131,14 @@ func (r *Repository) CreateTcpTarget(ctx context.Context, target *TcpTarget, opt
 // UpdateTcpTarget will update a target in the repository and return the written
 // target. fieldMaskPaths provides field_mask.proto paths for fields that should
 // be updated.  Fields will be set to NULL if the field is a zero value and
-// included in fieldMask. Name, Description, and WorkerFilter are the only
-// updatable fields. If no updatable fields are included in the fieldMaskPaths,
-// then an error is returned.
+// included in fieldMask. Name, Description, DefaultPort, SessionMaxSeconds,
+// SessionConnectionLimit, WorkerFilter, AllowedClientCidrs,
+// DeniedClientCidrs, AccessWindowSchedule, AccessWindowDurationSeconds and
+// AccessWindowTimeZone are the only updatable fields. AllowedClientCidrs and
+// DeniedClientCidrs replace the respective client cidrs of the target. The
+// access window of the target must be valid after the update. If no
+// updatable fields are included in the fieldMaskPaths, then an error is
+// returned.
 func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, version uint32, fieldMaskPaths []string, _ ...Option) (Target, []*TargetSet, int, error) {
 	const op = "target.(Repository).UpdateTcpTarget"
 	if target == nil {
@@ -122,14 +150,25 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 	if target.PublicId == "" {
 		return nil, nil, db.NoRowsAffected, errors.New(errors.InvalidParameter, op, "missing target public id")
 	}
+	var updateAllowedCidrs, updateDeniedCidrs, updateAccessWindow bool
 	for _, f := range fieldMaskPaths {
 		switch {
+		case strings.EqualFold("allowedclientcidrs", f):
+			updateAllowedCidrs = true
+		case strings.EqualFold("deniedclientcidrs", f):
+			updateDeniedCidrs = true
 		case strings.EqualFold("name", f):
 		case strings.EqualFold("description", f):
 		case strings.EqualFold("defaultport", f):
 		case strings.EqualFold("sessionmaxseconds", f):
 		case strings.EqualFold("sessionconnectionlimit", f):
 		case strings.EqualFold("workerfilter", f):
+		case strings.EqualFold("accesswindowschedule", f):
+			updateAccessWindow = true
+		case strings.EqualFold("accesswindowdurationseconds", f):
+			updateAccessWindow = true
+		case strings.EqualFold("accesswindowtimezone", f):
+			updateAccessWindow = true
 		default:
 			return nil, nil, db.NoRowsAffected, errors.New(errors.InvalidFieldMask, op, fmt.Sprintf("invalid field mask: %s", f))
 		}
@@ -143,12 +182,64 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 			"SessionMaxSeconds":      target.SessionMaxSeconds,
 			"SessionConnectionLimit": target.SessionConnectionLimit,
 			"WorkerFilter":           target.WorkerFilter,
+
+			"AccessWindowSchedule":        target.AccessWindowSchedule,
+			"AccessWindowDurationSeconds": target.AccessWindowDurationSeconds,
+			"AccessWindowTimeZone":        target.AccessWindowTimeZone,
 		},
 		fieldMaskPaths,
 		[]string{"SessionMaxSeconds", "SessionConnectionLimit"},
 	)
+	if updateAccessWindow {
+		// the access window fields which are not in the field mask are kept
+		current := allocTcpTarget()
+		current.PublicId = target.PublicId
+		if err := r.reader.LookupByPublicId(ctx, &current); err != nil {
+			return nil, nil, db.NoRowsAffected, errors.Wrap(err, op, errors.WithMsg(fmt.Sprintf("failed for %s", target.PublicId)))
+		}
+		for _, f := range fieldMaskPaths {
+			switch {
+			case strings.EqualFold("accesswindowschedule", f):
+				current.AccessWindowSchedule = target.AccessWindowSchedule
+			case strings.EqualFold("accesswindowdurationseconds", f):
+				current.AccessWindowDurationSeconds = target.AccessWindowDurationSeconds
+			case strings.EqualFold("accesswindowtimezone", f):
+				current.AccessWindowTimeZone = target.AccessWindowTimeZone
+			}
+		}
+		if _, err := parseAccessWindow(current.AccessWindowSchedule, current.AccessWindowDurationSeconds, current.AccessWindowTimeZone); err != nil {
+			return nil, nil, db.NoRowsAffected, errors.Wrap(err, op)
+		}
+	}
+	updateCidrs := updateAllowedCidrs || updateDeniedCidrs
 	if len(dbMask) == 0 && len(nullFields) == 0 {
-		return nil, nil, db.NoRowsAffected, errors.New(errors.EmptyFieldMask, op, "empty field mask")
+		if !updateCidrs {
+			return nil, nil, db.NoRowsAffected, errors.New(errors.EmptyFieldMask, op, "empty field mask")
+		}
+		// only the client cidrs are changing, bump the version of the target
+		// so the change is still versioned
+		dbMask = []string{"Version"}
+	}
+	var updateOpts []Option
+	if updateCidrs {
+		// the client cidrs which are not in the field mask are kept
+		allowed, denied := target.AllowedClientCidrs, target.DeniedClientCidrs
+		if !updateAllowedCidrs || !updateDeniedCidrs {
+			currentAllowed, currentDenied, err := fetchClientCidrs(ctx, r.reader, target.PublicId)
+			if err != nil {
+				return nil, nil, db.NoRowsAffected, errors.Wrap(err, op)
+			}
+			if !updateAllowedCidrs {
+				allowed = currentAllowed[target.PublicId]
+			}
+			if !updateDeniedCidrs {
+				denied = currentDenied[target.PublicId]
+			}
+		}
+		if _, err := newClientCidrs(target.PublicId, allowed, denied); err != nil {
+			return nil, nil, db.NoRowsAffected, errors.Wrap(err, op)
+		}
+		updateOpts = append(updateOpts, withSetClientCidrs(true), WithAllowedClientCidrs(allowed), WithDeniedClientCidrs(denied))
 	}
 	var returnedTarget Target
 	var rowsUpdated int
@@ -160,7 +251,10 @@ func (r *Repository) UpdateTcpTarget(ctx context.Context, target *TcpTarget, ver
 		func(read db.Reader, w db.Writer) error {
 			var err error
 			t := target.Clone().(*TcpTarget)
-			returnedTarget, targetSets, rowsUpdated, err = r.update(ctx, t, version, dbMask, nullFields)
+			if len(dbMask) == 1 && dbMask[0] == "Version" {
+				t.Version = version + 1
+			}
+			returnedTarget, targetSets, rowsUpdated, err = r.update(ctx, t, version, dbMask, nullFields, updateOpts...)
 			if err != nil {
 				return errors.Wrap(err, op)
 			}
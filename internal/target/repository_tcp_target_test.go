@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -61,6 +62,34 @@ func TestRepository_CreateTcpTarget(t *testing.T) {
 			wantErr:      false,
 			wantHostSets: sets,
 		},
+		{
+			name: "with-client-cidrs",
+			args: args{
+				target: func() *TcpTarget {
+					target, err := NewTcpTarget(proj.PublicId,
+						WithName("with-client-cidrs"),
+						WithAllowedClientCidrs([]string{"10.0.0.0/8", "192.168.1.1"}),
+						WithDeniedClientCidrs([]string{"10.1.0.0/16"}))
+					require.NoError(t, err)
+					return target
+				}(),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid-client-cidr",
+			args: args{
+				target: func() *TcpTarget {
+					target, err := NewTcpTarget(proj.PublicId,
+						WithName("invalid-client-cidr"),
+						WithAllowedClientCidrs([]string{"10.0.0.0/33"}))
+					require.NoError(t, err)
+					return target
+				}(),
+			},
+			wantErr:     true,
+			wantIsError: errors.InvalidParameter,
+		},
 		{
 			name: "nil-target",
 			args: args{
@@ -416,3 +445,166 @@ func TestRepository_UpdateTcpTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository_UpdateTcpTarget_ClientCidrs(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name           string
+		allowed        []string
+		denied         []string
+		fieldMaskPaths []string
+		wantAllowed    []string
+		wantDenied     []string
+		wantIsError    errors.Code
+	}{
+		{
+			name:           "allowed",
+			allowed:        []string{"192.168.1.1", "10.0.0.0/8"},
+			fieldMaskPaths: []string{"AllowedClientCidrs"},
+			wantAllowed:    []string{"10.0.0.0/8", "192.168.1.1/32"},
+			wantDenied:     []string{"172.16.0.0/12"},
+		},
+		{
+			name:           "denied",
+			denied:         []string{"10.1.0.0/16"},
+			fieldMaskPaths: []string{"DeniedClientCidrs"},
+			wantAllowed:    []string{"10.0.0.0/8"},
+			wantDenied:     []string{"10.1.0.0/16"},
+		},
+		{
+			name:           "remove-all",
+			fieldMaskPaths: []string{"AllowedClientCidrs", "DeniedClientCidrs"},
+		},
+		{
+			name:           "invalid",
+			allowed:        []string{"10.0.0.0/33"},
+			fieldMaskPaths: []string{"AllowedClientCidrs"},
+			wantIsError:    errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			tar := TestTcpTarget(t, conn, proj.PublicId, tt.name,
+				WithAllowedClientCidrs([]string{"10.0.0.0/8"}),
+				WithDeniedClientCidrs([]string{"172.16.0.0/12"}))
+
+			updateTarget := allocTcpTarget()
+			updateTarget.PublicId = tar.PublicId
+			updateTarget.AllowedClientCidrs = tt.allowed
+			updateTarget.DeniedClientCidrs = tt.denied
+			got, _, updated, err := repo.UpdateTcpTarget(ctx, &updateTarget, tar.Version, tt.fieldMaskPaths)
+			if tt.wantIsError != 0 {
+				assert.True(errors.Match(errors.T(tt.wantIsError), err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, updated)
+			assert.Equal(tar.Version+1, got.GetVersion())
+			assert.Equal(tt.wantAllowed, got.GetAllowedClientCidrs())
+			assert.Equal(tt.wantDenied, got.GetDeniedClientCidrs())
+
+			found, _, err := repo.LookupTarget(ctx, tar.PublicId)
+			require.NoError(err)
+			assert.True(proto.Equal(got.(*TcpTarget), found.(*TcpTarget)))
+		})
+	}
+}
+
+func TestRepository_UpdateTcpTarget_AccessWindow(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	tests := []struct {
+		name           string
+		update         TcpTarget
+		fieldMaskPaths []string
+		wantSchedule   string
+		wantDuration   uint32
+		wantTimeZone   string
+		wantIsError    errors.Code
+	}{
+		{
+			name: "set",
+			update: TcpTarget{TcpTarget: &store.TcpTarget{
+				AccessWindowSchedule:        "0 9 * * mon-fri",
+				AccessWindowDurationSeconds: 8 * 3600,
+				AccessWindowTimeZone:        "Europe/Berlin",
+			}},
+			fieldMaskPaths: []string{"AccessWindowSchedule", "AccessWindowDurationSeconds", "AccessWindowTimeZone"},
+			wantSchedule:   "0 9 * * mon-fri",
+			wantDuration:   8 * 3600,
+			wantTimeZone:   "Europe/Berlin",
+		},
+		{
+			name:           "only-duration",
+			update:         TcpTarget{TcpTarget: &store.TcpTarget{AccessWindowDurationSeconds: 3600}},
+			fieldMaskPaths: []string{"AccessWindowDurationSeconds"},
+			wantSchedule:   "0 0 * * *",
+			wantDuration:   3600,
+		},
+		{
+			name:           "remove",
+			update:         TcpTarget{TcpTarget: &store.TcpTarget{}},
+			fieldMaskPaths: []string{"AccessWindowSchedule", "AccessWindowDurationSeconds"},
+		},
+		{
+			name:           "missing-duration",
+			update:         TcpTarget{TcpTarget: &store.TcpTarget{}},
+			fieldMaskPaths: []string{"AccessWindowDurationSeconds"},
+			wantIsError:    errors.InvalidParameter,
+		},
+		{
+			name:           "invalid-schedule",
+			update:         TcpTarget{TcpTarget: &store.TcpTarget{AccessWindowSchedule: "every day"}},
+			fieldMaskPaths: []string{"AccessWindowSchedule"},
+			wantIsError:    errors.InvalidParameter,
+		},
+		{
+			name:           "invalid-time-zone",
+			update:         TcpTarget{TcpTarget: &store.TcpTarget{AccessWindowTimeZone: "Mars/Olympus_Mons"}},
+			fieldMaskPaths: []string{"AccessWindowTimeZone"},
+			wantIsError:    errors.InvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx := context.Background()
+			tar := TestTcpTarget(t, conn, proj.PublicId, tt.name,
+				WithAccessWindowSchedule("0 0 * * *"),
+				WithAccessWindowDurationSeconds(60))
+
+			tt.update.PublicId = tar.PublicId
+			got, _, updated, err := repo.UpdateTcpTarget(ctx, &tt.update, tar.Version, tt.fieldMaskPaths)
+			if tt.wantIsError != 0 {
+				assert.Truef(errors.Match(errors.T(tt.wantIsError), err), "unexpected error %v", err)
+				return
+			}
+			require.NoError(err)
+			assert.Equal(1, updated)
+			assert.Equal(tt.wantSchedule, got.GetAccessWindowSchedule())
+			assert.Equal(tt.wantDuration, got.GetAccessWindowDurationSeconds())
+			assert.Equal(tt.wantTimeZone, got.GetAccessWindowTimeZone())
+
+			found, _, err := repo.LookupTarget(ctx, tar.PublicId)
+			require.NoError(err)
+			assert.True(proto.Equal(got.(*TcpTarget), found.(*TcpTarget)))
+		})
+	}
+}
@@ -23,6 +23,11 @@ type Target interface {
 	GetSessionMaxSeconds() uint32
 	GetSessionConnectionLimit() int32
 	GetWorkerFilter() string
+	GetAllowedClientCidrs() []string
+	GetDeniedClientCidrs() []string
+	GetAccessWindowSchedule() string
+	GetAccessWindowDurationSeconds() uint32
+	GetAccessWindowTimeZone() string
 	oplog(op oplog.OpType) oplog.Metadata
 }
 
@@ -96,6 +101,9 @@ func (t *targetView) targetSubType() (Target, error) {
 		tcpTarget.SessionMaxSeconds = t.SessionMaxSeconds
 		tcpTarget.SessionConnectionLimit = t.SessionConnectionLimit
 		tcpTarget.WorkerFilter = t.WorkerFilter
+		tcpTarget.AccessWindowSchedule = t.AccessWindowSchedule
+		tcpTarget.AccessWindowDurationSeconds = t.AccessWindowDurationSeconds
+		tcpTarget.AccessWindowTimeZone = t.AccessWindowTimeZone
 		return &tcpTarget, nil
 	}
 	return nil, errors.New(errors.InvalidParameter, op, fmt.Sprintf("%s is an unknown target subtype of %s", t.PublicId, t.Type))
@@ -25,8 +25,11 @@ var (
 	_ oplog.ReplayableMessage = (*TcpTarget)(nil)
 )
 
-// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription and
-// WithDefaultPort options are supported
+// NewTcpTarget creates a new in memory tcp target.  WithName, WithDescription,
+// WithDefaultPort, WithSessionMaxSeconds, WithSessionConnectionLimit,
+// WithWorkerFilter, WithAllowedClientCidrs, WithDeniedClientCidrs,
+// WithAccessWindowSchedule, WithAccessWindowDurationSeconds and
+// WithAccessWindowTimeZone options are supported
 func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 	const op = "target.NewTcpTarget"
 	opts := getOpts(opt...)
@@ -42,6 +45,12 @@ func NewTcpTarget(scopeId string, opt ...Option) (*TcpTarget, error) {
 			SessionConnectionLimit: opts.withSessionConnectionLimit,
 			SessionMaxSeconds:      opts.withSessionMaxSeconds,
 			WorkerFilter:           opts.withWorkerFilter,
+			AllowedClientCidrs:     opts.withAllowedClientCidrs,
+			DeniedClientCidrs:      opts.withDeniedClientCidrs,
+
+			AccessWindowSchedule:        opts.withAccessWindowSchedule,
+			AccessWindowDurationSeconds: opts.withAccessWindowDuration,
+			AccessWindowTimeZone:        opts.withAccessWindowTimeZone,
 		},
 	}
 	return t, nil
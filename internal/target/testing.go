@@ -35,6 +35,12 @@ func TestTcpTarget(t *testing.T, conn *gorm.DB, scopeId, name string, opt ...Opt
 		err := rw.CreateItems(context.Background(), newHostSets)
 		require.NoError(err)
 	}
+	if len(target.AllowedClientCidrs) > 0 || len(target.DeniedClientCidrs) > 0 {
+		clientCidrs, err := newClientCidrs(target.PublicId, target.AllowedClientCidrs, target.DeniedClientCidrs)
+		require.NoError(err)
+		err = rw.CreateItems(context.Background(), clientCidrs)
+		require.NoError(err)
+	}
 	return target
 }
 
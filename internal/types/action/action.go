@@ -6,74 +6,106 @@ type Type uint
 
 // not using iota intentionally, since the values are stored in the db as well.
 const (
-	Unknown          Type = 0
-	List             Type = 1
-	Create           Type = 2
-	Update           Type = 3
-	Read             Type = 4
-	Delete           Type = 5
-	Authenticate     Type = 6
-	All              Type = 7
-	AuthorizeSession Type = 8
-	AddGrants        Type = 9
-	RemoveGrants     Type = 10
-	SetGrants        Type = 11
-	AddPrincipals    Type = 12
-	SetPrincipals    Type = 13
-	RemovePrincipals Type = 14
-	Deauthenticate   Type = 15
-	AddMembers       Type = 16
-	SetMembers       Type = 17
-	RemoveMembers    Type = 18
-	SetPassword      Type = 19
-	ChangePassword   Type = 20
-	AddHosts         Type = 21
-	SetHosts         Type = 22
-	RemoveHosts      Type = 23
-	AddHostSets      Type = 24
-	SetHostSets      Type = 25
-	RemoveHostSets   Type = 26
-	Cancel           Type = 27
-	AddAccounts      Type = 28
-	SetAccounts      Type = 29
-	RemoveAccounts   Type = 30
-	ReadSelf         Type = 31
-	CancelSelf       Type = 32
+	Unknown             Type = 0
+	List                Type = 1
+	Create              Type = 2
+	Update              Type = 3
+	Read                Type = 4
+	Delete              Type = 5
+	Authenticate        Type = 6
+	All                 Type = 7
+	AuthorizeSession    Type = 8
+	AddGrants           Type = 9
+	RemoveGrants        Type = 10
+	SetGrants           Type = 11
+	AddPrincipals       Type = 12
+	SetPrincipals       Type = 13
+	RemovePrincipals    Type = 14
+	Deauthenticate      Type = 15
+	AddMembers          Type = 16
+	SetMembers          Type = 17
+	RemoveMembers       Type = 18
+	SetPassword         Type = 19
+	ChangePassword      Type = 20
+	AddHosts            Type = 21
+	SetHosts            Type = 22
+	RemoveHosts         Type = 23
+	AddHostSets         Type = 24
+	SetHostSets         Type = 25
+	RemoveHostSets      Type = 26
+	Cancel              Type = 27
+	AddAccounts         Type = 28
+	SetAccounts         Type = 29
+	RemoveAccounts      Type = 30
+	ReadSelf            Type = 31
+	CancelSelf          Type = 32
+	Rotate              Type = 33
+	Derive              Type = 34
+	Unlock              Type = 35
+	EnrollTotp          Type = 36
+	ConfirmTotp         Type = 37
+	RemoveTotp          Type = 38
+	CreateResetToken    Type = 39
+	Disable             Type = 40
+	Enable              Type = 41
+	RotateKeys          Type = 42
+	ReencryptKeyVersion Type = 43
+	DestroyKeyVersion   Type = 44
+	ListKeys            Type = 45
+	ListChanges         Type = 46
+	ListJobs            Type = 47
+	RunJob              Type = 48
 )
 
 var Map = map[string]Type{
-	Create.String():           Create,
-	List.String():             List,
-	Update.String():           Update,
-	Read.String():             Read,
-	Delete.String():           Delete,
-	Authenticate.String():     Authenticate,
-	All.String():              All,
-	AuthorizeSession.String(): AuthorizeSession,
-	AddGrants.String():        AddGrants,
-	RemoveGrants.String():     RemoveGrants,
-	SetGrants.String():        SetGrants,
-	AddPrincipals.String():    AddPrincipals,
-	SetPrincipals.String():    SetPrincipals,
-	RemovePrincipals.String(): RemovePrincipals,
-	Deauthenticate.String():   Deauthenticate,
-	AddMembers.String():       AddMembers,
-	SetMembers.String():       SetMembers,
-	RemoveMembers.String():    RemoveMembers,
-	SetPassword.String():      SetPassword,
-	ChangePassword.String():   ChangePassword,
-	AddHosts.String():         AddHosts,
-	SetHosts.String():         SetHosts,
-	RemoveHosts.String():      RemoveHosts,
-	AddHostSets.String():      AddHostSets,
-	SetHostSets.String():      SetHostSets,
-	RemoveHostSets.String():   RemoveHostSets,
-	Cancel.String():           Cancel,
-	AddAccounts.String():      AddAccounts,
-	SetAccounts.String():      SetAccounts,
-	RemoveAccounts.String():   RemoveAccounts,
-	ReadSelf.String():         ReadSelf,
-	CancelSelf.String():       CancelSelf,
+	Create.String():              Create,
+	List.String():                List,
+	Update.String():              Update,
+	Read.String():                Read,
+	Delete.String():              Delete,
+	Authenticate.String():        Authenticate,
+	All.String():                 All,
+	AuthorizeSession.String():    AuthorizeSession,
+	AddGrants.String():           AddGrants,
+	RemoveGrants.String():        RemoveGrants,
+	SetGrants.String():           SetGrants,
+	AddPrincipals.String():       AddPrincipals,
+	SetPrincipals.String():       SetPrincipals,
+	RemovePrincipals.String():    RemovePrincipals,
+	Deauthenticate.String():      Deauthenticate,
+	AddMembers.String():          AddMembers,
+	SetMembers.String():          SetMembers,
+	RemoveMembers.String():       RemoveMembers,
+	SetPassword.String():         SetPassword,
+	ChangePassword.String():      ChangePassword,
+	AddHosts.String():            AddHosts,
+	SetHosts.String():            SetHosts,
+	RemoveHosts.String():         RemoveHosts,
+	AddHostSets.String():         AddHostSets,
+	SetHostSets.String():         SetHostSets,
+	RemoveHostSets.String():      RemoveHostSets,
+	Cancel.String():              Cancel,
+	AddAccounts.String():         AddAccounts,
+	SetAccounts.String():         SetAccounts,
+	RemoveAccounts.String():      RemoveAccounts,
+	ReadSelf.String():            ReadSelf,
+	CancelSelf.String():          CancelSelf,
+	Rotate.String():              Rotate,
+	Derive.String():              Derive,
+	Unlock.String():              Unlock,
+	EnrollTotp.String():          EnrollTotp,
+	ConfirmTotp.String():         ConfirmTotp,
+	RemoveTotp.String():          RemoveTotp,
+	CreateResetToken.String():    CreateResetToken,
+	Disable.String():             Disable,
+	Enable.String():              Enable,
+	RotateKeys.String():          RotateKeys,
+	ReencryptKeyVersion.String(): ReencryptKeyVersion,
+	DestroyKeyVersion.String():   DestroyKeyVersion,
+	ListKeys.String():            ListKeys,
+	ListChanges.String():         ListChanges,
+	ListJobs.String():            ListJobs,
+	RunJob.String():              RunJob,
 }
 
 func (a Type) String() string {
@@ -111,6 +143,22 @@ func (a Type) String() string {
 		"remove-accounts",
 		"read:self",
 		"cancel:self",
+		"rotate",
+		"derive",
+		"unlock",
+		"enroll-totp",
+		"confirm-totp",
+		"remove-totp",
+		"create-reset-token",
+		"disable",
+		"enable",
+		"rotate-keys",
+		"reencrypt-key-version",
+		"destroy-key-version",
+		"list-keys",
+		"list-changes",
+		"list-jobs",
+		"run-job",
 	}[a]
 }
 
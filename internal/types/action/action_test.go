@@ -83,6 +83,70 @@ func TestAction(t *testing.T) {
 			action: CancelSelf,
 			want:   "cancel:self",
 		},
+		{
+			action: Rotate,
+			want:   "rotate",
+		},
+		{
+			action: Derive,
+			want:   "derive",
+		},
+		{
+			action: Unlock,
+			want:   "unlock",
+		},
+		{
+			action: EnrollTotp,
+			want:   "enroll-totp",
+		},
+		{
+			action: ConfirmTotp,
+			want:   "confirm-totp",
+		},
+		{
+			action: RemoveTotp,
+			want:   "remove-totp",
+		},
+		{
+			action: CreateResetToken,
+			want:   "create-reset-token",
+		},
+		{
+			action: Disable,
+			want:   "disable",
+		},
+		{
+			action: Enable,
+			want:   "enable",
+		},
+		{
+			action: RotateKeys,
+			want:   "rotate-keys",
+		},
+		{
+			action: ReencryptKeyVersion,
+			want:   "reencrypt-key-version",
+		},
+		{
+			action: DestroyKeyVersion,
+			want:   "destroy-key-version",
+		},
+		{
+			action: ListKeys,
+			want:   "list-keys",
+		},
+		{
+			action: ListChanges,
+			want:   "list-changes",
+		},
+		{
+			action: ListJobs,
+			want:   "list-jobs",
+		},
+		{
+			action: RunJob,
+			want:   "run-job",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
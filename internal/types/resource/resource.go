@@ -20,6 +20,8 @@ const (
 	Controller  Type = 13
 	Worker      Type = 14
 	Session     Type = 15
+	ApiToken    Type = 16
+	Invite      Type = 17
 )
 
 func (r Type) String() string {
@@ -40,6 +42,8 @@ func (r Type) String() string {
 		"controller",
 		"worker",
 		"session",
+		"api-token",
+		"invite",
 	}[r]
 }
 
@@ -60,4 +64,6 @@ var Map = map[string]Type{
 	Controller.String():  Controller,
 	Worker.String():      Worker,
 	Session.String():     Session,
+	ApiToken.String():    ApiToken,
+	Invite.String():      Invite,
 }
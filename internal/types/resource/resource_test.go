@@ -75,6 +75,14 @@ func Test_Resource(t *testing.T) {
 			typeString: "session",
 			want:       Session,
 		},
+		{
+			typeString: "api-token",
+			want:       ApiToken,
+		},
+		{
+			typeString: "invite",
+			want:       Invite,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.typeString, func(t *testing.T) {
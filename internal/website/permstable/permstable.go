@@ -57,19 +57,21 @@ var table = &Table{
 		},
 	},
 	Body: &Body{
-		Resources: make([]*Resource, 0, 12),
+		Resources: make([]*Resource, 0, 14),
 	},
 }
 
 func main() {
 	table.Body.Resources = append(table.Body.Resources,
 		account,
+		apiToken,
 		authMethod,
 		authToken,
 		group,
 		host,
 		hostCatalog,
 		hostSet,
+		invite,
 		role,
 		scope,
 		session,
@@ -339,11 +341,111 @@ var account = &Resource{
 						"id=<pin>;type=<type>;actions=change-password",
 					},
 				},
+				&Action{
+					Name:        "unlock",
+					Description: "Unlock an account locked after failed authentication attempts",
+					Examples: []string{
+						"id=<id>;actions=unlock",
+						"id=<pin>;type=<type>;actions=unlock",
+					},
+				},
+				&Action{
+					Name:        "enroll-totp",
+					Description: "Enroll an account in TOTP multi-factor authentication",
+					Examples: []string{
+						"id=<id>;actions=enroll-totp",
+						"id=<pin>;type=<type>;actions=enroll-totp",
+					},
+				},
+				&Action{
+					Name:        "confirm-totp",
+					Description: "Confirm the TOTP enrollment of an account",
+					Examples: []string{
+						"id=<id>;actions=confirm-totp",
+						"id=<pin>;type=<type>;actions=confirm-totp",
+					},
+				},
+				&Action{
+					Name:        "remove-totp",
+					Description: "Remove the TOTP enrollment of an account",
+					Examples: []string{
+						"id=<id>;actions=remove-totp",
+						"id=<pin>;type=<type>;actions=remove-totp",
+					},
+				},
+				&Action{
+					Name:        "create-reset-token",
+					Description: "Create a one-time token which can be used to reset the password of an account",
+					Examples: []string{
+						"id=<id>;actions=create-reset-token",
+						"id=<pin>;type=<type>;actions=create-reset-token",
+					},
+				},
+				&Action{
+					Name:        "disable",
+					Description: "Disable an account, revoking its auth tokens and sessions",
+					Examples: []string{
+						"id=<id>;actions=disable",
+						"id=<pin>;type=<type>;actions=disable",
+					},
+				},
+				&Action{
+					Name:        "enable",
+					Description: "Enable a disabled account",
+					Examples: []string{
+						"id=<id>;actions=enable",
+						"id=<pin>;type=<type>;actions=enable",
+					},
+				},
 			),
 		},
 	},
 }
 
+var apiToken = &Resource{
+	Type:   "API Token",
+	Scopes: iamScopes,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/api-tokens",
+			Params: map[string]string{
+				"Type": "api-token",
+			},
+			Actions: clActions("an API token"),
+		},
+		{
+			Path: "/api-tokens/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "api-token",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read an API token",
+					Examples: []string{
+						"id=<id>;actions=read",
+					},
+				},
+				{
+					Name:        "delete",
+					Description: "Delete an API token",
+					Examples: []string{
+						"id=<id>;actions=delete",
+					},
+				},
+				{
+					Name:        "rotate",
+					Description: "Replace the value of an API token",
+					Examples: []string{
+						"id=<id>;actions=rotate",
+					},
+				},
+			},
+		},
+	},
+}
+
 var authMethod = &Resource{
 	Type:   "Auth Method",
 	Scopes: iamScopes,
@@ -365,7 +467,7 @@ var authMethod = &Resource{
 				rudActions("an auth method", false),
 				&Action{
 					Name:        "authenticate",
-					Description: "Authenticate to an auth method",
+					Description: "Authenticate to an auth method, reset the password of an account with a reset token, or redeem an invite",
 					Examples: []string{
 						"id=<id>;actions=authenticate",
 					},
@@ -415,6 +517,13 @@ var authToken = &Resource{
 						"id=<id>;actions=delete",
 					},
 				},
+				{
+					Name:        "derive",
+					Description: "Derive a restricted auth token from an auth token",
+					Examples: []string{
+						"id=<id>;actions=derive",
+					},
+				},
 			},
 		},
 	},
@@ -559,6 +668,46 @@ var hostSet = &Resource{
 	},
 }
 
+var invite = &Resource{
+	Type:   "Invite",
+	Scopes: iamScopes,
+	Endpoints: []*Endpoint{
+		{
+			Path: "/invites",
+			Params: map[string]string{
+				"Type": "invite",
+			},
+			Actions: clActions("an invite"),
+		},
+		{
+			Path: "/invites/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "invite",
+				"Pin":  "<auth-method-id>",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read an invite",
+					Examples: []string{
+						"id=<id>;actions=read",
+						"id=<pin>;type=<type>;actions=read",
+					},
+				},
+				{
+					Name:        "delete",
+					Description: "Delete an invite, revoking it if it has not been redeemed",
+					Examples: []string{
+						"id=<id>;actions=delete",
+						"id=<pin>;type=<type>;actions=delete",
+					},
+				},
+			},
+		},
+	},
+}
+
 var role = &Resource{
 	Type:   "Role",
 	Scopes: append(iamScopes, infraScope...),
@@ -642,7 +791,58 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(
+				rudActions("a scope", false),
+				&Action{
+					Name:        "rotate-keys",
+					Description: "Rotate the encryption keys of a scope",
+					Examples: []string{
+						"id=<id>;actions=rotate-keys",
+					},
+				},
+				&Action{
+					Name:        "reencrypt-key-version",
+					Description: "Re-encrypt the data encrypted with a key version of a scope",
+					Examples: []string{
+						"id=<id>;actions=reencrypt-key-version",
+					},
+				},
+				&Action{
+					Name:        "destroy-key-version",
+					Description: "Destroy a key version of a scope",
+					Examples: []string{
+						"id=<id>;actions=destroy-key-version",
+					},
+				},
+				&Action{
+					Name:        "list-keys",
+					Description: "List the encryption keys of a scope and their usage",
+					Examples: []string{
+						"id=<id>;actions=list-keys",
+					},
+				},
+				&Action{
+					Name:        "list-changes",
+					Description: "List the changes recorded in the oplog; only valid on the global scope",
+					Examples: []string{
+						"id=global;actions=list-changes",
+					},
+				},
+				&Action{
+					Name:        "list-jobs",
+					Description: "List the jobs run by the controllers and their recent runs; only valid on the global scope",
+					Examples: []string{
+						"id=global;actions=list-jobs",
+					},
+				},
+				&Action{
+					Name:        "run-job",
+					Description: "Run a job of the controllers now; only valid on the global scope",
+					Examples: []string{
+						"id=global;actions=run-job",
+					},
+				},
+			),
 		},
 	},
 }
@@ -798,6 +998,20 @@ var user = &Resource{
 						"id=<id>;actions=remove-accounts",
 					},
 				},
+				&Action{
+					Name:        "disable",
+					Description: "Disable a user, revoking its auth tokens and sessions",
+					Examples: []string{
+						"id=<id>;actions=disable",
+					},
+				},
+				&Action{
+					Name:        "enable",
+					Description: "Enable a disabled user",
+					Examples: []string{
+						"id=<id>;actions=enable",
+					},
+				},
 			),
 		},
 	},